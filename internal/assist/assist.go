@@ -0,0 +1,400 @@
+// Package assist asks a model from the user's own config to propose where
+// OpenClaw config sections the converter doesn't know belong in PicoClaw's
+// schema. Nothing here runs unless --assist is given, and proposals are only
+// ever returned for review, never written.
+package assist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/secrets"
+)
+
+// Provider is a model endpoint taken from the migrated PicoClaw config
+type Provider struct {
+	Name    string // providers key, e.g. "anthropic"
+	BaseURL string
+	APIKey  string
+	Model   string // model ID as the API expects it, without the vendor prefix
+}
+
+// apiKinds maps the providers assist can talk to onto their API flavour
+// and default base URL. Everything but Anthropic speaks the OpenAI chat API.
+var apiKinds = map[string]struct{ kind, base string }{
+	"ollama":     {"openai", "http://localhost:11434/v1"},
+	"anthropic":  {"anthropic", "https://api.anthropic.com"},
+	"openai":     {"openai", "https://api.openai.com/v1"},
+	"openrouter": {"openai", "https://openrouter.ai/api/v1"},
+	"groq":       {"openai", "https://api.groq.com/openai/v1"},
+	"deepseek":   {"openai", "https://api.deepseek.com/v1"},
+}
+
+// Local is true for providers that run on this machine, so nothing leaves it
+func (p Provider) Local() bool {
+	return p.Name == "ollama"
+}
+
+// Providers lists the usable providers in a PicoClaw config, local ones first.
+// The model is the agent default if it belongs to the provider, otherwise the
+// provider's model_list entry.
+func Providers(pico map[string]interface{}) []Provider {
+	providers, _ := pico["providers"].(map[string]interface{})
+	defaultModel := ""
+	if agents, ok := pico["agents"].(map[string]interface{}); ok {
+		if defaults, ok := agents["defaults"].(map[string]interface{}); ok {
+			defaultModel, _ = defaults["model"].(string)
+		}
+	}
+
+	var out []Provider
+	for name, v := range providers {
+		kind, ok := apiKinds[name]
+		conf, _ := v.(map[string]interface{})
+		if !ok || conf == nil {
+			continue
+		}
+		p := Provider{Name: name, BaseURL: kind.base}
+		p.APIKey, _ = conf["api_key"].(string)
+		if base, _ := conf["api_base"].(string); base != "" {
+			p.BaseURL = base
+		}
+		if p.APIKey == "" && !p.Local() {
+			continue
+		}
+		if strings.HasPrefix(defaultModel, name+"/") {
+			p.Model = strings.TrimPrefix(defaultModel, name+"/")
+		} else {
+			p.Model = listedModel(pico, name)
+		}
+		if p.Model == "" {
+			continue
+		}
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Local() != out[j].Local() {
+			return out[i].Local()
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// listedModel returns the model of a provider's model_list entry, without the vendor prefix
+func listedModel(pico map[string]interface{}, name string) string {
+	list, _ := pico["model_list"].([]interface{})
+	for _, v := range list {
+		entry, _ := v.(map[string]interface{})
+		if entry["model_name"] == name {
+			model, _ := entry["model"].(string)
+			return strings.TrimPrefix(model, name+"/")
+		}
+	}
+	return ""
+}
+
+// Proposal is a model's suggested mapping, in PicoClaw's config shape
+type Proposal struct {
+	Config map[string]interface{} `json:"config"`
+	Notes  string                 `json:"notes"`
+}
+
+// instructions is the system prompt for every request
+const instructions = `You convert configuration from OpenClaw (an AI agent gateway) to PicoClaw (a lightweight Go reimplementation).
+You are given OpenClaw config sections the automatic converter does not recognise, plus the user's current PicoClaw config as a reference for its schema.
+Reply with a single JSON object and nothing else:
+{"config": {...}, "notes": "..."}
+"config" holds only the PicoClaw settings that correspond to the given sections, in PicoClaw's shape (snake_case keys), ready to be deep-merged into config.json.
+Prefer keys and structures that already appear in the PicoClaw config. Leave out anything PicoClaw has no equivalent for and say so in "notes".
+Values like <secret-1> are placeholders for credentials: copy them verbatim where the credential belongs, never invent values.
+If nothing maps, reply {"config": {}, "notes": "<why>"}.`
+
+// Propose sends the sections and the current PicoClaw config, with every
+// credential replaced by a placeholder, and returns the model's mapping with
+// the credentials put back
+func Propose(p Provider, sections, pico map[string]interface{}, channels, tools []string) (Proposal, error) {
+	target, _, err := secrets.Split(pico)
+	if err != nil {
+		return Proposal{}, err
+	}
+	placeholders := make(map[string]string)
+	masked := mask(sections, placeholders)
+	target, _ = mask(target, placeholders).(map[string]interface{})
+
+	sectionJSON, _ := json.MarshalIndent(masked, "", "  ")
+	targetJSON, _ := json.MarshalIndent(target, "", "  ")
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Unrecognised OpenClaw sections:\n%s\n\n", sectionJSON)
+	fmt.Fprintf(&prompt, "Current PicoClaw config (credentials removed):\n%s\n", targetJSON)
+	if len(channels) > 0 {
+		fmt.Fprintf(&prompt, "\nPicoClaw channels: %s\n", strings.Join(channels, ", "))
+	}
+	if len(tools) > 0 {
+		fmt.Fprintf(&prompt, "PicoClaw tools: %s\n", strings.Join(tools, ", "))
+	}
+
+	var reply string
+	if apiKinds[p.Name].kind == "anthropic" {
+		reply, err = anthropicChat(p, prompt.String())
+	} else {
+		reply, err = openAIChat(p, prompt.String())
+	}
+	if err != nil {
+		return Proposal{}, err
+	}
+
+	proposal, err := parseProposal(reply)
+	if err != nil {
+		return Proposal{}, err
+	}
+	proposal.Config, _ = unmask(proposal.Config, placeholders).(map[string]interface{})
+	if proposal.Config == nil {
+		proposal.Config = make(map[string]interface{})
+	}
+	return proposal, nil
+}
+
+// mask replaces credential values with numbered placeholders, recording
+// each placeholder's value so unmask can restore it. Values under secret
+// key names are replaced whole; any other string loses the credentials it
+// carries (see maskValue), wherever it sits.
+func mask(v interface{}, placeholders map[string]string) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, child := range t {
+			if s, ok := child.(string); ok && s != "" && secrets.IsSecretKey(k) {
+				out[k] = placeholder(s, placeholders)
+				continue
+			}
+			out[k] = mask(child, placeholders)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, child := range t {
+			out[i] = mask(child, placeholders)
+		}
+		return out
+	case string:
+		return maskValue(t, placeholders)
+	}
+	return v
+}
+
+// maskValue replaces the credentials inside a string: a URL's userinfo and
+// the query values named like secrets or looking random, and elsewhere
+// every word that looks like a random token
+func maskValue(s string, placeholders map[string]string) string {
+	if u, err := url.Parse(s); err == nil && u.Scheme != "" && u.Host != "" {
+		scheme, rest, _ := strings.Cut(s, "://")
+		authority := rest
+		if end := strings.IndexAny(rest, "/?#"); end >= 0 {
+			authority = rest[:end]
+		}
+		if at := strings.LastIndex(authority, "@"); at >= 0 {
+			rest = placeholder(authority[:at], placeholders) + rest[at:]
+		}
+		s = scheme + "://" + rest
+		for _, pair := range strings.Split(u.RawQuery, "&") {
+			name, value, ok := strings.Cut(pair, "=")
+			if !ok || value == "" {
+				continue
+			}
+			plain, _ := url.QueryUnescape(value)
+			if secrets.IsSecretKey(name) || randomToken(plain) {
+				s = strings.Replace(s, pair, name+"="+placeholder(value, placeholders), 1)
+			}
+		}
+		return s
+	}
+	for _, word := range strings.Fields(s) {
+		if randomToken(word) {
+			s = strings.Replace(s, word, placeholder(word, placeholders), 1)
+		}
+	}
+	return s
+}
+
+// placeholder returns the placeholder for a credential, the same one each
+// time the credential appears
+func placeholder(secret string, placeholders map[string]string) string {
+	for ph, s := range placeholders {
+		if s == secret {
+			return ph
+		}
+	}
+	ph := fmt.Sprintf("<secret-%d>", len(placeholders)+1)
+	placeholders[ph] = secret
+	return ph
+}
+
+// randomToken guesses whether a word is a key or token rather than a
+// name, path or model ID: long hex, or long and high-entropy with letters
+// and digits mixed
+func randomToken(word string) bool {
+	if len(word) < 20 || strings.ContainsAny(word, "/ ") {
+		return false
+	}
+	hex, letters, digits := true, false, false
+	counts := make(map[rune]int)
+	for _, r := range word {
+		counts[r]++
+		switch {
+		case r >= '0' && r <= '9':
+			digits = true
+		case r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F':
+			letters = true
+		case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+			letters, hex = true, false
+		default:
+			hex = false
+		}
+	}
+	if hex && len(word) >= 32 {
+		return true
+	}
+	if !letters || !digits {
+		return false
+	}
+	// Shannon entropy in bits per character
+	var bits float64
+	n := float64(len(word))
+	for _, c := range counts {
+		p := float64(c) / n
+		bits -= p * math.Log2(p)
+	}
+	return bits >= 4
+}
+
+// unmask puts the values recorded by mask back in place of their placeholders
+func unmask(v interface{}, placeholders map[string]string) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			t[k] = unmask(child, placeholders)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = unmask(child, placeholders)
+		}
+	case string:
+		for ph, s := range placeholders {
+			t = strings.ReplaceAll(t, ph, s)
+		}
+		return t
+	}
+	return v
+}
+
+// parseProposal reads the JSON object out of a reply, tolerating code fences
+// or prose around it
+func parseProposal(reply string) (Proposal, error) {
+	var p Proposal
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return p, fmt.Errorf("the model did not reply with JSON")
+	}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &p); err != nil {
+		return p, fmt.Errorf("the model's reply is not valid JSON: %w", err)
+	}
+	return p, nil
+}
+
+// client allows for slow local models
+var client = &http.Client{Timeout: 2 * time.Minute}
+
+func anthropicChat(p Provider, prompt string) (string, error) {
+	body := map[string]interface{}{
+		"model":      p.Model,
+		"max_tokens": 4096,
+		"system":     instructions,
+		"messages":   []map[string]string{{"role": "user", "content": prompt}},
+	}
+	var resp struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	headers := map[string]string{"x-api-key": p.APIKey, "anthropic-version": "2023-06-01"}
+	if err := post(strings.TrimSuffix(p.BaseURL, "/")+"/v1/messages", headers, body, &resp); err != nil {
+		return "", err
+	}
+	var text strings.Builder
+	for _, c := range resp.Content {
+		if c.Type == "text" {
+			text.WriteString(c.Text)
+		}
+	}
+	return text.String(), nil
+}
+
+func openAIChat(p Provider, prompt string) (string, error) {
+	body := map[string]interface{}{
+		"model": p.Model,
+		"messages": []map[string]string{
+			{"role": "system", "content": instructions},
+			{"role": "user", "content": prompt},
+		},
+	}
+	var resp struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	headers := map[string]string{}
+	if p.APIKey != "" {
+		headers["Authorization"] = "Bearer " + p.APIKey
+	}
+	if err := post(strings.TrimSuffix(p.BaseURL, "/")+"/chat/completions", headers, body, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("empty reply from %s", p.Name)
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+// post sends a JSON request and decodes the JSON response into out
+func post(url string, headers map[string]string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status %d: %s", url, resp.StatusCode, strings.TrimSpace(string(raw[:min(len(raw), 200)])))
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("unexpected response from %s: %w", url, err)
+	}
+	return nil
+}
@@ -1,21 +1,29 @@
 package backup
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/iolimit"
+	"github.com/arunbluez/claw-migrate/internal/preserve"
 )
 
 // Result holds backup operation result
 type Result struct {
-	Path    string
-	Size    int64
-	Success bool
-	Error   error
+	Path     string
+	Size     int64
+	Success  bool
+	Verified bool     // set once VerifyBackup has passed
+	Skipped  []string // extra directories that could not be included
+	Error    error
 }
 
 // BackupInfo describes a found backup file
@@ -26,17 +34,65 @@ type BackupInfo struct {
 	Timestamp string // extracted from filename
 }
 
-// CreateBackup creates a tar.gz backup of the OpenClaw directory
-func CreateBackup(openclawDir string) Result {
+// Options controls how a backup is created
+type Options struct {
+	Limiter   *iolimit.Limiter // throttles archive writes (nil = unlimited)
+	ExtraDirs []string         // additional directories to include, e.g. a custom workspace
+	Progress  func(n int)      // called with the number of bytes archived (before compression)
+	Prefix    string           // file name prefix (default "openclaw-backup")
+	Format    string           // FormatTarGz (default) or FormatZip
+	Writer    io.Writer        // stream the archive here instead of a file in $HOME
+}
+
+// Archive formats for Options.Format, which are also the file extensions
+const (
+	FormatTarGz = "tar.gz"
+	FormatZip   = "zip" // opens natively on Windows and in file-sharing tools
+)
+
+// Timestamp extracts the timestamp from a backup file name, e.g.
+// openclaw-backup-20260220-140013.tar.gz → 20260220-140013
+func Timestamp(filename string) string {
+	ts := strings.TrimPrefix(filename, "openclaw-backup-")
+	for _, format := range []string{FormatTarGz, FormatZip} {
+		ts = strings.TrimSuffix(ts, "."+format)
+	}
+	return ts
+}
+
+// CreateBackup creates a tar.gz (or zip) backup of the OpenClaw directory.
+// With Options.Writer the archive is streamed there instead, and the result
+// has no Path; Size is the number of bytes written.
+func CreateBackup(openclawDir string, opts Options) Result {
+	format := opts.Format
+	if format == "" {
+		format = FormatTarGz
+	}
+	if opts.Writer != nil {
+		return streamBackup(openclawDir, format, opts)
+	}
+
 	home, _ := os.UserHomeDir()
 	timestamp := time.Now().Format("20060102-150405")
-	filename := fmt.Sprintf("openclaw-backup-%s.tar.gz", timestamp)
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = "openclaw-backup"
+	}
+	filename := fmt.Sprintf("%s-%s.%s", prefix, timestamp, format)
 	backupPath := filepath.Join(home, filename)
 
-	// Use tar to create backup
-	cmd := exec.Command("tar", "-czf", backupPath, "-C", filepath.Dir(openclawDir), filepath.Base(openclawDir))
-	if err := cmd.Run(); err != nil {
-		return Result{Error: fmt.Errorf("tar failed: %w", err)}
+	out, err := os.Create(backupPath)
+	if err != nil {
+		return Result{Error: fmt.Errorf("could not create backup file: %w", err)}
+	}
+	skipped, err := writeArchive(out, openclawDir, format, opts)
+	closeErr := out.Close()
+	if err == nil && closeErr != nil {
+		err = fmt.Errorf("write backup: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(backupPath)
+		return Result{Error: err}
 	}
 
 	// Get file size
@@ -49,11 +105,255 @@ func CreateBackup(openclawDir string) Result {
 		Path:    backupPath,
 		Size:    info.Size(),
 		Success: true,
+		Skipped: skipped,
+	}
+}
+
+// streamBackup writes the archive to opts.Writer, e.g. stdout piped to ssh.
+// Nothing touches the local disk, so there is nothing to clean up on failure.
+func streamBackup(openclawDir, format string, opts Options) Result {
+	cw := &countingWriter{w: opts.Writer}
+	skipped, err := writeArchive(cw, openclawDir, format, opts)
+	if err != nil {
+		return Result{Size: cw.n, Error: err}
+	}
+	return Result{Size: cw.n, Success: true, Skipped: skipped}
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
+
+// writeArchive archives openclawDir and opts.ExtraDirs into out, returning
+// the extra directories that had to be left out. Nothing is ever scrubbed:
+// the backup is what a restore, and the credentials, come back from.
+func writeArchive(out io.Writer, openclawDir, format string, opts Options) (skipped []string, err error) {
+	// Extra directories are stored relative to the same parent (normally
+	// $HOME) so RestoreBackup puts them back where they came from
+	parent := filepath.Dir(openclawDir)
+	args := []string{"-cf", "-", "-C", parent, filepath.Base(openclawDir)}
+	for _, dir := range opts.ExtraDirs {
+		rel, err := filepath.Rel(parent, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			skipped = append(skipped, dir)
+			continue
+		}
+		args = append(args, rel)
+	}
+
+	// tar produces the archive; compressing it here lets us count the
+	// uncompressed bytes for progress, and throttle the writes to disk.
+	// For zip, the tar stream is re-written entry by entry.
+	cmd := exec.Command("tar", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return skipped, fmt.Errorf("tar failed: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return skipped, fmt.Errorf("tar failed: %w", err)
+	}
+	src := progressReader{stdout, opts.Progress}
+	dst := iolimit.Writer(out, opts.Limiter)
+	var closers []io.Closer // innermost first
+	var copyErr error
+	switch {
+	case format == FormatZip:
+		zw := newZipWriter(dst, parent)
+		closers = []io.Closer{zw}
+		copyErr = copyEntries(zw, src)
+	default:
+		gz := gzip.NewWriter(dst)
+		closers = []io.Closer{gz}
+		_, copyErr = io.Copy(gz, src)
+	}
+	if copyErr != nil {
+		stdout.Close() // unblock tar so Wait can return
+	}
+	runErr := cmd.Wait()
+	for _, c := range closers {
+		if copyErr == nil {
+			copyErr = c.Close()
+		}
 	}
+	if runErr != nil {
+		return skipped, fmt.Errorf("tar failed: %w", runErr)
+	}
+	if copyErr != nil {
+		return skipped, fmt.Errorf("write backup: %w", copyErr)
+	}
+	return skipped, nil
+}
+
+// archiveWriter takes archive entries: a *tar.Writer, or a zipWriter
+type archiveWriter interface {
+	WriteHeader(hdr *tar.Header) error
+	Write(b []byte) (int, error)
 }
 
-// VerifyBackup checks that the backup file is valid
+// copyEntries re-writes the tar stream from r into tw entry by entry,
+// leaving tw open
+func copyEntries(tw archiveWriter, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// progressReader reports each read to an optional progress callback
+type progressReader struct {
+	r        io.Reader
+	progress func(int)
+}
+
+func (p progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 && p.progress != nil {
+		p.progress(n)
+	}
+	return n, err
+}
+
+// Entry describes one item stored in a backup archive
+type Entry struct {
+	Name    string
+	Size    int64
+	Mode    os.FileMode
+	ModTime time.Time
+	IsDir   bool
+}
+
+// Contents lists every entry in a backup without extracting it
+func Contents(backupPath string) ([]Entry, error) {
+	var entries []Entry
+	err := walkArchive(backupPath, func(hdr *tar.Header, _ io.Reader) (bool, error) {
+		entries = append(entries, Entry{
+			Name:    strings.TrimPrefix(hdr.Name, "./"),
+			Size:    hdr.Size,
+			Mode:    hdr.FileInfo().Mode(),
+			ModTime: hdr.ModTime,
+			IsDir:   hdr.Typeflag == tar.TypeDir,
+		})
+		return false, nil
+	})
+	return entries, err
+}
+
+// ReadFile returns the contents of one file from a backup, e.g.
+// ".openclaw/openclaw.json", without extracting anything to disk
+func ReadFile(backupPath, name string) ([]byte, error) {
+	var data []byte
+	found := false
+	err := walkArchive(backupPath, func(hdr *tar.Header, r io.Reader) (bool, error) {
+		if strings.TrimPrefix(hdr.Name, "./") != name || hdr.Typeflag != tar.TypeReg {
+			return false, nil
+		}
+		found = true
+		var err error
+		data, err = io.ReadAll(r)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%s not found in %s", name, filepath.Base(backupPath))
+	}
+	return data, nil
+}
+
+// ExtractFile copies a single file out of a backup to dest, keeping its permissions
+func ExtractFile(backupPath, name, dest string) error {
+	found := false
+	err := walkArchive(backupPath, func(hdr *tar.Header, r io.Reader) (bool, error) {
+		if strings.TrimPrefix(hdr.Name, "./") != name || hdr.Typeflag != tar.TypeReg {
+			return false, nil
+		}
+		found = true
+		out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+		if err != nil {
+			return true, err
+		}
+		if _, err := io.Copy(out, r); err != nil {
+			out.Close()
+			return true, err
+		}
+		return true, out.Close()
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%s not found in %s", name, filepath.Base(backupPath))
+	}
+	return nil
+}
+
+// walkArchive calls fn for each entry of a backup until fn reports done.
+// Zip backups are presented with tar headers too.
+func walkArchive(backupPath string, fn func(hdr *tar.Header, r io.Reader) (done bool, err error)) error {
+	if isZip(backupPath) {
+		return walkZip(backupPath, fn)
+	}
+	f, err := os.Open(backupPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("read backup: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read backup: %w", err)
+		}
+		done, err := fn(hdr, tr)
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// VerifyBackup checks that the backup file is valid. Zip backups are read
+// through in full, so every entry's CRC is checked.
 func VerifyBackup(backupPath string) error {
+	if isZip(backupPath) {
+		if err := walkArchive(backupPath, func(_ *tar.Header, r io.Reader) (bool, error) {
+			_, err := io.Copy(io.Discard, r)
+			return false, err
+		}); err != nil {
+			return fmt.Errorf("backup verification failed: %w", err)
+		}
+		return nil
+	}
 	cmd := exec.Command("tar", "-tzf", backupPath)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("backup verification failed: %w", err)
@@ -64,8 +364,11 @@ func VerifyBackup(backupPath string) error {
 // ListBackups finds all openclaw backup files in the home directory
 func ListBackups() []BackupInfo {
 	home, _ := os.UserHomeDir()
-	pattern := filepath.Join(home, "openclaw-backup-*.tar.gz")
-	matches, _ := filepath.Glob(pattern)
+	var matches []string
+	for _, format := range []string{FormatTarGz, FormatZip} {
+		found, _ := filepath.Glob(filepath.Join(home, "openclaw-backup-*."+format))
+		matches = append(matches, found...)
+	}
 
 	var backups []BackupInfo
 	for _, path := range matches {
@@ -74,15 +377,11 @@ func ListBackups() []BackupInfo {
 			continue
 		}
 		filename := filepath.Base(path)
-		// Extract timestamp from filename: openclaw-backup-20260220-140013.tar.gz
-		ts := strings.TrimPrefix(filename, "openclaw-backup-")
-		ts = strings.TrimSuffix(ts, ".tar.gz")
-
 		backups = append(backups, BackupInfo{
 			Path:      path,
 			Filename:  filename,
 			Size:      info.Size(),
-			Timestamp: ts,
+			Timestamp: Timestamp(filename),
 		})
 	}
 
@@ -94,24 +393,30 @@ func ListBackups() []BackupInfo {
 	return backups
 }
 
-// RestoreBackup extracts a backup archive to restore ~/.openclaw
+// RestoreBackup extracts a backup archive to restore ~/.openclaw. An archive
+// with entries or links reaching outside the home directory is refused
+// before anything is changed.
 func RestoreBackup(backupPath string) error {
 	home, _ := os.UserHomeDir()
 	openclawDir := filepath.Join(home, ".openclaw")
 
+	// Check every entry before anything is removed, so a crafted or
+	// damaged backup can't cost the current installation
+	if err := checkEntries(backupPath, home); err != nil {
+		return fmt.Errorf("restore refused: %w", err)
+	}
+
 	// Remove existing .openclaw if present
 	if _, err := os.Stat(openclawDir); err == nil {
-		if err := os.RemoveAll(openclawDir); err != nil {
+		if err := preserve.Remove(openclawDir); err != nil {
 			return fmt.Errorf("could not remove existing ~/.openclaw: %w", err)
 		}
 	}
 
 	// Extract backup
-	cmd := exec.Command("tar", "-xzf", backupPath, "-C", home)
-	if err := cmd.Run(); err != nil {
+	if err := extractArchive(backupPath, home); err != nil {
 		return fmt.Errorf("restore failed: %w", err)
 	}
-
 	return nil
 }
 
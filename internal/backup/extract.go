@@ -0,0 +1,166 @@
+package backup
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// entryTarget returns where an archive entry belongs under dir, refusing
+// absolute names, names that climb out of dir, and links whose target is
+// absolute or climbs out of dir. tar writes a file with several names as a
+// hard link for every name after the first, so those are allowed too.
+func entryTarget(hdr *tar.Header, dir string) (string, error) {
+	name := filepath.FromSlash(hdr.Name)
+	target := filepath.Join(dir, name)
+	if filepath.IsAbs(name) || strings.HasPrefix(hdr.Name, "/") || !within(dir, target) {
+		return "", fmt.Errorf("%s would be written outside %s", hdr.Name, dir)
+	}
+	switch hdr.Typeflag {
+	case tar.TypeSymlink:
+		if filepath.IsAbs(hdr.Linkname) || !within(dir, filepath.Join(filepath.Dir(target), hdr.Linkname)) {
+			return "", fmt.Errorf("%s links outside %s (to %s)", hdr.Name, dir, hdr.Linkname)
+		}
+	case tar.TypeLink:
+		if filepath.IsAbs(hdr.Linkname) || strings.HasPrefix(hdr.Linkname, "/") || !within(dir, filepath.Join(dir, filepath.FromSlash(hdr.Linkname))) {
+			return "", fmt.Errorf("%s links outside %s (to %s)", hdr.Name, dir, hdr.Linkname)
+		}
+	}
+	return target, nil
+}
+
+// linkSource returns the file a hard link entry gives another name to,
+// which must be a file extracted earlier from the same archive
+func linkSource(hdr *tar.Header, dir string, files map[string]bool) (string, error) {
+	source := filepath.Join(dir, filepath.FromSlash(hdr.Linkname))
+	if !files[source] {
+		return "", fmt.Errorf("%s links to %s, which is not a file earlier in the backup", hdr.Name, hdr.Linkname)
+	}
+	return source, nil
+}
+
+// within reports whether path is dir or inside it
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// checkEntries reads a backup through and fails on the first entry
+// extractArchive would refuse, without writing anything
+func checkEntries(backupPath, dir string) error {
+	links := make(map[string]bool)
+	files := make(map[string]bool)
+	return walkArchive(backupPath, func(hdr *tar.Header, _ io.Reader) (bool, error) {
+		target, err := entryTarget(hdr, dir)
+		if err != nil {
+			return true, err
+		}
+		if via := createdLink(links, dir, target); via != "" {
+			return true, fmt.Errorf("%s would be written through the link %s", hdr.Name, via)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeSymlink:
+			links[target] = true
+			delete(files, target)
+		case tar.TypeLink:
+			if _, err := linkSource(hdr, dir, files); err != nil {
+				return true, err
+			}
+			files[target] = true
+		case tar.TypeReg:
+			files[target] = true
+		}
+		return false, nil
+	})
+}
+
+// extractArchive unpacks a tar.gz or zip backup into dir, keeping modes,
+// times, symlinks and hard links. Entries outside dir and links pointing
+// out of it are refused, nothing is written through a link the archive
+// itself created, and links that resolve outside dir through other links
+// are removed.
+func extractArchive(backupPath, dir string) error {
+	links := make(map[string]bool) // symlinks created so far
+	files := make(map[string]bool) // regular files written so far
+	err := walkArchive(backupPath, func(hdr *tar.Header, r io.Reader) (bool, error) {
+		target, err := entryTarget(hdr, dir)
+		if err != nil {
+			return true, err
+		}
+		if via := createdLink(links, dir, target); via != "" {
+			return true, fmt.Errorf("%s would be written through the link %s", hdr.Name, via)
+		}
+		mode := hdr.FileInfo().Mode().Perm()
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			return false, os.MkdirAll(target, mode|0700)
+		case tar.TypeSymlink:
+			os.MkdirAll(filepath.Dir(target), 0755)
+			os.Remove(target)
+			links[target] = true
+			delete(files, target)
+			return false, os.Symlink(hdr.Linkname, target)
+		case tar.TypeLink:
+			source, err := linkSource(hdr, dir, files)
+			if err != nil {
+				return true, err
+			}
+			os.MkdirAll(filepath.Dir(target), 0755)
+			os.Remove(target)
+			files[target] = true
+			return false, os.Link(source, target)
+		case tar.TypeReg:
+		default:
+			return false, nil // devices, fifos and the like aren't restored
+		}
+
+		os.MkdirAll(filepath.Dir(target), 0755)
+		os.Remove(target) // replace rather than write through what's there
+		out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+		if err != nil {
+			return true, err
+		}
+		files[target] = true
+		if _, err := io.Copy(out, r); err != nil {
+			out.Close()
+			return true, err
+		}
+		if err := out.Close(); err != nil {
+			return true, err
+		}
+		return false, os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Each link's target was checked on its own; a chain of them can
+	// still lead somewhere else once they all exist
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	for link := range links {
+		resolved, err := filepath.EvalSymlinks(link)
+		if err == nil && !within(root, resolved) {
+			os.Remove(link)
+			return fmt.Errorf("%s resolves outside %s — removed", link, dir)
+		}
+	}
+	return nil
+}
+
+// createdLink returns the first parent of target, below dir, that is a
+// link created by this extraction
+func createdLink(links map[string]bool, dir, target string) string {
+	for p := filepath.Dir(target); p != dir && within(dir, p); p = filepath.Dir(p) {
+		if links[p] {
+			return p
+		}
+	}
+	return ""
+}
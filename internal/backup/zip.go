@@ -0,0 +1,162 @@
+package backup
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// zipWriter turns tar entries into zip entries, so a zip backup can be
+// made from the same tar stream as a tar.gz one
+type zipWriter struct {
+	zw   *zip.Writer
+	root string    // the directory tar's entry names are relative to
+	cur  io.Writer // the open entry's writer; nil discards (e.g. devices)
+}
+
+func newZipWriter(w io.Writer, root string) *zipWriter {
+	return &zipWriter{zw: zip.NewWriter(w), root: root}
+}
+
+// WriteHeader starts a zip entry for a tar entry. Directories, regular
+// files and symlinks (stored as their target, with the link mode) are
+// kept. Zip has no hard links, so one is stored as a regular file with
+// the contents of the file it links to. Anything else tar can hold, such
+// as a device or a pipe, has no zip equivalent and is dropped.
+func (z *zipWriter) WriteHeader(hdr *tar.Header) error {
+	z.cur = nil
+	switch hdr.Typeflag {
+	case tar.TypeDir, tar.TypeReg, tar.TypeSymlink:
+	case tar.TypeLink:
+		return z.writeHardLink(hdr)
+	default:
+		return nil
+	}
+	fh, err := zip.FileInfoHeader(hdr.FileInfo())
+	if err != nil {
+		return err
+	}
+	fh.Name = strings.TrimPrefix(hdr.Name, "./")
+	fh.Modified = hdr.ModTime
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		fh.Name = strings.TrimSuffix(fh.Name, "/") + "/"
+		fh.Method = zip.Store
+	case tar.TypeSymlink:
+		fh.Method = zip.Store
+	default:
+		fh.Method = zip.Deflate
+	}
+	w, err := z.zw.CreateHeader(fh)
+	if err != nil {
+		return err
+	}
+	if hdr.Typeflag == tar.TypeSymlink {
+		_, err = io.WriteString(w, hdr.Linkname)
+		return err
+	}
+	z.cur = w
+	return nil
+}
+
+// writeHardLink stores a hard link as a copy of the file it links to, read
+// from disk: tar sends that file's contents only once, with its first name
+func (z *zipWriter) writeHardLink(hdr *tar.Header) error {
+	f, err := os.Open(filepath.Join(z.root, filepath.FromSlash(hdr.Linkname)))
+	if err != nil {
+		return fmt.Errorf("hard link %s: %w", hdr.Name, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("hard link %s: %w", hdr.Name, err)
+	}
+	reg := *hdr
+	reg.Typeflag, reg.Linkname, reg.Size = tar.TypeReg, "", info.Size()
+	if err := z.WriteHeader(&reg); err != nil {
+		return err
+	}
+	if _, err := io.Copy(z.cur, f); err != nil {
+		return fmt.Errorf("hard link %s: %w", hdr.Name, err)
+	}
+	z.cur = nil // tar sends no contents of its own for the link
+	return nil
+}
+
+func (z *zipWriter) Write(b []byte) (int, error) {
+	if z.cur == nil {
+		return len(b), nil
+	}
+	return z.cur.Write(b)
+}
+
+// Close writes the zip's central directory
+func (z *zipWriter) Close() error {
+	return z.zw.Close()
+}
+
+// isZip reports whether a backup is a zip archive, from its first bytes
+// rather than its name
+func isZip(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false
+	}
+	return bytes.Equal(magic, []byte("PK\x03\x04")) || bytes.Equal(magic, []byte("PK\x05\x06"))
+}
+
+// walkZip is walkArchive for zip backups
+func walkZip(backupPath string, fn func(hdr *tar.Header, r io.Reader) (done bool, err error)) error {
+	zr, err := zip.OpenReader(backupPath)
+	if err != nil {
+		return fmt.Errorf("read backup: %w", err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		done, err := visitZipEntry(f, fn)
+		if err != nil || done {
+			return err
+		}
+	}
+	return nil
+}
+
+// visitZipEntry presents one zip entry to fn as a tar header and reader
+func visitZipEntry(f *zip.File, fn func(hdr *tar.Header, r io.Reader) (bool, error)) (bool, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return false, fmt.Errorf("read backup: %w", err)
+	}
+	defer rc.Close()
+
+	mode := f.Mode()
+	hdr := &tar.Header{
+		Name:     f.Name,
+		Size:     int64(f.UncompressedSize64),
+		Mode:     int64(mode.Perm()),
+		ModTime:  f.Modified,
+		Typeflag: tar.TypeReg,
+	}
+	switch {
+	case mode.IsDir():
+		hdr.Typeflag, hdr.Size = tar.TypeDir, 0
+	case mode&os.ModeSymlink != 0:
+		target, err := io.ReadAll(rc)
+		if err != nil {
+			return false, fmt.Errorf("read backup: %w", err)
+		}
+		hdr.Typeflag, hdr.Linkname, hdr.Size = tar.TypeSymlink, string(target), 0
+	}
+	return fn(hdr, rc)
+}
@@ -0,0 +1,455 @@
+// Package bundle packs a prepared PicoClaw home — the binary for the target
+// platform, the converted config and the workspace — into one archive with
+// an install script, so a migration prepared here can be applied on a
+// machine that can't run it itself, e.g. an offline device
+package bundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Names inside a bundle
+const (
+	Dir          = "picoclaw-bundle" // top-level directory everything is under
+	ManifestName = "manifest.json"
+	ScriptName   = "install.sh"
+	SumsName     = "SHA256SUMS" // sha256sum-style list of every other file
+)
+
+// Manifest describes what a bundle holds and what it was made for
+type Manifest struct {
+	ToolVersion     string    `json:"tool_version"` // claw-migrate that made it
+	PicoClawVersion string    `json:"picoclaw_version"`
+	OS              string    `json:"os"`
+	Arch            string    `json:"arch"`
+	Created         time.Time `json:"created"`
+	Data            bool      `json:"data"` // holds a config and workspace, not just the binary
+}
+
+// installScript installs a bundle from where it was unpacked: the binary
+// into ~/.local/bin, the config and workspace into ~/.picoclaw
+const installScript = `#!/bin/sh
+# Installs the PicoClaw in this bundle for the current user.
+# Usage: ./install.sh [--force]   (--force replaces an existing ~/.picoclaw config)
+set -e
+here=$(cd "$(dirname "$0")" && pwd)
+
+if [ -d "$here/.picoclaw" ] && [ -e "$HOME/.picoclaw/config.json" ] && [ "$1" != "--force" ]; then
+	echo "$HOME/.picoclaw already has a config.json - rerun with --force to replace it" >&2
+	exit 1
+fi
+
+mkdir -p "$HOME/.local/bin"
+cp "$here/.local/bin/picoclaw" "$HOME/.local/bin/picoclaw.new"
+chmod 755 "$HOME/.local/bin/picoclaw.new"
+mv "$HOME/.local/bin/picoclaw.new" "$HOME/.local/bin/picoclaw"
+echo "Installed $HOME/.local/bin/picoclaw"
+
+if [ -d "$here/.picoclaw" ]; then
+	mkdir -p "$HOME/.picoclaw"
+	cp -R "$here/.picoclaw/." "$HOME/.picoclaw/"
+	chmod 700 "$HOME/.picoclaw"
+	chmod 600 "$HOME/.picoclaw/config.json"
+	echo "Installed config and workspace in $HOME/.picoclaw"
+	next="picoclaw gateway"
+else
+	next="picoclaw onboard"
+fi
+
+case ":$PATH:" in
+*":$HOME/.local/bin:"*) ;;
+*) echo "Add $HOME/.local/bin to your PATH" ;;
+esac
+echo "Start it with: $next"
+`
+
+// WriteFiles adds the manifest, install script and checksums to a staged
+// home directory, next to its .local/bin and .picoclaw
+func WriteFiles(stage string, m Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(stage, ManifestName), append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(stage, ScriptName), []byte(installScript), 0755); err != nil {
+		return err
+	}
+
+	sums, err := hashFiles(stage)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buf bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%s  %s\n", sums[name], name)
+	}
+	return os.WriteFile(filepath.Join(stage, SumsName), buf.Bytes(), 0644)
+}
+
+// hashFiles returns the SHA-256 of every regular file under root except
+// SumsName, by slash-separated path relative to root
+func hashFiles(root string) (map[string]string, error) {
+	sums := make(map[string]string)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == SumsName {
+			return err
+		}
+		sum, err := HashFile(path)
+		if err != nil {
+			return err
+		}
+		sums[filepath.ToSlash(rel)] = sum
+		return nil
+	})
+	return sums, err
+}
+
+// HashFile returns the hex-encoded SHA-256 of a file
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Zstd reports whether an output name asks for zstd compression
+func Zstd(output string) bool {
+	return strings.HasSuffix(output, ".zst") || strings.HasSuffix(output, ".tzst")
+}
+
+// Pack archives a staged directory under Dir into output, compressed with
+// gzip, or with zstd for .zst/.tzst names (which needs the zstd command).
+// The archive holds credentials, so it is only readable by its owner.
+// Returns the archive's size.
+func Pack(stage, output string) (int64, error) {
+	out, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return 0, fmt.Errorf("could not create bundle: %w", err)
+	}
+
+	var packErr error
+	if Zstd(output) {
+		packErr = packZstd(stage, out)
+	} else {
+		gz := gzip.NewWriter(out)
+		packErr = writeTar(stage, gz)
+		if packErr == nil {
+			packErr = gz.Close()
+		}
+	}
+	if closeErr := out.Close(); packErr == nil {
+		packErr = closeErr
+	}
+	if packErr != nil {
+		os.Remove(output)
+		return 0, packErr
+	}
+
+	info, err := os.Stat(output)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// packZstd compresses the tar stream through the zstd command, as the
+// standard library has no zstd encoder
+func packZstd(stage string, out io.Writer) error {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		return fmt.Errorf("zstd is not installed — install it or name the bundle .tar.gz")
+	}
+	cmd := exec.Command("zstd", "-q", "-c", "-T0")
+	cmd.Stdout = out
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("zstd failed: %w", err)
+	}
+	writeErr := writeTar(stage, in)
+	in.Close()
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("zstd failed: %w", err)
+	}
+	return writeErr
+}
+
+// writeTar writes every file under stage into a tar stream, under Dir
+func writeTar(stage string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(stage, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(stage, path)
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		} else if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil // sockets, pipes and devices don't travel
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(filepath.Join(Dir, rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		hdr.Uname, hdr.Gname = "", "" // the owner is whoever unpacks it
+		hdr.Uid, hdr.Gid = 0, 0
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	return tw.Close()
+}
+
+// Unpack extracts a bundle into dir and returns the bundle's root inside it
+// (dir/Dir). Entries outside Dir, links pointing out of it and entries that
+// would be written through a link the bundle created are refused rather
+// than written.
+func Unpack(path, dir string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var r io.Reader
+	var cmd *exec.Cmd
+	if Zstd(path) {
+		if _, err := exec.LookPath("zstd"); err != nil {
+			return "", fmt.Errorf("zstd is not installed — it is needed to read %s", filepath.Base(path))
+		}
+		cmd = exec.Command("zstd", "-q", "-d", "-c")
+		cmd.Stdin = f
+		out, err := cmd.StdoutPipe()
+		if err != nil {
+			return "", err
+		}
+		if err := cmd.Start(); err != nil {
+			return "", fmt.Errorf("zstd failed: %w", err)
+		}
+		defer cmd.Wait()
+		r = out
+	} else {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return "", fmt.Errorf("not a bundle: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	root := filepath.Join(dir, Dir)
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return "", err
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+	links := make(map[string]bool) // symlinks created so far
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("read bundle: %w", err)
+		}
+		if err := unpackEntry(tr, hdr, dir, root, realRoot, links); err != nil {
+			return "", err
+		}
+	}
+
+	// Each link's target was checked on its own; a chain of them can still
+	// lead somewhere else once they all exist
+	for link := range links {
+		if resolved, err := filepath.EvalSymlinks(link); err == nil && !within(realRoot, resolved) {
+			os.Remove(link)
+			return "", fmt.Errorf("bundle link %s resolves outside the bundle — refusing to unpack", link)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, ManifestName)); err != nil {
+		return "", fmt.Errorf("not a bundle: no %s/%s inside", Dir, ManifestName)
+	}
+	return root, nil
+}
+
+// unpackEntry writes one tar entry under root. Nothing is written through
+// a symlink the bundle created (links), or into a directory that resolves
+// outside realRoot, root's real path.
+func unpackEntry(tr *tar.Reader, hdr *tar.Header, dir, root, realRoot string, links map[string]bool) error {
+	target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+	if !within(root, target) {
+		return fmt.Errorf("bundle entry %q is outside %s/ — refusing to unpack", hdr.Name, Dir)
+	}
+	if via := createdLink(links, root, target); via != "" {
+		return fmt.Errorf("bundle entry %q would be written through the link %s — refusing to unpack", hdr.Name, via)
+	}
+	inside := func() error {
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		parent, err := filepath.EvalSymlinks(filepath.Dir(target))
+		if err != nil {
+			return err
+		}
+		if !within(realRoot, parent) {
+			return fmt.Errorf("bundle entry %q is outside %s/ — refusing to unpack", hdr.Name, Dir)
+		}
+		return nil
+	}
+
+	mode := os.FileMode(hdr.Mode).Perm()
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if err := inside(); err != nil {
+			return err
+		}
+		return os.MkdirAll(target, 0755)
+	case tar.TypeSymlink:
+		if filepath.IsAbs(hdr.Linkname) || !within(root, filepath.Join(filepath.Dir(target), hdr.Linkname)) {
+			return fmt.Errorf("bundle link %q points outside the bundle — refusing to unpack", hdr.Name)
+		}
+		if err := inside(); err != nil {
+			return err
+		}
+		links[target] = true
+		return os.Symlink(hdr.Linkname, target)
+	case tar.TypeReg:
+		if err := inside(); err != nil {
+			return err
+		}
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(out, tr)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	}
+	return nil // nothing else is ever bundled
+}
+
+// createdLink returns the first parent of target, below root, that is a
+// link created by this extraction
+func createdLink(links map[string]bool, root, target string) string {
+	for p := filepath.Dir(target); p != root && within(root, p); p = filepath.Dir(p) {
+		if links[p] {
+			return p
+		}
+	}
+	return ""
+}
+
+// within reports whether path is root or inside it
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Verify reads an unpacked bundle's manifest and checks every file against
+// SHA256SUMS: each listed file must match, and nothing may be unlisted
+func Verify(root string) (Manifest, error) {
+	var m Manifest
+	data, err := os.ReadFile(filepath.Join(root, ManifestName))
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("unreadable %s: %w", ManifestName, err)
+	}
+
+	f, err := os.Open(filepath.Join(root, SumsName))
+	if err != nil {
+		return m, fmt.Errorf("the bundle has no %s", SumsName)
+	}
+	defer f.Close()
+	want := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		sum, name, ok := strings.Cut(scanner.Text(), "  ")
+		if ok {
+			want[name] = sum
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return m, err
+	}
+
+	got, err := hashFiles(root)
+	if err != nil {
+		return m, err
+	}
+	for name, sum := range want {
+		switch g, ok := got[name]; {
+		case !ok:
+			return m, fmt.Errorf("%s is listed in %s but missing", name, SumsName)
+		case g != sum:
+			return m, fmt.Errorf("checksum mismatch for %s — the bundle was changed or damaged", name)
+		}
+	}
+	for name := range got {
+		if _, ok := want[name]; !ok {
+			return m, fmt.Errorf("%s is not listed in %s — the bundle was changed", name, SumsName)
+		}
+	}
+	return m, nil
+}
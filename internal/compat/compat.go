@@ -0,0 +1,193 @@
+// Package compat scores how much of an OpenClaw installation will carry
+// over to PicoClaw, from detection alone, before anything is changed
+package compat
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/config"
+	"github.com/arunbluez/claw-migrate/internal/detect"
+)
+
+// Verdicts for Report.Verdict
+const (
+	VerdictSafe   = "safe to migrate"
+	VerdictReview = "review first"
+)
+
+// safeScore is the lowest overall score that counts as safe to migrate
+const safeScore = 80
+
+// Area is one aspect of the installation: how many of its items carry over
+type Area struct {
+	Name   string
+	Total  int
+	OK     int
+	Weight int      // share of the overall score
+	Notes  []string // what doesn't carry over, for display
+}
+
+// Percent is the share of the area's items that carry over
+func (a Area) Percent() int {
+	if a.Total == 0 {
+		return 100
+	}
+	return a.OK * 100 / a.Total
+}
+
+// Report is the compatibility assessment of one installation
+type Report struct {
+	Areas    []Area // only those with something in them
+	Score    int    // weighted average of the areas' percentages
+	Blockers []string
+}
+
+// Verdict is VerdictSafe when the score is high enough and nothing blocks
+func (r Report) Verdict() string {
+	if r.Score >= safeScore && len(r.Blockers) == 0 {
+		return VerdictSafe
+	}
+	return VerdictReview
+}
+
+// Assess scores an OpenClaw installation: config settings the conversion
+// has a place for, enabled channels PicoClaw supports, skills in a form
+// PicoClaw loads, and session history (which never converts)
+func Assess(oc detect.Installation) Report {
+	var r Report
+	for _, a := range []Area{configArea(oc), channelArea(oc), skillArea(oc), sessionArea(oc)} {
+		if a.Total > 0 {
+			r.Areas = append(r.Areas, a)
+		}
+	}
+
+	r.Score = 100
+	weights, sum := 0, 0
+	for _, a := range r.Areas {
+		weights += a.Weight
+		sum += a.Weight * a.Percent()
+	}
+	if weights > 0 {
+		r.Score = sum / weights
+	}
+
+	for _, a := range r.Areas {
+		if a.Name == "Channels" && a.OK < a.Total {
+			r.Blockers = append(r.Blockers, a.Notes...)
+		}
+	}
+	return r
+}
+
+func configArea(oc detect.Installation) Area {
+	a := Area{Name: "Config settings", Weight: 4}
+	if oc.Config == nil {
+		return a
+	}
+	for _, e := range config.Diff(oc.Config, config.ConvertConfig(oc.Config)) {
+		a.Total++
+		if e.Status != config.DiffDropped {
+			a.OK++
+		}
+	}
+	if unmapped := config.Unmapped(oc.Config); len(unmapped) > 0 {
+		names := make([]string, 0, len(unmapped))
+		for name := range unmapped {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		a.Notes = append(a.Notes, "no PicoClaw equivalent: "+strings.Join(names, ", "))
+	}
+	return a
+}
+
+func channelArea(oc detect.Installation) Area {
+	a := Area{Name: "Channels", Weight: 3}
+	if oc.Config == nil {
+		return a
+	}
+	channels := detect.GetConfiguredChannels(oc.Config)
+	sort.Strings(channels)
+	for _, ch := range channels {
+		a.Total++
+		if config.SupportsChannel(ch) {
+			a.OK++
+		} else {
+			a.Notes = append(a.Notes, ch+" is not supported by PicoClaw")
+		}
+	}
+	return a
+}
+
+// skillArea counts workspace skills with a SKILL.md PicoClaw can load, and
+// OpenClaw plugins, which have no PicoClaw equivalent
+func skillArea(oc detect.Installation) Area {
+	a := Area{Name: "Skills & plugins", Weight: 2}
+	entries, _ := os.ReadDir(filepath.Join(oc.WorkspaceDir, "skills"))
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		a.Total++
+		if hasSkillManifest(filepath.Join(oc.WorkspaceDir, "skills", entry.Name(), "SKILL.md")) {
+			a.OK++
+		} else {
+			a.Notes = append(a.Notes, entry.Name()+" has no SKILL.md with a name and description")
+		}
+	}
+
+	plugins, _ := os.ReadDir(filepath.Join(oc.HomeDir, "extensions"))
+	for _, entry := range plugins {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			a.Total++
+			a.Notes = append(a.Notes, entry.Name()+" is an OpenClaw plugin — reinstall it as a skill")
+		}
+	}
+	return a
+}
+
+// hasSkillManifest reports whether a SKILL.md starts with front matter
+// giving the skill a name and description
+func hasSkillManifest(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "---" {
+		return false
+	}
+	var name, description bool
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "---" {
+			break
+		}
+		name = name || strings.HasPrefix(line, "name:")
+		description = description || strings.HasPrefix(line, "description:")
+	}
+	return name && description
+}
+
+// sessionArea counts session history files. Their format is OpenClaw's
+// own, so none of them carry over; they stay in the backup.
+func sessionArea(oc detect.Installation) Area {
+	a := Area{Name: "Session history", Weight: 1}
+	for _, dir := range []string{
+		filepath.Join(oc.WorkspaceDir, "sessions"),
+		filepath.Join(oc.HomeDir, "sessions"),
+		filepath.Join(oc.HomeDir, "agents"),
+	} {
+		a.Total += detect.CountDirFiles(dir)
+	}
+	if a.Total > 0 {
+		a.Notes = append(a.Notes, "incompatible format — kept in the backup, not converted")
+	}
+	return a
+}
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════
+// Channel access: allowlists, admins and group settings
+// ════════════════════════════════════════════════════════════
+
+// AccessNote is something about who may talk to a channel that the
+// conversion couldn't carry over as it was
+type AccessNote struct {
+	ID   string
+	Text string
+}
+
+// Access settings, by their snake_case key. PicoClaw has one allow_from
+// list per channel: allowlists and admins go into it; per-chat and policy
+// settings it has no place for are left out and noted.
+var (
+	allowKeys      = setOf([]string{"allow_from", "allowed_users", "allowed_user_ids", "allowlist", "allow_list", "dm_allow_from"})
+	groupAllowKeys = setOf([]string{"group_allow_from", "group_allowed_users"})
+	adminKeys      = setOf([]string{"admins", "admin_ids", "admin_users", "owners", "owner_ids"})
+	perChatKeys    = setOf([]string{"groups", "guilds", "group_policy", "dm_policy", "dm"})
+)
+
+// idFormats are what user IDs look like on each platform, and how to say so
+var idFormats = map[string]struct {
+	re   *regexp.Regexp
+	want string
+}{
+	"telegram": {regexp.MustCompile(`^(-?\d+|@?[A-Za-z][A-Za-z0-9_]{4,31})$`), "a numeric user ID or @username"},
+	"discord":  {regexp.MustCompile(`^\d{17,20}$`), "a 17–20 digit user ID"},
+	"slack":    {regexp.MustCompile(`^[UW][A-Z0-9]{6,}$`), "a member ID such as U012AB3CD"},
+	"line":     {regexp.MustCompile(`^U[0-9a-f]{32}$`), "a user ID of U and 32 hex digits"},
+	"qq":       {regexp.MustCompile(`^\d{5,12}$`), "a numeric QQ number"},
+	"onebot":   {regexp.MustCompile(`^\d{5,12}$`), "a numeric QQ number"},
+	"feishu":   {regexp.MustCompile(`^o[un]_[0-9A-Za-z]+$`), "an open_id (ou_...) or union_id (on_...)"},
+}
+
+// maxExactID is the largest integer a JSON number holds exactly; longer
+// IDs written without quotes, like Discord's, have lost their last digits
+const maxExactID = 1 << 53
+
+// CheckChannelID says what is wrong with a user ID for a channel, or ""
+func CheckChannelID(channel, id string) string {
+	if id == "" {
+		return "is empty"
+	}
+	if f, ok := idFormats[channel]; ok && !f.re.MatchString(id) {
+		return fmt.Sprintf("%q doesn't look like %s", id, f.want)
+	}
+	return ""
+}
+
+// channelAccess sorts out one OpenClaw channel's access settings: the IDs
+// to write as allow_from, the keys it took care of, and what to check by
+// hand. An allowlist holding "*" lets everyone in, which in PicoClaw is
+// an empty allow_from, so none is written.
+func channelAccess(name string, ch map[string]interface{}) ([]interface{}, map[string]bool, []AccessNote) {
+	handled := make(map[string]bool)
+	var notes []AccessNote
+	note := func(key, format string, args ...interface{}) {
+		notes = append(notes, AccessNote{
+			ID:   "channel:" + name + ":" + key,
+			Text: fmt.Sprintf("channels.%s.%s ", name, key) + fmt.Sprintf(format, args...),
+		})
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	everyone := "" // the key whose allowlist held "*"
+	add := func(key string, v interface{}) {
+		var lossy, odd []string
+		for _, id := range accessIDs(v) {
+			switch {
+			case id.lossy:
+				lossy = append(lossy, id.value)
+				continue
+			case id.value == "*":
+				everyone = key
+				continue
+			case seen[id.value]:
+				continue
+			}
+			if problem := CheckChannelID(name, id.value); problem != "" {
+				odd = append(odd, problem)
+			}
+			seen[id.value] = true
+			ids = append(ids, id.value)
+		}
+		if len(lossy) > 0 {
+			note(key, "holds %s as bare numbers, which have lost their last digits and were left out — copy them from OpenClaw as quoted strings", strings.Join(lossy, ", "))
+		}
+		if len(odd) > 0 {
+			notes = append(notes, AccessNote{
+				ID:   "channel:" + name + ":" + key + ":format",
+				Text: fmt.Sprintf("channels.%s.%s has IDs that look wrong, kept as they are: %s", name, key, strings.Join(odd, "; ")),
+			})
+		}
+	}
+
+	for _, k := range sortedKeys(ch) {
+		key := camelToSnake(k)
+		switch {
+		case allowKeys[key]:
+			add(k, ch[k])
+		case groupAllowKeys[key]:
+			add(k, ch[k])
+			if len(accessIDs(ch[k])) > 0 {
+				note(k, "was merged into allow_from — PicoClaw has one list, so these users can now also message the bot directly")
+			}
+		case adminKeys[key]:
+			add(k, ch[k])
+			if len(accessIDs(ch[k])) > 0 {
+				note(k, "was merged into allow_from — PicoClaw has no admin role, so admins are ordinary allowed users")
+			}
+		case perChatKeys[key]:
+			if dm, ok := ch[k].(map[string]interface{}); ok && key == "dm" && !dmSettings(k, dm, add) {
+				break // only an allowlist, now in allow_from
+			}
+			note(k, "isn't carried over — PicoClaw has no per-chat or policy settings; %s", perChatAdvice(key, ch[k]))
+		default:
+			continue
+		}
+		handled[k] = true
+	}
+
+	if everyone != "" {
+		note(everyone, "lets everyone in (\"*\"), so no allow_from was written — PicoClaw answers anyone who can reach the bot")
+		return nil, handled, notes
+	}
+	allow := make([]interface{}, len(ids))
+	for i, id := range ids {
+		allow[i] = id
+	}
+	return allow, handled, notes
+}
+
+// dmSettings adds a direct-message block's allowlists and reports whether
+// it sets anything else
+func dmSettings(key string, dm map[string]interface{}, add func(string, interface{})) bool {
+	other := false
+	for _, k := range sortedKeys(dm) {
+		switch {
+		case allowKeys[camelToSnake(k)]:
+			add(key+"."+k, dm[k])
+		case k == "enabled" && dm[k] == true:
+		default:
+			other = true
+		}
+	}
+	return other
+}
+
+// perChatAdvice says what dropping a per-chat or policy setting means
+func perChatAdvice(key string, v interface{}) string {
+	switch key {
+	case "group_policy":
+		if s, _ := v.(string); s == "disabled" {
+			return "OpenClaw ignored group chats here, PicoClaw will answer in them — remove the bot from groups it shouldn't be in"
+		}
+	case "dm_policy":
+		if s, _ := v.(string); s == "pairing" {
+			return "users OpenClaw approved by pairing aren't in the config — add their IDs to allow_from"
+		}
+	}
+	return "restrictions set there now apply nowhere, so check who can reach the bot"
+}
+
+// accessID is one ID from an allowlist, as written in the config
+type accessID struct {
+	value string
+	lossy bool // a bare number too long to be exact
+}
+
+// accessIDs reads an allowlist: a list, or a single ID, of strings or
+// numbers. Telegram IDs are often written as numbers.
+func accessIDs(v interface{}) []accessID {
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+	var ids []accessID
+	for _, item := range items {
+		switch x := item.(type) {
+		case string:
+			if s := strings.TrimSpace(x); s != "" {
+				ids = append(ids, accessID{value: s})
+			}
+		case float64:
+			ids = append(ids, accessID{
+				value: strconv.FormatFloat(x, 'f', -1, 64),
+				lossy: x > maxExactID || x < -maxExactID,
+			})
+		}
+	}
+	return ids
+}
+
+// AccessNotes lists what to check by hand about who may reach each
+// channel PicoClaw supports once its access settings are converted
+func AccessNotes(openclaw map[string]interface{}) []AccessNote {
+	channels, _ := openclaw["channels"].(map[string]interface{})
+	var notes []AccessNote
+	for _, name := range sortedKeys(channels) {
+		ch, ok := channels[name].(map[string]interface{})
+		if !ok || !SupportsChannel(name) {
+			continue
+		}
+		_, _, n := channelAccess(name, ch)
+		notes = append(notes, n...)
+	}
+	return notes
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
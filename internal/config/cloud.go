@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Cloud providers that need more than an API key and base URL
+const (
+	cloudAzure   = "azure"
+	cloudBedrock = "bedrock"
+)
+
+// cloudKind says whether a provider is Azure OpenAI or AWS Bedrock, by its
+// name or its type field
+func cloudKind(name string, conf map[string]interface{}) string {
+	for _, s := range []string{name, str(conf, "type"), str(conf, "provider")} {
+		switch strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(s)) {
+		case "azure", "azureopenai":
+			return cloudAzure
+		case "bedrock", "awsbedrock", "amazonbedrock":
+			return cloudBedrock
+		}
+	}
+	return ""
+}
+
+// str returns the first non-empty string among a config's keys
+func str(conf map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		if s, ok := conf[k].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// azureDeployments lists the deployment names an Azure provider configures:
+// deployment, or a deployments list or map
+func azureDeployments(conf map[string]interface{}) []string {
+	if d := str(conf, "deployment", "deploymentName", "deployment_name"); d != "" {
+		return []string{d}
+	}
+	var names []string
+	switch d := conf["deployments"].(type) {
+	case []interface{}:
+		for _, item := range d {
+			switch v := item.(type) {
+			case string:
+				names = append(names, v)
+			case map[string]interface{}:
+				if n := str(v, "name", "deployment", "deploymentName"); n != "" {
+					names = append(names, n)
+				}
+			}
+		}
+	case map[string]interface{}:
+		for n := range d {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+	}
+	return names
+}
+
+// azureBase returns the OpenAI-compatible v1 base URL of an Azure OpenAI
+// resource, from its endpoint or resource name
+func azureBase(conf map[string]interface{}) string {
+	endpoint := str(conf, "endpoint", "api_base", "apiBase", "baseUrl", "baseURL", "base_url")
+	if endpoint == "" {
+		if resource := str(conf, "resourceName", "resource_name", "resource"); resource != "" {
+			endpoint = "https://" + resource + ".openai.azure.com"
+		}
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	// Only the resource matters; paths like /openai/deployments/x are per-request
+	return u.Scheme + "://" + u.Host + "/openai/v1"
+}
+
+// convertAzure maps an Azure OpenAI provider to one model_list entry per
+// deployment, through Azure's OpenAI-compatible v1 endpoint, which takes
+// the deployment name as the model and needs no api_version
+func convertAzure(name string, conf map[string]interface{}) (entries []map[string]interface{}, legacy map[string]interface{}) {
+	base := azureBase(conf)
+	key := str(conf, "api_key", "apiKey")
+	deployments := azureDeployments(conf)
+	if base == "" || len(deployments) == 0 {
+		return nil, nil // ProviderNotes says what's missing
+	}
+
+	for _, d := range deployments {
+		entry := map[string]interface{}{
+			"model_name": name,
+			"model":      "openai/" + d,
+			"api_base":   base,
+		}
+		if len(deployments) > 1 {
+			entry["model_name"] = name + "-" + d
+		}
+		if key != "" {
+			entry["api_key"] = key
+		}
+		entries = append(entries, entry)
+	}
+	legacy = map[string]interface{}{"api_base": base}
+	if key != "" {
+		legacy["api_key"] = key
+	}
+	return entries, legacy
+}
+
+// ProviderNote is what to do by hand for one provider
+type ProviderNote struct {
+	Provider string
+	Text     string
+}
+
+// ProviderNotes returns what to do by hand for providers the conversion
+// could not carry over: AWS Bedrock, whose requests are signed with AWS
+// credentials, and Azure OpenAI configs without an endpoint or deployment
+func ProviderNotes(openclaw map[string]interface{}) []ProviderNote {
+	providers, ok := openclaw["providers"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var notes []ProviderNote
+	for _, name := range names {
+		conf, ok := providers[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch cloudKind(name, conf) {
+		case cloudAzure:
+			switch {
+			case azureBase(conf) == "":
+				notes = append(notes, ProviderNote{name, fmt.Sprintf("providers.%s (Azure OpenAI) has no endpoint or resource name — add a model_list entry with model openai/<deployment> and api_base https://<resource>.openai.azure.com/openai/v1", name)})
+			case len(azureDeployments(conf)) == 0:
+				notes = append(notes, ProviderNote{name, fmt.Sprintf("providers.%s (Azure OpenAI) names no deployment — add a model_list entry with model openai/<deployment> and api_base %s", name, azureBase(conf))})
+			}
+		case cloudBedrock:
+			detail := []string{}
+			if region := str(conf, "region", "awsRegion", "aws_region"); region != "" {
+				detail = append(detail, "region "+region)
+			}
+			if model := str(conf, "model", "modelId", "model_id"); model != "" {
+				detail = append(detail, "model "+model)
+			}
+			what := "AWS Bedrock"
+			if len(detail) > 0 {
+				what += ", " + strings.Join(detail, ", ")
+			}
+			notes = append(notes, ProviderNote{name, fmt.Sprintf("providers.%s (%s) was not converted: PicoClaw can't sign AWS requests. Run an OpenAI-compatible gateway for Bedrock (e.g. LiteLLM or bedrock-access-gateway) and add it to model_list as openai/<model> with its api_base; AWS credentials were not copied", name, what)})
+		}
+	}
+	return notes
+}
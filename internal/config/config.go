@@ -4,10 +4,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/arunbluez/claw-migrate/internal/preserve"
 )
 
 // ConvertConfig converts OpenClaw config to PicoClaw config format
 func ConvertConfig(openclawConfig map[string]interface{}) map[string]interface{} {
+	picoConfig, _ := convert(openclawConfig)
+	return picoConfig
+}
+
+// convert does ConvertConfig, also returning what it noted about models
+func convert(openclawConfig map[string]interface{}) (map[string]interface{}, []ModelNote) {
 	picoConfig := make(map[string]interface{})
 
 	// Convert providers → model_list (new format) + providers (legacy compat)
@@ -25,48 +35,64 @@ func ConvertConfig(openclawConfig map[string]interface{}) map[string]interface{}
 	// Convert heartbeat
 	convertHeartbeat(openclawConfig, picoConfig)
 
+	// Carry the time zone and locale over; see timezone.go
+	convertTimezone(openclawConfig, picoConfig)
+
 	// Convert MCP servers
 	convertMCPServers(openclawConfig, picoConfig)
 
-	return picoConfig
+	// Give models the prefix of a provider with a key; see prefix.go
+	notes := normalizeModels(picoConfig)
+
+	return picoConfig, notes
 }
 
-// MergeConfig merges converted config into existing PicoClaw config
+// MergeConfig merges converted config into existing PicoClaw config. The
+// converted value wins every key both set; see Merge.
 func MergeConfig(existing, incoming map[string]interface{}) map[string]interface{} {
-	if existing == nil {
-		return incoming
-	}
-	merged := make(map[string]interface{})
-
-	// Copy existing
-	for k, v := range existing {
-		merged[k] = v
-	}
-
-	// Merge incoming (incoming wins for new keys, deep merge for objects)
-	for k, v := range incoming {
-		if existVal, ok := merged[k]; ok {
-			// Deep merge maps
-			if existMap, isMap := existVal.(map[string]interface{}); isMap {
-				if inMap, isMap2 := v.(map[string]interface{}); isMap2 {
-					merged[k] = deepMerge(existMap, inMap)
-					continue
-				}
-			}
-		}
-		merged[k] = v
-	}
-
-	return merged
+	return Merge(existing, incoming, nil)
 }
 
-// WriteConfig writes config to a file
+// WriteConfig writes config to a file and flushes it to disk, so a power
+// loss right after migration can't leave a truncated config behind.
+// The file holds API keys, so it is always left at 0600.
 func WriteConfig(config map[string]interface{}, path string) error {
 	data, err := json.MarshalIndent(config, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal config: %w", err)
 	}
-	return os.WriteFile(path, data, 0644)
+
+	if err := preserve.Keep(path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	// OpenFile only applies the mode on create; tighten existing files too
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	// Sync the parent directory so the file's entry is durable as well
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
 }
 
 // ReadConfig reads and parses a JSON config file
@@ -120,12 +146,32 @@ func convertProviders(src, dst map[string]interface{}) {
 		"ollama":     "ollama/llama3",
 	}
 
-	for name, v := range providers {
-		provConf, ok := v.(map[string]interface{})
+	// Walk providers in a fixed order so model_list comes out the same every run
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		provConf, ok := providers[name].(map[string]interface{})
 		if !ok {
 			continue
 		}
 
+		// Azure and Bedrock need their own handling; see cloud.go
+		switch cloudKind(name, provConf) {
+		case cloudAzure:
+			entries, legacy := convertAzure(name, provConf)
+			modelList = append(modelList, entries...)
+			if legacy != nil {
+				picoProviders[name] = legacy
+			}
+			continue
+		case cloudBedrock:
+			continue
+		}
+
 		apiKey, _ := provConf["api_key"].(string)
 		if apiKey == "" {
 			apiKey, _ = provConf["apiKey"].(string) // camelCase variant
@@ -210,6 +256,9 @@ func convertAgentDefaults(src, dst map[string]interface{}) {
 		}
 	}
 
+	// Fallback and image model chains; see routing.go
+	convertModelChains(agent, defaults)
+
 	// Map other known fields (camelCase → snake_case), skip model (handled above)
 	fieldMap := map[string]string{
 		"max_tokens":          "max_tokens",
@@ -240,6 +289,46 @@ func convertAgentDefaults(src, dst map[string]interface{}) {
 	dst["agents"] = picoAgent
 }
 
+// DefaultChannels are the channels PicoClaw is assumed to support when the
+// installed binary can't say
+var DefaultChannels = []string{"telegram", "discord", "qq", "dingtalk", "line", "slack", "feishu", "onebot"}
+
+var (
+	supportedChannels = setOf(DefaultChannels)
+	supportedTools    map[string]bool // nil = every tool is converted
+)
+
+// SetTarget narrows conversion to what the installed PicoClaw reports.
+// A nil list keeps the default for it.
+func SetTarget(channels, tools []string) {
+	supportedChannels = setOf(DefaultChannels)
+	if channels != nil {
+		supportedChannels = setOf(channels)
+	}
+	supportedTools = nil
+	if tools != nil {
+		supportedTools = setOf(tools)
+	}
+}
+
+// SupportsChannel reports whether the target PicoClaw has a channel
+func SupportsChannel(name string) bool {
+	return supportedChannels[name]
+}
+
+// SupportsTool reports whether the target PicoClaw has a tool
+func SupportsTool(name string) bool {
+	return supportedTools == nil || supportedTools[name]
+}
+
+func setOf(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
+
 func convertChannels(src, dst map[string]interface{}) {
 	channels, ok := src["channels"].(map[string]interface{})
 	if !ok {
@@ -248,15 +337,8 @@ func convertChannels(src, dst map[string]interface{}) {
 
 	picoChannels := make(map[string]interface{})
 
-	// Supported PicoClaw channels
-	supported := map[string]bool{
-		"telegram": true, "discord": true, "qq": true,
-		"dingtalk": true, "line": true, "slack": true,
-		"feishu": true, "onebot": true,
-	}
-
 	for name, v := range channels {
-		if !supported[name] {
+		if !SupportsChannel(name) {
 			continue // skip unsupported channels (whatsapp, signal, etc.)
 		}
 		chConf, ok := v.(map[string]interface{})
@@ -265,9 +347,16 @@ func convertChannels(src, dst map[string]interface{}) {
 		}
 
 		picoChannel := make(map[string]interface{})
-		// Copy all fields, converting camelCase to snake_case
+		// Access settings are mapped to allow_from; see access.go
+		allow, handled, _ := channelAccess(name, chConf)
+		// Copy all other fields, converting camelCase to snake_case
 		for k, val := range chConf {
-			picoChannel[camelToSnake(k)] = val
+			if !handled[k] {
+				picoChannel[camelToSnake(k)] = val
+			}
+		}
+		if len(allow) > 0 {
+			picoChannel["allow_from"] = allow
 		}
 		picoChannels[name] = picoChannel
 	}
@@ -286,7 +375,7 @@ func convertTools(src, dst map[string]interface{}) {
 	picoTools := make(map[string]interface{})
 
 	// Web search tools
-	if web, ok := tools["web"].(map[string]interface{}); ok {
+	if web, ok := tools["web"].(map[string]interface{}); ok && SupportsTool("web") {
 		picoWeb := make(map[string]interface{})
 		if brave, ok := web["brave"].(map[string]interface{}); ok {
 			picoWeb["brave"] = brave
@@ -300,7 +389,7 @@ func convertTools(src, dst map[string]interface{}) {
 	}
 
 	// Cron tools
-	if cron, ok := tools["cron"].(map[string]interface{}); ok {
+	if cron, ok := tools["cron"].(map[string]interface{}); ok && SupportsTool("cron") {
 		picoTools["cron"] = cron
 	}
 
@@ -338,10 +427,17 @@ func convertHeartbeat(src, dst map[string]interface{}) {
 func convertMCPServers(src, dst map[string]interface{}) {
 	// Try both camelCase and snake_case
 	var mcpServers []interface{}
-	if s, ok := src["mcp_servers"].([]interface{}); ok {
-		mcpServers = s
-	} else if s, ok := src["mcpServers"].([]interface{}); ok {
-		mcpServers = s
+keys:
+	for _, key := range []string{"mcp_servers", "mcpServers"} {
+		switch s := src[key].(type) {
+		case []interface{}:
+			mcpServers = s
+		case map[string]interface{}:
+			mcpServers = mcpServerList(s)
+		default:
+			continue
+		}
+		break keys // the first key present wins
 	}
 
 	if len(mcpServers) > 0 {
@@ -349,6 +445,31 @@ func convertMCPServers(src, dst map[string]interface{}) {
 	}
 }
 
+// mcpServerList turns the Claude-style {"github": {...}} form into
+// PicoClaw's list of named entries, sorted by name
+func mcpServerList(servers map[string]interface{}) []interface{} {
+	var names []string
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var list []interface{}
+	for _, name := range names {
+		srv, ok := servers[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		entry := map[string]interface{}{}
+		for k, val := range srv {
+			entry[camelToSnake(k)] = val
+		}
+		entry["name"] = name
+		list = append(list, entry)
+	}
+	return list
+}
+
 // --- Helpers ---
 
 func camelToSnake(s string) string {
@@ -364,23 +485,4 @@ func camelToSnake(s string) string {
 		}
 	}
 	return string(result)
-}
-
-func deepMerge(base, overlay map[string]interface{}) map[string]interface{} {
-	merged := make(map[string]interface{})
-	for k, v := range base {
-		merged[k] = v
-	}
-	for k, v := range overlay {
-		if baseVal, ok := merged[k]; ok {
-			if baseMap, isMap := baseVal.(map[string]interface{}); isMap {
-				if overlayMap, isMap2 := v.(map[string]interface{}); isMap2 {
-					merged[k] = deepMerge(baseMap, overlayMap)
-					continue
-				}
-			}
-		}
-		merged[k] = v
-	}
-	return merged
 }
\ No newline at end of file
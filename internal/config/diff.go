@@ -0,0 +1,219 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Diff statuses for an OpenClaw setting
+const (
+	DiffCarried     = "carried over" // same place, same value
+	DiffTransformed = "transformed"  // moved and/or converted by the migration
+	DiffDropped     = "dropped"      // the conversion has nowhere to put it
+	DiffChanged     = "changed"      // migrated, but PicoClaw's current value differs
+)
+
+// DiffEntry describes what happened to one OpenClaw setting
+type DiffEntry struct {
+	Path   string      // normalized (snake_case) OpenClaw path, e.g. agents.defaults.temperature
+	Value  interface{} // OpenClaw value
+	Status string
+	Dest   []string    // PicoClaw path(s) the setting maps to
+	Now    interface{} // current PicoClaw value at the first Dest (DiffChanged only)
+}
+
+// Diff compares an OpenClaw config with the PicoClaw config it was migrated
+// to. Each OpenClaw setting is traced through ConvertConfig by altering it and
+// seeing which converted settings change, so renames and restructuring (e.g.
+// providers → model_list) are followed rather than guessed from key names.
+func Diff(openclaw, pico map[string]interface{}) []DiffEntry {
+	// Round-trip through JSON so converted values have the same types as a config read from disk
+	baseline := Flatten(cloneConfig(ConvertConfig(cloneConfig(openclaw))))
+	current := Flatten(pico)
+
+	var entries []DiffEntry
+	for path, value := range flattenRaw(openclaw) {
+		e := DiffEntry{Path: normalizePath(path), Value: value}
+
+		probe := cloneConfig(openclaw)
+		setPath(probe, path, perturb(value))
+		for dest, v := range Flatten(cloneConfig(ConvertConfig(probe))) {
+			if old, ok := baseline[dest]; !ok || !reflect.DeepEqual(old, v) {
+				e.Dest = append(e.Dest, dest)
+			}
+		}
+		sort.Strings(e.Dest)
+
+		switch {
+		case len(e.Dest) == 0:
+			e.Status = DiffDropped
+		case !reflect.DeepEqual(current[e.Dest[0]], baseline[e.Dest[0]]):
+			e.Status = DiffChanged
+			e.Now = current[e.Dest[0]]
+		case len(e.Dest) == 1 && e.Dest[0] == e.Path && reflect.DeepEqual(baseline[e.Dest[0]], value):
+			e.Status = DiffCarried
+		default:
+			e.Status = DiffTransformed
+		}
+		entries = append(entries, e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries
+}
+
+// Unmapped returns the top-level OpenClaw sections none of whose settings
+// the conversion carries anywhere, e.g. a plugin's own block
+func Unmapped(openclaw map[string]interface{}) map[string]interface{} {
+	mapped := make(map[string]bool)
+	for _, e := range Diff(openclaw, ConvertConfig(cloneConfig(openclaw))) {
+		if e.Status == DiffDropped {
+			continue
+		}
+		top := e.Path
+		if i := strings.IndexAny(top, ".["); i >= 0 {
+			top = top[:i]
+		}
+		mapped[top] = true
+	}
+
+	out := make(map[string]interface{})
+	for k, v := range openclaw {
+		if strings.HasPrefix(k, "$") || mapped[camelToSnake(k)] {
+			continue // "$schema" and the like describe the file, not settings
+		}
+		if v != nil {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// Flatten turns a config into dotted paths (arrays as name[0]) mapped to
+// their leaf values, with keys normalized to snake_case
+func Flatten(cfg map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{})
+	for path, v := range flattenRaw(cfg) {
+		flat[normalizePath(path)] = v
+	}
+	return flat
+}
+
+// flattenRaw is Flatten without key normalization, so paths can be fed back to setPath
+func flattenRaw(cfg map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{})
+	var walk func(prefix string, v interface{})
+	walk = func(prefix string, v interface{}) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			if len(t) == 0 && prefix != "" {
+				flat[prefix] = t
+			}
+			for k, child := range t {
+				p := k
+				if prefix != "" {
+					p = prefix + "." + k
+				}
+				walk(p, child)
+			}
+		case []interface{}:
+			if len(t) == 0 {
+				flat[prefix] = t
+			}
+			for i, child := range t {
+				walk(fmt.Sprintf("%s[%d]", prefix, i), child)
+			}
+		default:
+			flat[prefix] = v
+		}
+	}
+	walk("", cfg)
+	return flat
+}
+
+// normalizePath converts every key in a dotted path to snake_case
+func normalizePath(path string) string {
+	parts := strings.Split(path, ".")
+	for i, p := range parts {
+		name, index, _ := strings.Cut(p, "[")
+		parts[i] = camelToSnake(name)
+		if index != "" {
+			parts[i] += "[" + index
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// setPath replaces the value at a path produced by flattenRaw
+func setPath(cfg map[string]interface{}, path string, value interface{}) {
+	var parent interface{} = cfg
+	parts := strings.Split(path, ".")
+	for i, p := range parts {
+		name, rest, _ := strings.Cut(p, "[")
+		var indexes []int
+		for rest != "" {
+			var n int
+			fmt.Sscanf(rest, "%d]", &n)
+			indexes = append(indexes, n)
+			_, rest, _ = strings.Cut(rest, "[")
+		}
+
+		m, ok := parent.(map[string]interface{})
+		if !ok {
+			return
+		}
+		last := i == len(parts)-1
+		if len(indexes) == 0 {
+			if last {
+				m[name] = value
+				return
+			}
+			parent = m[name]
+			continue
+		}
+
+		node := m[name]
+		for j, n := range indexes {
+			arr, ok := node.([]interface{})
+			if !ok || n >= len(arr) {
+				return
+			}
+			if last && j == len(indexes)-1 {
+				arr[n] = value
+				return
+			}
+			node = arr[n]
+		}
+		parent = node
+	}
+}
+
+// perturb returns a value of the same type that differs from v
+func perturb(v interface{}) interface{} {
+	switch t := v.(type) {
+	case string:
+		return t + "\x00probe"
+	case float64:
+		return t + 7919.5
+	case bool:
+		return !t
+	case nil:
+		return "\x00probe"
+	default:
+		return []interface{}{"\x00probe"}
+	}
+}
+
+// cloneConfig deep-copies a config so probing can't touch the original
+func cloneConfig(cfg map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(cfg)
+	var out map[string]interface{}
+	json.Unmarshal(data, &out)
+	if out == nil {
+		out = make(map[string]interface{})
+	}
+	return out
+}
@@ -0,0 +1,267 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Which side wins a key both configs set (--prefer)
+const (
+	PreferIncoming = "incoming" // the converted OpenClaw value (the default)
+	PreferExisting = "existing" // the value already in PicoClaw's config
+	PreferAsk      = "ask"      // ask about each such key at the review screen
+)
+
+// Conflict is a key both configs set to different values. Objects on both
+// sides are merged key by key, and keyed lists entry by entry, so a
+// conflict is always a value taken whole from one side: a scalar, a list,
+// or an object the other side has as something else.
+type Conflict struct {
+	Path     string // e.g. agents.defaults.model, or model_list[openrouter].api_key in a keyed list
+	Existing interface{}
+	Incoming interface{}
+}
+
+// keyedLists are lists of objects merged entry by entry instead of taken
+// whole, so entries added by hand survive. Entries are matched by the
+// field naming them.
+var keyedLists = map[string]string{
+	"model_list":  "model_name",
+	"mcp_servers": "name",
+}
+
+// unionLists are lists of plain values, like who may message a channel,
+// that gain what only the incoming list has
+var unionLists = map[string]bool{
+	"allow_from": true,
+}
+
+// Merge deep-merges incoming into existing. Objects are merged at every
+// level; keys only one side sets are kept. Keyed lists are merged by entry
+// name, an entry already there is not added twice, and allowlists are
+// combined. For a conflict, keepExisting is asked with its path whether
+// the existing value stays; nil lets incoming win every one.
+func Merge(existing, incoming map[string]interface{}, keepExisting func(path string) bool) map[string]interface{} {
+	if existing == nil {
+		return incoming
+	}
+	m := merger{keepExisting: keepExisting}
+	return m.object("", existing, incoming)
+}
+
+// Conflicts lists the keys Merge would have to pick a side for, by path
+func Conflicts(existing, incoming map[string]interface{}) []Conflict {
+	// Compare values as they'd be read back from disk, so a converted
+	// config's typed lists match (and flatten) like a parsed one's
+	m := merger{}
+	m.object("", cloneConfig(existing), cloneConfig(incoming))
+	sort.Slice(m.conflicts, func(i, j int) bool { return m.conflicts[i].Path < m.conflicts[j].Path })
+	return m.conflicts
+}
+
+// Missing lists the keys incoming sets that existing doesn't, by dotted
+// path. Objects both set are compared key by key, so a key missing under
+// an existing section is found too; an object existing lacks entirely is
+// one path.
+func Missing(existing, incoming map[string]interface{}) []string {
+	var paths []string
+	var walk func(prefix string, existing, incoming map[string]interface{})
+	walk = func(prefix string, existing, incoming map[string]interface{}) {
+		for k, v := range incoming {
+			path := joinPath(prefix, k)
+			old, ok := existing[k]
+			if !ok {
+				paths = append(paths, path)
+				continue
+			}
+			oldMap, isMap := old.(map[string]interface{})
+			newMap, isMap2 := v.(map[string]interface{})
+			if isMap && isMap2 {
+				walk(path, oldMap, newMap)
+			}
+		}
+	}
+	walk("", cloneConfig(existing), cloneConfig(incoming))
+	sort.Strings(paths)
+	return paths
+}
+
+// KeyConflict is a provider whose API key differs between the configs.
+// PicoClaw's config holds a key in model_list and providers alike, so one
+// KeyConflict covers every path the key is found at.
+type KeyConflict struct {
+	Provider string
+	Paths    []string
+	Existing string
+	Incoming string
+}
+
+// ProviderKeys splits conflicts into the providers' API keys, one per
+// provider, and everything else
+func ProviderKeys(conflicts []Conflict) ([]KeyConflict, []Conflict) {
+	var keys []KeyConflict
+	var rest []Conflict
+	index := make(map[string]int)
+	for _, c := range conflicts {
+		provider := keyProvider(c.Path)
+		existing, ok := c.Existing.(string)
+		incoming, ok2 := c.Incoming.(string)
+		if provider == "" || !ok || !ok2 {
+			rest = append(rest, c)
+			continue
+		}
+		if i, seen := index[provider]; seen {
+			keys[i].Paths = append(keys[i].Paths, c.Path)
+			continue
+		}
+		index[provider] = len(keys)
+		keys = append(keys, KeyConflict{Provider: provider, Paths: []string{c.Path}, Existing: existing, Incoming: incoming})
+	}
+	return keys, rest
+}
+
+// keyProvider returns the provider whose API key a path holds, from
+// model_list[name].api_key or providers.name.api_key, or ""
+func keyProvider(path string) string {
+	if rest, ok := strings.CutPrefix(path, "model_list["); ok {
+		if name, field, ok := strings.Cut(rest, "]."); ok && field == "api_key" {
+			return name
+		}
+		return ""
+	}
+	if rest, ok := strings.CutPrefix(path, "providers."); ok {
+		if name, field, ok := strings.Cut(rest, "."); ok && field == "api_key" {
+			return name
+		}
+	}
+	return ""
+}
+
+type merger struct {
+	keepExisting func(path string) bool
+	conflicts    []Conflict
+}
+
+func (m *merger) object(prefix string, existing, incoming map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(existing)+len(incoming))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range incoming {
+		if old, ok := merged[k]; ok {
+			merged[k] = m.value(joinPath(prefix, k), k, old, v)
+		} else {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// value merges a key both sides set
+func (m *merger) value(path, key string, old, v interface{}) interface{} {
+	oldMap, isMap := old.(map[string]interface{})
+	newMap, isMap2 := v.(map[string]interface{})
+	if isMap && isMap2 {
+		return m.object(path, oldMap, newMap)
+	}
+	oldList, isList := asList(old)
+	newList, isList2 := asList(v)
+	if isList && isList2 {
+		if by, ok := keyedLists[path]; ok {
+			return m.keyedList(path, by, oldList, newList)
+		}
+		if unionLists[key] {
+			return union(oldList, newList)
+		}
+	}
+	if reflect.DeepEqual(old, v) {
+		return v
+	}
+	m.conflicts = append(m.conflicts, Conflict{Path: path, Existing: old, Incoming: v})
+	if m.keepExisting != nil && m.keepExisting(path) {
+		return old
+	}
+	return v
+}
+
+// keyedList merges incoming entries into existing ones of the same name
+// and appends the rest, skipping any already there
+func (m *merger) keyedList(path, by string, existing, incoming []interface{}) []interface{} {
+	merged := append([]interface{}{}, existing...)
+	index := make(map[string]int)
+	for i, e := range merged {
+		if name := entryName(e, by); name != "" {
+			if _, dup := index[name]; !dup {
+				index[name] = i
+			}
+		}
+	}
+	for _, e := range incoming {
+		name := entryName(e, by)
+		if i, ok := index[name]; ok {
+			merged[i] = m.value(fmt.Sprintf("%s[%s]", path, name), "", merged[i], e)
+			continue
+		}
+		if contains(merged, e) {
+			continue
+		}
+		if name != "" {
+			index[name] = len(merged)
+		}
+		merged = append(merged, e)
+	}
+	return merged
+}
+
+// union appends the values only incoming has
+func union(existing, incoming []interface{}) []interface{} {
+	merged := append([]interface{}{}, existing...)
+	for _, v := range incoming {
+		if !contains(merged, v) {
+			merged = append(merged, v)
+		}
+	}
+	return merged
+}
+
+// contains compares by printed value too, so an ID given as a number
+// matches the same ID as text, and a parsed entry the converted one
+func contains(list []interface{}, v interface{}) bool {
+	for _, e := range list {
+		if reflect.DeepEqual(e, v) || fmt.Sprint(e) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func entryName(entry interface{}, by string) string {
+	e, _ := entry.(map[string]interface{})
+	name, _ := e[by].(string)
+	return name
+}
+
+// asList reads a list from a parsed config or a converted one, which
+// builds some lists as []map[string]interface{}
+func asList(v interface{}) ([]interface{}, bool) {
+	switch t := v.(type) {
+	case []interface{}:
+		return t, true
+	case []map[string]interface{}:
+		list := make([]interface{}, len(t))
+		for i, e := range t {
+			list[i] = e
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
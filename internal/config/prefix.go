@@ -0,0 +1,180 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════
+// Model prefixes and the providers that serve them
+// ════════════════════════════════════════════════════════════
+
+// ModelNote is a model whose provider prefix was changed to one the
+// config has a key for, or that no configured provider serves
+type ModelNote struct {
+	Path string // e.g. agents.defaults.model
+	Text string
+}
+
+// openRouter routes models of other vendors as openrouter/<vendor>/<model>
+const openRouter = "openrouter"
+
+// openRouterVendors are the vendors OpenRouter serves, by the name it
+// gives them
+var openRouterVendors = map[string]string{
+	"anthropic": "anthropic",
+	"openai":    "openai",
+	"gemini":    "google",
+	"deepseek":  "deepseek",
+	"zhipu":     "z-ai",
+}
+
+// families infers the vendor of a model named without a prefix
+var families = []struct{ prefix, vendor string }{
+	{"claude", "anthropic"},
+	{"gpt-", "openai"},
+	{"chatgpt", "openai"},
+	{"o1", "openai"},
+	{"o3", "openai"},
+	{"o4", "openai"},
+	{"gemini", "gemini"},
+	{"deepseek", "deepseek"},
+	{"glm", "zhipu"},
+}
+
+// normalizeModels cross-checks the agent's models against the providers
+// in model_list. A model is given the prefix of a provider that can serve
+// it: openrouter/anthropic/... becomes anthropic/... when there is an
+// Anthropic key but no OpenRouter one, anthropic/... goes through
+// OpenRouter when that is the only key, and a bare claude-... gets its
+// vendor. The default model is then what its provider's model_list entry
+// serves. Models no configured provider serves are left and noted.
+func normalizeModels(dst map[string]interface{}) []ModelNote {
+	list, _ := dst["model_list"].([]map[string]interface{})
+	if len(list) == 0 {
+		return nil
+	}
+	configured := make(map[string]map[string]interface{})
+	for _, entry := range list {
+		model, _ := entry["model"].(string)
+		if vendor, _, ok := strings.Cut(model, "/"); ok && configured[vendor] == nil {
+			configured[vendor] = entry
+		}
+	}
+	agents, _ := dst["agents"].(map[string]interface{})
+	defaults, _ := agents["defaults"].(map[string]interface{})
+	if defaults == nil {
+		return nil
+	}
+
+	var notes []ModelNote
+	fix := func(path, model string) string {
+		fixed, ok := servedModel(model, configured)
+		switch {
+		case !ok:
+			notes = append(notes, ModelNote{path, unservedText(path, model)})
+			return model
+		case fixed != model:
+			notes = append(notes, ModelNote{path, fmt.Sprintf("%s %s was written as %s, the provider this config has a key for — check it names the same model there", path, model, fixed)})
+		}
+		return fixed
+	}
+
+	for _, key := range []string{"model", "image_model"} {
+		if model, ok := defaults[key].(string); ok && model != "" {
+			defaults[key] = fix("agents.defaults."+key, model)
+		}
+	}
+	for _, key := range []string{"model_fallbacks", "image_model_fallbacks"} {
+		chain, _ := defaults[key].([]interface{})
+		for i, v := range chain {
+			if model, ok := v.(string); ok {
+				chain[i] = fix(fmt.Sprintf("agents.defaults.%s[%d]", key, i), model)
+			}
+		}
+	}
+
+	// Have the provider's entry serve the default model, so the agent
+	// names a model model_list knows
+	if model, ok := defaults["model"].(string); ok && !servedBy(list, model) {
+		vendor, _, _ := strings.Cut(model, "/")
+		if entry := configured[vendor]; entry != nil && entry["model_name"] == vendor {
+			entry["model"] = model
+		}
+	}
+	return notes
+}
+
+// servedModel returns model with the prefix of a configured provider that
+// serves it, or false if none does
+func servedModel(model string, configured map[string]map[string]interface{}) (string, bool) {
+	vendor, rest, prefixed := strings.Cut(model, "/")
+	if !prefixed {
+		vendor, rest = inferVendor(model), model
+		if vendor == "" {
+			return model, false
+		}
+		model = vendor + "/" + rest
+	}
+	switch {
+	case configured[vendor] != nil:
+		return model, true
+	case vendor == openRouter:
+		// openrouter/<vendor>/<model> without an OpenRouter key
+		inner, name, ok := strings.Cut(rest, "/")
+		for own, routed := range openRouterVendors {
+			if ok && routed == inner && configured[own] != nil {
+				return own + "/" + name, true
+			}
+		}
+	case configured[openRouter] != nil && openRouterVendors[vendor] != "":
+		return openRouter + "/" + openRouterVendors[vendor] + "/" + rest, true
+	}
+	return model, false
+}
+
+// inferVendor names the vendor of a model given without a prefix, or ""
+func inferVendor(model string) string {
+	lower := strings.ToLower(model)
+	for _, f := range families {
+		if strings.HasPrefix(lower, f.prefix) {
+			return f.vendor
+		}
+	}
+	return ""
+}
+
+func unservedText(path, model string) string {
+	vendor, _, prefixed := strings.Cut(model, "/")
+	if !prefixed {
+		return fmt.Sprintf("%s %s has no provider prefix and none could be inferred — write it as <provider>/%s", path, model, model)
+	}
+	return fmt.Sprintf("%s %s is served by %s, which the config has no key for — add it to model_list or pick a model from a provider it has", path, model, vendor)
+}
+
+// servedBy reports whether a model_list entry serves model
+func servedBy(list []map[string]interface{}, model string) bool {
+	for _, entry := range list {
+		if entry["model"] == model || entry["model_name"] == model {
+			return true
+		}
+	}
+	return false
+}
+
+// ModelNotes lists what converting an OpenClaw config changes or flags
+// about its models' providers
+func ModelNotes(openclaw map[string]interface{}) []ModelNote {
+	_, notes := convert(openclaw)
+	return notes
+}
+
+// ConvertedModel returns the default model as converting an OpenClaw
+// config writes it, with its provider prefix settled, or ""
+func ConvertedModel(openclaw map[string]interface{}) string {
+	cfg, _ := convert(openclaw)
+	agents, _ := cfg["agents"].(map[string]interface{})
+	defaults, _ := agents["defaults"].(map[string]interface{})
+	model, _ := defaults["model"].(string)
+	return model
+}
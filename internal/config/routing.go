@@ -0,0 +1,119 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// convertModelChains carries OpenClaw's model chains — a model object's
+// fallbacks, and the image model with its own fallbacks — into PicoClaw's
+// model_fallbacks, image_model and image_model_fallbacks
+func convertModelChains(agent, defaults map[string]interface{}) {
+	if m, ok := agent["model"].(map[string]interface{}); ok {
+		if fallbacks := stringList(m["fallbacks"]); len(fallbacks) > 0 {
+			defaults["model_fallbacks"] = fallbacks
+		}
+	}
+
+	for _, key := range []string{"imageModel", "image_model"} {
+		switch m := agent[key].(type) {
+		case string:
+			if m != "" {
+				defaults["image_model"] = m
+			}
+		case map[string]interface{}:
+			if primary := str(m, "primary", "name", "model", "default"); primary != "" {
+				defaults["image_model"] = primary
+			}
+			if fallbacks := stringList(m["fallbacks"]); len(fallbacks) > 0 {
+				defaults["image_model_fallbacks"] = fallbacks
+			}
+		}
+	}
+}
+
+// stringList returns the non-empty strings of a JSON list
+func stringList(v interface{}) []interface{} {
+	items, _ := v.([]interface{})
+	var out []interface{}
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Route is a model chosen for one task, at a config path
+type Route struct {
+	Path  string
+	Model string
+}
+
+// UnmappedRoutes lists the model routing in an OpenClaw config that
+// PicoClaw has no place for — a model chosen per task (heartbeat,
+// subagents, summaries, ...) rather than the default and image chains —
+// so the loss can be reported instead of silent. Those tasks will use the
+// default model.
+func UnmappedRoutes(openclaw map[string]interface{}) []Route {
+	agent, path := agentDefaults(openclaw)
+	if agent == nil {
+		return nil
+	}
+
+	var routes []Route
+	keys := make([]string, 0, len(agent))
+	for k := range agent {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch key {
+		case "model", "imageModel", "image_model":
+			continue // converted
+		}
+		routes = append(routes, modelRoutes(path+"."+key, agent[key])...)
+	}
+	return routes
+}
+
+// agentDefaults returns the OpenClaw agent defaults, from either config
+// shape, and their path
+func agentDefaults(openclaw map[string]interface{}) (map[string]interface{}, string) {
+	if agent, ok := openclaw["agent"].(map[string]interface{}); ok {
+		return agent, "agent"
+	}
+	if agents, ok := openclaw["agents"].(map[string]interface{}); ok {
+		if defaults, ok := agents["defaults"].(map[string]interface{}); ok {
+			return defaults, "agents.defaults"
+		}
+	}
+	return nil, ""
+}
+
+// modelRoutes finds the models a setting routes to: a "model" or
+// "primary" key anywhere below it, or a routing table of task → model
+func modelRoutes(path string, v interface{}) []Route {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var routes []Route
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	routing := strings.Contains(strings.ToLower(path), "rout")
+	for _, k := range keys {
+		switch val := m[k].(type) {
+		case string:
+			if k == "model" || k == "primary" || routing && strings.Contains(val, "/") {
+				routes = append(routes, Route{path + "." + k, val})
+			}
+		case map[string]interface{}:
+			routes = append(routes, modelRoutes(path+"."+k, val)...)
+		}
+	}
+	return routes
+}
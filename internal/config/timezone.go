@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ════════════════════════════════════════════════════════════
+// Time zone and locale
+// ════════════════════════════════════════════════════════════
+
+// ScheduleNote is a heartbeat or cron schedule that may fire at different
+// clock times once PicoClaw runs it
+type ScheduleNote struct {
+	ID   string
+	Text string
+}
+
+// convertTimezone carries the agent's time zone and locale over to
+// agents.defaults. Without a time zone in the OpenClaw config, the one
+// OpenClaw scheduled in was the system's, so that is written instead.
+func convertTimezone(src, dst map[string]interface{}) {
+	tz := Timezone(src)
+	if tz == "" {
+		tz = SystemTimezone()
+	}
+	locale := Locale(src)
+	if tz == "" && locale == "" {
+		return
+	}
+
+	agents, ok := dst["agents"].(map[string]interface{})
+	if !ok {
+		agents = map[string]interface{}{}
+		dst["agents"] = agents
+	}
+	defaults, ok := agents["defaults"].(map[string]interface{})
+	if !ok {
+		defaults = map[string]interface{}{}
+		agents["defaults"] = defaults
+	}
+	if tz != "" {
+		defaults["timezone"] = tz
+	}
+	if locale != "" {
+		defaults["locale"] = locale
+	}
+}
+
+// Timezone returns the time zone an OpenClaw config schedules in, from
+// the agent defaults or the top level, or "" if it sets none
+func Timezone(openclaw map[string]interface{}) string {
+	defaults, _ := agentDefaults(openclaw)
+	if tz := str(defaults, "userTimezone", "user_timezone", "timezone", "timeZone", "tz"); tz != "" {
+		return tz
+	}
+	return str(openclaw, "timezone", "timeZone", "tz")
+}
+
+// Locale returns the locale an OpenClaw config sets for the agent, or ""
+func Locale(openclaw map[string]interface{}) string {
+	defaults, _ := agentDefaults(openclaw)
+	if locale := str(defaults, "locale", "userLocale", "user_locale"); locale != "" {
+		return locale
+	}
+	return str(openclaw, "locale")
+}
+
+// SystemTimezone returns the IANA name of this machine's time zone, from
+// $TZ, /etc/timezone or the /etc/localtime link, or "" if it can't tell
+func SystemTimezone() string {
+	if tz := strings.TrimPrefix(os.Getenv("TZ"), ":"); tz != "" && !filepath.IsAbs(tz) {
+		return tz
+	}
+	if data, err := os.ReadFile("/etc/timezone"); err == nil {
+		if tz := strings.TrimSpace(string(data)); tz != "" {
+			return tz
+		}
+	}
+	if target, err := filepath.EvalSymlinks("/etc/localtime"); err == nil {
+		if _, tz, ok := strings.Cut(filepath.ToSlash(target), "zoneinfo/"); ok {
+			return tz
+		}
+	}
+	return ""
+}
+
+// ScheduleNotes returns what to check by hand about schedules after the
+// migration: heartbeat active hours, which PicoClaw doesn't have, and,
+// when OpenClaw scheduled in a time zone other than this machine's,
+// hourly or longer heartbeats and cron jobs, whose times may shift by the
+// difference if PicoClaw runs them on the system clock
+func ScheduleNotes(openclaw map[string]interface{}, hasCron bool) []ScheduleNote {
+	var notes []ScheduleNote
+	heartbeat, _ := openclaw["heartbeat"].(map[string]interface{})
+
+	if hours, ok := heartbeat["activeHours"].(map[string]interface{}); ok {
+		window := strings.Trim(str(hours, "start")+"–"+str(hours, "end"), "–")
+		if tz := str(hours, "timezone", "timeZone", "tz"); tz != "" {
+			window += " " + tz
+		}
+		notes = append(notes, ScheduleNote{"schedule:active-hours", fmt.Sprintf("heartbeat.activeHours (%s) isn't carried over — PicoClaw's heartbeat runs around the clock; disable it outside those hours by hand if that matters", window)})
+	}
+
+	tz, system := Timezone(openclaw), SystemTimezone()
+	shift := offsetBetween(tz, system)
+	if shift == 0 {
+		return notes
+	}
+	if interval, ok := heartbeat["interval"].(float64); ok && interval >= 60 {
+		notes = append(notes, ScheduleNote{"schedule:heartbeat", fmt.Sprintf("The heartbeat every %g min was timed in %s; this machine is on %s (%s), so beats tied to the clock may land at other hours", interval, tz, system, formatShift(shift))})
+	}
+	if hasCron {
+		notes = append(notes, ScheduleNote{"schedule:cron", fmt.Sprintf("Cron jobs were written for %s; this machine is on %s (%s) — adjust their times when recreating them if PicoClaw runs them on the system clock", tz, system, formatShift(shift))})
+	}
+	return notes
+}
+
+// offsetBetween returns how far the system time zone is ahead of tz right
+// now, or 0 if either is unknown
+func offsetBetween(tz, system string) time.Duration {
+	if tz == "" || system == "" || tz == system {
+		return 0
+	}
+	from, err := time.LoadLocation(tz)
+	if err != nil {
+		return 0
+	}
+	to, err := time.LoadLocation(system)
+	if err != nil {
+		return 0
+	}
+	now := time.Now()
+	_, a := now.In(from).Zone()
+	_, b := now.In(to).Zone()
+	return time.Duration(b-a) * time.Second
+}
+
+func formatShift(d time.Duration) string {
+	if d > 0 {
+		return fmt.Sprintf("%g h ahead", d.Hours())
+	}
+	return fmt.Sprintf("%g h behind", -d.Hours())
+}
@@ -0,0 +1,124 @@
+package detect
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Where a Capabilities value came from
+const (
+	CapsBinary  = "capabilities" // `picoclaw capabilities --json`
+	CapsHelp    = "help"         // parsed from `picoclaw --help`
+	CapsUnknown = "unknown"      // the binary couldn't be asked; assume the defaults
+)
+
+// capabilitiesSchema is the `capabilities --json` format this tool understands
+const capabilitiesSchema = 1
+
+// probeTimeout bounds each query of the binary, so a hung picoclaw can't stall the run
+const probeTimeout = 5 * time.Second
+
+// Capabilities is what the installed PicoClaw binary says it supports.
+// Lists the binary didn't report are nil, meaning "use the defaults".
+type Capabilities struct {
+	Source   string
+	Version  string
+	Commands []string
+	Channels []string
+	Tools    []string
+}
+
+// HasCommand reports whether the binary has a subcommand. Without a binary
+// it's false; if the binary's commands couldn't be learned it's assumed true.
+func (c Capabilities) HasCommand(name string) bool {
+	if c.Source == "" {
+		return false
+	}
+	if c.Commands == nil {
+		return true
+	}
+	for _, cmd := range c.Commands {
+		if cmd == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ProbePicoClaw asks an installed PicoClaw what it supports: first through
+// `picoclaw capabilities --json`, then by reading the command list from
+// `picoclaw --help`. It returns the zero value if there is no binary.
+func ProbePicoClaw(pc Installation) Capabilities {
+	if pc.BinaryPath == "" {
+		return Capabilities{}
+	}
+	caps := Capabilities{Source: CapsUnknown, Version: pc.Version}
+
+	if out, err := runProbe(pc.BinaryPath, "capabilities", "--json"); err == nil {
+		var reported struct {
+			Schema   int      `json:"schema"`
+			Version  string   `json:"version"`
+			Commands []string `json:"commands"`
+			Channels []string `json:"channels"`
+			Tools    []string `json:"tools"`
+		}
+		// Later schemas may change what the lists mean, so only trust the one we know
+		if json.Unmarshal(out, &reported) == nil && reported.Schema == capabilitiesSchema {
+			caps.Source = CapsBinary
+			if reported.Version != "" {
+				caps.Version = reported.Version
+			}
+			caps.Commands, caps.Channels, caps.Tools = reported.Commands, reported.Channels, reported.Tools
+			return caps
+		}
+	}
+
+	// Older releases: the help text lists commands but not channels or tools.
+	// Help often exits non-zero, so parse whatever was printed.
+	out, _ := runProbe(pc.BinaryPath, "--help")
+	if commands := parseHelpCommands(string(out)); len(commands) > 0 {
+		caps.Source = CapsHelp
+		caps.Commands = commands
+	}
+	return caps
+}
+
+// runProbe runs the binary with a timeout and returns its combined output
+func runProbe(binary string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	return exec.CommandContext(ctx, binary, args...).CombinedOutput()
+}
+
+// parseHelpCommands reads the command names from a "Commands:" or
+// "Available Commands:" section of help output (cobra and urfave/cli style)
+func parseHelpCommands(help string) []string {
+	var commands []string
+	inSection := false
+	scanner := bufio.NewScanner(strings.NewReader(help))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if !inSection {
+			lower := strings.ToLower(trimmed)
+			inSection = lower == "commands:" || lower == "available commands:"
+			continue
+		}
+		// The section ends at a blank or unindented line
+		if trimmed == "" || line[0] != ' ' && line[0] != '\t' {
+			if len(commands) > 0 {
+				break
+			}
+			continue
+		}
+		name := strings.TrimSuffix(strings.Fields(trimmed)[0], ",")
+		if name != "help" && !strings.HasPrefix(name, "-") {
+			commands = append(commands, name)
+		}
+	}
+	return commands
+}
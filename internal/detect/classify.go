@@ -0,0 +1,126 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// File kinds used in workspace composition reports
+const (
+	KindMarkdown = "markdown"
+	KindJSON     = "json"
+	KindText     = "text"
+	KindCode     = "code"
+	KindImage    = "image"
+	KindAudio    = "audio"
+	KindVideo    = "video"
+	KindArchive  = "archive"
+	KindDatabase = "database"
+	KindOther    = "other"
+)
+
+// kindByExt maps lower-case file extensions to kinds
+var kindByExt = map[string]string{
+	".md": KindMarkdown, ".markdown": KindMarkdown, ".mdx": KindMarkdown,
+
+	".json": KindJSON, ".jsonl": KindJSON, ".ndjson": KindJSON, ".json5": KindJSON,
+
+	".txt": KindText, ".log": KindText, ".csv": KindText, ".tsv": KindText,
+	".yaml": KindText, ".yml": KindText, ".toml": KindText, ".ini": KindText,
+	".xml": KindText, ".html": KindText, ".htm": KindText, ".env": KindText,
+
+	".py": KindCode, ".js": KindCode, ".mjs": KindCode, ".cjs": KindCode,
+	".ts": KindCode, ".tsx": KindCode, ".jsx": KindCode, ".go": KindCode,
+	".sh": KindCode, ".bash": KindCode, ".zsh": KindCode, ".rb": KindCode,
+	".rs": KindCode, ".java": KindCode, ".c": KindCode, ".h": KindCode,
+	".cpp": KindCode, ".css": KindCode, ".sql": KindCode, ".lua": KindCode,
+
+	".png": KindImage, ".jpg": KindImage, ".jpeg": KindImage, ".gif": KindImage,
+	".webp": KindImage, ".svg": KindImage, ".bmp": KindImage, ".ico": KindImage,
+	".heic": KindImage, ".tif": KindImage, ".tiff": KindImage,
+
+	".mp3": KindAudio, ".wav": KindAudio, ".ogg": KindAudio, ".oga": KindAudio,
+	".opus": KindAudio, ".m4a": KindAudio, ".flac": KindAudio, ".aac": KindAudio,
+
+	".mp4": KindVideo, ".mov": KindVideo, ".webm": KindVideo, ".mkv": KindVideo,
+	".avi": KindVideo,
+
+	".zip": KindArchive, ".tar": KindArchive, ".gz": KindArchive, ".tgz": KindArchive,
+	".bz2": KindArchive, ".xz": KindArchive, ".zst": KindArchive, ".7z": KindArchive,
+
+	".db": KindDatabase, ".sqlite": KindDatabase, ".sqlite3": KindDatabase,
+	".db-wal": KindDatabase, ".db-shm": KindDatabase,
+}
+
+// kindLabels are display names for the kinds
+var kindLabels = map[string]string{
+	KindMarkdown: "Markdown", KindJSON: "JSON", KindText: "Text", KindCode: "Code",
+	KindImage: "Images", KindAudio: "Audio", KindVideo: "Video",
+	KindArchive: "Archives", KindDatabase: "Databases", KindOther: "Other",
+}
+
+// KindLabel returns the display name of a kind
+func KindLabel(kind string) string {
+	if label, ok := kindLabels[kind]; ok {
+		return label
+	}
+	return kind
+}
+
+// Classify returns the kind of a file from its name
+func Classify(name string) string {
+	if kind, ok := kindByExt[strings.ToLower(filepath.Ext(name))]; ok {
+		return kind
+	}
+	return KindOther
+}
+
+// IsBinaryKind reports whether files of a kind are never worth counting lines in
+func IsBinaryKind(kind string) bool {
+	switch kind {
+	case KindImage, KindAudio, KindVideo, KindArchive, KindDatabase:
+		return true
+	}
+	return false
+}
+
+// KindStats totals the files of one kind
+type KindStats struct {
+	Kind  string
+	Files int
+	Size  int64
+}
+
+// Composition breaks the files under path down by kind, largest first
+func Composition(path string) []KindStats {
+	kinds, ok := cachedKinds(path)
+	if !ok {
+		kinds = make(map[string]DirStats)
+		filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			s := kinds[Classify(d.Name())]
+			s.Files++
+			if info, err := d.Info(); err == nil {
+				s.Size += info.Size()
+			}
+			kinds[Classify(d.Name())] = s
+			return nil
+		})
+	}
+
+	out := make([]KindStats, 0, len(kinds))
+	for kind, s := range kinds {
+		out = append(out, KindStats{Kind: kind, Files: s.Files, Size: s.Size})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Size != out[j].Size {
+			return out[i].Size > out[j].Size
+		}
+		return out[i].Kind < out[j].Kind
+	})
+	return out
+}
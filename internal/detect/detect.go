@@ -1,12 +1,15 @@
 package detect
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 )
 
@@ -21,12 +24,15 @@ type Installation struct {
 	WorkspaceFiles map[string]bool // which standard workspace files exist
 	ExtraFiles     []string        // non-standard .md files in workspace root
 	ExtraDirs      []string        // non-standard directories in workspace root
+	Extras         []ExtraItem     // non-standard, hidden and secret-bearing items, recursively
+	HomeItems      []WorkspaceItem // everything in the home dir besides the workspace and config
 	HasMemory      bool
 	HasSkills      bool
 	HasCron        bool
 	HasSessions    bool
 	Config         map[string]interface{} // parsed JSON config
 	ConfigSummary  ConfigSummary          // human-readable config overview
+	Capabilities   Capabilities           // what the binary supports, once probed (PicoClaw only)
 }
 
 // WorkspaceItem describes a file or directory in the workspace
@@ -81,6 +87,13 @@ var StandardDirs = map[string]bool{
 
 // DetectOpenClaw checks for an OpenClaw installation
 func DetectOpenClaw() Installation {
+	inst, _ := DetectOpenClawContext(context.Background())
+	return inst
+}
+
+// DetectOpenClawContext is DetectOpenClaw with a cancellable deep scan. On
+// cancellation it returns what was found so far along with ctx's error.
+func DetectOpenClawContext(ctx context.Context) (Installation, error) {
 	home, _ := os.UserHomeDir()
 	inst := Installation{
 		HomeDir:        filepath.Join(home, ".openclaw"),
@@ -89,7 +102,7 @@ func DetectOpenClaw() Installation {
 
 	// Check if directory exists
 	if _, err := os.Stat(inst.HomeDir); os.IsNotExist(err) {
-		return inst
+		return inst, nil
 	}
 	inst.Found = true
 
@@ -100,8 +113,23 @@ func DetectOpenClaw() Installation {
 		inst.ConfigSummary = extractConfigSummary(inst.Config, inst.ConfigPath)
 	}
 
-	// Workspace
+	// Workspace — the configured path is the source of truth
 	inst.WorkspaceDir = filepath.Join(inst.HomeDir, "workspace")
+	if inst.ConfigSummary.WorkspacePath != "" {
+		inst.WorkspaceDir = resolvePath(inst.ConfigSummary.WorkspacePath, inst.HomeDir)
+	}
+
+	// One concurrent scan of the home dir (and a workspace outside it) answers
+	// every size, file and line count here and in the later phases
+	lineDirs := []string{inst.WorkspaceDir}
+	if _, err := Scan(ctx, inst.HomeDir, lineDirs); err != nil {
+		return inst, err
+	}
+	if _, ok := cachedStats(inst.WorkspaceDir); !ok {
+		if _, err := Scan(ctx, inst.WorkspaceDir, lineDirs); err != nil {
+			return inst, err
+		}
+	}
 
 	// Scan ALL workspace contents
 	entries, err := os.ReadDir(inst.WorkspaceDir)
@@ -109,19 +137,24 @@ func DetectOpenClaw() Installation {
 		for _, entry := range entries {
 			name := entry.Name()
 			if entry.IsDir() {
-				if !StandardDirs[name] {
+				if !StandardDirs[name] && !IsJunk(name) {
 					inst.ExtraDirs = append(inst.ExtraDirs, name)
 				}
 			} else {
 				if StandardFiles[name] {
 					inst.WorkspaceFiles[name] = true
-				} else if name != ".DS_Store" && name != ".gitignore" {
+				} else if !IsJunk(name) && name != ".gitignore" {
 					inst.ExtraFiles = append(inst.ExtraFiles, name)
 				}
 			}
 		}
 	}
 
+	inst.Extras = scanExtras(inst.WorkspaceDir)
+
+	// Inventory the rest of the home directory (state, credentials, DBs, ...)
+	inst.HomeItems = scanHomeItems(inst.HomeDir, inst.ConfigPath, inst.WorkspaceDir)
+
 	// Check standard subdirectories
 	inst.HasMemory = dirHasFiles(filepath.Join(inst.WorkspaceDir, "memory"))
 	inst.HasSkills = dirHasFiles(filepath.Join(inst.WorkspaceDir, "skills"))
@@ -136,7 +169,7 @@ func DetectOpenClaw() Installation {
 		}
 	}
 
-	return inst
+	return inst, nil
 }
 
 // DetectPicoClaw checks for a PicoClaw installation
@@ -158,8 +191,8 @@ func DetectPicoClaw() Installation {
 		inst.Config = parseJSONFile(inst.ConfigPath)
 	}
 
-	// Workspace
-	inst.WorkspaceDir = filepath.Join(inst.HomeDir, "workspace")
+	// Workspace (the config may point it elsewhere)
+	inst.WorkspaceDir = PicoClawWorkspace(inst.HomeDir)
 
 	// Check workspace files
 	wsFiles := []string{"SOUL.md", "IDENTITY.md", "AGENTS.md", "USER.md", "TOOLS.md", "HEARTBEAT.md"}
@@ -181,6 +214,35 @@ func DetectPicoClaw() Installation {
 	return inst
 }
 
+// IsCustomWorkspace reports whether the workspace lives outside the default <home>/workspace
+func (inst Installation) IsCustomWorkspace() bool {
+	return inst.WorkspaceDir != filepath.Join(inst.HomeDir, "workspace")
+}
+
+// PicoClawWorkspace resolves the workspace directory PicoClaw will use,
+// honoring agents.defaults.workspace in its config and falling back to
+// <picoHome>/workspace
+func PicoClawWorkspace(picoHome string) string {
+	cfg := parseJSONFile(filepath.Join(picoHome, "config.json"))
+	if agents, ok := cfg["agents"].(map[string]interface{}); ok {
+		if defaults, ok := agents["defaults"].(map[string]interface{}); ok {
+			if ws, ok := defaults["workspace"].(string); ok && ws != "" {
+				return resolvePath(ws, picoHome)
+			}
+		}
+	}
+	return filepath.Join(picoHome, "workspace")
+}
+
+// ExpandHome expands a leading ~ to the user's home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
 // GetProviderKeys extracts provider API key names from OpenClaw config
 func GetProviderKeys(config map[string]interface{}) []string {
 	var keys []string
@@ -207,29 +269,29 @@ func GetConfiguredChannels(config map[string]interface{}) []string {
 	return channels
 }
 
-// GetMCPServers returns MCP server names from config
+// GetMCPServers returns MCP server names from config. Servers may be a
+// list of entries with a name, or a Claude-style object keyed by name.
 func GetMCPServers(config map[string]interface{}) []string {
 	var servers []string
 
-	// Check mcp_servers array
-	if mcpArr, ok := config["mcp_servers"].([]interface{}); ok {
-		for _, s := range mcpArr {
-			if srv, ok := s.(map[string]interface{}); ok {
-				if name, ok := srv["name"].(string); ok {
-					servers = append(servers, name)
+	// Check mcp_servers, then mcpServers (camelCase variant)
+	for _, key := range []string{"mcp_servers", "mcpServers"} {
+		switch mcp := config[key].(type) {
+		case []interface{}:
+			for _, s := range mcp {
+				if srv, ok := s.(map[string]interface{}); ok {
+					if name, ok := srv["name"].(string); ok {
+						servers = append(servers, name)
+					}
 				}
 			}
-		}
-	}
-
-	// Check mcpServers (camelCase variant)
-	if mcpArr, ok := config["mcpServers"].([]interface{}); ok {
-		for _, s := range mcpArr {
-			if srv, ok := s.(map[string]interface{}); ok {
-				if name, ok := srv["name"].(string); ok {
-					servers = append(servers, name)
-				}
+		case map[string]interface{}:
+			var names []string
+			for name := range mcp {
+				names = append(names, name)
 			}
+			sort.Strings(names)
+			servers = append(servers, names...)
 		}
 	}
 
@@ -250,6 +312,43 @@ func parseJSONFile(path string) map[string]interface{} {
 	return result
 }
 
+// scanHomeItems lists home directory entries other than the config file and workspace
+func scanHomeItems(homeDir, configPath, workspaceDir string) []WorkspaceItem {
+	entries, err := os.ReadDir(homeDir)
+	if err != nil {
+		return nil
+	}
+
+	var items []WorkspaceItem
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(homeDir, name)
+		if path == configPath || path == workspaceDir || name == "workspace" || IsJunk(name) {
+			continue
+		}
+
+		item := WorkspaceItem{Name: name, IsDir: entry.IsDir()}
+		if entry.IsDir() {
+			item.Files = CountDirFiles(path)
+			item.Size = DirSize(path)
+		} else if info, err := entry.Info(); err == nil {
+			item.Files = 1
+			item.Size = info.Size()
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// resolvePath expands ~ and makes relative paths relative to base
+func resolvePath(path, base string) string {
+	path = ExpandHome(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	return filepath.Clean(path)
+}
+
 func dirHasFiles(path string) bool {
 	entries, err := os.ReadDir(path)
 	if err != nil {
@@ -258,17 +357,76 @@ func dirHasFiles(path string) bool {
 	return len(entries) > 0
 }
 
-// CountFileLines counts lines in a file
-func CountFileLines(path string) int {
-	data, err := os.ReadFile(path)
+// LineCount is the result of counting the lines in a file
+type LineCount struct {
+	Lines  int   // newline-terminated lines, plus a final unterminated one
+	Size   int64 // file size in bytes
+	Binary bool  // the file looks binary; Lines is 0
+	Capped bool  // the file is over maxLineCountBytes and wasn't read; Lines is 0
+}
+
+// maxLineCountBytes is the largest file whose lines are counted, so a
+// multi-gigabyte session log doesn't stall detection or the copy
+const maxLineCountBytes = 64 << 20
+
+// lineCountBuffer is read at a time when counting; larger than io.Copy's
+// 32 KB so big logs take fewer system calls
+const lineCountBuffer = 1 << 20
+
+// CountFileLines counts lines in a file by streaming it, without loading
+// it into memory. Files with a NUL byte near the start are treated as binary.
+func CountFileLines(path string) LineCount {
+	if lc, ok := cachedLines(path); ok {
+		return lc
+	}
+	return countFileLines(path)
+}
+
+func countFileLines(path string) LineCount {
+	var lc LineCount
+	f, err := os.Open(path)
 	if err != nil {
-		return 0
+		return lc
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil {
+		lc.Size = info.Size()
 	}
-	return len(strings.Split(string(data), "\n"))
+	if lc.Size > maxLineCountBytes {
+		lc.Capped = true
+		return lc
+	}
+
+	buf := make([]byte, min(lineCountBuffer, max(lc.Size+1, 32<<10)))
+	var read int64
+	last := byte('\n')
+	for {
+		n, err := f.Read(buf)
+		chunk := buf[:n]
+		// Same heuristic as git and grep: a NUL in the first block means binary
+		if read == 0 && bytes.IndexByte(chunk[:min(n, 8000)], 0) >= 0 {
+			return LineCount{Size: lc.Size, Binary: true}
+		}
+		lc.Lines += bytes.Count(chunk, []byte{'\n'})
+		if n > 0 {
+			last = chunk[n-1]
+		}
+		read += int64(n)
+		if err != nil {
+			break
+		}
+	}
+	if last != '\n' {
+		lc.Lines++ // final line without a trailing newline
+	}
+	return lc
 }
 
 // CountDirFiles recursively counts files in a directory
 func CountDirFiles(path string) int {
+	if s, ok := cachedStats(path); ok {
+		return s.Files
+	}
 	count := 0
 	filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -284,6 +442,9 @@ func CountDirFiles(path string) int {
 
 // DirSize returns total size of a directory in bytes
 func DirSize(path string) int64 {
+	if s, ok := cachedStats(path); ok {
+		return s.Size
+	}
 	var size int64
 	filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -315,12 +476,18 @@ func FormatSize(bytes int64) string {
 }
 
 func extractConfigSummary(config map[string]interface{}, configPath string) ConfigSummary {
-	cs := ConfigSummary{}
+	cs := SummarizeConfig(config)
 
 	// File size
 	if info, err := os.Stat(configPath); err == nil {
 		cs.ConfigFileSize = info.Size()
 	}
+	return cs
+}
+
+// SummarizeConfig extracts the headline settings from an OpenClaw config
+func SummarizeConfig(config map[string]interface{}) ConfigSummary {
+	cs := ConfigSummary{}
 
 	// Agent defaults
 	if agent, ok := config["agent"].(map[string]interface{}); ok {
@@ -336,6 +503,9 @@ func extractConfigSummary(config map[string]interface{}, configPath string) Conf
 		if t, ok := agent["temperature"].(float64); ok {
 			cs.Temperature = t
 		}
+		if w, ok := agent["workspace"].(string); ok {
+			cs.WorkspacePath = w
+		}
 	}
 	// Try agents.defaults too
 	if agents, ok := config["agents"].(map[string]interface{}); ok {
@@ -349,7 +519,7 @@ func extractConfigSummary(config map[string]interface{}, configPath string) Conf
 			if mt, ok := defaults["max_tokens"].(float64); ok && cs.MaxTokens == 0 {
 				cs.MaxTokens = int(mt)
 			}
-			if w, ok := defaults["workspace"].(string); ok {
+			if w, ok := defaults["workspace"].(string); ok && cs.WorkspacePath == "" {
 				cs.WorkspacePath = w
 			}
 		}
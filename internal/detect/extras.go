@@ -0,0 +1,156 @@
+package detect
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ExtraItem is non-standard content found in the workspace
+type ExtraItem struct {
+	Path    string // relative to the workspace, slash-separated
+	IsDir   bool
+	Depth   int   // 1 = workspace root
+	Files   int   // recursive file count for directories, 1 for files
+	Size    int64 // recursive for directories
+	Hidden  bool  // dotfile or dot-directory
+	Secrets []string
+}
+
+// maxExtraDepth is how deep the workspace is listed item by item; anything
+// deeper is summarized in the counts of its ancestor at this depth
+const maxExtraDepth = 3
+
+// junkNames are OS metadata files that are never worth reporting
+var junkNames = map[string]bool{
+	".DS_Store": true, "Thumbs.db": true, "desktop.ini": true,
+	".localized": true, ".Spotlight-V100": true, ".Trashes": true,
+}
+
+// IsJunk reports whether a file is OS metadata such as .DS_Store or an
+// AppleDouble "._" file
+func IsJunk(name string) bool {
+	return junkNames[name] || strings.HasPrefix(name, "._")
+}
+
+// privateKeyNames are files that hold a private key whatever is in them
+var privateKeyNames = map[string]bool{
+	"id_rsa": true, "id_ecdsa": true, "id_ed25519": true, "id_dsa": true,
+}
+
+// scanExtras lists the non-standard content of a workspace: directories
+// outside the standard set down to maxExtraDepth, every hidden item, and
+// files that look like they hold secrets, wherever they are within that
+// depth. It reads the tree from detection's inventory rather than walking
+// the workspace again.
+func scanExtras(workspace string) []ExtraItem {
+	inv, ok := cachedInventory(workspace)
+	if !ok {
+		var err error
+		if inv, err = Scan(context.Background(), workspace, nil); err != nil {
+			return nil
+		}
+	}
+	base, _ := filepath.Rel(inv.Root, filepath.Clean(workspace))
+
+	var items []ExtraItem
+	var visit func(rel string, depth int)
+	visit = func(rel string, depth int) {
+		dir := inv.tree[filepath.Join(base, filepath.FromSlash(rel))]
+		for _, name := range dir.Subdirs {
+			if IsJunk(name) || name == ".git" || (depth == 1 && name == ".openclaw") {
+				continue // repository data and OpenClaw's own state
+			}
+			item := extraItem(rel, name, depth)
+			item.IsDir = true
+			if top := strings.SplitN(item.Path, "/", 2)[0]; !StandardDirs[top] || item.Hidden {
+				stats := inv.dirs[filepath.Join(base, filepath.FromSlash(item.Path))]
+				item.Files, item.Size = stats.Files, stats.Size
+				items = append(items, item)
+			}
+			if depth < maxExtraDepth {
+				visit(item.Path, depth+1)
+			}
+		}
+		for name, stamp := range dir.Stamps {
+			if IsJunk(name) {
+				continue
+			}
+			item := extraItem(rel, name, depth)
+			top := strings.SplitN(item.Path, "/", 2)[0]
+			standard := StandardDirs[top] || (depth == 1 && StandardFiles[name])
+			item.Files, item.Size = 1, stamp.Size
+			item.Secrets = fileSecrets(filepath.Join(workspace, filepath.FromSlash(item.Path)), name)
+			// Plain files inside project directories are summarized by their directory
+			if item.Hidden || len(item.Secrets) > 0 || (depth == 1 && !standard) {
+				items = append(items, item)
+			}
+		}
+	}
+	visit("", 1)
+	sort.Slice(items, func(i, j int) bool { return items[i].Path < items[j].Path })
+	return items
+}
+
+// extraItem starts the item for an entry of the workspace directory rel
+// (slash-separated, "" for the workspace itself)
+func extraItem(rel, name string, depth int) ExtraItem {
+	path := name
+	if rel != "" {
+		path = rel + "/" + name
+	}
+	return ExtraItem{Path: path, Depth: depth, Hidden: strings.HasPrefix(name, ".")}
+}
+
+// fileSecrets names the secrets a file appears to hold: the secret-looking
+// variables set in a .env file, or "private key" for key files
+func fileSecrets(path, name string) []string {
+	lower := strings.ToLower(name)
+	if privateKeyNames[lower] || strings.HasSuffix(lower, ".pem") || strings.HasSuffix(lower, ".p12") {
+		return []string{"private key"}
+	}
+	if lower != ".env" && !strings.HasPrefix(lower, ".env.") && !strings.HasSuffix(lower, ".env") {
+		return nil
+	}
+	if strings.HasSuffix(lower, ".example") || strings.HasSuffix(lower, ".sample") || strings.HasSuffix(lower, ".template") {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var found []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.HasPrefix(key, "#") {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		if value != "" && isSecretName(key) {
+			found = append(found, key)
+		}
+	}
+	return found
+}
+
+// isSecretName reports whether a variable name looks like a credential,
+// e.g. OPENAI_API_KEY or SLACK_BOT_TOKEN
+func isSecretName(key string) bool {
+	key = strings.ToLower(key)
+	for _, hint := range []string{"key", "token", "secret", "password", "passwd"} {
+		if strings.HasSuffix(key, hint) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,371 @@
+package detect
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DirStats totals the files under a directory, recursively
+type DirStats struct {
+	Files int
+	Size  int64
+}
+
+// Inventory is the result of one scan of a directory tree. DirSize,
+// CountDirFiles and CountFileLines answer from it for any path inside Root,
+// so phases that ask about the same tree don't walk it again.
+type Inventory struct {
+	Root  string
+	dirs  map[string]DirStats            // cumulative, by path relative to Root ("." is Root)
+	kinds map[string]map[string]DirStats // each directory's own files by kind
+	tree  map[string]snapshotDir         // each directory's own entries, by path relative to Root
+	lines map[string]LineCount           // line counts of files in the requested directories, by path
+}
+
+var (
+	inventoryMu sync.Mutex
+	inventories = make(map[string]*Inventory)
+)
+
+// cacheDir holds inventory snapshots between runs ("" = don't persist)
+var (
+	cacheDir     string
+	cacheRefresh bool
+)
+
+// UseCache persists inventories under dir so the next run only re-reads
+// directories whose modification time changed, or one of whose files
+// changed size or modification time. With refresh, existing snapshots are
+// ignored (and overwritten).
+func UseCache(dir string, refresh bool) {
+	cacheDir, cacheRefresh = dir, refresh
+}
+
+// snapshotVersion changes whenever snapshot gains fields, so older caches are rescanned
+const snapshotVersion = 2
+
+// snapshot is an inventory as saved to the cache
+type snapshot struct {
+	Version int                    `json:"version"`
+	Root    string                 `json:"root"`
+	Dirs    map[string]snapshotDir `json:"dirs"` // by path relative to Root
+}
+
+// snapshotDir records a directory's own files (not its subdirectories')
+// as of its modification time and theirs
+type snapshotDir struct {
+	ModTime time.Time            `json:"mtime"`
+	Files   int                  `json:"files"`
+	Size    int64                `json:"size"`
+	Subdirs []string             `json:"subdirs,omitempty"`
+	Kinds   map[string]DirStats  `json:"kinds,omitempty"`  // the files above by Classify kind
+	Stamps  map[string]fileStamp `json:"stamps,omitempty"` // the files above by name
+}
+
+// fileStamp is what tells a file edited in place from an unchanged one
+type fileStamp struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mtime"`
+}
+
+// unchanged reports whether every file recorded in d still has the size and
+// modification time it had. Editing a file in place doesn't touch its
+// directory's modification time, so that alone can't tell.
+func (d snapshotDir) unchanged(dir string) bool {
+	if len(d.Stamps) != d.Files {
+		return false // some file couldn't be stat'ed last time
+	}
+	for name, stamp := range d.Stamps {
+		info, err := os.Lstat(filepath.Join(dir, name))
+		if err != nil || info.Size() != stamp.Size || !info.ModTime().Equal(stamp.ModTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// scanWorkers bounds the directories read at once; scans are mostly waiting
+// on the filesystem, so this is higher than the CPU count
+var scanWorkers = runtime.NumCPU() * 4
+
+// Scan walks root once, reading directories concurrently, and caches the
+// result. Line counts are collected for the files directly inside lineDirs.
+// With UseCache, directories unchanged since the last run's snapshot are
+// taken from it instead of being read. If ctx is cancelled the scan stops
+// early, nothing is cached and ctx's error is returned.
+func Scan(ctx context.Context, root string, lineDirs []string) (*Inventory, error) {
+	root = filepath.Clean(root)
+	countLines := make(map[string]bool, len(lineDirs))
+	for _, dir := range lineDirs {
+		countLines[filepath.Clean(dir)] = true
+	}
+	previous := loadSnapshot(root)
+
+	next := snapshot{Version: snapshotVersion, Root: root, Dirs: make(map[string]snapshotDir)}
+	lines := make(map[string]LineCount)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, scanWorkers)
+
+	var visit func(dir string, modTime time.Time)
+	descend := func(path string, modTime time.Time) {
+		wg.Add(1)
+		select {
+		case sem <- struct{}{}:
+			go func() {
+				defer func() { <-sem }()
+				visit(path, modTime)
+			}()
+		default:
+			visit(path, modTime) // all workers busy — carry on in this one
+		}
+	}
+	visit = func(dir string, modTime time.Time) {
+		defer wg.Done()
+		if ctx.Err() != nil {
+			return
+		}
+		rel, _ := filepath.Rel(root, dir)
+
+		// A directory's mtime changes when entries are added, removed or
+		// renamed, and its files' when they are edited, so an unchanged one
+		// only needs its subdirectories checked. Line counts are always
+		// fresh, since they aren't part of the snapshot.
+		if prev, ok := previous.Dirs[rel]; ok && prev.ModTime.Equal(modTime) && !modTime.IsZero() && !countLines[dir] && prev.unchanged(dir) {
+			mu.Lock()
+			next.Dirs[rel] = prev
+			mu.Unlock()
+			for _, name := range prev.Subdirs {
+				path := filepath.Join(dir, name)
+				if info, err := os.Lstat(path); err == nil && info.IsDir() {
+					descend(path, info.ModTime())
+				}
+			}
+			return
+		}
+
+		// Like filepath.WalkDir: unreadable directories are skipped, symlinks aren't followed
+		entries, _ := os.ReadDir(dir)
+
+		d := snapshotDir{ModTime: modTime, Kinds: make(map[string]DirStats), Stamps: make(map[string]fileStamp)}
+		dirLines := make(map[string]LineCount)
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			info, err := entry.Info()
+			if entry.IsDir() {
+				d.Subdirs = append(d.Subdirs, entry.Name())
+				var mt time.Time
+				if err == nil {
+					mt = info.ModTime()
+				}
+				descend(path, mt)
+				continue
+			}
+			d.Files++
+			kindName := Classify(entry.Name())
+			kind := d.Kinds[kindName]
+			kind.Files++
+			if err == nil {
+				d.Size += info.Size()
+				kind.Size += info.Size()
+				d.Stamps[entry.Name()] = fileStamp{info.Size(), info.ModTime()}
+				if countLines[dir] && info.Mode().IsRegular() {
+					dirLines[path] = countFileLines(path)
+				}
+			}
+			d.Kinds[kindName] = kind
+		}
+
+		mu.Lock()
+		next.Dirs[rel] = d
+		for path, n := range dirLines {
+			lines[path] = n
+		}
+		mu.Unlock()
+	}
+
+	var rootTime time.Time
+	if info, err := os.Stat(root); err == nil {
+		rootTime = info.ModTime()
+	}
+	wg.Add(1)
+	visit(root, rootTime)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	saveSnapshot(next)
+
+	// Roll each directory's totals up into its ancestors, deepest first
+	dirs := make(map[string]DirStats, len(next.Dirs))
+	kinds := make(map[string]map[string]DirStats, len(next.Dirs))
+	paths := make([]string, 0, len(next.Dirs))
+	for rel, d := range next.Dirs {
+		dirs[rel] = DirStats{Files: d.Files, Size: d.Size}
+		kinds[rel] = d.Kinds
+		paths = append(paths, rel)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return strings.Count(paths[i], string(filepath.Separator)) > strings.Count(paths[j], string(filepath.Separator))
+	})
+	for _, rel := range paths {
+		if rel == "." {
+			continue
+		}
+		parent := filepath.Dir(rel)
+		p, s := dirs[parent], dirs[rel]
+		p.Files += s.Files
+		p.Size += s.Size
+		dirs[parent] = p
+	}
+
+	inv := &Inventory{Root: root, dirs: dirs, kinds: kinds, tree: next.Dirs, lines: lines}
+	inventoryMu.Lock()
+	inventories[root] = inv
+	inventoryMu.Unlock()
+	return inv, nil
+}
+
+// snapshotPath names a root's snapshot file after a hash of its path
+func snapshotPath(root string) string {
+	sum := sha256.Sum256([]byte(root))
+	return filepath.Join(cacheDir, "inventory-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// loadSnapshot reads the last saved snapshot of root, or an empty one
+func loadSnapshot(root string) snapshot {
+	var snap snapshot
+	if cacheDir == "" || cacheRefresh {
+		return snap
+	}
+	data, err := os.ReadFile(snapshotPath(root))
+	if err != nil || json.Unmarshal(data, &snap) != nil || snap.Version != snapshotVersion || snap.Root != root {
+		return snapshot{}
+	}
+	return snap
+}
+
+// saveSnapshot writes a snapshot for the next run; failures only cost speed
+func saveSnapshot(snap snapshot) {
+	if cacheDir == "" {
+		return
+	}
+	data, err := json.Marshal(snap)
+	if err != nil || os.MkdirAll(cacheDir, 0700) != nil {
+		return
+	}
+	tmp := snapshotPath(snap.Root) + ".tmp"
+	if os.WriteFile(tmp, data, 0600) == nil {
+		os.Rename(tmp, snapshotPath(snap.Root))
+	}
+}
+
+// Forget drops cached inventories that overlap path, after the tree has
+// been changed (e.g. files moved out of it)
+func Forget(path string) {
+	path = filepath.Clean(path)
+	inventoryMu.Lock()
+	defer inventoryMu.Unlock()
+	for root := range inventories {
+		if within(root, path) || within(path, root) {
+			delete(inventories, root)
+		}
+	}
+}
+
+// Stats returns the cached totals for a directory inside an inventory
+func (inv *Inventory) Stats(path string) (DirStats, bool) {
+	rel, err := filepath.Rel(inv.Root, filepath.Clean(path))
+	if err != nil {
+		return DirStats{}, false
+	}
+	s, ok := inv.dirs[rel]
+	return s, ok
+}
+
+// cachedInventory returns a cached inventory that covers a directory
+func cachedInventory(path string) (*Inventory, bool) {
+	path = filepath.Clean(path)
+	inventoryMu.Lock()
+	defer inventoryMu.Unlock()
+	for root, inv := range inventories {
+		if within(root, path) {
+			if _, ok := inv.Stats(path); ok {
+				return inv, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// cachedStats looks a directory up in any cached inventory
+func cachedStats(path string) (DirStats, bool) {
+	path = filepath.Clean(path)
+	inventoryMu.Lock()
+	defer inventoryMu.Unlock()
+	for root, inv := range inventories {
+		if within(root, path) {
+			if s, ok := inv.Stats(path); ok {
+				return s, true
+			}
+		}
+	}
+	return DirStats{}, false
+}
+
+// cachedKinds totals the files under a directory by kind from any cached inventory
+func cachedKinds(path string) (map[string]DirStats, bool) {
+	path = filepath.Clean(path)
+	inventoryMu.Lock()
+	defer inventoryMu.Unlock()
+	for root, inv := range inventories {
+		if !within(root, path) {
+			continue
+		}
+		rel, _ := filepath.Rel(root, path)
+		if _, ok := inv.dirs[rel]; !ok {
+			continue
+		}
+		totals := make(map[string]DirStats)
+		for dir, own := range inv.kinds {
+			if rel != "." && !within(rel, dir) {
+				continue
+			}
+			for kind, s := range own {
+				t := totals[kind]
+				t.Files += s.Files
+				t.Size += s.Size
+				totals[kind] = t
+			}
+		}
+		return totals, true
+	}
+	return nil, false
+}
+
+// cachedLines looks a file's line count up in any cached inventory
+func cachedLines(path string) (LineCount, bool) {
+	path = filepath.Clean(path)
+	inventoryMu.Lock()
+	defer inventoryMu.Unlock()
+	for _, inv := range inventories {
+		if n, ok := inv.lines[path]; ok {
+			return n, true
+		}
+	}
+	return LineCount{}, false
+}
+
+// within reports whether path is root or inside it
+func within(root, path string) bool {
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
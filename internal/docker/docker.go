@@ -0,0 +1,119 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DataDir is the directory next to the Dockerfile that holds the PicoClaw
+// home; it is mounted at ContainerHome rather than copied into the image,
+// so API keys never end up in an image layer
+const DataDir = "picoclaw"
+
+// ContainerHome is where PicoClaw's home is mounted inside the container.
+// The converted config's "~/.picoclaw/workspace" resolves to it, since the
+// container runs as root.
+const ContainerHome = "/root/.picoclaw"
+
+// DefaultTag names the image built by Build
+const DefaultTag = "picoclaw-migrated"
+
+// dockerfile installs a PicoClaw release for the build platform. %[1]s is
+// the PicoClaw version, %[2]s the release download URL prefix.
+const dockerfile = `# Generated by claw-migrate. The agent's config and workspace are not in
+# the image: they live in ./picoclaw, mounted at /root/.picoclaw.
+FROM debian:bookworm-slim
+
+ARG PICOCLAW_VERSION=%[1]s
+ARG TARGETARCH
+RUN set -eux; \
+    apt-get update; \
+    apt-get install -y --no-install-recommends ca-certificates curl; \
+    arch="${TARGETARCH:-$(dpkg --print-architecture)}"; \
+    case "$arch" in \
+      amd64) arch=x86_64 ;; \
+      arm64) arch=arm64 ;; \
+      arm|armhf) arch=armv6 ;; \
+      riscv64) arch=riscv64 ;; \
+      *) echo "PicoClaw has no release for $arch" >&2; exit 1 ;; \
+    esac; \
+    mkdir /tmp/picoclaw; \
+    curl -fsSL "%[2]s/v${PICOCLAW_VERSION}/picoclaw_Linux_${arch}.tar.gz" | tar -xz -C /tmp/picoclaw; \
+    find /tmp/picoclaw -type f -name picoclaw -exec install -m 0755 {} /usr/local/bin/picoclaw \; ; \
+    rm -rf /tmp/picoclaw; \
+    apt-get purge -y curl; apt-get autoremove -y; rm -rf /var/lib/apt/lists/*
+
+VOLUME %[3]s
+ENTRYPOINT ["picoclaw"]
+CMD ["gateway"]
+`
+
+// compose runs the image with the data directory mounted. %[1]s is the
+// image tag, %[2]s the data directory, %[3]s the mount point.
+const compose = `# Generated by claw-migrate
+services:
+  picoclaw:
+    build: .
+    image: %[1]s
+    restart: unless-stopped
+    volumes:
+      - ./%[2]s:%[3]s
+`
+
+// WriteFiles writes the Dockerfile, docker-compose.yml and .dockerignore
+// into dir. version is the PicoClaw release to install, baseURL where
+// releases are downloaded from.
+func WriteFiles(dir, version, baseURL string) ([]string, error) {
+	files := []struct {
+		name, content string
+	}{
+		{"Dockerfile", fmt.Sprintf(dockerfile, version, baseURL, ContainerHome)},
+		{"docker-compose.yml", fmt.Sprintf(compose, DefaultTag, DataDir, ContainerHome)},
+		// Keep the keys out of the build context as well as the image
+		{".dockerignore", DataDir + "/\n"},
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create %s: %w", dir, err)
+	}
+	var written []string
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if err := os.WriteFile(path, []byte(f.content), 0644); err != nil {
+			return written, fmt.Errorf("write %s: %w", f.name, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// Available reports whether the docker CLI is installed
+func Available() bool {
+	_, err := exec.LookPath("docker")
+	return err == nil
+}
+
+// Build runs `docker build` on dir, streaming its output
+func Build(dir, tag string) error {
+	cmd := exec.Command("docker", "build", "-t", tag, dir)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("docker build: %w", err)
+	}
+	return nil
+}
+
+// RunHint is the command that starts the container from dir
+func RunHint(dir string) string {
+	return "cd " + shellQuote(dir) + " && docker compose up -d"
+}
+
+func shellQuote(s string) string {
+	if !strings.ContainsAny(s, " '\"$\\") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,351 @@
+package dotfiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/detect"
+	"github.com/arunbluez/claw-migrate/internal/migrate"
+	"github.com/arunbluez/claw-migrate/internal/secrets"
+)
+
+// maxFileSize is the largest file worth tracking; bigger ones are data
+const maxFileSize = 1 << 20
+
+// skipDirs are directories under ~/.picoclaw (or its workspace) that hold
+// credentials or generated, bulky data rather than configuration
+var skipDirs = map[string]string{
+	"credentials": "credentials",
+	"sessions":    "session history",
+	"state":       "runtime state",
+	"media":       "media",
+	"logs":        "logs",
+	"cache":       "cache",
+	".git":        "repository data",
+}
+
+// File is a file under ~/.picoclaw chosen for the dotfiles repo
+type File struct {
+	Rel  string // relative to ~/.picoclaw, slash-separated
+	Mode fs.FileMode
+}
+
+// Select lists the files under picoHome worth tracking — everything except
+// config.json (handled by the caller, since it holds keys), credentials and
+// large or generated data. skipped maps what was left out to the reason.
+func Select(picoHome string) (files []File, skipped map[string]string) {
+	skipped = make(map[string]string)
+	filepath.WalkDir(picoHome, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == picoHome {
+			return nil
+		}
+		rel, _ := filepath.Rel(picoHome, path)
+		rel = filepath.ToSlash(rel)
+		name := d.Name()
+
+		if d.IsDir() {
+			if reason, ok := skipDirs[name]; ok {
+				skipped[rel+"/"] = reason
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || !info.Mode().IsRegular() || detect.IsJunk(name) {
+			return nil
+		}
+		switch {
+		case rel == "config.json":
+			return nil
+		case strings.HasPrefix(rel, "config.json."), strings.HasSuffix(name, ".bak"):
+			skipped[rel] = "backup copy"
+		case isSecretFile(name):
+			skipped[rel] = "credentials"
+		case info.Size() > maxFileSize:
+			skipped[rel] = "larger than " + detect.FormatSize(maxFileSize)
+		case detect.IsBinaryKind(detect.Classify(name)):
+			skipped[rel] = "binary data"
+		default:
+			files = append(files, File{Rel: rel, Mode: info.Mode().Perm()})
+		}
+		return nil
+	})
+	return files, skipped
+}
+
+// isSecretFile reports whether a file should never be committed
+func isSecretFile(name string) bool {
+	lower := strings.ToLower(name)
+	return lower == "auth.json" || lower == ".env" || strings.HasPrefix(lower, ".env.") ||
+		migrate.LooksSecret(name)
+}
+
+// IgnoreRules are the patterns that keep credentials and data out of the
+// repo, relative to prefix (e.g. ".picoclaw/"). chezmoi patterns match
+// everything below a directory only with "/**".
+func IgnoreRules(prefix string, chezmoi bool) []string {
+	rules := []string{}
+	names := make([]string, 0, len(skipDirs))
+	for name := range skipDirs {
+		if name != ".git" { // git never tracks nested repositories anyway
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if chezmoi {
+			rules = append(rules, prefix+"**/"+name+"/**")
+		} else {
+			rules = append(rules, prefix+"**/"+name+"/")
+		}
+	}
+	for _, pattern := range []string{"auth.json", ".env", ".env.*", "*.pem", "*.key", "*.p12", "*.pfx", "*token*", "*secret*", "*credential*", "config.json.*", "*.bak"} {
+		rules = append(rules, prefix+"**/"+pattern)
+	}
+	return rules
+}
+
+// ChezmoiTemplate renders config.json as a chezmoi template that reads the
+// credentials from secrets.PatchFile at apply time. The second result is
+// false if the config holds no credentials and can be stored as-is.
+func ChezmoiTemplate(public, private map[string]interface{}) (string, bool, error) {
+	found := secrets.Collect(private)
+	if len(found) == 0 {
+		data, err := json.MarshalIndent(public, "", "  ")
+		return string(data) + "\n", false, err
+	}
+
+	// Put a unique marker at each credential in a copy of public, then swap
+	// the quoted markers for template actions
+	var withMarkers map[string]interface{}
+	data, err := json.Marshal(public)
+	if err == nil {
+		err = json.Unmarshal(data, &withMarkers)
+	}
+	if err != nil {
+		return "", false, err
+	}
+	actions := make(map[string]string)
+	var mark func(dst map[string]interface{}, src interface{}, path []string)
+	mark = func(dst map[string]interface{}, src interface{}, path []string) {
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for k, v := range m {
+			p := append(append([]string{}, path...), strconv.Quote(k))
+			switch t := v.(type) {
+			case string:
+				marker := fmt.Sprintf("@@claw-migrate-secret-%d@@", len(actions))
+				actions[strconv.Quote(marker)] = "{{ index $secrets " + strings.Join(p, " ") + " | toJson }}"
+				dst[k] = marker
+			case map[string]interface{}:
+				child, ok := dst[k].(map[string]interface{})
+				if !ok {
+					child = make(map[string]interface{})
+					dst[k] = child
+				}
+				mark(child, t, p)
+			case []interface{}:
+				arr, _ := dst[k].([]interface{})
+				for i, el := range t {
+					if i < len(arr) {
+						if child, ok := arr[i].(map[string]interface{}); ok {
+							mark(child, el, append(append([]string{}, p...), strconv.Itoa(i)))
+						}
+					}
+				}
+			}
+		}
+	}
+	mark(withMarkers, private, nil)
+
+	data, err = json.MarshalIndent(withMarkers, "", "  ")
+	if err != nil {
+		return "", false, err
+	}
+	out := string(data)
+	for marker, action := range actions {
+		out = strings.Replace(out, marker, action, 1)
+	}
+	header := fmt.Sprintf("{{- $secrets := include (joinPath .chezmoi.homeDir %q) | fromJson -}}\n", secrets.PatchFile)
+	return header + out + "\n", true, nil
+}
+
+// ChezmoiSource returns chezmoi's source directory, or an error if chezmoi
+// isn't installed or initialized
+func ChezmoiSource() (string, error) {
+	out, err := exec.Command("chezmoi", "source-path").Output()
+	if err != nil {
+		return "", fmt.Errorf("chezmoi source-path: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// chezmoiName encodes a target name with chezmoi's source-state attributes
+func chezmoiName(name string, mode fs.FileMode, isDir bool) string {
+	prefix := ""
+	if mode&0077 == 0 {
+		prefix += "private_"
+	}
+	if !isDir && mode&0100 != 0 {
+		prefix += "executable_"
+	}
+	if strings.HasPrefix(name, ".") {
+		return prefix + "dot_" + name[1:]
+	}
+	return prefix + name
+}
+
+// chezmoiPath maps a path relative to ~/.picoclaw to its source path,
+// taking each directory's attributes from the real tree
+func chezmoiPath(picoHome, rel string, mode fs.FileMode) string {
+	homeMode := fs.FileMode(0700)
+	if info, err := os.Stat(picoHome); err == nil {
+		homeMode = info.Mode().Perm()
+	}
+	parts := []string{chezmoiName(".picoclaw", homeMode, true)}
+	segments := strings.Split(rel, "/")
+	dir := picoHome
+	for _, seg := range segments[:len(segments)-1] {
+		dir = filepath.Join(dir, seg)
+		dirMode := fs.FileMode(0755)
+		if info, err := os.Stat(dir); err == nil {
+			dirMode = info.Mode().Perm()
+		}
+		parts = append(parts, chezmoiName(seg, dirMode, true))
+	}
+	parts = append(parts, chezmoiName(segments[len(segments)-1], mode, false))
+	return filepath.Join(parts...)
+}
+
+// WriteChezmoi adds the files and the config template to a chezmoi source
+// directory and extends .chezmoiignore. Returns the paths written,
+// relative to source.
+func WriteChezmoi(source, picoHome string, files []File, public, private map[string]interface{}) ([]string, error) {
+	var written []string
+	for _, f := range files {
+		rel := chezmoiPath(picoHome, f.Rel, f.Mode)
+		if err := copyInto(filepath.Join(picoHome, filepath.FromSlash(f.Rel)), filepath.Join(source, rel)); err != nil {
+			return written, err
+		}
+		written = append(written, rel)
+	}
+
+	config, isTemplate, err := ChezmoiTemplate(public, private)
+	if err != nil {
+		return written, err
+	}
+	rel := chezmoiPath(picoHome, "config.json", 0600)
+	if isTemplate {
+		rel += ".tmpl"
+	}
+	if err := os.WriteFile(filepath.Join(source, rel), []byte(config), 0600); err != nil {
+		return written, err
+	}
+	written = append(written, rel)
+
+	if err := appendIgnore(filepath.Join(source, ".chezmoiignore"), IgnoreRules(".picoclaw/", true)); err != nil {
+		return written, err
+	}
+	return append(written, ".chezmoiignore"), nil
+}
+
+// WriteGit copies the files into repo/.picoclaw, mirroring the home
+// directory, with config.json stripped of its credentials, and extends the
+// repo's .gitignore. Returns the paths written, relative to repo.
+func WriteGit(repo, picoHome string, files []File, public map[string]interface{}) ([]string, error) {
+	var written []string
+	for _, f := range files {
+		rel := filepath.Join(".picoclaw", filepath.FromSlash(f.Rel))
+		if err := copyInto(filepath.Join(picoHome, filepath.FromSlash(f.Rel)), filepath.Join(repo, rel)); err != nil {
+			return written, err
+		}
+		written = append(written, rel)
+	}
+
+	data, err := json.MarshalIndent(public, "", "  ")
+	if err != nil {
+		return written, err
+	}
+	rel := filepath.Join(".picoclaw", "config.json")
+	if err := os.MkdirAll(filepath.Join(repo, ".picoclaw"), 0700); err != nil {
+		return written, err
+	}
+	if err := os.WriteFile(filepath.Join(repo, rel), append(data, '\n'), 0600); err != nil {
+		return written, err
+	}
+	written = append(written, rel)
+
+	if err := appendIgnore(filepath.Join(repo, ".gitignore"), IgnoreRules(".picoclaw/", false)); err != nil {
+		return written, err
+	}
+	return append(written, ".gitignore"), nil
+}
+
+// ignoreMarker starts the block of rules this tool adds, so re-runs don't repeat it
+const ignoreMarker = "# PicoClaw — added by claw-migrate"
+
+func appendIgnore(path string, rules []string) error {
+	existing, _ := os.ReadFile(path)
+	if strings.Contains(string(existing), ignoreMarker) {
+		return nil
+	}
+	block := ignoreMarker + "\n" + strings.Join(rules, "\n") + "\n"
+	if len(existing) > 0 {
+		block = "\n" + block
+		if !strings.HasSuffix(string(existing), "\n") {
+			block = "\n" + block
+		}
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(block); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func copyInto(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, info.Mode().Perm())
+}
+
+// IsGitRepo reports whether dir is inside a git work tree
+func IsGitRepo(dir string) bool {
+	return exec.Command("git", "-C", dir, "rev-parse", "--is-inside-work-tree").Run() == nil
+}
+
+// Commit stages paths (relative to repo) and commits them
+func Commit(repo string, paths []string, message string) error {
+	add := exec.Command("git", append([]string{"-C", repo, "add", "--"}, paths...)...)
+	if out, err := add.CombinedOutput(); err != nil {
+		return fmt.Errorf("git add: %s", strings.TrimSpace(string(out)))
+	}
+	commit := exec.Command("git", "-C", repo, "commit", "-m", message, "--")
+	commit.Args = append(commit.Args, paths...)
+	if out, err := commit.CombinedOutput(); err != nil {
+		return fmt.Errorf("git commit: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// Package envfile reads, merges and writes .env files, and checks their
+// credentials against the ones in a PicoClaw config
+package envfile
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/preserve"
+	"github.com/arunbluez/claw-migrate/internal/secrets"
+)
+
+// Name is the file PicoClaw loads environment variables from, in its home
+const Name = ".env"
+
+// Var is a variable set in a .env file
+type Var struct {
+	Key   string
+	Value string
+	Line  int // 1-based
+}
+
+// Conflict is a variable two files set to different values
+type Conflict struct {
+	Key      string
+	Existing string
+	Incoming string
+}
+
+// Parse reads the assignments in a .env file: KEY=value lines, optionally
+// after export, with single- or double-quoted values and # comments.
+// A key set twice keeps its last value, as dotenv loaders do.
+func Parse(data []byte) []Var {
+	var vars []Var
+	index := make(map[string]int)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		key, value, ok := parseLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		if i, dup := index[key]; dup {
+			vars[i] = Var{key, value, n}
+			continue
+		}
+		index[key] = len(vars)
+		vars = append(vars, Var{key, value, n})
+	}
+	return vars
+}
+
+// Read parses the .env file at path
+func Read(path string) ([]Var, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data), nil
+}
+
+func parseLine(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+	line = strings.TrimPrefix(line, "export ")
+	key, value, ok = strings.Cut(line, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" || strings.ContainsAny(key, " \t") {
+		return "", "", false
+	}
+	value = strings.TrimSpace(value)
+	switch {
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		value = strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		value = value[1 : len(value)-1]
+	default:
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i]) // trailing comment
+		}
+	}
+	return key, value, true
+}
+
+// format writes a variable as a .env line, quoting values that need it
+func format(key, value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\n\"'#$\\`") {
+		value = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", `\$`).Replace(value) + `"`
+	}
+	return key + "=" + value
+}
+
+// Merge applies incoming to the .env file text existing: keys it already
+// sets to another value are updated in place unless keepExisting says to
+// keep them (nil lets incoming win), and new keys are appended. Comments
+// and the order of the existing file are kept. Returns the new text and
+// the keys set to different values on each side.
+func Merge(existing []byte, incoming []Var, keepExisting func(key string) bool) ([]byte, []Conflict) {
+	current := make(map[string]Var)
+	for _, v := range Parse(existing) {
+		current[v.Key] = v
+	}
+	lines := strings.Split(strings.TrimSuffix(string(existing), "\n"), "\n")
+	if len(existing) == 0 {
+		lines = nil
+	}
+
+	var conflicts []Conflict
+	for _, v := range incoming {
+		old, ok := current[v.Key]
+		switch {
+		case !ok:
+			lines = append(lines, format(v.Key, v.Value))
+			current[v.Key] = Var{v.Key, v.Value, len(lines)}
+		case old.Value != v.Value:
+			conflicts = append(conflicts, Conflict{v.Key, old.Value, v.Value})
+			if keepExisting == nil || !keepExisting(v.Key) {
+				lines[old.Line-1] = format(v.Key, v.Value)
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return nil, conflicts
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), conflicts
+}
+
+// Write replaces the .env file at path with data, readable only by the
+// owner since it holds credentials
+func Write(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	if err := preserve.Keep(path); err != nil {
+		return err
+	}
+	tmp := path + ".claw-migrate.tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// ════════════════════════════════════════════════════════════
+// Credentials set both in .env and in the config
+// ════════════════════════════════════════════════════════════
+
+// ConfigKeys maps well-known variables onto the PicoClaw config keys
+// holding the same credential
+var ConfigKeys = map[string][]string{
+	"OPENAI_API_KEY":     {"model_list[openai].api_key", "providers.openai.api_key"},
+	"ANTHROPIC_API_KEY":  {"model_list[anthropic].api_key", "providers.anthropic.api_key"},
+	"OPENROUTER_API_KEY": {"model_list[openrouter].api_key", "providers.openrouter.api_key"},
+	"GEMINI_API_KEY":     {"model_list[gemini].api_key", "providers.gemini.api_key"},
+	"GROQ_API_KEY":       {"model_list[groq].api_key", "providers.groq.api_key"},
+	"DEEPSEEK_API_KEY":   {"model_list[deepseek].api_key", "providers.deepseek.api_key"},
+	"ZHIPU_API_KEY":      {"model_list[zhipu].api_key", "providers.zhipu.api_key"},
+	"TELEGRAM_BOT_TOKEN": {"channels.telegram.token"},
+	"DISCORD_BOT_TOKEN":  {"channels.discord.token"},
+	"SLACK_BOT_TOKEN":    {"channels.slack.bot_token"},
+	"SLACK_APP_TOKEN":    {"channels.slack.app_token"},
+}
+
+// Mismatch is a variable whose credential the config sets to another value
+type Mismatch struct {
+	Key    string
+	Path   string // the config key
+	Env    string
+	Config string
+}
+
+// CheckConfig lists the variables in vars that the config, in PicoClaw's
+// format, holds a different value for, once each
+func CheckConfig(vars []Var, cfg map[string]interface{}) []Mismatch {
+	held := make(map[string]string)
+	for _, s := range secrets.Collect(cfg) {
+		held[s.Path] = s.Value
+	}
+	var found []Mismatch
+	for _, v := range vars {
+		for _, path := range ConfigKeys[v.Key] {
+			if value, ok := held[path]; ok && value != v.Value && v.Value != "" {
+				found = append(found, Mismatch{v.Key, path, v.Value, value})
+				break // the other keys hold the same credential
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// English is the source language; its messages are the catalog keys
+const English = "en"
+
+// catalogs maps a locale to its translations, keyed by the English message
+var catalogs = map[string]map[string]string{
+	"zh-CN": zhCN,
+}
+
+// lang is the active locale
+var lang = English
+
+// Supported returns the locales that can be passed to --lang
+func Supported() []string {
+	langs := []string{English}
+	for l := range catalogs {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// Normalize turns a locale tag such as "zh_CN.UTF-8", "zh-Hans" or "zh"
+// into a catalog name, or returns "" if there is no catalog for it
+func Normalize(tag string) string {
+	tag, _, _ = strings.Cut(tag, ".") // drop encoding
+	tag, _, _ = strings.Cut(tag, "@") // drop modifier
+	tag = strings.ReplaceAll(tag, "_", "-")
+
+	lower := strings.ToLower(tag)
+	switch {
+	case lower == "" || lower == "c" || lower == "posix":
+		return ""
+	case lower == "en" || strings.HasPrefix(lower, "en-"):
+		return English
+	case lower == "zh" || lower == "zh-cn" || lower == "zh-sg" || lower == "zh-hans" || strings.HasPrefix(lower, "zh-hans-"):
+		return "zh-CN"
+	}
+	for l := range catalogs {
+		if strings.EqualFold(l, tag) {
+			return l
+		}
+	}
+	return ""
+}
+
+// Detect picks a locale from the environment (LC_ALL, LC_MESSAGES, LANG),
+// falling back to English
+func Detect() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(env)
+		if v == "" {
+			continue
+		}
+		// The first variable that is set wins, as with setlocale
+		if l := Normalize(v); l != "" {
+			return l
+		}
+		return English
+	}
+	return English
+}
+
+// SetLang switches the active locale
+func SetLang(tag string) error {
+	l := Normalize(tag)
+	if l == "" {
+		return fmt.Errorf("unsupported language %q (available: %s)", tag, strings.Join(Supported(), ", "))
+	}
+	lang = l
+	return nil
+}
+
+// Lang returns the active locale
+func Lang() string {
+	return lang
+}
+
+// T translates msg into the active locale and, if args are given, formats it
+// like fmt.Sprintf. Messages without a translation are used as-is.
+func T(msg string, args ...interface{}) string {
+	if translated, ok := catalogs[lang][msg]; ok {
+		msg = translated
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
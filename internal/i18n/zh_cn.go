@@ -0,0 +1,1117 @@
+package i18n
+
+// zhCN is the Simplified Chinese catalog. Keys are the English messages
+// exactly as passed to T; format verbs must appear in the same order.
+var zhCN = map[string]string{
+	// ── ui ──
+	"OpenClaw → PicoClaw Migration Wizard": "OpenClaw → PicoClaw 迁移向导",
+	"PHASE %d":                             "阶段 %d",
+	"not found":                            "未找到",
+	"%d lines":                             "%d 行",
+	"%s, too large to count lines":         "%s，文件过大，未统计行数",
+	"binary, %s":                           "二进制，%s",
+	"%s, %s":                               "%s，%s",
+	"%d files (%s, %d%%)":                  "%d 个文件（%s，%d%%）",
+	"Workspace — composition":              "工作区 — 文件构成",
+	"markdown":                             "Markdown",
+	"json":                                 "JSON",
+	"text":                                 "文本",
+	"code":                                 "代码",
+	"image":                                "图片",
+	"audio":                                "音频",
+	"video":                                "视频",
+	"archive":                              "压缩包",
+	"database":                             "数据库",
+	"other":                                "其他",
+	"Text":                                 "文本",
+	"Code":                                 "代码",
+	"Images":                               "图片",
+	"Audio":                                "音频",
+	"Video":                                "视频",
+	"Archives":                             "压缩包",
+	"Databases":                            "数据库",
+	"Other":                                "其他",
+	"skipped (not found in source)":        "已跳过（源中不存在）",
+	"skipped":                              "已跳过",
+	"Enter choice [1-%d]:":                 "请输入选项 [1-%d]：",
+	"estimating…":                          "正在估算…",
+	"%s left":                              "剩余 %s",
+	"Invalid choice, try again":            "无效选项，请重试",
+	"Migration Complete!":                  "迁移完成！",
+	"Your PicoClaw assistant is ready to go.": "你的 PicoClaw 助手已准备就绪。",
+	"Run: %s to start!":                       "运行 %s 即可启动！",
+
+	// ── Help ──
+	"Usage: claw-migrate [command] [flags]": "用法：claw-migrate [命令] [选项]",
+	"Commands:":                             "命令：",
+	"Flags:":                                "选项：",
+	"Run without arguments for interactive mode.":                                                                                            "不带参数运行将进入交互模式。",
+	"Full OpenClaw → PicoClaw migration (default)":                                                                                           "完整的 OpenClaw → PicoClaw 迁移（默认）",
+	"Create a backup of ~/.openclaw/ (or: backup list | show FILE | extract FILE PATH [DEST])":                                               "备份 ~/.openclaw/（或：backup list | show FILE | extract FILE PATH [DEST]）",
+	"Restore OpenClaw from a backup":                                                                                                         "从备份恢复 OpenClaw",
+	"Re-copy only the files that failed in the last migration":                                                                               "仅重新复制上次迁移中失败的文件",
+	"Carry out a plan written by migrate --dry-run --plan (default plan.json), if OpenClaw hasn't changed since":                             "执行 migrate --dry-run --plan 写出的计划（默认 plan.json），前提是 OpenClaw 此后未变",
+	"Put back the model the last migration upgraded, leaving the rest of the migration in place":                                             "恢复上次迁移升级前的模型，迁移的其余部分保持不变",
+	"Point the Telegram and Discord webhooks moved off OpenClaw back where they delivered":                                                   "将从 OpenClaw 移走的 Telegram 和 Discord Webhook 指回原来的地址",
+	"List past backups, migrations, restores and uninstalls, newest first (last N, default 20)":                                              "列出过去的备份、迁移、恢复和卸载，最新的在前（最近 N 次，默认 20）",
+	"Remove OpenClaw or PicoClaw":                                                                                                            "卸载 OpenClaw 或 PicoClaw",
+	"Preview without making changes":                                                                                                         "预览操作，不做任何更改",
+	"With --dry-run: write every intended action and the flags to FILE (default plan.json) for apply":                                        "配合 --dry-run：将每个预定操作和参数写入 FILE（默认 plan.json），供 apply 使用",
+	"Give a plan's recorded answers and PicoClaw release (used by apply)":                                                                    "给出计划记录的回答和 PicoClaw 版本（供 apply 使用）",
+	"Answer yes to every prompt but dangerous ones (unattended runs)":                                                                        "对除危险操作外的所有提示回答“是”（无人值守运行）",
+	"Use existing PicoClaw installation":                                                                                                     "使用已安装的 PicoClaw",
+	"Keep OpenClaw installed":                                                                                                                "保留 OpenClaw",
+	"Delete each source file once copied (for low disk space)":                                                                               "复制完成后立即删除源文件（适用于磁盘空间不足）",
+	"Flush copied files to disk: key (default), all, none":                                                                                   "将复制的文件刷写到磁盘：key（默认）、all、none",
+	"Throttle backup and copy IO, e.g. 50MB/s":                                                                                               "限制备份和复制的 IO 速率，例如 50MB/s",
+	"Throttle the PicoClaw download, e.g. 5MB/s":                                                                                             "限制 PicoClaw 下载速率，例如 5MB/s",
+	"Download PicoClaw in phase 3 instead of while the backup runs":                                                                          "在阶段 3 下载 PicoClaw，而不是在备份时同时下载",
+	"Abort the workspace copy after N failed files (default 50, 0 = never)":                                                                  "失败文件达到 N 个后中止工作区复制（默认 50，0 = 永不中止）",
+	"Opt in to anonymous migration stats (remembered; --no-share-stats to opt out)":                                                          "同意发送匿名迁移统计（会被记住；用 --no-share-stats 取消）",
+	"Interface language: en, zh-CN (default: from $LANG)":                                                                                    "界面语言：en、zh-CN（默认取自 $LANG）",
+	"Show installations, backups, last migration and rollback options":                                                                       "显示安装、备份、上次迁移和回滚选项",
+	"List or tick off items needing manual attention (todo done N)":                                                                          "列出或勾选需手动处理的项目（todo done N）",
+	"Show which OpenClaw settings were carried over, transformed or dropped":                                                                 "显示哪些 OpenClaw 设置被保留、转换或丢弃",
+	"Restore one file from the newest backup (restore-file PATH [openclaw|picoclaw])":                                                        "从最新备份恢复单个文件（restore-file PATH [openclaw|picoclaw]）",
+	"Write API keys and tokens to an encrypted bundle (export-secrets [FILE])":                                                               "将 API 密钥和令牌写入加密包（export-secrets [FILE]）",
+	"Add the keys from a bundle to the PicoClaw config (import-secrets FILE)":                                                                "将加密包中的密钥添加到 PicoClaw 配置（import-secrets FILE）",
+	"Secrets bundle encryption: age, gpg, passphrase (default: first available)":                                                             "密钥包加密方式：age、gpg、passphrase（默认：第一个可用的）",
+	"Encrypt the secrets bundle to an age or gpg public key":                                                                                 "使用 age 或 gpg 公钥加密密钥包",
+	"age identity file for importing a bundle encrypted to a recipient":                                                                      "导入按接收者加密的密钥包时使用的 age 身份文件",
+	"Install PicoClaw only, for a fresh start without migrating":                                                                             "仅安装 PicoClaw，不迁移，从头开始",
+	"Back up ~/.picoclaw, install the latest PicoClaw release and re-check it":                                                               "备份 ~/.picoclaw，安装最新的 PicoClaw 版本并重新检查",
+	"Rescan everything instead of reusing ~/.claw-migrate/cache":                                                                             "重新扫描全部内容，不复用 ~/.claw-migrate/cache",
+	"As root, run migrate or backup for every user with ~/.openclaw":                                                                         "以 root 身份为每个拥有 ~/.openclaw 的用户运行 migrate 或 backup",
+	"Migrate into a Dockerfile + volume in DIR (default ./picoclaw-docker), not the host":                                                    "迁移到 DIR 中的 Dockerfile 和数据卷（默认 ./picoclaw-docker），而非本机",
+	"Migrate into Kubernetes manifests + workspace tarball in DIR (default ./picoclaw-k8s)":                                                  "迁移到 DIR 中的 Kubernetes 清单和工作区压缩包（默认 ./picoclaw-k8s）",
+	"Declare the config and user service as a home-manager module in DIR (default ./picoclaw-nix)":                                           "将配置和用户服务声明为 DIR 中的 home-manager 模块（默认 ./picoclaw-nix）",
+	"Add ~/.picoclaw (minus keys and bulky data) to chezmoi or a git repo (dotfiles [REPO])":                                                 "将 ~/.picoclaw（不含密钥和大体积数据）添加到 chezmoi 或 git 仓库（dotfiles [REPO]）",
+	"Redact API keys and private keys found in migrated workspace files (the backup keeps them)":                                             "在迁移后的工作区文件中遮盖 API 密钥和私钥（备份中保留原文）",
+	"Ask a model from your config to map unrecognized config sections (review before applying)":                                              "让配置中的模型为无法识别的配置段提出映射（应用前需审阅）",
+	"How to copy files: auto (default), reflink, ssd, hdd, network":                                                                          "文件复制方式：auto（默认）、reflink、ssd、hdd、network",
+	"Backup archive format: tar.gz (default) or zip":                                                                                         "备份归档格式：tar.gz（默认）或 zip",
+	"Stream the backup archive to stdout for piping (messages go to stderr)":                                                                 "将备份归档输出到标准输出以便管道处理（消息输出到标准错误）",
+	"Install or upgrade to the newest release even if it is a pre-release":                                                                   "安装或升级到最新版本，即使它是预发布版本",
+	"Provision for another machine: linux, darwin or freebsd (with --target-arch)":                                                           "为另一台机器准备：linux、darwin 或 freebsd（与 --target-arch 配合使用）",
+	"Provision for another machine: amd64, arm64, arm, mips64 or riscv64":                                                                    "为另一台机器准备：amd64、arm64、arm、mips64 或 riscv64",
+	"Where --target-os/--target-arch put the binary, config and workspace":                                                                   "--target-os/--target-arch 放置二进制文件、配置和工作区的位置",
+	"Package the converted config, workspace, binary and an install script for another machine (--output FILE)":                              "将转换后的配置、工作区、二进制文件和安装脚本打包给另一台机器（--output FILE）",
+	"Check a bundle from export, install its binary, config and workspace, and verify (import FILE)":                                         "检查 export 生成的迁移包，安装其中的二进制文件、配置和工作区并验证（import FILE）",
+	"Before migrating, check PicoClaw accepts the converted config under a temporary HOME":                                                   "迁移前，在临时 HOME 下检查 PicoClaw 是否接受转换后的配置",
+	"Check a PicoClaw config (default ~/.picoclaw/config.json) for problems":                                                                 "检查 PicoClaw 配置（默认 ~/.picoclaw/config.json）中的问题",
+	"lint: don't check that api_base URLs are reachable":                                                                                     "lint：不检查 api_base URL 是否可访问",
+	"Point Telegram/Discord webhooks left by OpenClaw at <url>/<channel> instead of removing them":                                           "将 OpenClaw 留下的 Telegram/Discord webhook 指向 <url>/<channel>，而不是删除它们",
+	"uninstall-openclaw: remove everything without prompting, including ~/.openclaw and Docker volumes; purge-due: delete without prompting": "uninstall-openclaw：不经提示删除所有内容，包括 ~/.openclaw 和 Docker 数据卷；purge-due：不经提示直接删除",
+	"uninstall-openclaw: remove the binary and services without prompting, keeping ~/.openclaw":                                              "uninstall-openclaw：不经提示删除二进制文件和服务，保留 ~/.openclaw",
+	"Show version":   "显示版本",
+	"Show this help": "显示此帮助",
+
+	// ── Flags and menus ──
+	"%s requires a value":                                           "%s 需要一个值",
+	"--max-errors expects a non-negative number":                    "--max-errors 需要一个非负整数",
+	"--fsync expects one of: key, all, none":                        "--fsync 只能是：key、all、none",
+	"--encrypt expects one of: age, gpg, passphrase":                "--encrypt 只能是以下之一：age、gpg、passphrase",
+	"--keep-data and --purge can't be used together":                "--keep-data 和 --purge 不能同时使用",
+	"--format expects tar.gz or zip":                                "--format 只能是 tar.gz 或 zip",
+	"Unknown command: %s":                                           "未知命令：%s",
+	"Could not save stats preference: %v":                           "无法保存统计偏好：%v",
+	"What would you like to do?":                                    "你想做什么？",
+	"Migrate   — Full OpenClaw → PicoClaw migration":                "迁移 — 完整的 OpenClaw → PicoClaw 迁移",
+	"Backup    — Create a backup of OpenClaw":                       "备份 — 创建 OpenClaw 备份",
+	"Restore   — Restore OpenClaw from a backup":                    "恢复 — 从备份恢复 OpenClaw",
+	"Uninstall — Remove OpenClaw or PicoClaw":                       "卸载 — 移除 OpenClaw 或 PicoClaw",
+	"What do you want to uninstall?":                                "你想卸载哪个？",
+	"OpenClaw  — Remove OpenClaw (binary + data)":                   "OpenClaw — 移除 OpenClaw（程序 + 数据）",
+	"PicoClaw  — Remove PicoClaw (binary + data) for a fresh start": "PicoClaw — 移除 PicoClaw（程序 + 数据）以便重新开始",
+
+	// ── Backup and restore ──
+	"Backup OpenClaw":                                                          "备份 OpenClaw",
+	"Restore OpenClaw from backup":                                             "从备份恢复 OpenClaw",
+	"Creating full backup of ~/.openclaw/":                                     "正在完整备份 ~/.openclaw/",
+	"Creating backup":                                                          "正在创建备份",
+	"Backup failed: %v":                                                        "备份失败：%v",
+	"Backup created: %s (%s)":                                                  "备份已创建：%s（%s）",
+	"Not included in backup (outside your home directory): %s":                 "未包含在备份中（位于主目录之外）：%s",
+	"Backup streamed to stdout (%s)":                                           "备份已输出到标准输出（%s）",
+	"Verify the copy where it landed, e.g.: %s":                                "请在接收端校验备份，例如：%s",
+	"[DRY RUN] Would stream a %s backup to stdout":                             "[演练] 将把 %s 备份输出到标准输出",
+	"--stdout only works with: claw-migrate backup":                            "--stdout 仅适用于：claw-migrate backup",
+	"Refusing to write the backup archive to a terminal — pipe or redirect it": "拒绝将备份归档写入终端 — 请使用管道或重定向",
+	"Verifying backup integrity":                                               "正在校验备份完整性",
+	"Verifying backup...":                                                      "正在校验备份...",
+	"Verifying...":                                                             "正在校验...",
+	"Backup verified":                                                          "备份校验通过",
+	"Backup verified successfully":                                             "备份校验成功",
+	"Backup verification warning: %v":                                          "备份校验警告：%v",
+	"Backup is corrupted: %v":                                                  "备份已损坏：%v",
+	"Continue WITHOUT backup? (not recommended)":                               "不备份继续？（不推荐）",
+	"[DRY RUN] Would create backup: ~/openclaw-backup-YYYYMMDD-HHMMSS.%s":      "[演练] 将创建备份：~/openclaw-backup-YYYYMMDD-HHMMSS.%s",
+	"No backup files found (looking for ~/openclaw-backup-*.tar.gz or .zip)":   "未找到备份文件（查找 ~/openclaw-backup-*.tar.gz 或 .zip）",
+	"Found %d backup(s)":                                                       "找到 %d 个备份",
+	"Which backup do you want to restore?":                                     "要恢复哪个备份？",
+	"This will replace ~/.openclaw with the contents of %s":                    "这将用 %s 的内容替换 ~/.openclaw",
+	"Proceed with restore?":                                                    "继续恢复？",
+	"Restore cancelled.":                                                       "已取消恢复。",
+	"Restoring":                                                                "正在恢复",
+	"Restoring OpenClaw...":                                                    "正在恢复 OpenClaw...",
+	"Restore failed: %v":                                                       "恢复失败：%v",
+	"OpenClaw restored from backup!":                                           "已从备份恢复 OpenClaw！",
+	"Run: openclaw status":                                                     "运行：openclaw status",
+	"Done!":                                                                    "完成！",
+
+	// ── Retry ──
+	"Retry failed files": "重试失败的文件",
+	"No migration journal found — run 'claw-migrate migrate' first": "未找到迁移日志 — 请先运行 'claw-migrate migrate'",
+	"Last migration": "上次迁移",
+	"Outcome":        "结果",
+
+	// ── History ──
+	"Could not record the run in %s: %v":                                      "无法将本次运行记录到 %s：%v",
+	"Usage: claw-migrate history [N]":                                         "用法：claw-migrate history [N]",
+	"Could not read the run history: %v":                                      "无法读取运行历史：%v",
+	"No runs recorded yet":                                                    "尚无运行记录",
+	"Showing the last %d of %d runs — claw-migrate history %d lists them all": "显示最近 %d 次运行，共 %d 次 — claw-migrate history %d 可列出全部",
+	"Recorded in %s":                                                          "记录于 %s",
+	"backup: %s":                                                              "备份：%s",
+	"before %s on %s, %s":                                                     "于 %[2]s 的 %[1]s 之前，%[3]s",
+	"Last run":                                                                "上次运行",
+	"%s — %s (%s)":                                                            "%s — %s（%s）",
+	"See every run with: claw-migrate history":                                "查看所有运行：claw-migrate history",
+	"It was made %s":                                                          "该备份创建于 %s",
+	"success":                                                                 "成功",
+	"completed with errors":                                                   "完成但有错误",
+	"failed":                                                                  "失败",
+	"aborted":                                                                 "已中止",
+
+	"No failed files recorded — nothing to retry":                                                           "没有失败记录 — 无需重试",
+	"The last run was aborted before every file was copied — run 'claw-migrate migrate' again for the rest": "上次运行在复制完所有文件前中止了——请再次运行 'claw-migrate migrate' 复制其余文件",
+	"%d file(s) failed in the last run":                                                                     "上次运行有 %d 个文件失败",
+	"The last run used move mode — sources will be deleted once copied":                                     "上次运行使用了移动模式 — 复制完成后将删除源文件",
+	"[DRY RUN] Would retry %s":                                                                              "[演练] 将重试 %s",
+	"Retry %d file(s)?":                                                                                     "重试 %d 个文件？",
+	"Retry cancelled.":                                                                                      "已取消重试。",
+	"Copying":                                                                                               "正在复制",
+	"Retrying failed files...":                                                                              "正在重试失败的文件...",
+	"Could not update migration journal: %v":                                                                "无法更新迁移日志：%v",
+	"%d migrated, %d still failing":                                                                         "已迁移 %d 个，仍有 %d 个失败",
+	"All %d file(s) migrated":                                                                               "全部 %d 个文件已迁移",
+
+	// ── Lint ──
+	"Could not read %s: %v":                                                 "无法读取 %s：%v",
+	"Could not write %s: %v":                                                "无法写入 %s：%v",
+	"%s is set differently in %s and %s — keeping the first":                "%s 在 %s 和 %s 中的值不同 — 保留前者",
+	"%d variable(s) in %s":                                                  "%[2]s 中有 %[1]d 个变量",
+	"Not carried over, as they only configure OpenClaw: %s":                 "未迁移（仅用于配置 OpenClaw）：%s",
+	"%s in .env is %s, but %s in the config is %s":                          ".env 中的 %s 为 %s，但配置中的 %s 为 %s",
+	"Variables PicoClaw's .env sets differently (PicoClaw's → OpenClaw's):": "PicoClaw 的 .env 中取值不同的变量（PicoClaw 的 → OpenClaw 的）：",
+	"[DRY RUN] Would merge %d variable(s) into %s":                          "[演练] 将把 %d 个变量合并到 %s",
+	"Keep which value of %s?":                                               "保留 %s 的哪个值？",
+	"Let OpenClaw's .env replace these %d value(s)?":                        "用 OpenClaw 的 .env 替换这 %d 个值？",
+	"Environment variables merged into %s":                                  "环境变量已合并到 %s",
+	"No problems found":                                                     "未发现问题",
+	"%d error(s), %d warning(s)":                                            "%d 个错误，%d 个警告",
+	"%d warning(s)":                                                         "%d 个警告",
+
+	// ── Uninstall ──
+	"Uninstall PicoClaw":                                           "卸载 PicoClaw",
+	"Uninstall OpenClaw":                                           "卸载 OpenClaw",
+	"OpenClaw installation not found":                              "未找到 OpenClaw 安装",
+	"PicoClaw installation not found":                              "未找到 PicoClaw 安装",
+	"It's recommended to create a backup before uninstalling.":     "建议在卸载前先创建备份。",
+	"Create a backup first?":                                       "先创建备份？",
+	"This will remove PicoClaw completely so you can start fresh.": "这将完全移除 PicoClaw，以便重新开始。",
+	"This will remove OpenClaw completely:":                        "这将完全移除 OpenClaw：",
+	"Uninstall PicoClaw?":                                          "卸载 PicoClaw？",
+	"Uninstall OpenClaw?":                                          "卸载 OpenClaw？",
+	"Uninstalling OpenClaw (%s)":                                   "正在卸载 OpenClaw（%s）",
+	"Cancelled.":                                                   "已取消。",
+	"Uninstall cancelled. Nothing was removed.":                    "已取消卸载，未删除任何内容。",
+	"cancelled":                   "已取消",
+	"Stopping PicoClaw processes": "正在停止 PicoClaw 进程",
+	"Stopping OpenClaw processes": "正在停止 OpenClaw 进程",
+	"Processes stopped":           "进程已停止",
+	"Time taken, not counting time waiting for answers:": "耗时（不含等待回答的时间）：",
+	"Total":                       "合计",
+	"Stopped %s under %s":         "已停止 %[2]s 管理的 %[1]s",
+	"Send Ctrl-C to %s in %s %s?": "向 %[2]s %[3]s 中的 %[1]s 发送 Ctrl-C？",
+	"Stop and remove %s from %s? It runs: %s": "停止 %[1]s 并将其从 %[2]s 中移除？它运行的是：%[3]s",
+	"Left %s under %s running":                "保留 %[2]s 管理的 %[1]s 继续运行",
+	"Could not stop %s under %s: %v":          "无法停止 %[2]s 管理的 %[1]s：%[3]v",
+	"Removing binary":                         "正在删除程序",
+	"Binary removed":                          "程序已删除",
+	"Could not remove binary: %v":             "无法删除程序：%v",
+	"You may need to manually delete: %s":     "你可能需要手动删除：%s",
+	"Removing launch agents":                  "正在删除启动项",
+	"Removed %d launch agent(s)":              "已删除 %d 个启动项",
+	"No launch agents found":                  "未发现启动项",
+	"Removed %s browser host %s":              "已删除 %s 浏览器本地主机 %s",
+	"Browser host":                            "浏览器本地主机",
+	"%s browser host %s runs OpenClaw and PicoClaw has no equivalent — it will stop working once OpenClaw is uninstalled": "%s 浏览器本地主机 %s 运行的是 OpenClaw，PicoClaw 没有对应功能 —— 卸载 OpenClaw 后它将无法使用",
+	"Point %s browser host %s at PicoClaw?":                  "将 %s 浏览器本地主机 %s 指向 PicoClaw？",
+	"%s browser host %s now runs %s":                         "%s 浏览器本地主机 %s 现在运行 %s",
+	"Removing Docker containers and images":                  "正在删除 Docker 容器和镜像",
+	"Could not list Docker artifacts: %v":                    "无法列出 Docker 资源：%v",
+	"No OpenClaw Docker containers, images or volumes found": "未发现 OpenClaw 的 Docker 容器、镜像或数据卷",
+	"Compose projects":                                       "Compose 项目",
+	"Containers":                                             "容器",
+	"Container images":                                       "容器镜像",
+	"Volumes":                                                "数据卷",
+	"Remove OpenClaw's Docker containers and images?":        "删除 OpenClaw 的 Docker 容器和镜像？",
+	"Docker containers and images removed":                   "Docker 容器和镜像已删除",
+	"Docker containers and images preserved":                 "已保留 Docker 容器和镜像",
+	"Docker volumes are not part of the backup — their data is lost once they're deleted": "Docker 数据卷不在备份范围内 — 删除后其中的数据将丢失",
+	"Delete OpenClaw's Docker volumes?":                                                   "删除 OpenClaw 的 Docker 数据卷？",
+	"Delete OpenClaw's Docker volumes without a backup?":                                  "在没有备份的情况下删除 OpenClaw 的 Docker 数据卷？",
+	"No verified backup of OpenClaw's data exists either":                                 "OpenClaw 的数据也没有已验证的备份",
+	"No verified backup exists — refusing to delete OpenClaw's Docker volumes unattended": "没有已验证的备份 — 拒绝在无人值守时删除 OpenClaw 的 Docker 数据卷",
+	"Create one with: claw-migrate backup — or pass --force to delete them anyway":        "使用 claw-migrate backup 创建备份 — 或传入 --force 强制删除",
+	"Docker volumes removed":                   "Docker 数据卷已删除",
+	"Docker volumes preserved":                 "已保留 Docker 数据卷",
+	"Docker volumes preserved (--keep-data)":   "已保留 Docker 数据卷（--keep-data）",
+	"Keeping %s (--keep-data)":                 "保留 %s（--keep-data）",
+	"Data: %s (kept)":                          "数据：%s（保留）",
+	"Could not remove %v":                      "无法删除 %v",
+	"Cleaning shell startup files and crontab": "正在清理 Shell 启动文件和 crontab",
+	"No OpenClaw aliases, completions or PATH entries in shell startup files":                 "Shell 启动文件中没有 OpenClaw 的别名、补全或 PATH 设置",
+	"%d line(s) in shell startup files refer to OpenClaw:":                                    "Shell 启动文件中有 %d 行引用了 OpenClaw：",
+	"Comment out the %d standalone line(s)? (each file is copied to .claw-migrate.bak first)": "将这 %d 行独立语句注释掉？（每个文件会先复制为 .claw-migrate.bak）",
+	"These are part of a larger statement — edit them by hand:":                               "这些行属于更大的语句——请手动编辑：",
+	"Could not update shell startup files: %v":                                                "无法更新 Shell 启动文件：%v",
+	"Commented out — search for \"disabled by claw-migrate\" to find them":                    "已注释 — 搜索 \"disabled by claw-migrate\" 可找到它们",
+	"Left as they are — they will fail once OpenClaw is gone":                                 "保持不变 — OpenClaw 删除后它们会出错",
+	"For PicoClaw in %s:":     "%s 中为 PicoClaw 添加：",
+	"Add to %s?":              "添加到 %s？",
+	"Could not update %s: %v": "无法更新 %s：%v",
+	"Added %d line(s) to %s — open a new shell to use them":                                              "已向 %[2]s 添加 %[1]d 行 — 打开新的 Shell 即可使用",
+	"Could not read the crontab: %v":                                                                     "无法读取 crontab：%v",
+	"No crontab entries run OpenClaw":                                                                    "crontab 中没有运行 OpenClaw 的条目",
+	"%d crontab entr(ies) refer to OpenClaw:":                                                            "crontab 中有 %d 个条目引用了 OpenClaw：",
+	"(no PicoClaw equivalent)":                                                                           "（PicoClaw 没有对应命令）",
+	"Point them at PicoClaw (commenting out the %d with no equivalent)":                                  "改为使用 PicoClaw（注释掉 %d 个没有对应命令的条目）",
+	"Point them at PicoClaw":                                                                             "改为使用 PicoClaw",
+	"Comment them all out":                                                                               "全部注释掉",
+	"Leave them":                                                                                         "保持不变",
+	"What should happen to these entries?":                                                               "如何处理这些条目？",
+	"Could not update the crontab: %v":                                                                   "无法更新 crontab：%v",
+	"Crontab updated — the previous one is saved in %s":                                                  "crontab 已更新 — 原来的保存在 %s",
+	"Removing data directory":                                                                            "正在删除数据目录",
+	"About to delete: %s":                                                                                "即将删除：%s",
+	"Delete all PicoClaw data?":                                                                          "删除全部 PicoClaw 数据？",
+	"Delete all OpenClaw data?":                                                                          "删除全部 OpenClaw 数据？",
+	"Verified backup: %s":                                                                                "已验证的备份：%s",
+	"No verified backup, but it will be set aside under %s (--no-delete)":                                "没有已验证的备份，但数据会被移到 %s（--no-delete）",
+	"No verified backup exists — deleting anyway (--force)":                                              "没有已验证的备份 — 仍然删除（--force）",
+	"No verified backup exists — refusing to delete OpenClaw's data unattended":                          "没有已验证的备份 — 拒绝在无人值守时删除 OpenClaw 数据",
+	"Create one with: claw-migrate backup — or pass --force to delete it anyway":                         "请先运行 claw-migrate backup 创建备份，或使用 --force 强制删除",
+	"No verified backup exists — once deleted, this data can't be restored":                              "没有已验证的备份 — 删除后数据将无法恢复",
+	"Delete all OpenClaw data without a backup?":                                                         "在没有备份的情况下删除全部 OpenClaw 数据？",
+	"With --purge-after it stays for %d more day(s) first":                                               "使用 --purge-after 时，数据会先保留 %d 天",
+	"Could not schedule the purge: %v":                                                                   "无法安排清除：%v",
+	"%s stays until %s, then: claw-migrate purge-due":                                                    "%s 保留到 %s，之后运行：claw-migrate purge-due",
+	"Changed your mind? Keep it with: claw-migrate purge-due cancel":                                     "改变主意了？保留数据：claw-migrate purge-due cancel",
+	"Run purge-due daily from your crontab, so it happens on its own?":                                   "在 crontab 中每天运行 purge-due，让清除自动进行？",
+	"Added — the entry removes itself once the purge is done":                                            "已添加 — 清除完成后该条目会自动移除",
+	"No purge is scheduled":                                                                              "没有安排清除",
+	"Could not cancel the purge: %v":                                                                     "无法取消清除：%v",
+	"Purge cancelled — %s stays":                                                                         "已取消清除 — 保留 %s",
+	"Unknown purge-due action: %s (use: purge-due, purge-due cancel)":                                    "未知的 purge-due 操作：%s（可用：purge-due、purge-due cancel）",
+	"%s is already gone":                                                                                 "%s 已不存在",
+	"%s is due for deletion on %s, in %d day(s)":                                                         "%s 将于 %s 删除，还有 %d 天",
+	"The scheduled purge of ~/.openclaw was cancelled":                                                   "已取消对 ~/.openclaw 的计划清除",
+	"%s will be deleted on %s — keep it with: claw-migrate purge-due cancel":                             "%s 将于 %s 删除 — 保留数据：claw-migrate purge-due cancel",
+	"--purge-after takes a number of days, e.g. --purge-after 7":                                         "--purge-after 需要天数，例如 --purge-after 7",
+	"--keep-data keeps ~/.openclaw, so it can't be used with --purge-after":                              "--keep-data 会保留 ~/.openclaw，不能与 --purge-after 同时使用",
+	"Uninstall: keep ~/.openclaw for DAYS more, then delete it with purge-due":                           "卸载：再保留 ~/.openclaw DAYS 天，之后由 purge-due 删除",
+	"Delete OpenClaw's data once a --purge-after grace period is over (purge-due cancel keeps it)":       "在 --purge-after 宽限期结束后删除 OpenClaw 数据（purge-due cancel 可保留）",
+	"After migrating, check every minute for HOURS (default 24) that PicoClaw stays up, alerting if not": "迁移后在 HOURS 小时内（默认 24）每分钟检查 PicoClaw 是否正常运行，异常时提醒",
+	"POST the migration result, and watch's alerts, to <url> as JSON (Slack-compatible)":                 "以 JSON 将迁移结果和 watch 的提醒 POST 到 <url>（兼容 Slack）",
+	"Get alerted if it goes down in the next 24 hours":                                                   "接下来 24 小时内出现故障时提醒你",
+	"Usage: claw-migrate watch [HOURS]":                                                                  "用法：claw-migrate watch [HOURS]",
+	"Watching PicoClaw":                                                                                  "正在监视 PicoClaw",
+	"PicoClaw is not installed":                                                                          "未安装 PicoClaw",
+	"Checking every minute until %s — Ctrl-C stops":                                                      "每分钟检查一次，直到 %s — 按 Ctrl-C 停止",
+	"Alerts also go to %s":                                                                               "提醒也会发送到 %s",
+	"No problems in %g hour(s) of watching":                                                              "监视 %g 小时，未发现问题",
+	"%d problem(s) seen in %g hour(s) of watching":                                                       "监视 %[2]g 小时，发现 %[1]d 个问题",
+	"PicoClaw recovered":                                                                                 "PicoClaw 已恢复",
+	"PicoClaw is down":                                                                                   "PicoClaw 出现故障",
+	"Could not post the alert: %v":                                                                       "无法发送提醒：%v",
+	"claw-migrate on %s: %s — %d of %d files migrated, %d skipped, %d errors":                            "%s 上的 claw-migrate：%s — 已迁移 %d/%d 个文件，跳过 %d 个，%d 个错误",
+	"claw-migrate on %s failed: %s":                                                                      "%s 上的 claw-migrate 失败：%s",
+	"Could not post the result: %v":                                                                      "无法发送结果：%v",
+	"Result posted to %s":                                                                                "结果已发送到 %s",
+	"Don't show desktop notifications when long phases finish or watch alerts":                           "长时间运行的阶段完成或 watch 发出提醒时，不显示桌面通知",
+	"Backup complete":                                                                                    "备份完成",
+	"Migration finished":                                                                                 "迁移完成",
+	"Took %s — claw-migrate may need you for the next step":                                              "耗时 %s — claw-migrate 的下一步可能需要你操作",
+	"Take the safe answer to a prompt nobody answers within D, e.g. 300s (no to dangerous ones)":         "提示在 D 时间内（如 300s）无人回答时采用安全答案（危险操作答否）",
+	"--prompt-timeout takes a duration, e.g. --prompt-timeout 300s":                                      "--prompt-timeout 需要一个时长，例如 --prompt-timeout 300s",
+	"(no answer after %s)":                                                                               "（%s 内无人回答）",
+	"Migration plan":                                                                                     "迁移计划",
+	"Backup":                                                                                             "备份",
+	"Target":                                                                                             "目标",
+	"Install":                                                                                            "安装",
+	"Files":                                                                                              "文件",
+	"Config":                                                                                             "配置",
+	"Uninstall":                                                                                          "卸载",
+	"streamed to stdout, %s before compression":                                                          "输出到 stdout，压缩前 %s",
+	"~/openclaw-backup-<date>.%s, %s before compression":                                                 "~/openclaw-backup-<日期>.%s，压缩前 %s",
+	"Dockerfile and volume in %s":                                                                        "%s 中的 Dockerfile 和数据卷",
+	"Kubernetes manifests in %s":                                                                         "%s 中的 Kubernetes 清单",
+	"home-manager module in %s":                                                                          "%s 中的 home-manager 模块",
+	"%s/%s home directory in %s":                                                                         "%[3]s 中的 %[1]s/%[2]s 主目录",
+	"skipped (--skip-install)":                                                                           "跳过（--skip-install）",
+	"keep %s %s, or replace it with v%s":                                                                 "保留 %s %s，或替换为 v%s",
+	"pre-built v%s download, or build from source":                                                       "下载预编译的 v%s，或从源码构建",
+	"%d files (%s) copied to ~/.picoclaw/workspace":                                                      "%d 个文件（%s）复制到 ~/.picoclaw/workspace",
+	"%d files (%s) moved to ~/.picoclaw/workspace (--move)":                                              "%d 个文件（%s）移动到 ~/.picoclaw/workspace（--move）",
+	"%d provider(s) → model_list":                                                                        "%d 个提供商 → model_list",
+	"%d channel(s)":                                                                                      "%d 个频道",
+	"%d MCP server(s)":                                                                                   "%d 个 MCP 服务器",
+	"heartbeat":                                                                                          "心跳",
+	"offer %s → %s":                                                                                      "提供 %s → %s 升级",
+	"openclaw.json → config.json":                                                                        "openclaw.json → config.json",
+	" (merged into the existing one)":                                                                    "（合并到现有配置）",
+	"no (--skip-uninstall)":                                                                              "否（--skip-uninstall）",
+	"OpenClaw, asking first; ~/.openclaw is deleted after %d days":                                       "卸载 OpenClaw，会先询问；~/.openclaw 在 %d 天后删除",
+	"OpenClaw, asking first; ~/.openclaw is kept":                                                        "卸载 OpenClaw，会先询问；保留 ~/.openclaw",
+	"OpenClaw, asking first; ~/.openclaw is deleted if the backup verifies":                              "卸载 OpenClaw，会先询问；备份验证通过后删除 ~/.openclaw",
+	"OpenClaw, asking first, including about ~/.openclaw":                                                "卸载 OpenClaw，会先询问，包括如何处理 ~/.openclaw",
+	"Ask again about model upgrades declined in earlier runs":                                            "重新询问之前运行中拒绝过的模型升级",
+	"Forgot %d declined model upgrade(s)":                                                                "已清除 %d 个拒绝过的模型升级",
+	"Could not save settings: %v":                                                                        "无法保存设置：%v",
+	"Model: %s (upgrade to %s declined before — --reset-decisions asks again)":                           "模型：%s（之前已拒绝升级到 %s — 使用 --reset-decisions 可重新询问）",
+	"Won't suggest upgrading %s again (--reset-decisions to undo)":                                       "不会再建议升级 %s（使用 --reset-decisions 撤销）",
+	"  provider API keys:       %d":                                                                      "  其中提供商 API 密钥：    %d",
+	"Providers with a different API key on each side (PicoClaw's → OpenClaw's):":                         "两边 API 密钥不同的提供商（PicoClaw 的 → OpenClaw 的）：",
+	"Keep which API key for %s?":                                                                         "%s 保留哪个 API 密钥？",
+	"OpenClaw's %s":                                                                                      "OpenClaw 的 %s",
+	"PicoClaw's %s":                                                                                      "PicoClaw 的 %s",
+	"Delete ~/.openclaw and OpenClaw's Docker volumes under --yes or --purge even without a verified backup": "即使没有已验证的备份，也在 --yes 或 --purge 下删除 ~/.openclaw 和 OpenClaw 的 Docker 数据卷",
+	"Could not remove data: %v":                                                            "无法删除数据：%v",
+	"Data directory preserved at %s":                                                       "数据目录已保留在 %s",
+	"Data directory preserved.":                                                            "数据目录已保留。",
+	"No verified backup (%v) — deleting anyway (--force)":                                  "没有经过验证的备份（%v）——仍然删除（--force）",
+	"No verified backup (%v) — once deleted, this data can't be restored":                  "没有经过验证的备份（%v）——删除后这些数据无法恢复",
+	"%s changed since the uninstall — OpenClaw may have been reinstalled or used again":    "%s 在卸载后发生了变化——OpenClaw 可能已被重新安装或再次使用",
+	"Refusing to delete OpenClaw's data unattended — run claw-migrate purge-due to decide": "拒绝在无人值守时删除 OpenClaw 的数据——请运行 claw-migrate purge-due 自行决定",
+	"Deleting %s (--purge)":                                                                "正在删除 %s（--purge）",
+	"PicoClaw data removed":                                                                "PicoClaw 数据已删除",
+	"OpenClaw data removed":                                                                "OpenClaw 数据已删除",
+	"Verifying removal":                                                                    "正在确认删除结果",
+	"PicoClaw completely removed":                                                          "PicoClaw 已完全移除",
+	"OpenClaw completely removed":                                                          "OpenClaw 已完全移除",
+	"Binary still found — try: sudo rm %s":                                                 "程序仍然存在 — 请尝试：sudo rm %s",
+	"Service still found — try: systemctl disable --now %s && rm %s":                       "服务仍然存在 — 请尝试：systemctl disable --now %s && rm %s",
+	"Service":                                                        "服务",
+	"Removed %s":                                                     "已删除 %s",
+	"Removing launch agents and services":                            "正在删除启动项和服务",
+	"Stopped and removed %d systemd unit(s)":                         "已停止并删除 %d 个 systemd 单元",
+	"No launch agents or services found":                             "未发现启动项或服务",
+	"Data still found — try: rm -rf %s":                              "数据仍然存在 — 请尝试：rm -rf %s",
+	"%d trace(s) of OpenClaw remain:":                                "仍残留 %d 处 OpenClaw 痕迹：",
+	"You can now run a fresh migration with: ./claw-migrate migrate": "现在可以重新迁移：./claw-migrate migrate",
+	"OpenClaw preserved. You can uninstall later with:":              "已保留 OpenClaw。之后可以这样卸载：",
+	"[DRY RUN] Would uninstall OpenClaw":                             "[演练] 将卸载 OpenClaw",
+	"Binary: %s":                                                     "程序：%s",
+	"Data: %s":                                                       "数据：%s",
+
+	// ── Migration: detect ──
+	"DRY RUN mode — no changes will be made":                    "演练模式 — 不会做任何更改",
+	"Detecting installations":                                   "检测安装",
+	"OpenClaw installation not found at ~/.openclaw/":           "在 ~/.openclaw/ 未找到 OpenClaw 安装",
+	"Make sure OpenClaw is installed and has been initialized.": "请确认 OpenClaw 已安装并完成初始化。",
+	"Ready to begin migration?":                                 "准备开始迁移？",
+	"Migration cancelled. No changes made.":                     "已取消迁移，未做任何更改。",
+	"Migration cancelled.":                                      "已取消迁移。",
+	"Scanning OpenClaw files...":                                "正在扫描 OpenClaw 文件...",
+	"Scan cancelled — no changes made":                          "扫描已取消 — 未做任何更改",
+	"Copy strategy: %s (%s) — %d file(s) at once, %s buffers":   "复制策略：%s（%s）— 同时复制 %d 个文件，缓冲区 %s",
+	"network filesystem":                                        "网络文件系统",
+	"spinning disk":                                             "机械硬盘",
+	"same filesystem":                                           "同一文件系统",
+	"different local filesystems":                               "不同的本地文件系统",
+	"System information":                                        "系统信息",
+	"OpenClaw installation":                                     "OpenClaw 安装",
+	"PicoClaw installation":                                     "PicoClaw 安装",
+	"Configuration":                                             "配置",
+	"Platform":                                                  "平台",
+	"Directory":                                                 "目录",
+	"Binary":                                                    "程序",
+	"Data":                                                      "数据",
+	"Version":                                                   "版本",
+	"Size":                                                      "大小",
+	"Config file":                                               "配置文件",
+	"Default model":                                             "默认模型",
+	"Max tokens":                                                "最大 token 数",
+	"Providers":                                                 "模型提供商",
+	"Channels":                                                  "消息渠道",
+	"MCP Servers":                                               "MCP 服务器",
+	"Heartbeat":                                                 "心跳",
+	"Workspace":                                                 "工作区",
+	"Workspace (from config)":                                   "工作区（来自配置）",
+	"Location":                                                  "位置",
+	"PicoClaw":                                                  "PicoClaw",
+	"enabled (every %d min)":                                    "已启用（每 %d 分钟）",
+	"Default model          %s (outdated → %s available)":                            "默认模型               %s（已过时 → 可升级到 %s）",
+	"Workspace — agent files":                                                        "工作区 — 智能体文件",
+	"Workspace — custom files (%d)":                                                  "工作区 — 自定义文件（%d）",
+	"Workspace — standard directories":                                               "工作区 — 标准目录",
+	"Workspace — project directories (%d)":                                           "工作区 — 项目目录（%d）",
+	"... and %d more subdirectories":                                                 "... 还有 %d 个子目录",
+	"Workspace — hidden and sensitive items (%d)":                                    "工作区 — 隐藏和敏感项目（%d）",
+	"%s holds secrets (%s) — it is copied as-is":                                     "%s 含有密钥（%s）— 将原样复制",
+	"workspace/%s holds secrets (%s) — move them into config.json or a secret store": "workspace/%s 含有密钥（%s）— 请将其移入 config.json 或密钥存储",
+	"%d files (%s)":                                "%d 个文件（%s）",
+	"Total: %d files, %d directories (%s)":         "合计：%d 个文件，%d 个目录（%s）",
+	"OpenClaw home — other data (%d)":              "OpenClaw 主目录 — 其他数据（%d）",
+	"PicoClaw will be installed in the next phase": "PicoClaw 将在下一阶段安装",
+
+	// ── Migration: install ──
+	"Install PicoClaw":                 "安装 PicoClaw",
+	"Install PicoClaw (skipped)":       "安装 PicoClaw（已跳过）",
+	"--skip-install flag set":          "已设置 --skip-install",
+	"Checking latest PicoClaw release": "正在检查 PicoClaw 最新版本",
+	"Fetching latest version...":       "正在获取最新版本...",
+	"v%s (%s)":                         "v%s（%s）",
+	"cached":                           "缓存",
+	"stale cache":                      "过期缓存",
+	"fallback":                         "内置版本",
+	"from the plan":                    "来自计划",
+	"Could not check the latest PicoClaw release: %v":                                           "无法检查 PicoClaw 最新版本：%v",
+	"Using the built-in version v%s, which may be out of date":                                  "使用内置版本 v%s，可能已过时",
+	"Using v%s from an earlier check, which may be out of date":                                 "使用先前检查得到的 v%s，可能已过时",
+	"Could not find out the latest release — try again later or set GITHUB_TOKEN":               "无法获取最新版本 — 请稍后重试或设置 GITHUB_TOKEN",
+	"Provision PicoClaw for %s/%s":                                                              "为 %s/%s 准备 PicoClaw",
+	"Copy it into the target's home directory:":                                                 "将其复制到目标机器的主目录：",
+	"Then on the target:":                                                                       "然后在目标机器上运行：",
+	"Ready for %s/%s":                                                                           "已为 %s/%s 准备就绪",
+	"--target-os expects one of: linux, darwin, freebsd":                                        "--target-os 可选值：linux、darwin、freebsd",
+	"--target-arch expects one of: amd64, arm64, arm, mips64, riscv64":                          "--target-arch 可选值：amd64、arm64、arm、mips64、riscv64",
+	"--target-os, --target-arch and --output only work with: migrate, install-picoclaw, export": "--target-os、--target-arch 和 --output 仅适用于：migrate、install-picoclaw、export",
+	"Export migration bundle":                                                                   "导出迁移包",
+	"Compressing...":                                                                            "正在压缩...",
+	"Not bundling an incomplete workspace":                                                      "工作区不完整，不打包",
+	"Packing the bundle":                                                                        "打包中",
+	"Could not write the install script: %v":                                                    "无法写入安装脚本：%v",
+	"Bundle written: %s (%s)":                                                                   "迁移包已写入：%s（%s）",
+	"Bundle ready":                                                                              "迁移包已就绪",
+	"Copy it to the %s/%s machine, then run there:":                                             "将其复制到 %s/%s 机器上，然后在该机器上运行：",
+	"The bundle holds your API keys — keep it private":                                          "迁移包包含你的 API 密钥 — 请妥善保管",
+	"zstd is not installed — install it or name the bundle .tar.gz":                             "未安装 zstd — 请安装或将迁移包命名为 .tar.gz",
+	"Import migration bundle":                                                                   "导入迁移包",
+	"Usage: claw-migrate import FILE":                                                           "用法：claw-migrate import FILE",
+	"Checking the bundle":                                                                       "检查迁移包",
+	"Unpacking and checking files...":                                                           "正在解包并检查文件...",
+	"Bundle check failed: %v":                                                                   "迁移包检查失败：%v",
+	"Every file matches its checksum":                                                           "所有文件均与校验和一致",
+	"%s by claw-migrate %s":                                                                     "%s，由 claw-migrate %s 创建",
+	"This bundle is for %s/%s, but this machine is %s/%s":                                       "此迁移包适用于 %s/%s，但本机为 %s/%s",
+	"Export again with: --target-os %s --target-arch %s":                                        "请使用以下参数重新导出：--target-os %s --target-arch %s",
+	"[DRY RUN] Would replace %s":                                                                "[演练] 将替换 %s",
+	"[DRY RUN] Would install /usr/local/bin/picoclaw":                                           "[演练] 将安装 /usr/local/bin/picoclaw",
+	"[DRY RUN] Would copy the config and workspace into %s":                                     "[演练] 将把配置和工作区复制到 %s",
+	"Install this bundle?":                                                                      "安装此迁移包？",
+	"Installing config and workspace":                                                           "安装配置和工作区",
+	"%s already has a config":                                                                   "%s 已有配置",
+	"Replace it with the bundle's config (the current one is kept as config.json.bak)?":         "用迁移包中的配置替换它（当前配置保留为 config.json.bak）？",
+	"Config and workspace left as they are":                                                     "配置和工作区保持不变",
+	"Copied %d files, %d failed":                                                                "已复制 %d 个文件，%d 个失败",
+	"Copied %d files into %s":                                                                   "已复制 %d 个文件到 %s",
+	"Bundle imported":                                                                           "迁移包已导入",
+	"Start it with: picoclaw gateway":                                                           "启动命令：picoclaw gateway",
+	"Set it up with: picoclaw onboard":                                                          "初始化命令：picoclaw onboard",
+	"or, where claw-migrate is available: claw-migrate import %s":                               "或在装有 claw-migrate 的机器上运行：claw-migrate import %s",
+	"Latest version":                                                                            "最新版本",
+	"PicoClaw already installed: %s":                                                            "PicoClaw 已安装：%s",
+	"Version: %s":                                                                               "版本：%s",
+	"Skip installation and use existing PicoClaw?":                                              "跳过安装并使用现有的 PicoClaw？",
+	"Initializing PicoClaw workspace":                                                           "正在初始化 PicoClaw 工作区",
+	"Initializing PicoClaw":                                                                     "正在初始化 PicoClaw",
+	"How would you like to install PicoClaw?":                                                   "你想如何安装 PicoClaw？",
+	"Download pre-built binary (%s, recommended)":                                               "下载预编译程序（%s，推荐）",
+	"Build from source (latest features, requires Go 1.21+)":                                    "从源码构建（最新功能，需要 Go 1.21+）",
+	"[DRY RUN] Would download: %s":                                                              "[演练] 将下载：%s",
+	"[DRY RUN] Would clone and build from source":                                               "[演练] 将克隆并从源码构建",
+	"PicoClaw already initialized — skipping onboard":                                           "PicoClaw 已初始化 — 跳过 onboard",
+	"[DRY RUN] Would run: picoclaw onboard":                                                     "[演练] 将运行：picoclaw onboard",
+	"Running: picoclaw onboard":                                                                 "正在运行：picoclaw onboard",
+	"Running picoclaw onboard (non-interactive)...":                                             "正在运行 picoclaw onboard（非交互）...",
+	"Onboard had issues: %v":                                                                    "onboard 出现问题：%v",
+	"This PicoClaw's onboard has no non-interactive mode — skipped under --yes":                 "此版本 PicoClaw 的 onboard 没有非交互模式 — 在 --yes 下已跳过",
+	"Run 'picoclaw onboard' after the migration to finish setting it up":                        "迁移完成后运行 'picoclaw onboard' 完成设置",
+	"You may need to run 'picoclaw onboard' manually after migration":                           "迁移后你可能需要手动运行 'picoclaw onboard'",
+	"PicoClaw initialized":                                                                      "PicoClaw 已初始化",
+	"PicoClaw initialized (non-interactive)":                                                    "PicoClaw 已初始化（非交互）",
+	"Downloading PicoClaw binary":                                                               "正在下载 PicoClaw 程序",
+	"Unsupported platform: %v":                                                                  "不支持的平台：%v",
+	"URL: %s":                                                                                   "地址：%s",
+	"Downloading":                                                                               "正在下载",
+	"Download failed: %v":                                                                       "下载失败：%v",
+	"Download complete":                                                                         "下载完成",
+	"Nothing was deleted (--no-delete): %d item(s) set aside in %s":                             "未删除任何内容（--no-delete）：%d 项已移至 %s",
+	"Docker containers, images and volumes can't be set aside — left in place (--no-delete)":            "Docker 容器、镜像和卷无法移至一旁 —— 保持原样（--no-delete）",
+	"--move deletes sources as they are copied, so it can't be used with --no-delete":                   "--move 会在复制后删除源文件，不能与 --no-delete 同时使用",
+	"Never delete: move anything that would be removed or overwritten to ~/.claw-migrate/preserved":     "从不删除：将会被删除或覆盖的内容移至 ~/.claw-migrate/preserved",
+	"Which value wins a config key OpenClaw and PicoClaw both set: incoming, existing or ask (per key)": "OpenClaw 和 PicoClaw 都设置的配置键以哪边为准：incoming、existing 或 ask（逐个询问）",
+	"--prefer expects one of: existing, incoming, ask":                                                  "--prefer 只能是：existing、incoming、ask",
+	"[DRY RUN] Downloading the release into the cache, so the real run can skip it":                     "[演练] 正在将发布包下载到缓存，正式运行时可跳过下载",
+	"Using the archive downloaded earlier: %s":                                                          "使用之前下载的归档：%s",
+	"Downloading PicoClaw":                                                   "正在下载 PicoClaw",
+	"PicoClaw v%s downloaded during the backup":                              "已在备份期间下载 PicoClaw v%s",
+	"PicoClaw is still downloading — the install picks it up when it's done": "PicoClaw 仍在下载 — 下载完成后安装会使用它",
+	"Download during the backup failed: %v — trying again":                   "备份期间的下载失败：%v — 正在重试",
+	"Installing binary":                                                      "正在安装程序",
+	"Extraction failed: %v":                                                  "解压失败：%v",
+	"Installing to /usr/local/bin/picoclaw (may require sudo)":               "正在安装到 /usr/local/bin/picoclaw（可能需要 sudo）",
+	"Install failed: %v":                                                     "安装失败：%v",
+	"PicoClaw installed":                                                     "PicoClaw 已安装",
+	"Building PicoClaw from source":                                          "正在从源码构建 PicoClaw",
+	"Cloning and building (this may take a few minutes)...":                  "正在克隆并构建（可能需要几分钟）...",
+	"Build failed: %v":                                                       "构建失败：%v",
+	"PicoClaw built and installed from source":                               "PicoClaw 已从源码构建并安装",
+
+	// ── Migration: migrate ──
+	"Trying the converted config in a sandbox first (--sandbox)":                              "先在沙盒中试用转换后的配置（--sandbox）",
+	"[DRY RUN] Would run picoclaw status against the converted config under a temporary HOME": "[演练] 将在临时 HOME 下用转换后的配置运行 picoclaw status",
+	"PicoClaw is not installed — skipping the sandbox check":                                  "未安装 PicoClaw — 跳过沙盒检查",
+	"Could not prepare the sandbox: %v":                                                       "无法准备沙盒：%v",
+	"Sandbox HOME %s with the converted config and %d workspace file(s)":                      "沙盒 HOME %s，含转换后的配置和 %d 个工作区文件",
+	"%s accepts the converted config":                                                         "%s 接受转换后的配置",
+	"%s failed: %v":                                                                           "%s 失败：%v",
+	"PicoClaw did not accept the converted config — migrate into ~/.picoclaw anyway?":         "PicoClaw 未接受转换后的配置 — 仍要迁移到 ~/.picoclaw 吗？",
+	"Stopped before ~/.picoclaw was touched. Your backup and OpenClaw are unchanged.":         "已在改动 ~/.picoclaw 之前停止。你的备份和 OpenClaw 均未改变。",
+	"Migrate data": "迁移数据",
+	"Checking for PicoClaw's built-in migration tool":                         "正在检查 PicoClaw 内置迁移工具",
+	"Built-in 'picoclaw migrate' command is available":                        "内置 'picoclaw migrate' 命令可用",
+	"Use PicoClaw's built-in migration tool? (recommended)":                   "使用 PicoClaw 内置迁移工具？（推荐）",
+	"Skipping 'picoclaw migrate' — it would overwrite what you chose to keep": "跳过 'picoclaw migrate' — 它会覆盖你选择保留的内容",
+	"Could not compare configs: %v":                                           "无法比较配置：%v",
+	"PicoClaw already has data — review before migrating":                     "PicoClaw 已有数据 — 迁移前请先查看",
+	"Same on both sides:        %d file(s) — nothing to do":                   "两边相同：    %d 个文件 — 无需处理",
+	"Different on each side:    %d file(s)":                                   "两边不同：    %d 个文件",
+	"  newer in PicoClaw:       %d file(s)":                                   "  PicoClaw 较新：%d 个文件",
+	"Only in PicoClaw:          %d file(s) — left alone":                      "仅 PicoClaw 有：%d 个文件 — 保持不动",
+	"Config values that differ: %d":                                           "不一致的配置值：%d",
+	"Files that differ (★ = changed more recently in PicoClaw):":              "不同的文件（★ = 在 PicoClaw 中修改得更近）：",
+	"…and %d more": "…还有 %d 个",
+	"Dates and sizes are OpenClaw's → PicoClaw's":           "日期和大小为 OpenClaw 的 → PicoClaw 的",
+	"Config values that differ (PicoClaw's → OpenClaw's):":  "不一致的配置值（PicoClaw 的 → OpenClaw 的）：",
+	"[DRY RUN] Would ask which side to keep for these":      "[演练] 将询问这些保留哪一边",
+	"Replace them all with OpenClaw's version":              "全部替换为 OpenClaw 的版本",
+	"Keep PicoClaw's %d newer file(s), replace the rest":    "保留 PicoClaw 较新的 %d 个文件，替换其余文件",
+	"Keep all of PicoClaw's files, only add what's missing": "保留 PicoClaw 的所有文件，只添加缺少的",
+	"Files that differ:":                                                                    "不同的文件：",
+	"Keeping %d of PicoClaw's file(s)":                                                      "保留 PicoClaw 的 %d 个文件",
+	"Let OpenClaw's config replace these %d value(s)?":                                      "让 OpenClaw 的配置替换这 %d 个值？",
+	"Keeping PicoClaw's config values; OpenClaw's fill in the rest":                         "保留 PicoClaw 的配置值；其余由 OpenClaw 的补充",
+	"OpenClaw's config values win (--prefer incoming)":                                      "OpenClaw 的配置值优先（--prefer incoming）",
+	"PicoClaw's config values stay (--prefer existing)":                                     "保留 PicoClaw 的配置值（--prefer existing）",
+	"Keep which value?":                                                                     "保留哪个值？",
+	"OpenClaw's":                                                                            "OpenClaw 的",
+	"PicoClaw's":                                                                            "PicoClaw 的",
+	"Keeping PicoClaw's value for %d of %d key(s)":                                          "%[2]d 个键中保留 PicoClaw 的值 %[1]d 个",
+	"[DRY RUN] Would run: picoclaw migrate --force":                                         "[演练] 将运行：picoclaw migrate --force",
+	"Running: picoclaw migrate --force":                                                     "正在运行：picoclaw migrate --force",
+	"Running PicoClaw's built-in migration...":                                              "正在运行 PicoClaw 内置迁移...",
+	"picoclaw migrate failed (exit code %d): %v":                                            "picoclaw migrate 失败（退出码 %d）：%v",
+	"Falling back to claw-migrate's own conversion":                                         "改用 claw-migrate 自带的转换",
+	"picoclaw migrate completed":                                                            "picoclaw migrate 已完成",
+	"Copying workspace files":                                                               "正在复制工作区文件",
+	"Moving workspace files":                                                                "正在移动工作区文件",
+	"Migrating workspace (all files and directories)":                                       "正在迁移工作区（所有文件和目录）",
+	"[DRY RUN] Would migrate %d files across %d directories":                                "[演练] 将迁移 %[2]d 个目录中的 %[1]d 个文件",
+	"[DRY RUN] Would copy ~/.openclaw/%s → workspace/%s":                                    "[演练] 将复制 ~/.openclaw/%s → workspace/%s",
+	"Move mode needs a verified backup to roll back from — copying instead":                 "移动模式需要已校验的备份用于回滚 — 改为复制",
+	"Copied %d file(s) of other OpenClaw data (%d errors)":                                  "已复制 %d 个其他 OpenClaw 数据文件（%d 个错误）",
+	"Migrated %d credential file(s) with 0600 permissions":                                  "已以 0600 权限迁移 %d 个凭据文件",
+	"%s would overwrite another credential file at %s — copying it to %s instead":           "%s 会覆盖 %s 处的另一个凭据文件——改为复制到 %s",
+	"Merge the two by hand if PicoClaw needs what both hold":                                "如果 PicoClaw 需要两者的内容，请手动合并",
+	"These secret files were readable by other users in ~/.openclaw: %s":                    "以下密钥文件在 ~/.openclaw 中可被其他用户读取：%s",
+	"Their PicoClaw copies are 0600 — consider rotating the keys if this machine is shared": "它们在 PicoClaw 中的副本为 0600 — 如果这台机器是共享的，请考虑轮换密钥",
+	"Workspace copy aborted after %d errors: %v":                                            "工作区复制在 %d 个错误后中止：%v",
+	"Migrated %d files (%d skipped, %d errors)":                                             "已迁移 %d 个文件（跳过 %d 个，错误 %d 个）",
+	"Not in the OpenClaw workspace: %s":                                                     "OpenClaw 工作区中没有：%s",
+	"[DRY RUN] Would offer PicoClaw starter versions of them":                               "[演练] 将提示为它们创建 PicoClaw 初始版本",
+	"Create starter versions of %d file(s)? (edit them afterwards)":                         "为 %d 个文件创建初始版本？（之后可编辑）",
+	"Could not create %s: %v":                                                               "无法创建 %s：%v",
+	"Created %d starter file(s) in %s":                                                      "已在 %[2]s 中创建 %[1]d 个初始文件",
+	"Could not search the workspace for OpenClaw paths: %v":                                 "无法在工作区中搜索 OpenClaw 路径：%v",
+	"%d line(s) in %d markdown file(s) refer to OpenClaw's locations:":                      "%[2]d 个 Markdown 文件中有 %[1]d 行引用了 OpenClaw 的位置：",
+	"Point these at PicoClaw's locations in all %d file(s)?":                                "将全部 %d 个文件中的这些引用改为 PicoClaw 的位置？",
+	"Could not rewrite paths: %v":                                                           "无法改写路径：%v",
+	"Updated paths in %d file(s)":                                                           "已更新 %d 个文件中的路径",
+	"Could not search the workspace scripts: %v":                                            "无法搜索工作区脚本：%v",
+	"%d line(s) in scripts run openclaw commands PicoClaw has no equivalent for — listed under manual attention": "脚本中有 %d 行运行了 PicoClaw 没有对应命令的 openclaw 命令 — 已列入需手动处理的事项",
+	"%d line(s) in %d script(s) run openclaw or use OpenClaw's locations:":                                       "%[2]d 个脚本中有 %[1]d 行运行 openclaw 或使用 OpenClaw 的位置：",
+	"Rewrite these for PicoClaw in all %d script(s)?":                                                            "在全部 %d 个脚本中将这些改写为 PicoClaw 的？",
+	"Scripts left as they are":                                                "脚本保持不变",
+	"Could not rewrite scripts: %v":                                           "无法改写脚本：%v",
+	"Updated %d script(s)":                                                    "已更新 %d 个脚本",
+	"%s:%d runs openclaw with no PicoClaw equivalent: %s":                     "%s:%d 运行了 PicoClaw 没有对应命令的 openclaw：%s",
+	"%d file(s) cloned copy-on-write (no extra disk space used)":              "%d 个文件通过写时复制克隆（未占用额外磁盘空间）",
+	"Sources were removed as they were copied. To roll back, restore %s":      "源文件已在复制后删除。如需回滚，请恢复 %s",
+	"Retry the %d failed file(s)?":                                            "重试 %d 个失败的文件？",
+	"Retry: %d migrated, %d still failing":                                    "重试：已迁移 %d 个，仍有 %d 个失败",
+	"You can retry the failed files later with: claw-migrate retry":           "之后可以用以下命令重试失败的文件：claw-migrate retry",
+	"Could not write migration journal: %v":                                   "无法写入迁移日志：%v",
+	"Move mode needs the journal to record what it deletes — copying instead": "移动模式需要日志记录删除的内容——改为复制",
+	"%s: %d file(s)":           "%s：%d 个文件",
+	"    ... and %d more":      "    ……以及另外 %d 个",
+	"  %s: %v":                 "  %s：%v",
+	"Converting configuration": "正在转换配置",
+	"[DRY RUN] Would convert: openclaw.json → config.json":  "[演练] 将转换：openclaw.json → config.json",
+	"Config converted by picoclaw migrate — nothing to add": "配置已由 picoclaw migrate 转换 — 无需补充",
+	"Config converted by picoclaw migrate; added %s":        "配置已由 picoclaw migrate 转换；补充了 %s",
+	"Config supplement failed: %v":                          "配置补充失败：%v",
+	"Config migration failed: %v":                           "配置迁移失败：%v",
+	"Configuration converted and written":                   "配置已转换并写入",
+	"Previous config backed up to config.json.bak":          "原配置已备份到 config.json.bak",
+	"Checking model version":                                "正在检查模型版本",
+	"No default model detected in config":                   "配置中未检测到默认模型",
+	"Current model: %s (outdated)":                          "当前模型：%s（已过时）",
+	"Recommended:   %s":                                     "推荐：         %s",
+	"Update model to %s?":                                   "将模型更新为 %s？",
+	"Could not update model: %v":                            "无法更新模型：%v",
+	"Model updated to %s":                                   "模型已更新为 %s",
+	"Could not record the upgrade, so it can't be undone with undo model-upgrade: %v": "无法记录此次升级，因此无法用 undo model-upgrade 撤销：%v",
+	"If %s isn't available on your plan: claw-migrate undo model-upgrade":             "如果你的套餐无法使用 %s：claw-migrate undo model-upgrade",
+
+	// ── Undo ──
+	"Usage: claw-migrate undo model-upgrade|webhooks":                "用法：claw-migrate undo model-upgrade|webhooks",
+	"Undo the model upgrade":                                         "撤销模型升级",
+	"Undo the webhook moves":                                         "撤销 Webhook 迁移",
+	"No moved webhooks recorded — nothing to undo":                   "没有记录已迁移的 Webhook — 无需撤销",
+	"Could not read the PicoClaw config for the bot tokens: %v":      "无法读取 PicoClaw 配置以获取机器人令牌：%v",
+	"%s has no bot token in the PicoClaw config — can't put it back": "PicoClaw 配置中没有 %s 的机器人令牌 — 无法恢复",
+	"%s now delivers to %s, changed since — left as it is":           "%s 现在投递到 %s，之后已被更改 — 保持不变",
+	"[DRY RUN] Would point %s back at %s":                            "[演练] 将把 %s 指回 %s",
+	"Point %s back at %s?":                                           "将 %s 指回 %s？",
+	"%s delivers to %s again":                                        "%s 已重新投递到 %s",
+	"(removed)":                                                      "（已移除）",
+	"The last migration didn't upgrade the model — nothing to undo":  "上次迁移没有升级模型 — 无需撤销",
+	"Upgrade": "升级",
+	"Changed": "更改时间",
+	"The config no longer names %s where the upgrade put it — nothing to undo": "配置中升级写入的位置已不再是 %s — 无需撤销",
+	"%d place(s) changed since the upgrade are left as they are":               "升级后又被修改的 %d 处保持不变",
+	"[DRY RUN] Would put %s back":                                              "[演练] 将恢复 %s",
+	"Put %s back?":                                                             "恢复 %s？",
+	"Undo cancelled.":                                                          "已取消撤销。",
+
+	// ── Plans ──
+	"[DRY RUN]": "[演练]",
+	"--plan only works with: claw-migrate migrate --dry-run":   "--plan 只能用于：claw-migrate migrate --dry-run",
+	"Could not write the plan: %v":                             "无法写入计划：%v",
+	"Plan written to %s (%d actions, %d files)":                "计划已写入 %s（%d 个操作，%d 个文件）",
+	"Review it, then carry it out with: claw-migrate apply %s": "审阅后用以下命令执行：claw-migrate apply %s",
+	"Apply a migration plan":                                   "执行迁移计划",
+	"Could not read the plan: %v":                              "无法读取计划：%v",
+	"Plan":                                                     "计划",
+	"Made":                                                     "创建",
+	"%s on %s":                                                 "%[2]s 上的 %[1]s",
+	"(none)":                                                   "（无）",
+	"Flags":                                                    "参数",
+	"The plan was made by claw-migrate %s; this is %s, which may act differently": "该计划由 claw-migrate %s 创建；当前为 %s，行为可能不同",
+	"Checking OpenClaw is unchanged":                                              "检查 OpenClaw 是否未变",
+	"Could not read OpenClaw's files: %v":                                         "无法读取 OpenClaw 的文件：%v",
+	"OpenClaw has changed since the plan was made (%d difference(s)):":            "创建计划后 OpenClaw 已发生变化（%d 处不同）：",
+	"Make a new plan with: claw-migrate migrate --dry-run --plan":                 "请重新创建计划：claw-migrate migrate --dry-run --plan",
+	"%d file(s) and the config are as planned":                                    "%d 个文件和配置与计划一致",
+	"Planned actions": "计划的操作",
+	"[DRY RUN] Would run the migration with the plan's flags":                                         "[演练] 将使用计划中的参数运行迁移",
+	"Carry out these %d action(s)?":                                                                   "执行这 %d 个操作？",
+	"%d answer(s) from the plan are given again; anything else, and every dangerous step, still asks": "将再次给出计划中的 %d 个回答；其他问题和所有危险步骤仍会询问",
+	"--from-plan only works with: claw-migrate migrate":                                               "--from-plan 只能用于：claw-migrate migrate",
+	"Cannot find the claw-migrate binary: %v":                                                         "找不到 claw-migrate 程序：%v",
+	"The migration failed: %v":                                                                        "迁移失败：%v",
+
+	"Model set back to %s":       "模型已恢复为 %s",
+	"Restart PicoClaw to use it": "重启 PicoClaw 以使用它",
+
+	"Keeping %s — you can change later in ~/.picoclaw/config.json": "保留 %s — 之后可在 ~/.picoclaw/config.json 中修改",
+	"[DRY RUN] Would offer to upgrade to %s":                       "[演练] 将提示升级到 %s",
+	"The PicoClaw config no longer uses %s — nothing to update":    "PicoClaw 配置已不再使用 %s — 无需更新",
+	"%s: %s is not reachable from this machine (%v)":               "%s：本机无法访问 %s（%v）",
+	"%s: endpoint reachable (could not list its models: %v)":       "%s：端点可访问（无法列出其模型：%v）",
+	"%s: %s is available":        "%s：%s 可用",
+	"%s: %s is not served by %s": "%s：%s 未由 %s 提供",
+	"Served models: %s":          "可用模型：%s",
+	"Keep %s":                    "保留 %s",
+	"Which model should %s use?": "%s 应使用哪个模型？",
+	"Keeping %s — pull it before starting PicoClaw":                                     "保留 %s — 启动 PicoClaw 前请先拉取它",
+	"Could not update the config: %v":                                                   "无法更新配置：%v",
+	"%s → %s — PicoClaw has no per-task model routing; this will use the default model": "%s → %s — PicoClaw 不支持按任务路由模型；将使用默认模型",
+	"Context window": "上下文窗口",
+	"Max output":     "最大输出",
+	"Input $/MTok":   "输入 $/百万词元",
+	"Output $/MTok":  "输出 $/百万词元",
+	"List prices as of %s — check your provider for current pricing":        "标价截至 %s — 当前价格请以服务商为准",
+	"Could not ask %s where it delivers: %v":                                "无法查询 %s 的消息投递地址：%v",
+	"%s: no webhook to move":                                                "%s：没有需要迁移的 Webhook",
+	"%s already delivers to %s":                                             "%s 已投递到 %s",
+	"%s still delivers to %s":                                               "%s 仍在向 %s 投递消息",
+	"%d update(s) are waiting to be delivered":                              "有 %d 条更新等待投递",
+	"Remove the %s webhook so PicoClaw can receive messages directly?":      "删除 %s 的 Webhook，让 PicoClaw 直接接收消息？",
+	"Point the %s webhook at %s?":                                           "将 %s 的 Webhook 指向 %s？",
+	"Left as it is — PicoClaw won't receive %s messages until it's changed": "保持不变 — 修改之前 PicoClaw 收不到 %s 消息",
+	"Could not update the %s webhook: %v":                                   "无法更新 %s 的 Webhook：%v",
+	"Telegram and Discord webhooks were left delivering to OpenClaw while it runs — claw-migrate uninstall-openclaw offers to move them": "Telegram 和 Discord Webhook 在 OpenClaw 运行期间仍投递给它 — claw-migrate uninstall-openclaw 会提供迁移",
+	"[DRY RUN] Would offer to move the Telegram and Discord webhooks OpenClaw left, once it is stopped":                                  "[演练] OpenClaw 停止后将提供迁移其遗留的 Telegram 和 Discord Webhook",
+	"Could not record the old webhooks, so they can't be put back with undo webhooks: %v":                                                "无法记录原 Webhook，因此无法用 undo webhooks 恢复：%v",
+	"%s delivered to %s": "%s 原投递到 %s",
+	"To point them back at what they delivered to before: claw-migrate undo webhooks": "要将它们指回原来的地址：claw-migrate undo webhooks",
+	"%s now delivers to %s": "%s 现在向 %s 投递消息",
+	"%s webhook removed":    "%s 的 Webhook 已删除",
+	"Set the Telegram command menu to the %d custom command(s) in the config?":           "将 Telegram 命令菜单设置为配置中的 %d 条自定义命令？",
+	"Could not update the command menu: %v":                                              "无法更新命令菜单：%v",
+	"Telegram command menu updated":                                                      "Telegram 命令菜单已更新",
+	"Could not write the Slack app manifest: %v":                                         "无法写入 Slack 应用清单：%v",
+	"Slack: the app still has OpenClaw's settings — manifest for PicoClaw written to %s": "Slack：应用仍是 OpenClaw 的设置 — PicoClaw 的应用清单已写入 %s",
+	"Open %s, choose the app and go to App Manifest":                                     "打开 %s，选择该应用并进入 App Manifest 页面",
+	"Open %s": "打开 %s",
+	"Replace the manifest with the contents of %s and save":                                                                    "用 %s 的内容替换清单并保存",
+	"Reinstall the app to your workspace (OAuth & Permissions) to grant any new scopes":                                        "在 OAuth & Permissions 中将应用重新安装到工作区，以授予新的权限",
+	"Slack will verify %s — whatever serves it must be running":                                                                "Slack 会验证 %s — 提供该地址的服务必须正在运行",
+	"Under Basic Information → App-Level Tokens, create a token with connections:write and set it as channels.slack.app_token": "在 Basic Information → App-Level Tokens 中创建带 connections:write 权限的令牌，并设置为 channels.slack.app_token",
+	"Slack — apply ~/.picoclaw/%s to the Slack app and reinstall it":                                                           "Slack — 将 ~/.picoclaw/%s 应用到 Slack 应用并重新安装",
+	"Model: %s (current)":                                                              "模型：%s（最新）",
+	"Could not search the workspace for models: %v":                                    "无法在工作区中查找模型：%v",
+	"No outdated models referenced in workspace files":                                 "工作区文件中没有引用过时的模型",
+	"%d outdated model reference(s) in %d workspace file(s):":                          "%d 处过时模型引用，位于 %d 个工作区文件中：",
+	"...and more in %s":                                                                "...%s 中还有更多",
+	"[DRY RUN] Would offer to rewrite %d file(s)":                                      "[演练] 将提供改写 %d 个文件",
+	"Rewrite these to the recommended models in all %d file(s)?":                       "将全部 %d 个文件中的这些引用改为推荐模型？",
+	"Workspace files left as they are":                                                 "工作区文件保持不变",
+	"Could not rewrite models: %v":                                                     "无法改写模型：%v",
+	"Updated models in %d file(s)":                                                     "已更新 %d 个文件中的模型",
+	"Items requiring manual attention":                                                 "需要手动处理的项目",
+	"MCP Servers (%s) — verify format in config":                                       "MCP 服务器（%s）— 请检查配置中的格式",
+	"Cron jobs — recreate with: picoclaw cron add ...":                                 "定时任务 — 请用 picoclaw cron add ... 重新创建",
+	"Unsupported channels: %s (not available in PicoClaw)":                             "不支持的渠道：%s（PicoClaw 中不可用）",
+	"Tools not available in the installed PicoClaw: %s":                                "已安装的 PicoClaw 中不可用的工具：%s",
+	"PicoClaw reports %d channels, %d tools and %d commands":                           "PicoClaw 报告支持 %d 个渠道、%d 个工具和 %d 个命令",
+	"Read PicoClaw's commands from --help; assuming the default channels and tools":    "已从 --help 读取 PicoClaw 的命令；假定使用默认渠道和工具",
+	"Could not ask PicoClaw what it supports; assuming the default channels and tools": "无法查询 PicoClaw 支持的功能；假定使用默认渠道和工具",
+	"The following items need manual attention:":                                       "以下项目需要手动处理：",
+	"No manual items — everything migrated automatically!":                             "无需手动处理 — 全部已自动迁移！",
+
+	// ── Migration: verify ──
+	"Verify migration":              "校验迁移",
+	"Checking PicoClaw workspace":   "正在检查 PicoClaw 工作区",
+	"PicoClaw workspace not found!": "未找到 PicoClaw 工作区！",
+	"Workspace directory exists":    "工作区目录存在",
+	"Configuration file exists":     "配置文件存在",
+	"Configuration file missing":    "配置文件缺失",
+	"Verifying copied files":        "正在校验已复制的文件",
+	"No migration journal for this workspace — skipping checksum verification": "此工作区没有迁移日志 — 跳过校验和验证",
+	"%d files match the journal (%d rehashed)":                                 "%d 个文件与日志一致（重新计算了 %d 个）",
+	"%d migrated file(s) are missing: %s":                                      "%d 个已迁移的文件缺失：%s",
+	"%d file(s) changed since migration: %s":                                   "%d 个文件在迁移后被修改：%s",
+	"Checking key files":                                                       "正在检查关键文件",
+	"All key files present":                                                    "关键文件齐全",
+	"Auditing permissions":                                                     "正在审核权限",
+	"Permissions look good":                                                    "权限正常",
+	"Fixed permissions on %d path(s):":                                         "已修正 %d 个路径的权限：",
+	"Would fix permissions on %d path(s):":                                     "将修正 %d 个路径的权限：",
+	"Checking the gateway port":                                                "正在检查网关端口",
+	"No PicoClaw config to check the gateway port in":                          "没有可检查网关端口的 PicoClaw 配置",
+	"Gateway":             "网关",
+	"Port %d is free":     "端口 %d 空闲",
+	"Port %d is free now": "端口 %d 现已空闲",
+	"Port %d is in use by PicoClaw (pid %d) — the gateway is already running": "端口 %d 被 PicoClaw（pid %d）占用 — 网关已在运行",
+	"Port %d is still held by OpenClaw (pid %d)":                              "端口 %d 仍被 OpenClaw（pid %d）占用",
+	"[DRY RUN] Would offer to stop it":                                        "[演练] 将提示停止它",
+	"Stop OpenClaw (pid %d)?":                                                 "停止 OpenClaw（pid %d）？",
+	"Port %d is in use by %s (pid %d)":                                        "端口 %d 被 %s（pid %d）占用",
+	"Port %d is in use by another process":                                    "端口 %d 被其他进程占用",
+	"No free port found after %d — set gateway.port in %s by hand":            "%d 之后没有空闲端口 — 请在 %s 中手动设置 gateway.port",
+	"[DRY RUN] Would offer to move the gateway to port %d":                    "[演练] 将提示把网关改到端口 %d",
+	"Move the gateway to port %d?":                                            "把网关改到端口 %d？",
+	"Keeping port %d — free it before running picoclaw gateway":               "保留端口 %d — 运行 picoclaw gateway 前请先释放它",
+	"Gateway port set to %d":                                                  "网关端口已设为 %d",
+	"Other machines may not reach the gateway: %s":                            "其他机器可能无法访问网关：%s",
+	"Firewall: %s":                    "防火墙：%s",
+	"Could not stop pid %d: %v":       "无法停止 pid %d：%v",
+	"Stopped pid %d":                  "已停止 pid %d",
+	"pid %d is still running":         "pid %d 仍在运行",
+	"Test your PicoClaw installation": "测试你的 PicoClaw 安装",
+	"Try these commands:":             "试试这些命令：",
+	"Check status":                    "查看状态",
+	"Chat with your agent":            "与你的智能体对话",
+	"Start the gateway":               "启动网关",
+
+	// ── Migration: uninstall and stats ──
+	"Uninstall OpenClaw (skipped)":                                      "卸载 OpenClaw（已跳过）",
+	"--skip-uninstall flag set. You can uninstall later with:":          "已设置 --skip-uninstall。之后可以这样卸载：",
+	"Sharing anonymous migration stats (opted in with --share-stats):":  "正在发送匿名迁移统计（已通过 --share-stats 同意）：",
+	"No stats endpoint configured (set stats_url in %s) — nothing sent": "未配置统计地址（请在 %s 中设置 stats_url）— 未发送任何内容",
+	"Could not send stats: %v":                                          "无法发送统计：%v",
+	"Stats sent — thank you!":                                           "统计已发送 — 谢谢！",
+
+	// ── Status ──
+	"Migration status":              "迁移状态",
+	"Installations":                 "安装",
+	"Backups":                       "备份",
+	"Last backup":                   "最近的备份",
+	"No migration has been run yet": "尚未运行过迁移",
+	"Started":                       "开始于",
+	"%d migrated, %d failed":        "已迁移 %d 个，失败 %d 个",
+	"Retry the failures with: claw-migrate retry": "重试失败的文件：claw-migrate retry",
+	"Manual attention":                     "需手动处理",
+	"No manual items":                      "没有需手动处理的项目",
+	"Rollback":                             "回滚",
+	"Possible — claw-migrate restore (%s)": "可以 — claw-migrate restore（%s）",
+	"No backup, but OpenClaw is still installed — nothing to roll back yet": "没有备份，但 OpenClaw 仍已安装 — 暂无需回滚",
+	"Not possible — no backup found":                                        "无法回滚 — 未找到备份",
+
+	// ── Todo ──
+	"done":                               "完成",
+	"Could not read PicoClaw config: %v": "无法读取 PicoClaw 配置：%v",
+	"No checklist found at %s — it is written by 'claw-migrate migrate'": "%s 处没有清单 — 它由 'claw-migrate migrate' 生成",
+	"No item %s — run 'claw-migrate todo' to see the list":               "没有第 %s 项 — 运行 'claw-migrate todo' 查看清单",
+	"Mark it done anyway?":                                                 "仍然标记为完成？",
+	"Usage: claw-migrate todo [done N | undo N]":                           "用法：claw-migrate todo [done N | undo N]",
+	"%d of %d item(s) left — mark one done with: claw-migrate todo done N": "还剩 %d/%d 项 — 标记完成：claw-migrate todo done N",
+	"All manual items done":                                                "所有需手动处理的项目均已完成",
+	"MCP servers missing from PicoClaw: %s":                                "PicoClaw 中缺少的 MCP 服务器：%s",
+	"Could not list PicoClaw cron jobs: %v":                                "无法列出 PicoClaw 定时任务：%v",
+	"Saved to %s — track progress with: claw-migrate todo":                 "已保存到 %s — 查看进度：claw-migrate todo",
+
+	// ── Compare ──
+	"OpenClaw config":                 "OpenClaw 配置",
+	"PicoClaw config":                 "PicoClaw 配置",
+	"Compare configurations":          "比较配置",
+	"Sources":                         "来源",
+	"Settings":                        "设置",
+	"No OpenClaw settings match %q":   "没有与 %q 匹配的 OpenClaw 设置",
+	"OpenClaw config has no settings": "OpenClaw 配置中没有设置",
+	"%d carried over (=), %d transformed (→), %d changed since migration (≠), %d dropped (✗)": "%d 项保留（=），%d 项转换（→），%d 项迁移后已更改（≠），%d 项丢弃（✗）",
+	"No OpenClaw config found at ~/.openclaw/openclaw.json and no backup to read it from":     "在 ~/.openclaw/openclaw.json 未找到 OpenClaw 配置，也没有可读取的备份",
+	"%s (from backup)": "%s（来自备份）",
+
+	// ── Backup contents ──
+	"Usage: claw-migrate backup show FILE":                  "用法：claw-migrate backup show FILE",
+	"Usage: claw-migrate backup extract FILE PATH [DEST]":   "用法：claw-migrate backup extract FILE PATH [DEST]",
+	"Unknown backup command: %s":                            "未知的 backup 命令：%s",
+	"Created":                                               "创建时间",
+	"Archive size":                                          "归档大小",
+	"Contents":                                              "内容",
+	"%d files (%s uncompressed)":                            "%d 个文件（解压后 %s）",
+	"Could not parse openclaw.json: %v":                     "无法解析 openclaw.json：%v",
+	"%s not found in %s — see: claw-migrate backup show %s": "在 %[2]s 中未找到 %[1]s — 查看：claw-migrate backup show %[3]s",
+	"%s exists. Overwrite?":                                 "%s 已存在。覆盖？",
+	"Extracted %s → %s":                                     "已提取 %s → %s",
+	"Backup not found: %s — see: claw-migrate backup list":  "未找到备份：%s — 查看：claw-migrate backup list",
+
+	// ── Restore a file ──
+	"Usage: claw-migrate restore-file PATH [openclaw|picoclaw]":       "用法：claw-migrate restore-file PATH [openclaw|picoclaw]",
+	"Target must be openclaw or picoclaw":                             "目标必须是 openclaw 或 picoclaw",
+	"%s not found in %s — see: claw-migrate backup show latest":       "在 %[2]s 中未找到 %[1]s — 查看：claw-migrate backup show latest",
+	"%d files in the backup match %s. Which one?":                     "备份中有 %d 个文件匹配 %s。选择哪一个？",
+	"%s is not migrated to PicoClaw — restore it to openclaw instead": "%s 不会迁移到 PicoClaw — 请将其恢复到 openclaw",
+	"PicoClaw — %s":             "PicoClaw — %s",
+	"OpenClaw — %s":             "OpenClaw — %s",
+	"Restore to:":               "恢复到：",
+	"Restoring %s from %s (%s)": "正在从 %[2]s（%[3]s）恢复 %[1]s",
+	"Overwrite %s?":             "覆盖 %s？",
+	"Restored %s":               "已恢复 %s",
+
+	// ── Secrets export/import ──
+	"Bundle":         "包",
+	"Export secrets": "导出密钥",
+	"No API keys or tokens found in the OpenClaw or PicoClaw config": "在 OpenClaw 或 PicoClaw 配置中未找到 API 密钥或令牌",
+	"%d secret(s)": "%d 个密钥",
+	"--recipient needs --encrypt age or --encrypt gpg": "--recipient 需要 --encrypt age 或 --encrypt gpg",
+	"Encrypting with %s":                                 "使用 %s 加密",
+	"Passphrase for the bundle":                          "加密包的密码",
+	"A passphrase is required":                           "必须提供密码",
+	"Repeat the passphrase":                              "再次输入密码",
+	"Passphrases do not match":                           "两次输入的密码不一致",
+	"Export failed: %v":                                  "导出失败：%v",
+	"Wrote %d secret(s) to %s":                           "已将 %d 个密钥写入 %s",
+	"On the new machine: claw-migrate import-secrets %s": "在新机器上运行：claw-migrate import-secrets %s",
+	"Usage: claw-migrate import-secrets FILE":            "用法：claw-migrate import-secrets FILE",
+	"Import secrets":                                     "导入密钥",
+	"Could not decrypt %s: %v":                           "无法解密 %s：%v",
+	"%d secret(s) from %s, %s":                           "%d 个密钥，来自 %s，%s",
+	"No PicoClaw config yet — keys for model_list entries need one (run: picoclaw onboard)": "尚无 PicoClaw 配置 — model_list 条目的密钥需要它（运行：picoclaw onboard）",
+	"Changes":            "更改",
+	"already set":        "已设置",
+	"add %s":             "添加 %s",
+	"replace %s with %s": "将 %s 替换为 %s",
+	"PicoClaw already has every secret in the bundle":       "PicoClaw 已包含加密包中的所有密钥",
+	"Write %d secret(s) to %s?":                             "将 %d 个密钥写入 %s？",
+	"Could not back up %s: %v":                              "无法备份 %s：%v",
+	"Could not write PicoClaw config: %v":                   "无法写入 PicoClaw 配置：%v",
+	"Imported %d secret(s)":                                 "已导入 %d 个密钥",
+	"Skipped %s — no matching entry in the PicoClaw config": "已跳过 %s — PicoClaw 配置中没有对应条目",
+
+	// ── Install PicoClaw ──
+	"PicoClaw is ready": "PicoClaw 已就绪",
+	"Config:    %s":     "配置：  %s",
+	"Workspace: %s":     "工作区：%s",
+	"Coming from OpenClaw later? Run: claw-migrate migrate --skip-install": "以后要从 OpenClaw 迁移？运行：claw-migrate migrate --skip-install",
+	"Checksum verified": "校验和已验证",
+	"Release publishes no checksum for this archive — skipping verification": "该版本未发布此归档的校验和 — 跳过验证",
+	"Checksum verification failed: %v":                                       "校验和验证失败：%v",
+
+	// ── Upgrade PicoClaw ──
+	"Upgrade PicoClaw": "升级 PicoClaw",
+	"PicoClaw is not installed — run: claw-migrate install-picoclaw": "PicoClaw 未安装 — 运行：claw-migrate install-picoclaw",
+	"Checking versions": "正在检查版本",
+	"Installed":         "已安装",
+	"Could not tell which version is installed": "无法确定已安装的版本",
+	"Install v%s anyway?":                       "仍然安装 v%s？",
+	"PicoClaw is up to date":                    "PicoClaw 已是最新版本",
+	"Upgrade PicoClaw v%s → v%s?":               "将 PicoClaw 从 v%s 升级到 v%s？",
+	"[DRY RUN] Would back up ~/.picoclaw to ~/picoclaw-backup-YYYYMMDD-HHMMSS.tar.gz": "[演练] 将把 ~/.picoclaw 备份到 ~/picoclaw-backup-YYYYMMDD-HHMMSS.tar.gz",
+	"Backing up ~/.picoclaw": "正在备份 ~/.picoclaw",
+	"Upgrade cancelled.":     "升级已取消。",
+	"Replacing binary":       "正在替换二进制文件",
+	"Installed v%s at %s":    "已将 v%s 安装到 %s",
+	"Checking PicoClaw":      "正在检查 PicoClaw",
+	"PicoClaw upgraded":      "PicoClaw 已升级",
+	"Checks failed. Roll back to the previous binary (%s)?": "检查失败。回滚到之前的二进制文件（%s）？",
+	"Rollback failed: %v — the previous binary is at %s":    "回滚失败：%v — 之前的二进制文件位于 %s",
+	"Previous binary restored":                              "已恢复之前的二进制文件",
+	"~/.picoclaw backup kept at %s":                         "~/.picoclaw 备份保留在 %s",
+	"Previous binary kept at %s":                            "之前的二进制文件保留在 %s",
+	"picoclaw --version did not run":                        "picoclaw --version 无法运行",
+	"picoclaw reports %s, expected v%s":                     "picoclaw 报告版本 %s，期望 v%s",
+	"Binary runs: %s":                                       "二进制文件可运行：%s",
+	"Config not readable: %v":                               "配置不可读：%v",
+
+	// ── All users ──
+	"--all-users works with migrate and backup":        "--all-users 仅适用于 migrate 和 backup",
+	"--all-users must be run as root (e.g. with sudo)": "--all-users 必须以 root 身份运行（例如使用 sudo）",
+	"No users with ~/.openclaw were found":             "未找到拥有 ~/.openclaw 的用户",
+	"Users with OpenClaw: %s":                          "拥有 OpenClaw 的用户：%s",
+	"Run %s for each of these %d users in turn?":       "依次为这 %[2]d 个用户运行 %[1]s？",
+	"User %s (%s)": "用户 %s（%s）",
+	"failed: %v":   "失败：%v",
+	"Could not give %s's files back to them: %v": "无法将文件归还给 %s：%v",
+
+	// ── Docker ──
+	"[DRY RUN] Would write %s":      "[演练] 将写入 %s",
+	"Build container layout":        "生成容器布局",
+	"Output":                        "输出",
+	"Volume":                        "数据卷",
+	"[DRY RUN] Would copy %s to %s": "[演练] 将把 %s 复制到 %s",
+	"[DRY RUN] Would write Dockerfile, docker-compose.yml and .dockerignore in %s": "[演练] 将在 %s 中写入 Dockerfile、docker-compose.yml 和 .dockerignore",
+	"Copying workspace into the volume":                                            "正在将工作区复制到数据卷",
+	"Converting config":                                                            "正在转换配置",
+	"Config conversion failed: %v":                                                 "配置转换失败：%v",
+	"Config converted":                                                             "配置已转换",
+	"Writing Dockerfile":                                                           "正在写入 Dockerfile",
+	"Could not save the migration journal: %v":                                     "无法保存迁移日志：%v",
+	"Building the image":                                                           "正在构建镜像",
+	"Build the %s image now?":                                                      "现在构建 %s 镜像？",
+	"Built image %s":                                                               "已构建镜像 %s",
+	"docker is not installed here — copy the directory to a Docker host to build it": "此处未安装 docker — 请将目录复制到 Docker 主机上构建",
+	"Start the agent with:":                            "启动智能体：",
+	"(docker compose builds the image on first start)": "（docker compose 会在首次启动时构建镜像）",
+	"Keys are in %s — keep it out of version control":  "密钥位于 %s — 请勿将其纳入版本控制",
+	"Container ready":                                  "容器已就绪",
+
+	// ── Kubernetes ──
+	"Generate Kubernetes manifests":                        "生成 Kubernetes 清单",
+	"Cannot read OpenClaw config: %v":                      "无法读取 OpenClaw 配置：%v",
+	"Secrets":                                              "密钥",
+	"%d moved out of the ConfigMap":                        "%d 个已移出 ConfigMap",
+	"[DRY RUN] Would archive %s to %s":                     "[演练] 将把 %s 归档到 %s",
+	"Writing manifests":                                    "正在写入清单",
+	"Could not write manifests: %v":                        "无法写入清单：%v",
+	"ConfigMap, Secret, StatefulSet":                       "ConfigMap、Secret、StatefulSet",
+	"Archiving workspace":                                  "正在归档工作区",
+	"Could not archive the workspace: %v":                  "无法归档工作区：%v",
+	"%d files, %s":                                         "%d 个文件，%s",
+	"Manifests ready":                                      "清单已就绪",
+	"Apply them, then load the workspace into the volume:": "应用清单，然后将工作区载入数据卷：",
+	"config.json is rebuilt from the ConfigMap and Secret on every start —": "每次启动时 config.json 都会根据 ConfigMap 和 Secret 重新生成 —",
+	"edit those, not the file in the pod. Keep %s out of version control.":  "请修改它们，而不是 Pod 中的文件。请勿将 %s 纳入版本控制。",
+
+	// ── Nix ──
+	"Copying workspace":            "正在复制工作区",
+	"Generate home-manager module": "生成 home-manager 模块",
+	"PicoClaw is not installed; the module assumes %s — edit it if yours is elsewhere": "PicoClaw 未安装；模块假定其位于 %s — 如不在此处请自行修改",
+	"[DRY RUN] Would write %d credential(s) to %s":                                     "[演练] 将把 %d 个凭据写入 %s",
+	"Writing module":                             "正在写入模块",
+	"Could not write the module: %v":             "无法写入模块：%v",
+	"Storing credentials outside the Nix store":  "正在将凭据存储到 Nix store 之外",
+	"%d credential(s) written to %s (0600)":      "已将 %d 个凭据写入 %s（0600）",
+	"Module ready":                               "模块已就绪",
+	"Add it to your home-manager configuration:": "将其添加到你的 home-manager 配置中：",
+	"then run: home-manager switch":              "然后运行：home-manager switch",
+	"~/.picoclaw/config.json is generated on each service start — change the module, not the file.": "~/.picoclaw/config.json 会在每次服务启动时生成 — 请修改模块，而不是该文件。",
+
+	// ── Dotfiles ──
+	"No PicoClaw config found — run a migration first":                             "未找到 PicoClaw 配置 — 请先运行迁移",
+	"chezmoi is not set up — pass a git dotfiles repo: claw-migrate dotfiles REPO": "chezmoi 尚未设置 — 请指定 git dotfiles 仓库：claw-migrate dotfiles REPO",
+	"Choosing files":                  "正在选择文件",
+	"chezmoi source":                  "chezmoi 源目录",
+	"Repository":                      "仓库",
+	"%d, plus config.json":            "%d 个，另加 config.json",
+	"Left out":                        "已排除",
+	"Cannot read PicoClaw config: %v": "无法读取 PicoClaw 配置：%v",
+	"Credentials":                     "凭据",
+	"%d, read from %s by the config.json template":                  "%d 个，由 config.json 模板从 %s 读取",
+	"%d, removed from the committed config.json and kept in %s":     "%d 个，已从提交的 config.json 中移除并保存在 %s",
+	"[DRY RUN] Would add %d file(s) to %s":                          "[演练] 将把 %d 个文件添加到 %s",
+	"Add these files to your dotfiles?":                             "将这些文件添加到你的 dotfiles？",
+	"Writing files":                                                 "正在写入文件",
+	"Could not write dotfiles: %v":                                  "无法写入 dotfiles：%v",
+	"Wrote %d file(s) and the ignore rules":                         "已写入 %d 个文件和忽略规则",
+	"Committing":                                                    "正在提交",
+	"%s is not a git repository — commit the files yourself":        "%s 不是 git 仓库 — 请自行提交文件",
+	"Commit them now?":                                              "现在提交？",
+	"Committed":                                                     "已提交",
+	"On another machine, put the keys in ~/%s before chezmoi apply": "在另一台机器上，请在 chezmoi apply 之前将密钥放入 ~/%s",
+	"Keys aren't in the repo — bring them over with export-secrets / import-secrets": "密钥不在仓库中 — 请使用 export-secrets / import-secrets 迁移",
+
+	// ── Workspace git ──
+	"Version control for the workspace":                                                                   "工作区版本控制",
+	"The workspace is already a git repository":                                                           "工作区已是 git 仓库",
+	"git is not installed — skipping":                                                                     "未安装 git — 跳过",
+	"[DRY RUN] Would offer to git init %s with a .gitignore for sessions, caches and secrets":             "[演练] 将提议对 %s 执行 git init，并用 .gitignore 排除会话、缓存和密钥",
+	"Track the workspace in git? (.gitignore keeps sessions, caches and secrets out)":                     "用 git 跟踪工作区？（.gitignore 会排除会话、缓存和密钥）",
+	"Could not create the workspace repository: %v":                                                       "无法创建工作区仓库：%v",
+	"Initialized a git repository in %s (%d files committed)":                                             "已在 %s 中初始化 git 仓库（已提交 %d 个文件）",
+	"[DRY RUN] Would offer to carry the workspace's git history over to %s":                               "[演练] 将提议把工作区的 git 历史迁移到 %s",
+	"The OpenClaw workspace is a git repository — carry its history over?":                                "OpenClaw 工作区是 git 仓库 — 迁移其历史记录？",
+	"Could not carry the history over: %v":                                                                "无法迁移历史记录：%v",
+	"Git history preserved":                                                                               "已保留 git 历史记录",
+	"Updated paths to the new workspace in: %s":                                                           "已在以下文件中更新为新工作区路径：%s",
+	"%d path(s) differ from the last commit (e.g. %s) — items the migration doesn't copy show as deleted": "%d 个路径与上次提交不同（例如 %s）— 迁移未复制的项目会显示为已删除",
+
+	// ── Scrub ──
+	"--scrub keeps the unredacted originals — copying instead of moving":                        "--scrub 会保留未遮盖的原文件 — 改为复制而不是移动",
+	"Redacted %d secret(s) in %d file(s): %s":                                                   "已在 %[2]d 个文件中遮盖 %[1]d 个密钥：%[3]s",
+	"The originals are unchanged in ~/.openclaw — move any keys you still need into the config": "~/.openclaw 中的原文件保持不变 — 请将仍需要的密钥移入配置",
+
+	// ── Assist ──
+	"Not converted (unknown to the converter): %s — --assist can propose a mapping":   "未转换（转换器无法识别）：%s — --assist 可以提出映射",
+	"[DRY RUN] Would ask a configured model to map: %s":                               "[演练] 将请已配置的模型映射：%s",
+	"--assist needs a provider with an API key (or Ollama) in config.json — skipping": "--assist 需要 config.json 中有带 API 密钥的提供商（或 Ollama）— 跳过",
+	"Which model should propose a mapping?":                                           "由哪个模型提出映射？",
+	"Send %s to %s? (credentials are replaced by placeholders)":                       "将 %s 发送给 %s？（凭据会被替换为占位符）",
+	"Asking %s/%s...": "正在询问 %s/%s...",
+	"No proposal: %v": "没有提议：%v",
+	"%s says: %s":     "%s 回复：%s",
+	"The proposal doesn't change config.json":                                    "该提议不会更改 config.json",
+	"Not applied under --yes — rerun without it to review and apply the mapping": "--yes 模式下未应用 — 请不带该参数重新运行以审阅并应用映射",
+	"Apply these %d change(s) to config.json?":                                   "将这 %d 项更改应用到 config.json？",
+	"config.json left unchanged":                                                 "config.json 保持不变",
+	"Could not write config: %v":                                                 "无法写入配置：%v",
+	"Applied the proposed mapping for %s":                                        "已应用 %s 的映射提议",
+
+	// ── Compatibility ──
+	"Compatibility with PicoClaw":    "与 PicoClaw 的兼容性",
+	"Config settings":                "配置项",
+	"Skills & plugins":               "技能和插件",
+	"Session history":                "会话历史",
+	"%d of %d carry over (%d%%)":     "%d/%d 可迁移（%d%%）",
+	"Compatibility %d%% — %s":        "兼容性 %d%% — %s",
+	"safe to migrate":                "可以安全迁移",
+	"review first":                   "请先审阅",
+	"Won't work after migrating: %s": "迁移后无法使用：%s",
+}
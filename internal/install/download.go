@@ -0,0 +1,158 @@
+package install
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/iolimit"
+)
+
+// downloadDir keeps release archives between runs, one directory per
+// version ("" = download to the temp dir each time)
+var downloadDir string
+
+// downloadLimit throttles release downloads (nil = unlimited)
+var downloadLimit *iolimit.Limiter
+
+// LimitDownloads caps the throughput of release downloads (set by --net-limit)
+func LimitDownloads(l *iolimit.Limiter) {
+	downloadLimit = l
+}
+
+// ArchivePath returns where to download a release archive: in the download
+// cache under its version, so a retry after a failed run, or a real run
+// after a dry run, finds it already there
+func ArchivePath(version, filename string) string {
+	if downloadDir == "" {
+		return filepath.Join(os.TempDir(), filename)
+	}
+	return filepath.Join(downloadDir, "v"+version, filename)
+}
+
+// Download fetches url to destPath. progress, if set, is called as data
+// arrives with the bytes received so far and the total size (-1 if the
+// server didn't say).
+//
+// An archive already at destPath from an earlier run is reused if the
+// server says it hasn't changed (by its ETag), and reused is true. A
+// download cut short is resumed from where it stopped.
+func Download(url, destPath string, progress func(done, total int64)) (reused bool, err error) {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return false, fmt.Errorf("could not create file: %w", err)
+	}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	part := destPath + ".part"
+	var offset int64
+	if etag := readETag(destPath); etag != "" && exists(destPath) && !cacheRefresh {
+		req.Header.Set("If-None-Match", etag)
+	} else if etag := readETag(part); etag != "" {
+		if info, err := os.Stat(part); err == nil && info.Size() > 0 {
+			offset = info.Size()
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+			req.Header.Set("If-Range", etag) // if the file changed, it comes back whole
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		if progress != nil {
+			if info, err := os.Stat(destPath); err == nil {
+				progress(info.Size(), info.Size())
+			}
+		}
+		return true, nil
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags = os.O_WRONLY | os.O_APPEND
+	case resp.StatusCode == http.StatusOK:
+		offset = 0
+	default:
+		return false, fmt.Errorf("download returned status %d", resp.StatusCode)
+	}
+	writeETag(part, resp.Header.Get("ETag"))
+
+	out, err := os.OpenFile(part, flags, 0644)
+	if err != nil {
+		return false, fmt.Errorf("could not create file: %w", err)
+	}
+	var body io.Reader = iolimit.Reader(resp.Body, downloadLimit)
+	if progress != nil {
+		total := resp.ContentLength
+		if total >= 0 {
+			total += offset
+		}
+		body = &countingReader{r: body, done: offset, total: total, progress: progress}
+	}
+	// What arrived stays in the .part file for the next attempt to resume
+	if _, err := io.Copy(out, body); err != nil {
+		out.Close()
+		return false, err
+	}
+	if err := out.Close(); err != nil {
+		return false, err
+	}
+
+	os.Remove(destPath + ".etag")
+	if err := os.Rename(part, destPath); err != nil {
+		return false, err
+	}
+	os.Rename(part+".etag", destPath+".etag")
+	return false, nil
+}
+
+// DiscardArchive removes a downloaded archive, with what was remembered
+// about it, so the next run downloads it afresh
+func DiscardArchive(path string) {
+	for _, p := range []string{path, path + ".etag", path + ".part", path + ".part.etag"} {
+		os.Remove(p)
+	}
+}
+
+// CleanupArchive removes an archive downloaded to the temp dir once it is
+// installed. One in the download cache is kept for next time, and those of
+// older versions are removed.
+func CleanupArchive(path string) {
+	if downloadDir == "" || !within(downloadDir, path) {
+		os.Remove(path)
+		return
+	}
+	keep := filepath.Dir(path)
+	entries, _ := os.ReadDir(downloadDir)
+	for _, e := range entries {
+		if dir := filepath.Join(downloadDir, e.Name()); e.IsDir() && dir != keep {
+			os.RemoveAll(dir)
+		}
+	}
+}
+
+func readETag(path string) string {
+	data, _ := os.ReadFile(path + ".etag")
+	return strings.TrimSpace(string(data))
+}
+
+func writeETag(path, etag string) {
+	if etag == "" {
+		os.Remove(path + ".etag")
+		return
+	}
+	os.WriteFile(path+".etag", []byte(etag+"\n"), 0644)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
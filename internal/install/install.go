@@ -1,15 +1,24 @@
 package install
 
 import (
-	"encoding/json"
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/detect"
 )
 
 const (
@@ -17,6 +26,8 @@ const (
 	FallbackVersion = "0.1.2"
 	// RepoAPI for fetching latest release
 	RepoAPI = "https://api.github.com/repos/sipeed/picoclaw/releases/latest"
+	// ReleasesAPI lists releases, pre-releases included
+	ReleasesAPI = "https://api.github.com/repos/sipeed/picoclaw/releases?per_page=100"
 	// BaseURL for GitHub releases
 	BaseURL = "https://github.com/sipeed/picoclaw/releases/download"
 )
@@ -24,46 +35,61 @@ const (
 // LatestVersion holds the resolved version (fetched or fallback)
 var LatestVersion string
 
-// FetchLatestVersion queries GitHub API for the latest PicoClaw release tag
+// FetchLatestVersion queries GitHub API for the latest PicoClaw release tag.
+// A recent answer is reused from the cache (see UseCache); when the API
+// can't be reached, an older cached answer or FallbackVersion is used and
+// LatestSource/LatestError say so.
 func FetchLatestVersion() string {
 	if LatestVersion != "" {
 		return LatestVersion
 	}
 
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", RepoAPI, nil)
-	if err != nil {
-		LatestVersion = FallbackVersion
+	cached, fresh := readReleaseCache()
+	if fresh {
+		LatestVersion, LatestSource = cached.Version, SourceCache
 		return LatestVersion
 	}
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	resp, err := client.Do(req)
-	if err != nil {
-		LatestVersion = FallbackVersion
-		return LatestVersion
+	version, err := fetchLatestRelease()
+	switch {
+	case err == nil:
+		LatestVersion, LatestSource = version, SourceAPI
+		writeReleaseCache(version)
+	case cached.Version != "":
+		LatestVersion, LatestSource, LatestError = cached.Version, SourceStaleCache, err
+	default:
+		LatestVersion, LatestSource, LatestError = FallbackVersion, SourceFallback, err
 	}
-	defer resp.Body.Close()
+	return LatestVersion
+}
 
-	if resp.StatusCode != 200 {
-		LatestVersion = FallbackVersion
-		return LatestVersion
+// fetchLatestRelease asks the GitHub API for the latest release's version.
+// Pre-releases never show up as "latest", so with IncludePrereleases — or
+// when there is no full release to report (404) — the releases list is
+// searched for the highest version instead.
+func fetchLatestRelease() (string, error) {
+	if IncludePrereleases {
+		return highestListedRelease()
+	}
+	req, err := http.NewRequest("GET", RepoAPI, nil)
+	if err != nil {
+		return "", err
 	}
-
 	var release struct {
 		TagName string `json:"tag_name"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		LatestVersion = FallbackVersion
-		return LatestVersion
+	if err := githubGet(req, &release); err == errNotFound {
+		return highestListedRelease()
+	} else if err != nil {
+		return "", err
 	}
 
 	// Strip leading "v" if present (tag is "v0.1.2", we need "0.1.2")
-	LatestVersion = strings.TrimPrefix(release.TagName, "v")
-	if LatestVersion == "" {
-		LatestVersion = FallbackVersion
+	version := strings.TrimPrefix(release.TagName, "v")
+	if version == "" {
+		return "", fmt.Errorf("the latest release has no tag")
 	}
-	return LatestVersion
+	return version, nil
 }
 
 // VersionTag returns the version with "v" prefix for display
@@ -71,15 +97,48 @@ func VersionTag() string {
 	return "v" + FetchLatestVersion()
 }
 
-// GetDownloadURL returns the appropriate download URL for the current platform
+// Target platform for downloads, in Go's names ("" = this machine), set by
+// --target-os and --target-arch to provision another machine
+var TargetOS, TargetArch string
+
+// Platform returns the OS and architecture downloads are for
+func Platform() (string, string) {
+	goos, goarch := TargetOS, TargetArch
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	return goos, goarch
+}
+
+// CrossTarget reports whether downloads are for a different machine than this one
+func CrossTarget() bool {
+	goos, goarch := Platform()
+	return goos != runtime.GOOS || goarch != runtime.GOARCH
+}
+
+// GetDownloadURL returns the appropriate download URL for the target platform
 // PicoClaw release naming: picoclaw_{OS}_{arch}.tar.gz
 //   OS:   Darwin, Linux, Freebsd
 //   arch: arm64, x86_64, armv6, mips64, riscv64
 func GetDownloadURL() (string, string, error) {
 	version := FetchLatestVersion()
-	goos := runtime.GOOS
-	goarch := runtime.GOARCH
+	filename, err := ReleaseFilename(Platform())
+	if err != nil {
+		return "", "", err
+	}
+	return ReleaseURL(version, filename), filename, nil
+}
 
+// ReleaseURL returns where a version's release file is downloaded from
+func ReleaseURL(version, filename string) string {
+	return fmt.Sprintf("%s/v%s/%s", BaseURL, version, filename)
+}
+
+// ReleaseFilename returns the release archive for a Go OS and architecture
+func ReleaseFilename(goos, goarch string) (string, error) {
 	// Map Go OS names to PicoClaw release names
 	osName := ""
 	switch goos {
@@ -90,7 +149,7 @@ func GetDownloadURL() (string, string, error) {
 	case "freebsd":
 		osName = "Freebsd"
 	default:
-		return "", "", fmt.Errorf("unsupported OS: %s", goos)
+		return "", fmt.Errorf("unsupported OS: %s", goos)
 	}
 
 	// Map Go arch names to PicoClaw release names
@@ -107,83 +166,349 @@ func GetDownloadURL() (string, string, error) {
 	case "riscv64":
 		archName = "riscv64"
 	default:
-		return "", "", fmt.Errorf("unsupported architecture: %s", goarch)
+		return "", fmt.Errorf("unsupported architecture: %s", goarch)
 	}
 
-	filename := fmt.Sprintf("picoclaw_%s_%s.tar.gz", osName, archName)
-	url := fmt.Sprintf("%s/v%s/%s", BaseURL, version, filename)
-	return url, filename, nil
+	return fmt.Sprintf("picoclaw_%s_%s.tar.gz", osName, archName), nil
+}
+
+// countingReader reports cumulative progress while reading a download
+type countingReader struct {
+	r        io.Reader
+	done     int64
+	total    int64
+	progress func(done, total int64)
 }
 
-// Download downloads a file from URL to the given path
-func Download(url, destPath string) error {
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.done += int64(n)
+	c.progress(c.done, c.total)
+	return n, err
+}
+
+// ErrNoChecksums means the release publishes no checksum for the archive
+var ErrNoChecksums = errors.New("release has no checksums.txt entry for this archive")
+
+// VerifyChecksum compares a downloaded release archive against the SHA-256
+// listed in checksums.txt of the release it was downloaded from
+func VerifyChecksum(archivePath, version, filename string) error {
+	url := ReleaseURL(version, "checksums.txt")
 	resp, err := http.Get(url)
 	if err != nil {
-		return fmt.Errorf("download failed: %w", err)
+		return fmt.Errorf("fetch checksums: %w", err)
 	}
 	defer resp.Body.Close()
-
+	if resp.StatusCode == 404 {
+		return ErrNoChecksums
+	}
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("download returned status %d", resp.StatusCode)
+		return fmt.Errorf("fetch checksums: status %d", resp.StatusCode)
 	}
 
-	out, err := os.Create(destPath)
-	if err != nil {
-		return fmt.Errorf("could not create file: %w", err)
+	// Lines look like "<sha256>  picoclaw_Linux_x86_64.tar.gz"
+	want := ""
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == filename {
+			want = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read checksums: %w", err)
+	}
+	if want == "" {
+		return ErrNoChecksums
 	}
-	defer out.Close()
 
-	_, err = io.Copy(out, resp.Body)
-	return err
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", filename, got, want)
+	}
+	return nil
 }
 
-// Extract extracts the downloaded tar.gz archive
+// Extract unpacks a downloaded tar.gz release into destDir and returns the
+// picoclaw binary in it. Entries that would land outside destDir, absolute
+// paths and links pointing out of it are refused rather than written. If
+// there's no binary, the error lists what the archive does contain.
 func Extract(archivePath, destDir string) (string, error) {
-	cmd := exec.Command("tar", "-xzf", archivePath, "-C", destDir)
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("tar extract failed: %w", err)
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a gzip archive: %w", filepath.Base(archivePath), err)
 	}
+	defer gz.Close()
 
-	// Find the picoclaw binary in the extracted files
-	binaryPath := filepath.Join(destDir, "picoclaw")
-	if _, err := os.Stat(binaryPath); err == nil {
-		return binaryPath, nil
+	root, err := filepath.EvalSymlinks(destDir)
+	if err != nil {
+		return "", err
 	}
+	var names []string
+	binaryPath := ""
+	links := make(map[string]bool) // symlinks created so far
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("read %s: %w", filepath.Base(archivePath), err)
+		}
+		names = append(names, hdr.Name)
 
-	// Try common patterns (some releases nest in a subdirectory)
-	patterns := []string{
-		filepath.Join(destDir, "picoclaw-*", "picoclaw"),
-		filepath.Join(destDir, "picoclaw_*", "picoclaw"),
+		target, err := extractEntry(tr, hdr, destDir, root, links)
+		if err != nil {
+			return "", err
+		}
+		// The shallowest picoclaw wins (some releases nest in a subdirectory)
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(target) == "picoclaw" &&
+			(binaryPath == "" || strings.Count(target, string(filepath.Separator)) < strings.Count(binaryPath, string(filepath.Separator))) {
+			binaryPath = target
+		}
 	}
-	for _, pattern := range patterns {
-		matches, _ := filepath.Glob(pattern)
-		if len(matches) > 0 {
-			return matches[0], nil
+
+	// Each link's target was checked on its own; a chain of them can still
+	// lead somewhere else once they all exist
+	for link := range links {
+		if resolved, err := filepath.EvalSymlinks(link); err == nil && !within(root, resolved) {
+			os.Remove(link)
+			return "", fmt.Errorf("archive link %s resolves outside %s — refusing to extract", link, destDir)
 		}
 	}
 
-	return "", fmt.Errorf("picoclaw binary not found in extracted archive")
+	if binaryPath == "" {
+		if len(names) > 10 {
+			names = append(names[:10], fmt.Sprintf("… %d more", len(names)-10))
+		}
+		return "", fmt.Errorf("no picoclaw binary in %s (it contains: %s)", filepath.Base(archivePath), strings.Join(names, ", "))
+	}
+	return binaryPath, nil
+}
+
+// extractEntry writes one archive entry under destDir and returns its path.
+// Nothing is written through a symlink the archive created (links), or
+// into a directory that resolves outside root, destDir's real path.
+func extractEntry(tr *tar.Reader, hdr *tar.Header, destDir, root string, links map[string]bool) (string, error) {
+	target := filepath.Join(destDir, filepath.FromSlash(hdr.Name))
+	if filepath.IsAbs(hdr.Name) || !within(destDir, target) {
+		return "", fmt.Errorf("archive entry %q would be written outside %s — refusing to extract", hdr.Name, destDir)
+	}
+	if via := createdLink(links, destDir, target); via != "" {
+		return "", fmt.Errorf("archive entry %q would be written through the link %s — refusing to extract", hdr.Name, via)
+	}
+	inside := func() error {
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		parent, err := filepath.EvalSymlinks(filepath.Dir(target))
+		if err != nil {
+			return err
+		}
+		if !within(root, parent) {
+			return fmt.Errorf("archive entry %q would be written outside %s — refusing to extract", hdr.Name, destDir)
+		}
+		return nil
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if err := inside(); err != nil {
+			return "", err
+		}
+		return target, os.MkdirAll(target, 0755)
+	case tar.TypeSymlink:
+		if filepath.IsAbs(hdr.Linkname) || !within(destDir, filepath.Join(filepath.Dir(target), hdr.Linkname)) {
+			return "", fmt.Errorf("archive link %q points outside %s — refusing to extract", hdr.Name, destDir)
+		}
+		if err := inside(); err != nil {
+			return "", err
+		}
+		os.Remove(target)
+		links[target] = true
+		return target, os.Symlink(hdr.Linkname, target)
+	case tar.TypeReg:
+		if err := inside(); err != nil {
+			return "", err
+		}
+		// Replace rather than write through whatever is already there
+		os.Remove(target)
+		delete(links, target)
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, os.FileMode(hdr.Mode).Perm())
+		if err != nil {
+			return "", fmt.Errorf("extract %s: %w", hdr.Name, err)
+		}
+		_, err = io.Copy(out, tr)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", fmt.Errorf("extract %s: %w", hdr.Name, err)
+		}
+		return target, nil
+	}
+	return target, nil // hard links, devices and the like aren't part of a release
+}
+
+// within reports whether path is dir or inside it
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// createdLink returns the first parent of target, below dir, that is a
+// link created by this extraction
+func createdLink(links map[string]bool, dir, target string) string {
+	for p := filepath.Dir(target); p != dir && within(dir, p); p = filepath.Dir(p) {
+		if links[p] {
+			return p
+		}
+	}
+	return ""
+}
+
+// versionPattern finds a dotted version in `picoclaw --version` output
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+(-[0-9A-Za-z][0-9A-Za-z.]*)?`)
+
+// ParseVersion extracts the version number from a version string such as
+// "picoclaw v0.1.2 (abc123)", or returns "" if there is none
+func ParseVersion(s string) string {
+	return versionPattern.FindString(s)
+}
+
+// CompareVersions compares two dotted versions numerically, returning
+// -1, 0 or 1. Missing components count as zero. A pre-release suffix
+// ("0.2.0-rc.1") sorts before the release itself, as in semver.
+func CompareVersions(a, b string) int {
+	a, aPre := splitPrerelease(a)
+	b, bPre := splitPrerelease(b)
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return comparePrerelease(aPre, bPre)
+}
+
+// splitPrerelease separates "0.2.0-rc.1+build" into "0.2.0" and "rc.1"
+func splitPrerelease(v string) (string, string) {
+	v, _, _ = strings.Cut(v, "+")
+	core, pre, _ := strings.Cut(v, "-")
+	return core, pre
+}
+
+// comparePrerelease orders pre-release identifiers the semver way: numeric
+// ones numerically and below alphanumeric ones, which compare as text;
+// a shorter list sorts first when the rest is equal
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, xErr := strconv.Atoi(as[i])
+		y, yErr := strconv.Atoi(bs[i])
+		switch {
+		case xErr == nil && yErr == nil:
+			if x != y {
+				return cmpInt(x, y)
+			}
+		case xErr == nil:
+			return -1
+		case yErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmpInt(len(as), len(bs))
+}
+
+func cmpInt(x, y int) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
 }
 
 // InstallBinary copies the binary to /usr/local/bin (may require sudo)
 func InstallBinary(binaryPath string) error {
-	destPath := "/usr/local/bin/picoclaw"
+	return InstallBinaryTo(binaryPath, "/usr/local/bin/picoclaw")
+}
 
+// ReplaceBinary installs a new binary over an existing one, keeping the old
+// one next to it as <dest>.previous so an upgrade can be rolled back
+func ReplaceBinary(binaryPath, destPath string) (string, error) {
+	previous := destPath + ".previous"
+	if err := copyFile(destPath, previous); err != nil {
+		if out, sudoErr := exec.Command("sudo", "cp", "-p", destPath, previous).CombinedOutput(); sudoErr != nil {
+			return "", fmt.Errorf("could not keep previous binary: %w (%s)", err, strings.TrimSpace(string(out)))
+		}
+	}
+	return previous, InstallBinaryTo(binaryPath, destPath)
+}
+
+// InstallBinaryTo copies the binary to destPath (may require sudo)
+func InstallBinaryTo(binaryPath, destPath string) error {
 	// Make executable
 	if err := os.Chmod(binaryPath, 0755); err != nil {
 		return fmt.Errorf("chmod failed: %w", err)
 	}
 
-	// Ensure /usr/local/bin exists
-	os.MkdirAll("/usr/local/bin", 0755)
-
-	// Try direct copy first
-	if err := copyFile(binaryPath, destPath); err == nil {
-		return nil
+	// Ensure the destination directory exists
+	os.MkdirAll(filepath.Dir(destPath), 0755)
+
+	// Copy next to the target and rename over it, so a running binary
+	// isn't written to in place ("text file busy")
+	tmp := destPath + ".new"
+	if err := copyFile(binaryPath, tmp); err == nil {
+		if err := os.Chmod(tmp, 0755); err == nil {
+			if err := os.Rename(tmp, destPath); err == nil {
+				return nil
+			}
+		}
+		os.Remove(tmp)
 	}
 
 	// Fall back to sudo
-	exec.Command("sudo", "mkdir", "-p", "/usr/local/bin").Run()
+	exec.Command("sudo", "mkdir", "-p", filepath.Dir(destPath)).Run()
 	cmd := exec.Command("sudo", "cp", binaryPath, destPath)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
@@ -200,6 +525,113 @@ func RunOnboard() error {
 	return cmd.Run()
 }
 
+// ListCronJobs returns the output of `picoclaw cron list`
+func ListCronJobs() (string, error) {
+	out, err := exec.Command("picoclaw", "cron", "list").CombinedOutput()
+	return string(out), err
+}
+
+// NeedsOnboard reports whether `picoclaw onboard` still has to run for the
+// given PicoClaw home. Onboarding writes config.json and creates the
+// workspace, so both being present means it already ran.
+func NeedsOnboard(picoHome string) bool {
+	if _, err := os.Stat(filepath.Join(picoHome, "config.json")); err != nil {
+		return true
+	}
+	info, err := os.Stat(detect.PicoClawWorkspace(picoHome))
+	return err != nil || !info.IsDir()
+}
+
+// OnboardEnv maps converted agent defaults to PicoClaw's environment
+// overrides, so onboarding starts from the migrated values
+func OnboardEnv(defaults map[string]interface{}) []string {
+	envNames := map[string]string{
+		"model":               "PICOCLAW_AGENTS_DEFAULTS_MODEL",
+		"max_tokens":          "PICOCLAW_AGENTS_DEFAULTS_MAX_TOKENS",
+		"temperature":         "PICOCLAW_AGENTS_DEFAULTS_TEMPERATURE",
+		"max_tool_iterations": "PICOCLAW_AGENTS_DEFAULTS_MAX_TOOL_ITERATIONS",
+		"workspace":           "PICOCLAW_AGENTS_DEFAULTS_WORKSPACE",
+	}
+
+	var env []string
+	for key, name := range envNames {
+		if v, ok := defaults[key]; ok {
+			env = append(env, fmt.Sprintf("%s=%v", name, v))
+		}
+	}
+	return env
+}
+
+// ErrNoUnattendedOnboard means picoclaw onboard has no flag to run
+// without asking questions
+var ErrNoUnattendedOnboard = errors.New("picoclaw onboard has no non-interactive mode")
+
+// unattendedFlags are the flags PicoClaw versions have used to run onboard
+// without prompts, in the order they are preferred
+var unattendedFlags = []string{"--non-interactive", "--yes", "-y"}
+
+// OnboardFlag returns the flag that makes picoclaw onboard run without
+// prompts, as listed by `picoclaw onboard --help`, or "" if it has none
+func OnboardFlag() string {
+	out, _ := exec.Command("picoclaw", "onboard", "--help").CombinedOutput()
+	for _, flag := range unattendedFlags {
+		for _, field := range strings.FieldsFunc(string(out), func(r rune) bool {
+			return r == ' ' || r == '\t' || r == '\n' || r == ',' || r == '='
+		}) {
+			if field == flag {
+				return flag
+			}
+		}
+	}
+	return ""
+}
+
+// RunOnboardNonInteractive runs picoclaw onboard with its non-interactive
+// flag and no terminal: extra env vars seed its values. Without such a
+// flag it runs nothing and returns ErrNoUnattendedOnboard, since answers
+// piped to its prompts would go wrong as soon as they change.
+func RunOnboardNonInteractive(env []string) (string, error) {
+	flag := OnboardFlag()
+	if flag == "" {
+		return "", ErrNoUnattendedOnboard
+	}
+	cmd := exec.Command("picoclaw", "onboard", flag)
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput() // stdin is /dev/null, so a prompt can't block
+	return string(out), err
+}
+
+// BuiltInMigrateResult holds the outcome of running `picoclaw migrate`
+type BuiltInMigrateResult struct {
+	Output   string
+	ExitCode int
+}
+
+// Problems returns output lines that mention warnings, errors or skipped items
+func (r BuiltInMigrateResult) Problems() []string {
+	var lines []string
+	for _, line := range strings.Split(r.Output, "\n") {
+		lower := strings.ToLower(line)
+		if strings.Contains(lower, "warn") || strings.Contains(lower, "error") ||
+			strings.Contains(lower, "fail") || strings.Contains(lower, "skip") {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return lines
+}
+
+// RunBuiltInMigrate runs PicoClaw's own `picoclaw migrate --force` and
+// captures its output. A non-nil error means the command failed or exited non-zero.
+func RunBuiltInMigrate() (BuiltInMigrateResult, error) {
+	cmd := exec.Command("picoclaw", "migrate", "--force")
+	out, err := cmd.CombinedOutput()
+	result := BuiltInMigrateResult{Output: string(out)}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		result.ExitCode = exitErr.ExitCode()
+	}
+	return result, err
+}
+
 // BuildFromSource clones and builds PicoClaw from source
 func BuildFromSource(workDir string) error {
 	repoDir := filepath.Join(workDir, "picoclaw")
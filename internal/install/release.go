@@ -0,0 +1,180 @@
+package install
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Where LatestVersion came from, for LatestSource
+const (
+	SourceAPI        = "GitHub API"
+	SourceCache      = "cached"        // a recent answer from the API
+	SourceStaleCache = "stale cache"   // an older answer, because the API failed
+	SourceFallback   = "fallback"      // FallbackVersion, because the API failed and nothing was cached
+	SourcePlan       = "from the plan" // the release a plan being applied was made for
+)
+
+var (
+	// IncludePrereleases lets FetchLatestVersion pick a pre-release (set by --include-prereleases)
+	IncludePrereleases bool
+	// LatestSource says where LatestVersion came from
+	LatestSource string
+	// LatestError is why the API wasn't used (SourceStaleCache, SourceFallback)
+	LatestError error
+)
+
+// releaseCacheTTL is how long a fetched version is trusted without asking
+// again, so repeated runs behind one NAT address don't use up the API quota
+const releaseCacheTTL = time.Hour
+
+// releaseCachePath is where the latest version is cached ("" = don't cache)
+var (
+	releaseCachePath string
+	cacheRefresh     bool
+)
+
+// UseCache keeps the latest version and downloaded archives under dir
+// between runs. With refresh, the cached version is only used if GitHub
+// can't be reached, and archives are downloaded again.
+func UseCache(dir string, refresh bool) {
+	releaseCachePath = filepath.Join(dir, "latest-release.json")
+	downloadDir = filepath.Join(dir, "downloads")
+	cacheRefresh = refresh
+}
+
+// releaseCache is the cached answer to FetchLatestVersion
+type releaseCache struct {
+	Version     string    `json:"version"`
+	Checked     time.Time `json:"checked"`
+	Prereleases bool      `json:"prereleases,omitempty"` // whether pre-releases were considered
+}
+
+// readReleaseCache returns the cached version, and whether it is recent
+// enough to use without asking GitHub
+func readReleaseCache() (releaseCache, bool) {
+	var c releaseCache
+	if releaseCachePath == "" {
+		return c, false
+	}
+	data, err := os.ReadFile(releaseCachePath)
+	if err != nil || json.Unmarshal(data, &c) != nil || c.Prereleases != IncludePrereleases {
+		return releaseCache{}, false
+	}
+	return c, !cacheRefresh && time.Since(c.Checked) < releaseCacheTTL
+}
+
+func writeReleaseCache(version string) {
+	if releaseCachePath == "" {
+		return
+	}
+	data, _ := json.Marshal(releaseCache{Version: version, Checked: time.Now(), Prereleases: IncludePrereleases})
+	if os.MkdirAll(filepath.Dir(releaseCachePath), 0755) == nil {
+		os.WriteFile(releaseCachePath, data, 0644)
+	}
+}
+
+// githubToken returns the token for GitHub API requests, if one is set.
+// Authenticated requests get a far larger rate limit than anonymous ones,
+// which share a quota per IP address.
+func githubToken() string {
+	for _, name := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := strings.TrimSpace(os.Getenv(name)); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// highestListedRelease returns the highest version in the releases list,
+// skipping drafts, and pre-releases unless IncludePrereleases is set
+func highestListedRelease() (string, error) {
+	req, err := http.NewRequest("GET", ReleasesAPI, nil)
+	if err != nil {
+		return "", err
+	}
+	var releases []struct {
+		TagName    string `json:"tag_name"`
+		Draft      bool   `json:"draft"`
+		Prerelease bool   `json:"prerelease"`
+	}
+	if err := githubGet(req, &releases); err != nil {
+		return "", err
+	}
+
+	best, skipped := "", 0
+	for _, r := range releases {
+		version := strings.TrimPrefix(r.TagName, "v")
+		if r.Draft || version == "" || ParseVersion(version) != version {
+			continue
+		}
+		if r.Prerelease && !IncludePrereleases {
+			skipped++
+			continue
+		}
+		if best == "" || CompareVersions(version, best) > 0 {
+			best = version
+		}
+	}
+	switch {
+	case best != "":
+		return best, nil
+	case skipped > 0:
+		return "", fmt.Errorf("only pre-releases are published (%d) — use --include-prereleases to install one", skipped)
+	}
+	return "", fmt.Errorf("no PicoClaw releases are published")
+}
+
+// errNotFound is githubGet's error for a 404
+var errNotFound = errors.New("not found")
+
+// apiClient bounds GitHub API requests so an unreachable API falls back promptly
+var apiClient = &http.Client{Timeout: 15 * time.Second}
+
+// githubGet sends a GitHub API request, authenticated when a token is set,
+// and decodes the JSON response into out. Rate limiting gets an error that
+// says when it resets and how to avoid it.
+func githubGet(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if token := githubToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := apiClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not reach the GitHub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if (resp.StatusCode == 403 || resp.StatusCode == 429) && resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		msg := "GitHub API rate limit exceeded"
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			msg += " until " + time.Unix(reset, 0).Format("15:04")
+		}
+		if githubToken() == "" {
+			msg += " — set GITHUB_TOKEN to use your own quota"
+		}
+		return errors.New(msg)
+	}
+	if resp.StatusCode == 401 {
+		return fmt.Errorf("GitHub rejected the token (status 401) — check GITHUB_TOKEN")
+	}
+	if resp.StatusCode == 404 {
+		return errNotFound
+	}
+	if resp.StatusCode != 200 {
+		io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("GitHub API returned status %d for %s", resp.StatusCode, req.URL)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("unexpected response from the GitHub API: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package iolimit
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limiter caps throughput to a fixed number of bytes per second.
+// A nil *Limiter imposes no limit, so callers can pass it around unconditionally.
+type Limiter struct {
+	rate int64 // bytes per second
+
+	mu   sync.Mutex
+	next time.Time // earliest time the next transfer may start
+}
+
+// New returns a limiter allowing bytesPerSec bytes per second
+func New(bytesPerSec int64) *Limiter {
+	if bytesPerSec <= 0 {
+		return nil
+	}
+	return &Limiter{rate: bytesPerSec}
+}
+
+// Rate returns the configured limit in bytes per second (0 = unlimited)
+func (l *Limiter) Rate() int64 {
+	if l == nil {
+		return 0
+	}
+	return l.rate
+}
+
+// Wait blocks until n more bytes may be transferred
+func (l *Limiter) Wait(n int) {
+	if l == nil || n <= 0 {
+		return
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	l.next = l.next.Add(time.Duration(int64(n) * int64(time.Second) / l.rate))
+	wait := l.next.Sub(now)
+	l.mu.Unlock()
+
+	time.Sleep(wait)
+}
+
+// Reader wraps r so reads are throttled by l
+func Reader(r io.Reader, l *Limiter) io.Reader {
+	if l == nil {
+		return r
+	}
+	return &reader{r: r, l: l}
+}
+
+// Writer wraps w so writes are throttled by l
+func Writer(w io.Writer, l *Limiter) io.Writer {
+	if l == nil {
+		return w
+	}
+	return &writer{w: w, l: l}
+}
+
+type reader struct {
+	r io.Reader
+	l *Limiter
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.l.Wait(n)
+	return n, err
+}
+
+type writer struct {
+	w io.Writer
+	l *Limiter
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	w.l.Wait(len(p))
+	return w.w.Write(p)
+}
+
+// ParseRate parses a human-readable rate such as "50MB/s", "512K" or "1.5GB/s"
+// into bytes per second. Units are binary (1 KB = 1024 bytes), matching FormatSize.
+func ParseRate(s string) (int64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	v = strings.TrimSuffix(v, "/S")
+	v = strings.TrimSuffix(v, "B")
+	v = strings.TrimSuffix(v, "I") // KiB, MiB, ...
+
+	multiplier := int64(1)
+	if v != "" {
+		switch v[len(v)-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		}
+		if multiplier > 1 {
+			v = v[:len(v)-1]
+		}
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid rate %q (expected e.g. 50MB/s)", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
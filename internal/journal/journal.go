@@ -0,0 +1,235 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/models"
+)
+
+// File statuses recorded in the journal
+const (
+	StatusMigrated = "migrated"
+	StatusSkipped  = "skipped"
+	StatusFailed   = "failed"
+)
+
+// Run outcomes recorded in the journal
+const (
+	OutcomeSuccess = "success"
+	OutcomeErrors  = "completed with errors"
+	OutcomeAborted = "aborted"
+)
+
+// Journal records what the last migration run did, so later commands
+// (retry, status, ...) can act on it without a full re-run
+type Journal struct {
+	Started   time.Time   `json:"started"`
+	Updated   time.Time   `json:"updated"`
+	Outcome   string      `json:"outcome"`
+	SourceDir string      `json:"source_dir"`
+	DestDir   string      `json:"dest_dir"`
+	Files     []FileEntry `json:"files"`
+
+	// Move mode deletes sources as it goes; the backup is the only way back
+	Move           bool   `json:"move,omitempty"`
+	BackupPath     string `json:"backup_path,omitempty"`
+	BackupVerified bool   `json:"backup_verified,omitempty"`
+
+	// The model upgrade accepted after the copy, so undo model-upgrade
+	// can put the old model back
+	ModelUpgrade *ModelUpgrade `json:"model_upgrade,omitempty"`
+
+	// Chat webhooks moved off OpenClaw, so undo webhooks can point them back
+	Webhooks []WebhookMove `json:"webhooks,omitempty"`
+}
+
+// WebhookMove is a platform callback that delivered to OpenClaw
+type WebhookMove struct {
+	Channel string    `json:"channel"` // telegram or discord
+	From    string    `json:"from"`
+	To      string    `json:"to,omitempty"` // "" = removed
+	Changed time.Time `json:"changed"`
+}
+
+// ModelUpgrade is a default model replaced in the PicoClaw config
+type ModelUpgrade struct {
+	Config  string          `json:"config"`
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Changed time.Time       `json:"changed"`
+	Changes []models.Change `json:"changes"`
+}
+
+// FileEntry is the journal record for a single copied file
+type FileEntry struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Dest   string `json:"dest"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Moved  bool   `json:"moved,omitempty"` // source deleted after a verified copy
+
+	// Recorded for migrated files so verification only rehashes what changed
+	SHA256  string    `json:"sha256,omitempty"`
+	Size    int64     `json:"size,omitempty"`
+	ModTime time.Time `json:"mod_time,omitempty"`
+}
+
+// Dir returns the claw-migrate state directory (~/.claw-migrate)
+func Dir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".claw-migrate")
+}
+
+// Path returns the location of the journal file
+func Path() string {
+	return filepath.Join(Dir(), "journal.json")
+}
+
+// New starts a fresh journal for a migration from srcDir to dstDir
+func New(srcDir, dstDir string) *Journal {
+	now := time.Now()
+	return &Journal{
+		Started:   now,
+		Updated:   now,
+		SourceDir: srcDir,
+		DestDir:   dstDir,
+	}
+}
+
+// logPath is where Append keeps entries recorded since the last Save
+func logPath() string {
+	return filepath.Join(Dir(), "journal.log")
+}
+
+// logMu serializes Append across copy workers
+var logMu sync.Mutex
+
+// Load reads the journal of the last migration run, with the entries
+// appended since it was last saved
+func Load() (*Journal, error) {
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		return nil, err
+	}
+	var j Journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("parse journal: %w", err)
+	}
+	if log, err := os.ReadFile(logPath()); err == nil {
+		var entries []FileEntry
+		for _, line := range bytes.Split(log, []byte("\n")) {
+			var e FileEntry
+			if json.Unmarshal(line, &e) == nil && e.Dest != "" {
+				entries = append(entries, e) // a line cut short by a crash is skipped
+			}
+		}
+		j.Record(entries)
+	}
+	return &j, nil
+}
+
+// Save writes the journal to disk, replacing it whole so a crash leaves
+// the old one or the new one, and clears the appended entries it now holds
+func (j *Journal) Save() error {
+	if err := os.MkdirAll(Dir(), 0700); err != nil {
+		return err
+	}
+	j.Updated = time.Now()
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal journal: %w", err)
+	}
+	tmp := Path() + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, Path()); err != nil {
+		return err
+	}
+	if err := os.Remove(logPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Append records one entry on disk without rewriting the journal, synced
+// before it returns. Move mode calls it before deleting each source, so a
+// run cut short still says what it moved; Load folds the entries in.
+func Append(e FileEntry) error {
+	logMu.Lock()
+	defer logMu.Unlock()
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal journal entry: %w", err)
+	}
+	f, err := os.OpenFile(logPath(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Record adds file entries, replacing any existing entry for the same destination
+func (j *Journal) Record(entries []FileEntry) {
+	index := make(map[string]int, len(j.Files))
+	for i, f := range j.Files {
+		index[f.Dest] = i
+	}
+	for _, e := range entries {
+		if i, ok := index[e.Dest]; ok {
+			j.Files[i] = e
+			continue
+		}
+		index[e.Dest] = len(j.Files)
+		j.Files = append(j.Files, e)
+	}
+}
+
+// Migrated returns the entries that were copied successfully
+func (j *Journal) Migrated() []FileEntry {
+	var migrated []FileEntry
+	for _, f := range j.Files {
+		if f.Status == StatusMigrated {
+			migrated = append(migrated, f)
+		}
+	}
+	return migrated
+}
+
+// Failed returns the entries that still need a retry
+func (j *Journal) Failed() []FileEntry {
+	var failed []FileEntry
+	for _, f := range j.Files {
+		if f.Status == StatusFailed {
+			failed = append(failed, f)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,202 @@
+package k8s
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/detect"
+)
+
+// Name is used for every generated object and as the app label
+const Name = "picoclaw"
+
+// MountPath is where the PersistentVolumeClaim holding PicoClaw's home is
+// mounted; the container runs as root, so "~/.picoclaw" resolves to it
+const MountPath = "/root/.picoclaw"
+
+// mergeScript rebuilds config.json from the ConfigMap and the Secret inside
+// an init container. Arrays merge by position, matching secrets.Split.
+const mergeScript = `import json, sys
+
+def merge(dst, src):
+    if isinstance(src, dict):
+        for k, v in src.items():
+            if isinstance(v, (dict, list)) and k in dst:
+                merge(dst[k], v)
+            else:
+                dst[k] = v
+    elif isinstance(src, list):
+        for i, v in enumerate(src):
+            if i < len(dst):
+                merge(dst[i], v)
+
+config = json.load(open(sys.argv[1]))
+merge(config, json.load(open(sys.argv[2])))
+with open(sys.argv[3], "w") as f:
+    json.dump(config, f, indent=2)
+`
+
+// Manifests renders a ConfigMap with the credential-free config, a Secret
+// with the credentials and a single-replica StatefulSet whose init
+// container merges the two into the volume on every start
+func Manifests(public, private map[string]interface{}, image string) (string, error) {
+	configJSON, err := json.MarshalIndent(public, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal config: %w", err)
+	}
+	secretJSON, err := json.MarshalIndent(private, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal secrets: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `# Generated by claw-migrate. Credentials are only in the Secret below.
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %[1]s-config
+  labels:
+    app: %[1]s
+data:
+  config.json: |
+%[2]s
+  merge.py: |
+%[3]s
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: %[1]s-secrets
+  labels:
+    app: %[1]s
+type: Opaque
+stringData:
+  secrets.json: |
+%[4]s
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: %[1]s
+  labels:
+    app: %[1]s
+spec:
+  serviceName: %[1]s
+  replicas: 1
+  selector:
+    matchLabels:
+      app: %[1]s
+  template:
+    metadata:
+      labels:
+        app: %[1]s
+    spec:
+      initContainers:
+        - name: config
+          image: python:3.12-alpine
+          command: ["python3", "/config/merge.py", "/config/config.json", "/secrets/secrets.json", "%[5]s/config.json"]
+          volumeMounts:
+            - { name: config, mountPath: /config }
+            - { name: secrets, mountPath: /secrets }
+            - { name: data, mountPath: %[5]s }
+      containers:
+        - name: %[1]s
+          image: %[6]s  # build with: claw-migrate migrate --to-docker, then push to your registry
+          args: ["gateway"]
+          volumeMounts:
+            - { name: data, mountPath: %[5]s }
+      volumes:
+        - name: config
+          configMap:
+            name: %[1]s-config
+        - name: secrets
+          secret:
+            secretName: %[1]s-secrets
+            defaultMode: 0400
+  volumeClaimTemplates:
+    - metadata:
+        name: data
+      spec:
+        accessModes: ["ReadWriteOnce"]
+        resources:
+          requests:
+            storage: 1Gi
+`, Name, indent(string(configJSON), 4), indent(mergeScript, 4), indent(string(secretJSON), 4), MountPath, image)
+	return b.String(), nil
+}
+
+// indent prefixes every non-empty line, for YAML block scalars
+func indent(s string, n int) string {
+	pad := strings.Repeat(" ", n)
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = pad + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// WriteWorkspaceTarball archives a workspace as workspace/... so it can be
+// unpacked straight into the volume's MountPath. OS metadata files and
+// symlinks are left out. Returns the number of files written.
+func WriteWorkspaceTarball(workspace, dest string) (int, error) {
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	files := 0
+	err = filepath.Walk(workspace, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if detect.IsJunk(info.Name()) || info.Mode()&os.ModeSymlink != 0 {
+			return nil
+		}
+		rel, _ := filepath.Rel(workspace, path)
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(filepath.Join("workspace", rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(tw, f); err != nil {
+			return fmt.Errorf("archive %s: %w", rel, err)
+		}
+		files++
+		return nil
+	})
+	if err != nil {
+		return files, err
+	}
+	if err := tw.Close(); err != nil {
+		return files, err
+	}
+	if err := gz.Close(); err != nil {
+		return files, err
+	}
+	return files, out.Close()
+}
@@ -0,0 +1,451 @@
+// Package lint checks a PicoClaw config.json for problems PicoClaw would
+// only report at runtime, or not at all: wrong types, deprecated models,
+// duplicate model_list entries, allowed user IDs in the wrong format,
+// unreachable api_base URLs and secrets referenced from environment
+// variables that aren't set
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/config"
+)
+
+// Finding severities
+const (
+	Error   = "error"   // PicoClaw won't load or use this as intended
+	Warning = "warning" // works, but probably not as meant
+)
+
+// Finding is one problem, at a dotted path such as model_list[1].api_base
+type Finding struct {
+	Severity string
+	Path     string
+	Message  string
+}
+
+// Options controls the checks that depend on more than the file
+type Options struct {
+	Deprecated func(model string) (string, bool) // recommended replacement for an outdated model
+	Network    bool                              // try every api_base URL
+}
+
+// urlTimeout bounds each api_base request
+const urlTimeout = 5 * time.Second
+
+// Check lints a config file's contents. Findings come back section by
+// section, with unset environment variables and unreachable URLs last.
+func Check(data []byte, opts Options) []Finding {
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return []Finding{{Error, "", syntaxMessage(data, err)}}
+	}
+
+	l := &linter{opts: opts}
+	l.modelList(cfg["model_list"])
+	l.providers(cfg["providers"])
+	l.agents(cfg["agents"], cfg["model_list"])
+	l.objectOfObjects("channels", cfg["channels"])
+	l.allowFrom(cfg["channels"])
+	l.object("tools", cfg["tools"])
+	l.heartbeat(cfg["heartbeat"])
+	if v, ok := cfg["mcp_servers"]; ok {
+		if _, ok := v.([]interface{}); !ok {
+			l.add(Error, "mcp_servers", "should be a list of servers")
+		}
+	}
+	l.envRefs("", cfg)
+	if opts.Network {
+		l.checkURLs()
+	}
+	return l.findings
+}
+
+// syntaxMessage says where a JSON error is, by line
+func syntaxMessage(data []byte, err error) string {
+	if _, ok := err.(*json.UnmarshalTypeError); ok {
+		return "the file must hold a JSON object"
+	}
+	serr, ok := err.(*json.SyntaxError)
+	if !ok {
+		return fmt.Sprintf("not valid JSON: %v", err)
+	}
+	line := bytes.Count(data[:min(int(serr.Offset), len(data))], []byte("\n")) + 1
+	return fmt.Sprintf("not valid JSON (line %d): %v", line, err)
+}
+
+type linter struct {
+	opts     Options
+	findings []Finding
+	urls     []urlRef
+}
+
+// urlRef is an api_base to try, and where it was found
+type urlRef struct {
+	path string
+	url  *url.URL
+}
+
+func (l *linter) add(severity, path, format string, args ...interface{}) {
+	l.findings = append(l.findings, Finding{severity, path, fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) object(path string, v interface{}) (map[string]interface{}, bool) {
+	if v == nil {
+		return nil, false
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		l.add(Error, path, "should be an object, not %s", kind(v))
+	}
+	return m, ok
+}
+
+func (l *linter) objectOfObjects(path string, v interface{}) {
+	m, ok := l.object(path, v)
+	if !ok {
+		return
+	}
+	for _, name := range sortedKeys(m) {
+		item, isMap := m[name].(map[string]interface{})
+		if !isMap {
+			l.add(Error, path+"."+name, "should be an object, not %s", kind(m[name]))
+			continue
+		}
+		if enabled, ok := item["enabled"]; ok {
+			if _, isBool := enabled.(bool); !isBool {
+				l.add(Error, path+"."+name+".enabled", "should be true or false, not %s", kind(enabled))
+			}
+		}
+	}
+}
+
+func (l *linter) modelList(v interface{}) {
+	if v == nil {
+		return
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		l.add(Error, "model_list", "should be a list, not %s", kind(v))
+		return
+	}
+
+	names := make(map[string]int)
+	entries := make(map[string]int)
+	for i, item := range list {
+		path := fmt.Sprintf("model_list[%d]", i)
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			l.add(Error, path, "should be an object, not %s", kind(item))
+			continue
+		}
+
+		name, _ := entry["model_name"].(string)
+		model, _ := entry["model"].(string)
+		if name == "" {
+			l.add(Error, path+".model_name", "is missing — agents refer to models by this name")
+		} else if first, dup := names[name]; dup {
+			l.add(Error, path+".model_name", "%q is already used by model_list[%d]", name, first)
+		} else {
+			names[name] = i
+		}
+		switch {
+		case model == "":
+			l.add(Error, path+".model", "is missing")
+		case !strings.Contains(model, "/"):
+			l.add(Warning, path+".model", "%q has no vendor prefix (e.g. openai/%s)", model, model)
+		default:
+			l.deprecated(path+".model", model)
+		}
+
+		apiBase, _ := entry["api_base"].(string)
+		key := model + "\x00" + apiBase
+		if first, dup := entries[key]; dup && model != "" {
+			l.add(Warning, path, "same model and api_base as model_list[%d]", first)
+		} else {
+			entries[key] = i
+		}
+		l.apiBase(path+".api_base", entry["api_base"])
+	}
+}
+
+func (l *linter) providers(v interface{}) {
+	m, ok := l.object("providers", v)
+	if !ok {
+		return
+	}
+	for _, name := range sortedKeys(m) {
+		path := "providers." + name
+		p, isMap := m[name].(map[string]interface{})
+		if !isMap {
+			l.add(Error, path, "should be an object, not %s", kind(m[name]))
+			continue
+		}
+		l.apiBase(path+".api_base", p["api_base"])
+	}
+}
+
+// apiBase checks an api_base is a usable URL and queues it for checkURLs
+func (l *linter) apiBase(path string, v interface{}) {
+	if v == nil {
+		return
+	}
+	s, ok := v.(string)
+	if !ok {
+		l.add(Error, path, "should be a URL string, not %s", kind(v))
+		return
+	}
+	if envRef(s) != "" {
+		return // checked by envRefs
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		l.add(Error, path, "%q is not an http(s) URL", s)
+		return
+	}
+	l.urls = append(l.urls, urlRef{path, u})
+}
+
+func (l *linter) agents(v, modelList interface{}) {
+	agents, ok := l.object("agents", v)
+	if !ok {
+		return
+	}
+	defaults, ok := l.object("agents.defaults", agents["defaults"])
+	if !ok {
+		return
+	}
+
+	if m, ok := defaults["model"]; ok {
+		model, isString := m.(string)
+		switch {
+		case !isString:
+			l.add(Error, "agents.defaults.model", "should be a model name string, not %s", kind(m))
+		case model == "":
+			l.add(Error, "agents.defaults.model", "is empty")
+		default:
+			l.deprecated("agents.defaults.model", model)
+			if list, ok := modelList.([]interface{}); ok && len(list) > 0 && !listed(list, model) {
+				l.add(Warning, "agents.defaults.model", "%q is not a model_name or model in model_list", model)
+			}
+		}
+	}
+	l.number("agents.defaults.max_tokens", defaults["max_tokens"], 1, 0)
+	l.number("agents.defaults.max_tool_iterations", defaults["max_tool_iterations"], 1, 0)
+	l.number("agents.defaults.temperature", defaults["temperature"], 0, 2)
+	if w, ok := defaults["workspace"]; ok {
+		if _, isString := w.(string); !isString {
+			l.add(Error, "agents.defaults.workspace", "should be a path string, not %s", kind(w))
+		}
+	}
+}
+
+// listed reports whether model names a model_list entry or its model
+func listed(list []interface{}, model string) bool {
+	for _, item := range list {
+		entry, _ := item.(map[string]interface{})
+		if entry["model_name"] == model || entry["model"] == model {
+			return true
+		}
+	}
+	return false
+}
+
+// allowFrom checks each channel's allow_from is a list of IDs that look
+// like the platform's
+func (l *linter) allowFrom(v interface{}) {
+	chans, _ := v.(map[string]interface{})
+	for _, name := range sortedKeys(chans) {
+		ch, _ := chans[name].(map[string]interface{})
+		list, ok := ch["allow_from"]
+		if !ok {
+			continue
+		}
+		path := "channels." + name + ".allow_from"
+		items, isList := list.([]interface{})
+		if !isList {
+			l.add(Error, path, "should be a list of user IDs, not %s", kind(list))
+			continue
+		}
+		for i, item := range items {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			id, isString := item.(string)
+			if !isString {
+				l.add(Warning, itemPath, "is %s; write user IDs as strings so long ones keep every digit", kind(item))
+				continue
+			}
+			if problem := config.CheckChannelID(name, id); problem != "" {
+				l.add(Warning, itemPath, "%s", problem)
+			}
+		}
+	}
+}
+
+func (l *linter) heartbeat(v interface{}) {
+	hb, ok := l.object("heartbeat", v)
+	if !ok {
+		return
+	}
+	if enabled, ok := hb["enabled"]; ok {
+		if _, isBool := enabled.(bool); !isBool {
+			l.add(Error, "heartbeat.enabled", "should be true or false, not %s", kind(enabled))
+		}
+	}
+	l.number("heartbeat.interval", hb["interval"], 1, 0)
+}
+
+// number checks an optional number is at least lo and, if hi > lo, at most hi
+func (l *linter) number(path string, v interface{}, lo, hi float64) {
+	if v == nil {
+		return
+	}
+	n, ok := v.(float64)
+	switch {
+	case !ok:
+		l.add(Error, path, "should be a number, not %s", kind(v))
+	case n < lo || (hi > lo && n > hi):
+		if hi > lo {
+			l.add(Error, path, "%v is outside %v–%v", n, lo, hi)
+		} else {
+			l.add(Error, path, "%v should be at least %v", n, lo)
+		}
+	}
+}
+
+func (l *linter) deprecated(path, model string) {
+	if l.opts.Deprecated == nil {
+		return
+	}
+	if next, ok := l.opts.Deprecated(model); ok {
+		l.add(Warning, path, "%s is deprecated — use %s", model, next)
+	}
+}
+
+// envPattern matches a value that is only a variable reference:
+// $NAME, ${NAME} or env:NAME
+var envPattern = regexp.MustCompile(`^(?:\$\{([A-Za-z_][A-Za-z0-9_]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)|env:([A-Za-z_][A-Za-z0-9_]*))$`)
+
+// envRef returns the variable a value refers to, or ""
+func envRef(s string) string {
+	m := envPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return ""
+	}
+	return m[1] + m[2] + m[3]
+}
+
+// envRefs reports every string that refers to an unset environment variable
+func (l *linter) envRefs(path string, v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for _, k := range sortedKeys(val) {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			l.envRefs(child, val[k])
+		}
+	case []interface{}:
+		for i, item := range val {
+			l.envRefs(fmt.Sprintf("%s[%d]", path, i), item)
+		}
+	case string:
+		name := envRef(val)
+		if name == "" {
+			return
+		}
+		if value, set := os.LookupEnv(name); !set {
+			l.add(Error, path, "refers to $%s, which is not set", name)
+		} else if strings.TrimSpace(value) == "" {
+			l.add(Error, path, "refers to $%s, which is empty", name)
+		}
+	}
+}
+
+// checkURLs tries every api_base at once. Any HTTP answer, even an error
+// status, means the server is there; only failing to connect is reported.
+func (l *linter) checkURLs() {
+	client := &http.Client{
+		Timeout: urlTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	errs := make([]error, len(l.urls))
+	var wg sync.WaitGroup
+	for i, ref := range l.urls {
+		wg.Add(1)
+		go func(i int, u *url.URL) {
+			defer wg.Done()
+			resp, err := client.Head(u.String())
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			resp.Body.Close()
+		}(i, ref.url)
+	}
+	wg.Wait()
+	for i, ref := range l.urls {
+		if errs[i] != nil {
+			l.add(Warning, ref.path, "%s is unreachable: %v", ref.url.Host, unwrapURLError(errs[i]))
+		}
+	}
+}
+
+// unwrapURLError drops the method and URL net/http wraps around errors,
+// which the finding already names
+func unwrapURLError(err error) error {
+	if ue, ok := err.(*url.Error); ok {
+		return ue.Err
+	}
+	return err
+}
+
+func kind(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	case string:
+		return "a string"
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Errors counts the findings that are errors
+func Errors(findings []Finding) int {
+	n := 0
+	for _, f := range findings {
+		if f.Severity == Error {
+			n++
+		}
+	}
+	return n
+}
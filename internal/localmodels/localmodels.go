@@ -0,0 +1,144 @@
+// Package localmodels asks self-hosted model servers — Ollama, or any
+// OpenAI-compatible api_base — which models they actually serve, so a
+// migrated config can be pointed at one that exists on this machine
+package localmodels
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OllamaDefault is where Ollama listens unless configured otherwise
+const OllamaDefault = "http://localhost:11434"
+
+// requestTimeout bounds each request; a local server answers at once or not at all
+const requestTimeout = 5 * time.Second
+
+var client = &http.Client{Timeout: requestTimeout}
+
+// Server is the answer from one endpoint
+type Server struct {
+	URL       string   // what was asked
+	Reachable bool     // something answered
+	Models    []string // served models; nil if they couldn't be listed
+	Err       error    // why it wasn't reachable, or why models couldn't be listed
+}
+
+// Ollama lists the models pulled into an Ollama server, given its base URL
+// with or without the /v1 suffix of its OpenAI-compatible API
+func Ollama(apiBase string) Server {
+	root := OllamaDefault
+	if apiBase != "" {
+		u, err := url.Parse(apiBase)
+		if err != nil || u.Host == "" {
+			return Server{URL: apiBase, Err: fmt.Errorf("not a URL")}
+		}
+		root = u.Scheme + "://" + u.Host
+	}
+
+	var tags struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	s := get(root+"/api/tags", "", &tags)
+	if s.Err == nil {
+		s.Models = []string{}
+		for _, m := range tags.Models {
+			s.Models = append(s.Models, m.Name)
+		}
+		sort.Strings(s.Models)
+	}
+	return s
+}
+
+// OpenAI lists the models of an OpenAI-compatible server (GET /models)
+func OpenAI(apiBase, apiKey string) Server {
+	var list struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	s := get(strings.TrimRight(apiBase, "/")+"/models", apiKey, &list)
+	if s.Err == nil {
+		s.Models = []string{}
+		for _, m := range list.Data {
+			s.Models = append(s.Models, m.ID)
+		}
+		sort.Strings(s.Models)
+	}
+	return s
+}
+
+// get fetches a JSON listing. Any HTTP answer counts as reachable, even
+// when the listing itself is refused.
+func get(endpoint, apiKey string, out interface{}) Server {
+	s := Server{URL: endpoint}
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		s.Err = err
+		return s
+	}
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ue, ok := err.(*url.Error); ok {
+			err = ue.Err
+		}
+		s.Err = err
+		return s
+	}
+	defer resp.Body.Close()
+	s.Reachable = true
+	if resp.StatusCode != 200 {
+		s.Err = fmt.Errorf("listing models returned status %d", resp.StatusCode)
+		return s
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		s.Err = fmt.Errorf("unexpected model listing: %w", err)
+	}
+	return s
+}
+
+// Match looks for want among the served models. Ollama's implicit
+// ":latest" tag is ignored. Without an exact match, the models with the
+// same name under another tag (llama3 → llama3:8b) come back as
+// suggestions, or failing that those sharing its family (llama3 →
+// llama3.1:latest).
+func Match(want string, have []string) (exact string, suggestions []string) {
+	base := func(name string) string {
+		return strings.TrimSuffix(name, ":latest")
+	}
+	for _, h := range have {
+		if base(h) == base(want) {
+			return h, nil
+		}
+	}
+
+	name, _, _ := strings.Cut(want, ":")
+	for _, h := range have {
+		if n, _, _ := strings.Cut(h, ":"); n == name {
+			suggestions = append(suggestions, h)
+		}
+	}
+	if len(suggestions) > 0 {
+		return "", suggestions
+	}
+	family := strings.TrimRight(name, "0123456789.-")
+	if family == "" {
+		return "", nil
+	}
+	for _, h := range have {
+		if strings.HasPrefix(h, family) {
+			suggestions = append(suggestions, h)
+		}
+	}
+	return "", suggestions
+}
@@ -0,0 +1,46 @@
+//go:build darwin
+
+package migrate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// sysClonefileat is clonefileat(2), which the syscall package doesn't name
+const sysClonefileat = 462
+
+// atFDCWD resolves relative paths against the working directory
+var atFDCWD = -2
+
+// cloneFile makes dst a copy-on-write clone of src with clonefileat(2). It
+// only succeeds on APFS when both paths are on the same volume.
+func cloneFile(src, dst string) error {
+	os.MkdirAll(filepath.Dir(dst), 0755)
+
+	// clonefile refuses to replace an existing file
+	os.Remove(dst)
+
+	from, err := syscall.BytePtrFromString(src)
+	if err != nil {
+		return err
+	}
+	to, err := syscall.BytePtrFromString(dst)
+	if err != nil {
+		return err
+	}
+	if _, _, errno := syscall.Syscall6(sysClonefileat, uintptr(atFDCWD), uintptr(unsafe.Pointer(from)),
+		uintptr(atFDCWD), uintptr(unsafe.Pointer(to)), 0, 0); errno != 0 {
+		return &os.PathError{Op: "clonefile", Path: dst, Err: errno}
+	}
+	return nil
+}
+
+// cloneUnsupported reports whether a clone failed because the volume can't
+// clone at all, rather than because of this one file
+func cloneUnsupported(err error) bool {
+	return errors.Is(err, syscall.ENOTSUP) || errors.Is(err, syscall.EXDEV)
+}
@@ -0,0 +1,48 @@
+//go:build linux
+
+package migrate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// ficlone is the FICLONE ioctl request: _IOW(0x94, 9, int)
+const ficlone = 0x40049409
+
+// cloneFile makes dst a copy-on-write clone of src (btrfs, XFS, bcachefs).
+// It fails on filesystems without reflink support or across filesystems.
+func cloneFile(src, dst string) error {
+	os.MkdirAll(filepath.Dir(dst), 0755)
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, out.Fd(), ficlone, in.Fd()); errno != 0 {
+		out.Close()
+		return &os.PathError{Op: "ficlone", Path: dst, Err: errno}
+	}
+	return out.Close()
+}
+
+// cloneUnsupported reports whether a clone failed because the filesystem
+// can't clone at all (or not between these two), rather than because of
+// this one file
+func cloneUnsupported(err error) bool {
+	for _, errno := range []syscall.Errno{syscall.EOPNOTSUPP, syscall.EXDEV, syscall.EINVAL, syscall.ENOTTY} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+
+package migrate
+
+import "errors"
+
+// cloneFile is not supported on this platform; callers fall back to a byte copy
+func cloneFile(src, dst string) error {
+	return errors.New("file cloning not supported on this platform")
+}
+
+// cloneUnsupported is always true here: no file can be cloned
+func cloneUnsupported(err error) bool {
+	return true
+}
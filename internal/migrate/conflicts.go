@@ -0,0 +1,139 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/config"
+)
+
+// ════════════════════════════════════════════════════════════
+// Conflicts with data PicoClaw already has
+// ════════════════════════════════════════════════════════════
+
+// KeepExisting decides, for a key both the existing PicoClaw config and
+// the converted one set, whether the existing value stays (--prefer, or
+// the answers at the conflict review). It is only set by the review, which
+// runs when PicoClaw was set up before this run: against a config onboard
+// just wrote, nil lets the converted value win.
+var KeepExisting func(path string) bool
+
+// Conflict is a workspace file both sides have, with different contents
+type Conflict struct {
+	Rel              string // relative to the workspace
+	Src, Dst         string
+	SrcTime, DstTime time.Time
+	SrcSize, DstSize int64
+}
+
+// DestNewer reports whether PicoClaw's copy changed more recently than
+// OpenClaw's, so replacing it may lose recent work
+func (c Conflict) DestNewer() bool {
+	return c.DstTime.After(c.SrcTime)
+}
+
+// Overlap is what a workspace copy would find already in the destination
+type Overlap struct {
+	Identical int        // files the same on both sides
+	Conflicts []Conflict // files that differ, by path
+	DestOnly  int        // files only the destination has, which the copy leaves alone
+}
+
+// FindOverlap compares the files MigrateWorkspace would copy from
+// srcWorkspace with what dstWorkspace already holds
+func FindOverlap(srcWorkspace, dstWorkspace string) Overlap {
+	var o Overlap
+	seen := map[string]bool{}
+	filepath.Walk(srcWorkspace, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(srcWorkspace, path)
+		if rel != "." && filepath.Dir(rel) == "." && SkipEntries[rel] {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		seen[rel] = true
+		dst := filepath.Join(dstWorkspace, rel)
+		dstInfo, err := os.Stat(dst)
+		if err != nil || !dstInfo.Mode().IsRegular() {
+			return nil
+		}
+		if dstInfo.Size() == info.Size() && sameContents(path, dst) {
+			o.Identical++
+			return nil
+		}
+		o.Conflicts = append(o.Conflicts, Conflict{
+			Rel: rel, Src: path, Dst: dst,
+			SrcTime: info.ModTime(), DstTime: dstInfo.ModTime(),
+			SrcSize: info.Size(), DstSize: dstInfo.Size(),
+		})
+		return nil
+	})
+	filepath.Walk(dstWorkspace, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			if rel, _ := filepath.Rel(dstWorkspace, path); !seen[rel] {
+				o.DestOnly++
+			}
+		}
+		return nil
+	})
+	sort.Slice(o.Conflicts, func(i, j int) bool { return o.Conflicts[i].Rel < o.Conflicts[j].Rel })
+	return o
+}
+
+// sameContents compares two files of the same size
+func sameContents(a, b string) bool {
+	fa, err := os.Open(a)
+	if err != nil {
+		return false
+	}
+	defer fa.Close()
+	fb, err := os.Open(b)
+	if err != nil {
+		return false
+	}
+	defer fb.Close()
+	bufA, bufB := make([]byte, 64*1024), make([]byte, 64*1024)
+	for {
+		na, errA := io.ReadFull(fa, bufA)
+		nb, errB := io.ReadFull(fb, bufB)
+		if na != nb || !bytes.Equal(bufA[:na], bufB[:nb]) {
+			return false
+		}
+		if errA != nil || errB != nil {
+			return errA == errB
+		}
+	}
+}
+
+// ConfigConflicts converts the OpenClaw config and lists the keys where it
+// and the existing PicoClaw config disagree, which MigrateConfig settles
+// with KeepExisting. Nothing is written.
+func ConfigConflicts(openclawConfigPath, picoConfigPath string) ([]config.Conflict, error) {
+	existing, err := config.ReadConfig(picoConfigPath)
+	if err != nil {
+		return nil, nil // no config yet: nothing to conflict with
+	}
+	ocConfig, err := config.ReadConfig(openclawConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("read openclaw config: %w", err)
+	}
+	var conflicts []config.Conflict
+	for _, c := range config.Conflicts(existing, config.ConvertConfig(ocConfig)) {
+		if c.Path != "agents.defaults.workspace" { // always kept, see keepWorkspace
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts, nil
+}
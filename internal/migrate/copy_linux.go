@@ -0,0 +1,33 @@
+//go:build linux
+
+package migrate
+
+import (
+	"io"
+	"os"
+)
+
+// kernelCopySupported reports whether kernelCopy avoids user space here
+const kernelCopySupported = true
+
+// kernelCopyChunk is copied per call, so progress still moves on huge files
+const kernelCopyChunk = 64 << 20
+
+// kernelCopy copies in to out with copy_file_range, which the os package
+// uses for file-to-file copies on Linux: the data never passes through
+// user space, and NFS 4.2 and reflink-capable filesystems can copy it
+// without moving it at all
+func kernelCopy(out, in *os.File, progress func(int)) error {
+	for {
+		n, err := io.CopyN(out, in, kernelCopyChunk)
+		if n > 0 && progress != nil {
+			progress(int(n))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
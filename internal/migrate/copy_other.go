@@ -0,0 +1,16 @@
+//go:build !linux
+
+package migrate
+
+import (
+	"errors"
+	"os"
+)
+
+// kernelCopySupported reports whether kernelCopy avoids user space here
+const kernelCopySupported = false
+
+// kernelCopy is not supported on this platform; big files are streamed instead
+func kernelCopy(out, in *os.File, progress func(int)) error {
+	return errors.New("kernel copy not supported on this platform")
+}
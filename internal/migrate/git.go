@@ -0,0 +1,235 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// workspaceIgnore keeps sessions, caches and secrets out of a workspace repository
+var workspaceIgnore = []string{
+	"# Generated by claw-migrate",
+	"sessions/",
+	"state/",
+	"cache/",
+	".cache/",
+	"logs/",
+	"*.log",
+	"media/",
+	"credentials/",
+	".env",
+	".env.*",
+	"*.pem",
+	"*.key",
+	"*.p12",
+	".DS_Store",
+}
+
+// GitAvailable reports whether git is installed
+func GitAvailable() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+// IsRepo reports whether dir has its own .git
+func IsRepo(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// InitWorkspaceRepo turns a migrated workspace into a git repository: it
+// writes a .gitignore (keeping the rules of srcIgnore, the OpenClaw
+// workspace's own .gitignore, if there is one), then runs git init and
+// commits everything. Returns the number of files committed.
+func InitWorkspaceRepo(workspace, srcIgnore string) (int, error) {
+	rules := append([]string{}, workspaceIgnore...)
+	if data, err := os.ReadFile(srcIgnore); err == nil {
+		seen := make(map[string]bool, len(rules))
+		for _, r := range rules {
+			seen[r] = true
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimRight(line, "\r")
+			if line != "" && !seen[line] {
+				rules = append(rules, line)
+				seen[line] = true
+			}
+		}
+	}
+	if err := os.WriteFile(filepath.Join(workspace, ".gitignore"), []byte(strings.Join(rules, "\n")+"\n"), 0644); err != nil {
+		return 0, fmt.Errorf("write .gitignore: %w", err)
+	}
+
+	if err := git(workspace, "init", "-q"); err != nil {
+		return 0, err
+	}
+	if err := git(workspace, "add", "-A"); err != nil {
+		return 0, err
+	}
+	out, err := exec.Command("git", "-C", workspace, "diff", "--cached", "--name-only").Output()
+	if err != nil {
+		return 0, fmt.Errorf("git diff: %w", err)
+	}
+	files := len(strings.Fields(string(out)))
+
+	// A fresh machine may have no git identity yet; don't fail the commit over it
+	args := []string{"commit", "-q", "-m", "Workspace migrated from OpenClaw"}
+	if email, _ := exec.Command("git", "-C", workspace, "config", "user.email").Output(); len(strings.TrimSpace(string(email))) == 0 {
+		args = append([]string{"-c", "user.name=claw-migrate", "-c", "user.email=claw-migrate@localhost"}, args...)
+	}
+	if err := git(workspace, args...); err != nil {
+		return files, err
+	}
+	return files, nil
+}
+
+// git runs a git command in dir, returning its output as the error on failure
+func git(dir string, args ...string) error {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// TransplantRepo copies the OpenClaw workspace's .git into the migrated
+// workspace so its history comes along, along with the .gitignore the
+// workspace copy leaves out. Absolute references to the old workspace in
+// .git/config and the hooks are rewritten to the new one; the rewritten
+// files are returned, relative to the workspace.
+func TransplantRepo(srcWorkspace, dstWorkspace string) ([]string, error) {
+	src := filepath.Join(srcWorkspace, ".git")
+	info, err := os.Lstat(src)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		// A "gitdir:" file: the workspace is a worktree or submodule of a repository elsewhere
+		return nil, fmt.Errorf("%s points to a repository outside the workspace — clone that instead", src)
+	}
+	dst := filepath.Join(dstWorkspace, ".git")
+	if _, err := os.Lstat(dst); err == nil {
+		return nil, fmt.Errorf("%s already exists", dst)
+	}
+	if err := copyTree(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return nil, fmt.Errorf("copy .git: %w", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dstWorkspace, ".gitignore")); os.IsNotExist(err) {
+		if data, err := os.ReadFile(filepath.Join(srcWorkspace, ".gitignore")); err == nil {
+			os.WriteFile(filepath.Join(dstWorkspace, ".gitignore"), data, 0644)
+		}
+	}
+
+	// The workspace may have been reached through a symlink; rewrite both spellings
+	olds := []string{filepath.Clean(srcWorkspace)}
+	if real, err := filepath.EvalSymlinks(srcWorkspace); err == nil && real != olds[0] {
+		olds = append(olds, real)
+	}
+	var rewritten []string
+	candidates := []string{filepath.Join(dst, "config")}
+	hooks, _ := filepath.Glob(filepath.Join(dst, "hooks", "*"))
+	for _, h := range hooks {
+		if !strings.HasSuffix(h, ".sample") {
+			candidates = append(candidates, h)
+		}
+	}
+	for _, path := range candidates {
+		changed, err := replaceInFile(path, olds, dstWorkspace)
+		if err != nil {
+			return rewritten, err
+		}
+		if changed {
+			rel, _ := filepath.Rel(dstWorkspace, path)
+			rewritten = append(rewritten, filepath.ToSlash(rel))
+		}
+	}
+
+	// The index still holds the old files' inode and timestamps; refresh it so
+	// unchanged files don't show as modified (exits non-zero if some really are)
+	exec.Command("git", "-C", dstWorkspace, "update-index", "-q", "--refresh").Run()
+	return rewritten, nil
+}
+
+// RepoChanges lists the paths that differ from the last commit, e.g. files
+// the migration deliberately didn't copy
+func RepoChanges(dir string) []string {
+	out, err := exec.Command("git", "-C", dir, "status", "--porcelain").Output()
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		if len(line) > 3 {
+			paths = append(paths, line[3:])
+		}
+	}
+	return paths
+}
+
+// replaceInFile replaces every occurrence of olds with replacement, keeping the file's mode
+func replaceInFile(path string, olds []string, replacement string) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	updated := data
+	for _, old := range olds {
+		updated = bytes.ReplaceAll(updated, []byte(old), []byte(replacement))
+	}
+	if bytes.Equal(updated, data) {
+		return false, nil
+	}
+	return true, os.WriteFile(path, updated, info.Mode().Perm())
+}
+
+// copyTree copies a directory tree, keeping modes and symlinks as they are
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case !info.Mode().IsRegular():
+			return nil
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
@@ -0,0 +1,217 @@
+package migrate
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Actions for items in ~/.openclaw outside the workspace
+const (
+	ActionHandled = "handled" // migrated by another step
+	ActionCopy    = "copy"    // copied as-is into the PicoClaw tree
+	ActionSkip    = "skip"    // not needed or incompatible with PicoClaw
+	ActionManual  = "manual"  // needs a human decision
+	ActionSecret  = "secret"  // copied into the PicoClaw home with 0600 permissions
+)
+
+// HomeRule says what to do with an item in ~/.openclaw outside the workspace
+type HomeRule struct {
+	Action      string
+	Dest        string // destination relative to the PicoClaw workspace (ActionCopy) or home (ActionSecret)
+	Description string
+}
+
+// HomeRules covers the known non-workspace artifacts of an OpenClaw install
+var HomeRules = map[string]HomeRule{
+	"openclaw.json":       {ActionHandled, "", "main config — converted to config.json"},
+	"workspace":           {ActionHandled, "", "agent workspace"},
+	"state":               {ActionCopy, "state", "agent state — PicoClaw keeps it in the workspace"},
+	"media":               {ActionCopy, "media", "received attachments and media"},
+	"attachments":         {ActionCopy, "media", "received attachments and media"},
+	"canvas":              {ActionCopy, "canvas", "canvas documents"},
+	"credentials":         {ActionSecret, "credentials", "channel and OAuth credentials"},
+	"credentials.json":    {ActionSecret, "credentials/credentials.json", "stored API credentials"},
+	"auth-profiles.json":  {ActionSecret, "auth.json", "provider auth profiles"},
+	"auth.json":           {ActionSecret, "auth.json", "provider auth profiles"},
+	".env":                {ActionHandled, "", "environment variables — merged into PicoClaw's .env"},
+	"extensions":          {ActionManual, "", "OpenClaw plugins — no PicoClaw equivalent, reinstall as skills"},
+	"cron":                {ActionManual, "", "scheduled jobs — recreate with picoclaw cron add"},
+	"memory":              {ActionSkip, "", "vector index — PicoClaw rebuilds memory from workspace/memory"},
+	"agents":              {ActionSkip, "", "per-agent session history — incompatible format"},
+	"sessions":            {ActionSkip, "", "session history — incompatible format"},
+	"subagents":           {ActionSkip, "", "sub-agent run history"},
+	"sandboxes":           {ActionSkip, "", "sandbox containers state"},
+	"devices":             {ActionSkip, "", "paired device tokens — re-pair with PicoClaw"},
+	"identity":            {ActionSkip, "", "device identity keys — PicoClaw generates its own"},
+	"telegram":            {ActionSkip, "", "Telegram update offsets"},
+	"logs":                {ActionSkip, "", "log files"},
+	"update-check.json":   {ActionSkip, "", "update checker cache"},
+	"exec-approvals.json": {ActionSkip, "", "command approval history"},
+}
+
+// RuleFor returns the rule for a home directory item. Unknown items need a
+// human decision; they are still preserved in the Phase 2 backup.
+func RuleFor(name string) HomeRule {
+	if rule, ok := HomeRules[name]; ok {
+		return rule
+	}
+	if LooksSecret(name) {
+		return HomeRule{ActionSecret, filepath.Join("credentials", name), "looks like a secret"}
+	}
+	return HomeRule{ActionManual, "", "unknown item — kept in the backup"}
+}
+
+// LooksSecret guesses from a file name whether it holds credentials
+func LooksSecret(name string) bool {
+	lower := strings.ToLower(name)
+	for _, ext := range []string{".key", ".pem", ".p12", ".pfx"} {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	for _, word := range []string{"token", "secret", "credential", "password", "apikey", "api_key"} {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretCollisions finds secret items whose destination another item in
+// names also has, such as auth.json and auth-profiles.json, and returns
+// where each one that gives way goes instead (relative to the PicoClaw
+// home), by name. The item named like the destination keeps it; otherwise
+// the first by name does.
+func SecretCollisions(names []string) map[string]string {
+	byDest := make(map[string][]string)
+	for _, name := range names {
+		if rule := RuleFor(name); rule.Action == ActionSecret {
+			byDest[rule.Dest] = append(byDest[rule.Dest], name)
+		}
+	}
+	moved := make(map[string]string)
+	for dest, items := range byDest {
+		if len(items) < 2 {
+			continue
+		}
+		sort.Slice(items, func(i, j int) bool {
+			if a, b := items[i] == filepath.Base(dest), items[j] == filepath.Base(dest); a != b {
+				return a
+			}
+			return items[i] < items[j]
+		})
+		for _, name := range items[1:] {
+			moved[name] = filepath.Join("credentials", name)
+		}
+	}
+	return moved
+}
+
+// MigrateSecrets copies credential items into the PicoClaw home. Files are
+// created 0600 and directories 0700 whatever the source's mode, so a secret
+// is never on disk readable by anyone else, even briefly. Items that would
+// land on the same file go where SecretCollisions says instead.
+func MigrateSecrets(openclawHome, picoHome string, names []string, opts Options) Result {
+	result := Result{}
+	opts = opts.forRun()
+	opts.Scrub = false // these files are meant to hold credentials
+	opts.Mode = 0600
+	moved := SecretCollisions(names)
+	for _, name := range names {
+		rule := RuleFor(name)
+		if rule.Action != ActionSecret {
+			continue
+		}
+		if dest, ok := moved[name]; ok {
+			rule.Dest = dest
+		}
+
+		srcPath := filepath.Join(openclawHome, name)
+		dstPath := filepath.Join(picoHome, rule.Dest)
+
+		info, err := os.Stat(srcPath)
+		if err != nil {
+			continue
+		}
+		os.MkdirAll(filepath.Dir(dstPath), 0700)
+		if info.IsDir() {
+			os.MkdirAll(dstPath, 0700)
+			migrateDirectory(srcPath, dstPath, opts, &result)
+			hardenDir(dstPath)
+		} else {
+			result.add(migrateFile(srcPath, dstPath, rule.Dest, opts), opts)
+		}
+
+		if result.Aborted {
+			break
+		}
+	}
+	return result
+}
+
+// ExposedSecrets returns the secret items under openclawHome that other
+// users on this machine can read
+func ExposedSecrets(openclawHome string, names []string) []string {
+	var exposed []string
+	for _, name := range names {
+		if RuleFor(name).Action != ActionSecret {
+			continue
+		}
+		filepath.WalkDir(filepath.Join(openclawHome, name), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil && info.Mode().Perm()&0044 != 0 {
+				rel, _ := filepath.Rel(openclawHome, path)
+				exposed = append(exposed, rel)
+			}
+			return nil
+		})
+	}
+	return exposed
+}
+
+// hardenDir sets 0700 on a directory tree
+func hardenDir(root string) {
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			os.Chmod(path, 0700)
+		}
+		return nil
+	})
+}
+
+// MigrateHomeItems copies the home directory items whose rule is ActionCopy
+// into the PicoClaw workspace
+func MigrateHomeItems(openclawHome, picoWorkspace string, names []string, opts Options) Result {
+	result := Result{}
+	opts = opts.forRun()
+	for _, name := range names {
+		rule := RuleFor(name)
+		if rule.Action != ActionCopy {
+			continue
+		}
+
+		srcPath := filepath.Join(openclawHome, name)
+		dstPath := filepath.Join(picoWorkspace, rule.Dest)
+
+		info, err := os.Stat(srcPath)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			os.MkdirAll(dstPath, 0755)
+			migrateDirectory(srcPath, dstPath, opts, &result)
+		} else {
+			result.add(migrateFile(srcPath, dstPath, rule.Dest, opts), opts)
+		}
+
+		if result.Aborted {
+			break
+		}
+	}
+	return result
+}
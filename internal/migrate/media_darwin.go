@@ -0,0 +1,34 @@
+//go:build darwin
+
+package migrate
+
+import "syscall"
+
+// networkFS are the statfs type names of network filesystems
+var networkFS = map[string]bool{
+	"nfs": true, "smbfs": true, "afpfs": true, "webdav": true, "macfuse": true, "osxfuse": true,
+}
+
+// probeMedia reads the filesystem type from statfs. Macs are assumed to
+// be solid-state: there's no cheap way to ask without diskutil.
+func probeMedia(path string) media {
+	var m media
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return m
+	}
+	m.dev = uint64(st.Dev)
+
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err == nil {
+		name := make([]byte, 0, len(fs.Fstypename))
+		for _, c := range fs.Fstypename {
+			if c == 0 {
+				break
+			}
+			name = append(name, byte(c))
+		}
+		m.network = networkFS[string(name)]
+	}
+	return m
+}
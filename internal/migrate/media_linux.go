@@ -0,0 +1,56 @@
+//go:build linux
+
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// networkFS are statfs f_type magic numbers of network and FUSE filesystems
+var networkFS = map[int64]bool{
+	0x6969:     true, // NFS
+	0x517b:     true, // SMB
+	0xff534d42: true, // CIFS
+	0xfe534d42: true, // SMB2
+	0x00c36400: true, // Ceph
+	0x01021997: true, // 9p (WSL, VMs)
+	0x65735546: true, // FUSE (sshfs, rclone, ...)
+}
+
+// probeMedia reads the filesystem type from statfs and whether the backing
+// block device is rotational from sysfs
+func probeMedia(path string) media {
+	var m media
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return m
+	}
+	m.dev = st.Dev
+
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err == nil {
+		m.network = networkFS[int64(uint32(fs.Type))]
+	}
+	if m.network {
+		return m
+	}
+
+	// /sys/dev/block/MAJ:MIN is the partition; its disk's queue is one level up
+	major := (st.Dev>>8)&0xfff | (st.Dev>>32)&^0xfff
+	minor := st.Dev&0xff | (st.Dev>>12)&^0xff
+	dev, err := filepath.EvalSymlinks(fmt.Sprintf("/sys/dev/block/%d:%d", major, minor))
+	if err != nil {
+		return m // e.g. btrfs subvolumes and overlayfs have no block device of their own
+	}
+	for _, dir := range []string{dev, filepath.Dir(dev)} {
+		if data, err := os.ReadFile(filepath.Join(dir, "queue", "rotational")); err == nil {
+			m.rotational = strings.TrimSpace(string(data)) == "1"
+			break
+		}
+	}
+	return m
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package migrate
+
+// probeMedia can't tell anything about the filesystem on this platform
+func probeMedia(path string) media {
+	return media{}
+}
@@ -1,16 +1,25 @@
 package migrate
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/arunbluez/claw-migrate/internal/config"
+	"github.com/arunbluez/claw-migrate/internal/detect"
+	"github.com/arunbluez/claw-migrate/internal/iolimit"
+	"github.com/arunbluez/claw-migrate/internal/preserve"
+	"github.com/arunbluez/claw-migrate/internal/scrub"
 )
 
-
 // FileResult tracks the migration result for a single file
 type FileResult struct {
 	Source      string
@@ -20,7 +29,13 @@ type FileResult struct {
 	Migrated   bool
 	Skipped    bool
 	BackedUp   bool
+	Cloned     bool      // copied via reflink/clonefile instead of bytes
+	Moved      bool      // source deleted after a verified copy (move mode)
+	Redacted   int       // secrets replaced with markers in the copy (scrub mode)
 	Error      error
+	SHA256     string    // hash of the source (of the redacted copy in scrub mode), verified against the destination
+	Size       int64     // destination size after copy
+	ModTime    time.Time // destination mtime after copy
 }
 
 // Result tracks the overall migration result
@@ -31,8 +46,67 @@ type Result struct {
 	Migrated     int
 	Skipped      int
 	Errors       int
+	Cloned       int   // files copied via reflink/clonefile
+	Aborted      bool  // copy stopped early because the error budget ran out
+	AbortReason  error // the error that triggered the abort
+}
+
+// Options controls how the workspace copy behaves
+type Options struct {
+	Force     bool
+	MaxErrors int              // abort after this many failed files (0 = never abort on count)
+	Limiter   *iolimit.Limiter // throttles file reads (nil = unlimited)
+	Sync      string           // which files to fsync: SyncKeyFiles (default), SyncAll or SyncNone
+	Move      bool             // delete each source file once its copy is verified
+	Progress  func(n int)      // called with the number of bytes copied as they are copied (may be nil)
+	Scrub     bool             // redact secrets found in text files instead of copying them verbatim
+	Strategy  Strategy         // workers, buffers and cloning (zero value: one file at a time)
+	Keep      map[string]bool  // destination files to leave as they are (from the conflict review)
+	Mode      os.FileMode      // create files with this mode instead of the source's (0 = the source's)
+	// BeforeRemove is called in move mode with each verified, durable copy
+	// before its source is deleted, to record it; an error keeps the source
+	BeforeRemove func(fr FileResult) error
+
+	// cloneOff is set after a clone fails because the filesystem can't
+	// clone, so the rest of this run copies bytes without trying again
+	cloneOff *atomic.Bool
 }
 
+// forRun returns the options with clone state of their own, so one copy
+// step's filesystem doesn't decide cloning for another
+func (o Options) forRun() Options {
+	o.cloneOff = new(atomic.Bool)
+	return o
+}
+
+// Sync modes for Options.Sync
+const (
+	SyncKeyFiles = "key"  // fsync the standard agent files only
+	SyncAll      = "all"  // fsync every copied file
+	SyncNone     = "none" // leave flushing to the OS
+)
+
+// shouldSync reports whether a copied file must be flushed to disk
+func (o Options) shouldSync(name string) bool {
+	switch o.Sync {
+	case SyncAll:
+		return true
+	case SyncNone:
+		return false
+	default:
+		return detect.StandardFiles[name]
+	}
+}
+
+// Error causes used to group failures in the summary
+const (
+	CausePermission = "permission denied"
+	CauseNoSpace    = "disk full"
+	CausePathLength = "path too long"
+	CauseNotFound   = "file vanished"
+	CauseOther      = "other"
+)
+
 // SkipEntries are items we never migrate
 var SkipEntries = map[string]bool{
 	".git":       true,
@@ -44,8 +118,11 @@ var SkipEntries = map[string]bool{
 
 // MigrateWorkspace copies the ENTIRE workspace from OpenClaw to PicoClaw
 // including all files, custom directories, project folders, etc.
-func MigrateWorkspace(srcWorkspace, dstWorkspace string, force bool) Result {
+// The copy stops early once opts.MaxErrors files have failed, or as soon as
+// the destination runs out of space.
+func MigrateWorkspace(srcWorkspace, dstWorkspace string, opts Options) Result {
 	result := Result{}
+	opts = opts.forRun()
 
 	// Ensure destination exists
 	os.MkdirAll(dstWorkspace, 0755)
@@ -56,6 +133,8 @@ func MigrateWorkspace(srcWorkspace, dstWorkspace string, force bool) Result {
 		return result
 	}
 
+	var jobs []copyJob
+	var dirs []string
 	for _, entry := range entries {
 		name := entry.Name()
 
@@ -69,37 +148,91 @@ func MigrateWorkspace(srcWorkspace, dstWorkspace string, force bool) Result {
 
 		if entry.IsDir() {
 			// Migrate entire directory recursively
-			os.MkdirAll(dstPath, 0755)
-			dirResults := migrateDirectory(srcPath, dstPath, force)
-			for _, fr := range dirResults {
-				result.Files = append(result.Files, fr)
-				result.TotalFiles++
-				if fr.Migrated {
-					result.Migrated++
-				} else if fr.Skipped {
-					result.Skipped++
-				} else if fr.Error != nil {
-					result.Errors++
-				}
-			}
+			collectDirectory(srcPath, dstPath, &jobs, &dirs)
 		} else {
-			// Migrate file
-			fr := migrateFile(srcPath, dstPath, name, force)
-			result.Files = append(result.Files, fr)
-			result.TotalFiles++
-			if fr.Migrated {
-				result.Migrated++
-			} else if fr.Skipped {
-				result.Skipped++
-			} else if fr.Error != nil {
-				result.Errors++
-			}
+			jobs = append(jobs, copyJob{srcPath, dstPath, name})
 		}
 	}
 
+	copyFiles(jobs, opts, &result)
+	if opts.Move {
+		removeEmptied(dirs)
+	}
+	return result
+}
+
+// RetryFailed re-attempts every file that failed in a previous result
+func RetryFailed(previous Result, opts Options) Result {
+	result := Result{}
+	opts = opts.forRun()
+	var jobs []copyJob
+	for _, fr := range previous.Failed() {
+		jobs = append(jobs, copyJob{fr.Source, fr.Dest, fr.Name})
+	}
+	copyFiles(jobs, opts, &result)
 	return result
 }
 
+// Failed returns the file results that ended in an error
+func (r Result) Failed() []FileResult {
+	var failed []FileResult
+	for _, fr := range r.Files {
+		if fr.Error != nil {
+			failed = append(failed, fr)
+		}
+	}
+	return failed
+}
+
+// ErrorsByCause groups failed files by the cause of their error
+func (r Result) ErrorsByCause() map[string][]FileResult {
+	groups := make(map[string][]FileResult)
+	for _, fr := range r.Failed() {
+		cause := ClassifyError(fr.Error)
+		groups[cause] = append(groups[cause], fr)
+	}
+	return groups
+}
+
+// ClassifyError maps a copy error to one of the Cause* constants
+func ClassifyError(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, syscall.ENOSPC), errors.Is(err, syscall.EDQUOT):
+		return CauseNoSpace
+	case errors.Is(err, fs.ErrPermission):
+		return CausePermission
+	case errors.Is(err, syscall.ENAMETOOLONG):
+		return CausePathLength
+	case errors.Is(err, fs.ErrNotExist):
+		return CauseNotFound
+	default:
+		return CauseOther
+	}
+}
+
+// add records a file result and checks whether the error budget is spent
+func (r *Result) add(fr FileResult, opts Options) {
+	r.Files = append(r.Files, fr)
+	r.TotalFiles++
+	if fr.Migrated {
+		r.Migrated++
+		if fr.Cloned {
+			r.Cloned++
+		}
+	} else if fr.Skipped {
+		r.Skipped++
+	} else if fr.Error != nil {
+		r.Errors++
+		// A full disk will fail every remaining file, so stop right away
+		if !r.Aborted && (ClassifyError(fr.Error) == CauseNoSpace || (opts.MaxErrors > 0 && r.Errors >= opts.MaxErrors)) {
+			r.Aborted = true
+			r.AbortReason = fr.Error
+		}
+	}
+}
+
 // MigrateConfig converts and writes the PicoClaw config
 func MigrateConfig(openclawConfigPath, picoConfigPath string, force bool) FileResult {
 	fr := FileResult{
@@ -121,15 +254,17 @@ func MigrateConfig(openclawConfigPath, picoConfigPath string, force bool) FileRe
 	// Read existing PicoClaw config if present
 	existingConfig, _ := config.ReadConfig(picoConfigPath)
 
-	// Merge (existing config takes precedence for manually configured values)
+	// Merge; which side wins a key both set is up to KeepExisting
 	if existingConfig != nil {
-		picoConfig = config.MergeConfig(existingConfig, picoConfig)
+		picoConfig = config.Merge(existingConfig, picoConfig, KeepExisting)
+		keepWorkspace(existingConfig, picoConfig)
 	}
 
 	// Backup existing config if present
 	if _, err := os.Stat(picoConfigPath); err == nil {
 		backupPath := picoConfigPath + ".bak"
-		if err := copyFileSafe(picoConfigPath, backupPath); err == nil {
+		if _, err := copyFileSafe(picoConfigPath, backupPath, nil, nil, 0, true, 0600); err == nil {
+			os.Chmod(backupPath, 0600)
 			fr.BackedUp = true
 		}
 	}
@@ -144,9 +279,71 @@ func MigrateConfig(openclawConfigPath, picoConfigPath string, force bool) FileRe
 	return fr
 }
 
+// keepWorkspace preserves a workspace path the user already configured in
+// PicoClaw, since the workspace was copied there before the config merge
+func keepWorkspace(existing, merged map[string]interface{}) {
+	agents, ok := existing["agents"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	defaults, ok := agents["defaults"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	ws, ok := defaults["workspace"].(string)
+	if !ok || ws == "" {
+		return
+	}
+	if mergedAgents, ok := merged["agents"].(map[string]interface{}); ok {
+		if mergedDefaults, ok := mergedAgents["defaults"].(map[string]interface{}); ok {
+			mergedDefaults["workspace"] = ws
+		}
+	}
+}
+
+// SupplementConfig fills gaps in a config that was already converted by
+// `picoclaw migrate`. Values written by the built-in migrator win; only keys
+// it didn't produce (e.g. model_list, or a setting under a section it did
+// write) are added from our own conversion. Returns the dotted paths of the
+// keys that were added.
+func SupplementConfig(openclawConfigPath, picoConfigPath string) (FileResult, []string) {
+	fr := FileResult{
+		Source: openclawConfigPath,
+		Dest:   picoConfigPath,
+		Name:   "config.json",
+	}
+
+	ocConfig, err := config.ReadConfig(openclawConfigPath)
+	if err != nil {
+		fr.Error = fmt.Errorf("read openclaw config: %w", err)
+		return fr, nil
+	}
+	builtIn, err := config.ReadConfig(picoConfigPath)
+	if err != nil {
+		fr.Error = fmt.Errorf("read picoclaw config: %w", err)
+		return fr, nil
+	}
+
+	converted := config.ConvertConfig(ocConfig)
+	added := config.Missing(builtIn, converted)
+	if len(added) == 0 {
+		fr.Skipped = true
+		return fr, nil
+	}
+
+	merged := config.MergeConfig(converted, builtIn)
+	if err := config.WriteConfig(merged, picoConfigPath); err != nil {
+		fr.Error = fmt.Errorf("write picoclaw config: %w", err)
+		return fr, nil
+	}
+
+	fr.Migrated = true
+	return fr, added
+}
+
 // --- Internal helpers ---
 
-func migrateFile(src, dst, name string, force bool) FileResult {
+func migrateFile(src, dst, name string, opts Options) FileResult {
 	fr := FileResult{
 		Source: src,
 		Dest:   dst,
@@ -155,43 +352,202 @@ func migrateFile(src, dst, name string, force bool) FileResult {
 
 	// Check source exists
 	srcInfo, err := os.Stat(src)
-	if os.IsNotExist(err) {
+	if os.IsNotExist(err) || opts.Keep[dst] {
 		fr.Skipped = true
 		return fr
 	}
 
 	// Count lines
-	if data, err := os.ReadFile(src); err == nil {
-		fr.Lines = len(strings.Split(string(data), "\n"))
+	fr.Lines = detect.CountFileLines(src).Lines
+
+	// With --no-delete, what's already there is set aside before it's replaced
+	if err := preserve.Keep(dst); err != nil {
+		fr.Error = err
+		return fr
 	}
 
 	// Check if destination already exists
-	if _, err := os.Stat(dst); err == nil && !force {
+	if _, err := os.Stat(dst); err == nil && !opts.Force {
 		// File exists and not force — backup then overwrite
 		backupPath := dst + ".bak"
-		copyFileSafe(dst, backupPath)
+		copyFileSafe(dst, backupPath, nil, nil, 0, false, opts.Mode)
 		fr.BackedUp = true
 	}
 
-	// Copy file
-	if err := copyFileSafe(src, dst); err != nil {
-		fr.Error = fmt.Errorf("copy %s: %w", name, err)
-		return fr
+	// Clone when the filesystem supports it (instant, no extra space),
+	// otherwise copy the bytes, hashing the source as it streams through.
+	// In scrub mode, text files holding secrets are written redacted instead.
+	sync := opts.shouldSync(name)
+	var sum string
+	if opts.Scrub && scrub.Scannable(name, srcInfo.Size()) {
+		sum, fr.Redacted, err = copyRedacted(src, dst, srcInfo.Mode(), sync)
+		if err != nil {
+			fr.Error = fmt.Errorf("copy %s: %w", name, err)
+			return fr
+		}
+	}
+	if fr.Redacted > 0 {
+		if opts.Progress != nil {
+			opts.Progress(int(srcInfo.Size()))
+		}
+	} else if cloneSum, cloned := cloneAndHash(src, dst, sync, opts); cloned {
+		sum = cloneSum
+		fr.Cloned = true
+		if opts.Progress != nil {
+			opts.Progress(int(srcInfo.Size()))
+		}
+	} else {
+		sum, err = copyFileSafe(src, dst, opts.Limiter, opts.Progress, opts.strategy().BufferSize, sync, opts.Mode)
+		if err != nil {
+			fr.Error = fmt.Errorf("copy %s: %w", name, err)
+			return fr
+		}
+	}
+
+	// Re-read the destination to catch truncation or corruption at copy time.
+	// A clone shares the source's blocks, so its hash was read from dst already.
+	if !fr.Cloned {
+		if err := VerifyFile(dst, sum); err != nil {
+			fr.Error = fmt.Errorf("verify %s: %w", name, err)
+			return fr
+		}
+	}
+	fr.SHA256 = sum
+
+	// Preserve permissions, or make sure the forced ones stuck
+	if opts.Mode != 0 {
+		if err := os.Chmod(dst, opts.Mode); err != nil {
+			fr.Error = fmt.Errorf("chmod %s: %w", name, err)
+			return fr
+		}
+	} else {
+		os.Chmod(dst, srcInfo.Mode())
+	}
+
+	if info, err := os.Stat(dst); err == nil {
+		fr.Size = info.Size()
+		fr.ModTime = info.ModTime()
+	}
+
+	// Make the new directory entry durable too, not just the file contents.
+	// Move mode always syncs: the source is about to disappear.
+	if sync || opts.Move {
+		if !sync {
+			if err := syncFile(dst); err != nil {
+				fr.Error = fmt.Errorf("sync %s: %w", name, err)
+				return fr
+			}
+		}
+		if err := syncDir(filepath.Dir(dst)); err != nil {
+			fr.Error = fmt.Errorf("sync %s: %w", name, err)
+			return fr
+		}
 	}
 
-	// Preserve permissions
-	os.Chmod(dst, srcInfo.Mode())
+	// Free the source's space now that the copy is verified, durable and recorded
+	if opts.Move {
+		if opts.BeforeRemove != nil {
+			moved := fr
+			moved.Migrated, moved.Moved = true, true
+			if err := opts.BeforeRemove(moved); err != nil {
+				fr.Error = fmt.Errorf("record %s before removing its source: %w", name, err)
+				return fr
+			}
+		}
+		if err := os.Remove(src); err != nil {
+			fr.Error = fmt.Errorf("remove source %s: %w", name, err)
+			return fr
+		}
+		fr.Moved = true
+	}
 
 	fr.Migrated = true
 	return fr
 }
 
-func migrateDirectory(srcDir, dstDir string, force bool) []FileResult {
-	var results []FileResult
+// copyRedacted writes src to dst with its secrets redacted and returns the
+// hash of what was written and the number of redactions. Nothing is
+// written if no secrets were found, so the caller copies as usual.
+func copyRedacted(src, dst string, mode os.FileMode, sync bool) (string, int, error) {
+	data, err := os.ReadFile(src)
+	if err != nil || !scrub.IsText(data) {
+		return "", 0, err
+	}
+	redacted, findings := scrub.Redact(data)
+	if len(findings) == 0 {
+		return "", 0, nil
+	}
+	os.MkdirAll(filepath.Dir(dst), 0755)
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := out.Write(redacted); err != nil {
+		out.Close()
+		return "", 0, err
+	}
+	if sync {
+		if err := out.Sync(); err != nil {
+			out.Close()
+			return "", 0, err
+		}
+	}
+	if err := out.Close(); err != nil {
+		return "", 0, err
+	}
+	sum := sha256.Sum256(redacted)
+	return hex.EncodeToString(sum[:]), len(findings), nil
+}
+
+// HashFile returns the hex-encoded SHA-256 of a file
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
 
+	var buf []byte
+	if info, err := f.Stat(); err == nil && info.Size() >= bigFileSize {
+		buf = make([]byte, bigFileBuffer)
+	}
+	h := sha256.New()
+	if _, err := io.CopyBuffer(h, struct{ io.Reader }{f}, buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyFile checks that a file's SHA-256 matches the expected hash
+func VerifyFile(path, expected string) error {
+	actual, err := HashFile(path)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch (expected %.12s, got %.12s)", expected, actual)
+	}
+	return nil
+}
+
+func migrateDirectory(srcDir, dstDir string, opts Options, result *Result) {
+	var jobs []copyJob
+	var dirs []string
+	collectDirectory(srcDir, dstDir, &jobs, &dirs)
+	copyFiles(jobs, opts, result)
+	if opts.Move {
+		removeEmptied(dirs)
+	}
+}
+
+// collectDirectory creates dstDir and its subdirectories to mirror srcDir,
+// and lists the files to copy. Source directories are listed parents first.
+func collectDirectory(srcDir, dstDir string, jobs *[]copyJob, dirs *[]string) {
+	os.MkdirAll(dstDir, 0755)
+	*dirs = append(*dirs, srcDir)
 	entries, err := os.ReadDir(srcDir)
 	if err != nil {
-		return results
+		return
 	}
 
 	for _, entry := range entries {
@@ -200,35 +556,172 @@ func migrateDirectory(srcDir, dstDir string, force bool) []FileResult {
 
 		if entry.IsDir() {
 			// Recursively copy subdirectories
-			os.MkdirAll(dstPath, 0755)
-			subResults := migrateDirectory(srcPath, dstPath, force)
-			results = append(results, subResults...)
+			collectDirectory(srcPath, dstPath, jobs, dirs)
 		} else {
 			name := filepath.Join(filepath.Base(srcDir), entry.Name())
-			fr := migrateFile(srcPath, dstPath, name, force)
-			results = append(results, fr)
+			*jobs = append(*jobs, copyJob{srcPath, dstPath, name})
 		}
 	}
+}
 
-	return results
+// removeEmptied drops source directories that move mode has emptied,
+// children before parents (fails harmlessly on any that aren't empty)
+func removeEmptied(dirs []string) {
+	for i := len(dirs) - 1; i >= 0; i-- {
+		os.Remove(dirs[i])
+	}
 }
 
-func copyFileSafe(src, dst string) error {
+// copyFileSafe copies src to dst and returns the SHA-256 of the bytes copied,
+// bufSize bytes at a time (0 = io.Copy's default). With sync set, the data
+// is flushed to disk before returning.
+func copyFileSafe(src, dst string, limiter *iolimit.Limiter, progress func(int), bufSize int, sync bool, mode os.FileMode) (string, error) {
 	// Ensure parent directory exists
 	os.MkdirAll(filepath.Dir(dst), 0755)
 
 	in, err := os.Open(src)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer in.Close()
 
-	out, err := os.Create(dst)
+	var out *os.File
+	if mode == 0 {
+		out, err = os.Create(dst)
+	} else {
+		// OpenFile leaves an existing file's mode alone, so tighten it
+		// before anything is written
+		out, err = os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+		if err == nil {
+			if err = out.Chmod(mode); err != nil {
+				out.Close()
+			}
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var size int64
+	if info, err := in.Stat(); err == nil {
+		size = info.Size()
+	}
+	if size >= bigFileSize {
+		bufSize = max(bufSize, bigFileBuffer)
+	}
+
+	var sum string
+	if size >= bigFileSize && limiter == nil && kernelCopySupported {
+		// Big unthrottled files are copied by the kernel, then the source
+		// is hashed on its own
+		err = kernelCopy(out, in, progress)
+		if err == nil {
+			sum, err = HashFile(src)
+		}
+	} else {
+		sum, err = streamCopy(out, in, limiter, progress, bufSize)
+	}
+	if err != nil {
+		out.Close()
+		return "", err
+	}
+	if sync {
+		if err := out.Sync(); err != nil {
+			out.Close()
+			return "", err
+		}
+	}
+	// Close errors matter here: a full disk may only surface on flush
+	if err := out.Close(); err != nil {
+		return "", err
+	}
+	return sum, nil
+}
+
+// Files from bigFileSize up (multi-gigabyte session logs, say) are read
+// bigFileBuffer at a time, or copied in the kernel where it can
+const (
+	bigFileSize   = 64 << 20
+	bigFileBuffer = 4 << 20
+)
+
+// streamCopy copies in to out through a buffer of bufSize bytes (0 =
+// io.Copy's default), hashing the bytes as they pass
+func streamCopy(out io.Writer, in *os.File, limiter *iolimit.Limiter, progress func(int), bufSize int) (string, error) {
+	h := sha256.New()
+	// Hide *os.File's WriterTo, which would otherwise bypass buf
+	var r io.Reader = struct{ io.Reader }{iolimit.Reader(in, limiter)}
+	if progress != nil {
+		r = &progressReader{r: r, progress: progress}
+	}
+	var buf []byte
+	if bufSize > 0 {
+		buf = make([]byte, bufSize)
+	}
+	if _, err := io.CopyBuffer(io.MultiWriter(out, h), r, buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// progressReader reports every read to a progress callback
+type progressReader struct {
+	r        io.Reader
+	progress func(int)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.progress(n)
+	}
+	return n, err
+}
+
+// cloneAndHash clones src to dst and returns the clone's hash, read once
+// from dst. It reports false when cloning isn't possible or allowed, leaving
+// the caller to copy bytes instead. Only a filesystem that can't clone turns
+// cloning off for the rest of the run; other failures cost just this file.
+func cloneAndHash(src, dst string, sync bool, opts Options) (string, bool) {
+	// A clone starts out with the source's mode, so not when one is forced
+	if !opts.strategy().Clone || opts.Mode != 0 || (opts.cloneOff != nil && opts.cloneOff.Load()) {
+		return "", false
+	}
+	if err := cloneFile(src, dst); err != nil {
+		if cloneUnsupported(err) && opts.cloneOff != nil {
+			opts.cloneOff.Store(true)
+		}
+		return "", false
+	}
+
+	sum, err := HashFile(dst)
+	if err != nil {
+		return "", false
+	}
+	if sync {
+		if err := syncFile(dst); err != nil {
+			return "", false
+		}
+	}
+	return sum, true
+}
+
+// syncFile flushes an already-written file to disk
+func syncFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
 	if err != nil {
 		return err
 	}
-	defer out.Close()
+	defer f.Close()
+	return f.Sync()
+}
 
-	_, err = io.Copy(out, in)
-	return err
+// syncDir fsyncs a directory so newly created entries survive a power loss
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
 }
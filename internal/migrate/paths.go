@@ -0,0 +1,235 @@
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/preserve"
+)
+
+// ════════════════════════════════════════════════════════════
+// References to OpenClaw's locations in workspace files
+// ════════════════════════════════════════════════════════════
+
+// maxPathFileSize skips files too large to be hand-written text
+const maxPathFileSize = 1 << 20
+
+// PathRule maps a reference to an OpenClaw location onto PicoClaw's
+type PathRule struct {
+	Old, New string
+}
+
+// PathMatch is a line of a workspace file that refers to OpenClaw's locations
+type PathMatch struct {
+	File      string // relative to the scanned root
+	Line      int    // 1-based
+	Text      string // the whole line, trimmed
+	Rewritten string // the line with the references rewritten, trimmed
+}
+
+// PathRules lists where references to OpenClaw's locations go: the
+// workspace to PicoClaw's, items the migration carried elsewhere to where
+// HomeRules put them, openclaw.json to config.json, and anything else in
+// ~/.openclaw to ~/.picoclaw. Each is given as an absolute path and, under
+// the home directory, as ~/ and $HOME/ paths too. The most specific come
+// first.
+func PathRules(ocHome, ocWorkspace, picoHome, picoWorkspace string) []PathRule {
+	home, _ := os.UserHomeDir()
+	var rules []PathRule
+	add := func(old, new string) {
+		rules = append(rules, PathRule{old, new})
+		rel, err := filepath.Rel(home, old)
+		if home == "" || err != nil || strings.HasPrefix(rel, "..") {
+			return
+		}
+		newRel, err := filepath.Rel(home, new)
+		if err != nil || strings.HasPrefix(newRel, "..") {
+			return
+		}
+		rel, newRel = filepath.ToSlash(rel), filepath.ToSlash(newRel)
+		rules = append(rules,
+			PathRule{"~/" + rel, "~/" + newRel},
+			PathRule{"$HOME/" + rel, "$HOME/" + newRel},
+			PathRule{"${HOME}/" + rel, "${HOME}/" + newRel},
+		)
+	}
+
+	add(ocWorkspace, picoWorkspace)
+	add(filepath.Join(ocHome, "openclaw.json"), filepath.Join(picoHome, "config.json"))
+	for name, rule := range HomeRules {
+		switch rule.Action {
+		case ActionCopy:
+			add(filepath.Join(ocHome, name), filepath.Join(picoWorkspace, rule.Dest))
+		case ActionSecret:
+			add(filepath.Join(ocHome, name), filepath.Join(picoHome, rule.Dest))
+		}
+	}
+	add(ocHome, picoHome)
+
+	sort.SliceStable(rules, func(i, j int) bool { return len(rules[i].Old) > len(rules[j].Old) })
+	return rules
+}
+
+// ReplacePaths returns text with each reference the rules cover rewritten.
+// A reference must end where the path does, so ~/.openclaw/media doesn't
+// rewrite ~/.openclaw/media-old.
+func ReplacePaths(text string, rules []PathRule) string {
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(text); {
+		r, ok := pathAt(text, i, rules)
+		if !ok {
+			i++
+			continue
+		}
+		b.WriteString(text[last:i])
+		b.WriteString(r.New)
+		i += len(r.Old)
+		last = i
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func pathAt(text string, i int, rules []PathRule) (PathRule, bool) {
+	for _, r := range rules {
+		if !strings.HasPrefix(text[i:], r.Old) {
+			continue
+		}
+		if endsPath(text, i+len(r.Old)) {
+			return r, true
+		}
+	}
+	return PathRule{}, false
+}
+
+// endsPath reports whether a path can end at text[end]. A full stop ends
+// it unless a name continues after it, as in ~/.openclaw.bak.
+func endsPath(text string, end int) bool {
+	if end == len(text) {
+		return true
+	}
+	if c := text[end]; c == '.' {
+		return end+1 == len(text) || !isNameChar(text[end+1])
+	}
+	return !isNameChar(text[end])
+}
+
+func isNameChar(c byte) bool {
+	return c == '-' || c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// IsMarkdown reports whether a file name is a markdown document
+func IsMarkdown(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".markdown", ".mdx":
+		return true
+	}
+	return false
+}
+
+// FindPaths searches the files under root that include accepts for
+// references to OpenClaw's locations
+func FindPaths(root string, rules []PathRule, include func(name string) bool) ([]PathMatch, error) {
+	var matches []PathMatch
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // unreadable entries are skipped, not fatal
+		}
+		if info.IsDir() {
+			switch info.Name() {
+			case ".git", "node_modules", "sessions":
+				if path != root {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || info.Size() > maxPathFileSize || !include(info.Name()) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || bytes.IndexByte(data, 0) >= 0 {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), maxPathFileSize)
+		for n := 1; scanner.Scan(); n++ {
+			line := scanner.Text()
+			if rewritten := ReplacePaths(line, rules); rewritten != line {
+				matches = append(matches, PathMatch{
+					File:      filepath.ToSlash(rel),
+					Line:      n,
+					Text:      strings.TrimSpace(line),
+					Rewritten: strings.TrimSpace(rewritten),
+				})
+			}
+		}
+		return nil
+	})
+	return matches, err
+}
+
+// PathFiles returns the distinct files of a list of matches, in order
+func PathFiles(matches []PathMatch) []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		if !seen[m.File] {
+			seen[m.File] = true
+			files = append(files, m.File)
+		}
+	}
+	return files
+}
+
+// RewritePaths rewrites the references in the given files under root,
+// keeping each file's mode. Returns the files changed, as absolute paths.
+func RewritePaths(root string, files []string, rules []PathRule) ([]string, error) {
+	return rewriteFiles(root, files, func(text string) string {
+		return ReplacePaths(text, rules)
+	})
+}
+
+// rewriteFiles replaces the contents of the given files under root with
+// what rewrite makes of them, keeping each file's mode
+func rewriteFiles(root string, files []string, rewrite func(string) string) ([]string, error) {
+	var changed []string
+	for _, rel := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		info, err := os.Stat(path)
+		if err != nil {
+			return changed, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return changed, err
+		}
+		updated := rewrite(string(data))
+		if updated == string(data) {
+			continue
+		}
+
+		if err := preserve.Keep(path); err != nil {
+			return changed, err
+		}
+		tmp := path + ".claw-migrate.tmp"
+		if err := os.WriteFile(tmp, []byte(updated), info.Mode().Perm()); err != nil {
+			return changed, err
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			os.Remove(tmp)
+			return changed, err
+		}
+		changed = append(changed, path)
+	}
+	return changed, nil
+}
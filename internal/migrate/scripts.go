@@ -0,0 +1,219 @@
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════
+// openclaw commands in workspace scripts
+// ════════════════════════════════════════════════════════════
+
+// ScriptsDir is where a workspace keeps its scripts
+const ScriptsDir = "scripts"
+
+// CommandMapping is an OpenClaw CLI command with a PicoClaw equivalent
+type CommandMapping struct {
+	OpenClaw string            // the words after openclaw, e.g. "cron list"
+	PicoClaw string            // the PicoClaw command replacing them
+	Flags    map[string]string // flags renamed on the way
+}
+
+// Commands maps OpenClaw CLI commands onto PicoClaw's. A command missing
+// here, or one the installed PicoClaw lacks, is left for a human.
+var Commands = []CommandMapping{
+	{OpenClaw: "gateway", PicoClaw: "gateway"},
+	{OpenClaw: "status", PicoClaw: "status"},
+	{OpenClaw: "onboard", PicoClaw: "onboard"},
+	{OpenClaw: "agent", PicoClaw: "agent", Flags: map[string]string{"--message": "-m", "--session-id": "-s"}},
+	{OpenClaw: "cron list", PicoClaw: "cron list"},
+	{OpenClaw: "cron add", PicoClaw: "cron add"},
+	{OpenClaw: "cron remove", PicoClaw: "cron remove"},
+	{OpenClaw: "cron rm", PicoClaw: "cron remove"},
+	{OpenClaw: "skills list", PicoClaw: "skills list"},
+	{OpenClaw: "skills install", PicoClaw: "skills install"},
+	{OpenClaw: "migrate", PicoClaw: "migrate"},
+	{OpenClaw: "--version", PicoClaw: "version"},
+	{OpenClaw: "version", PicoClaw: "version"},
+}
+
+// ScriptIssue is an openclaw command in a script that couldn't be rewritten
+type ScriptIssue struct {
+	File string // relative to the workspace
+	Line int    // 1-based
+	Text string // the whole line, trimmed
+}
+
+// ScriptFixer rewrites openclaw commands and OpenClaw paths in scripts
+type ScriptFixer struct {
+	Rules []PathRule
+	// Has reports whether the installed PicoClaw has a top-level command
+	Has func(command string) bool
+}
+
+// word is a shell word and the separators before it
+var word = regexp.MustCompile(`([;&|(\x60{}]*)\s*([^\s;&|()\x60{}]+)`)
+
+// wrappers run the word after them as a command
+var wrappers = map[string]bool{
+	"sudo": true, "exec": true, "nohup": true, "env": true, "command": true, "time": true, "nice": true,
+	"then": true, "do": true, "else": true, "if": true, "while": true, "until": true, "!": true,
+}
+
+// FixLine rewrites the OpenClaw paths and openclaw commands in a line of a
+// script. ok is false if an openclaw command is left with no equivalent.
+func (f ScriptFixer) FixLine(line string) (fixed string, ok bool) {
+	line = ReplacePaths(line, f.Rules)
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return line, true // comments and shebangs run no openclaw command
+	}
+
+	words := word.FindAllStringSubmatchIndex(line, -1)
+	sep := func(i int) string { return line[words[i][2]:words[i][3]] }
+	text := func(i int) string { return line[words[i][4]:words[i][5]] }
+
+	var b strings.Builder
+	last, ok := 0, true
+	commandPos := false
+	for i := 0; i < len(words); i++ {
+		if i == 0 || sep(i) != "" {
+			commandPos = true
+		}
+		name := strings.Trim(text(i), `"'`)
+		if !commandPos || !isOpenClaw(name) {
+			// Variable assignments and wrappers keep the next word in command position
+			commandPos = commandPos && (wrappers[text(i)] || strings.Contains(text(i), "="))
+			continue
+		}
+		commandPos = false
+
+		// The words of this command, up to the next separator
+		end := i + 1
+		for end < len(words) && sep(end) == "" {
+			end++
+		}
+		var args []string
+		for j := i + 1; j < end && len(args) < 2; j++ {
+			args = append(args, text(j))
+		}
+		m, n := f.mapping(args)
+		if m == nil && len(args) > 0 {
+			ok = false
+			i = end - 1
+			continue
+		}
+
+		b.WriteString(line[last:words[i][4]])
+		b.WriteString(strings.Replace(text(i), name, "picoclaw", 1))
+		last = words[i][5]
+		if m == nil {
+			continue // bare openclaw: PicoClaw's usage instead
+		}
+		b.WriteString(" " + m.PicoClaw)
+		if n > 0 {
+			last = words[i+n][5]
+		}
+		for j := i + n + 1; j < end; j++ {
+			flag, value, hasValue := strings.Cut(text(j), "=")
+			if renamed, found := m.Flags[flag]; found {
+				b.WriteString(line[last:words[j][4]])
+				switch {
+				case hasValue && strings.HasPrefix(renamed, "--"):
+					renamed += "=" + value
+				case hasValue:
+					renamed += " " + value // short flags take their value separately
+				}
+				b.WriteString(renamed)
+				last = words[j][5]
+			}
+		}
+		i = end - 1
+	}
+	b.WriteString(line[last:])
+	return b.String(), ok
+}
+
+// mapping finds the mapping for the words after openclaw, and how many of
+// them it covers
+func (f ScriptFixer) mapping(args []string) (*CommandMapping, int) {
+	for n := len(args); n >= 1; n-- {
+		key := strings.Join(args[:n], " ")
+		for i := range Commands {
+			m := &Commands[i]
+			if m.OpenClaw == key && f.has(strings.Fields(m.PicoClaw)[0]) {
+				return m, n
+			}
+		}
+	}
+	return nil, 0
+}
+
+func (f ScriptFixer) has(command string) bool {
+	return f.Has == nil || f.Has(command)
+}
+
+// isOpenClaw reports whether a word runs openclaw, by name or by path
+func isOpenClaw(word string) bool {
+	return word == "openclaw" || strings.HasSuffix(word, "/openclaw")
+}
+
+// FixScripts searches the scripts under the workspace's scripts directory
+// for OpenClaw paths and commands. It returns the lines it would rewrite
+// and the lines with openclaw commands it can't.
+func (f ScriptFixer) FixScripts(workspace string) ([]PathMatch, []ScriptIssue, error) {
+	var matches []PathMatch
+	var issues []ScriptIssue
+	root := filepath.Join(workspace, ScriptsDir)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // no scripts directory, or unreadable entries
+		}
+		if info.IsDir() {
+			if path != root && (info.Name() == ".git" || info.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || info.Size() > maxPathFileSize {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || bytes.IndexByte(data, 0) >= 0 {
+			return nil
+		}
+		rel, _ := filepath.Rel(workspace, path)
+		rel = filepath.ToSlash(rel)
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), maxPathFileSize)
+		for n := 1; scanner.Scan(); n++ {
+			line := scanner.Text()
+			fixed, ok := f.FixLine(line)
+			if fixed != line {
+				matches = append(matches, PathMatch{File: rel, Line: n, Text: strings.TrimSpace(line), Rewritten: strings.TrimSpace(fixed)})
+			}
+			if !ok {
+				issues = append(issues, ScriptIssue{File: rel, Line: n, Text: strings.TrimSpace(line)})
+			}
+		}
+		return nil
+	})
+	return matches, issues, err
+}
+
+// RewriteScripts applies FixLine to the given files under the workspace,
+// keeping each file's mode. Returns the files changed, as absolute paths.
+func (f ScriptFixer) RewriteScripts(workspace string, files []string) ([]string, error) {
+	return rewriteFiles(workspace, files, func(text string) string {
+		lines := strings.SplitAfter(text, "\n")
+		for i, line := range lines {
+			body := strings.TrimSuffix(line, "\n")
+			fixed, _ := f.FixLine(body)
+			lines[i] = fixed + line[len(body):]
+		}
+		return strings.Join(lines, "")
+	})
+}
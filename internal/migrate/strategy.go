@@ -0,0 +1,165 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Copy strategies for Options.Strategy
+const (
+	StrategyAuto    = "auto"    // pick one from the source and destination filesystems
+	StrategyReflink = "reflink" // same filesystem: clone where possible, bytes otherwise
+	StrategySSD     = "ssd"     // solid-state: several files at once
+	StrategyHDD     = "hdd"     // spinning disk: one file at a time, large buffers
+	StrategyNetwork = "network" // NFS/SMB: many files at once to hide latency
+)
+
+// Strategy is how files are copied
+type Strategy struct {
+	Name       string
+	Clone      bool // try a copy-on-write clone before copying bytes
+	Workers    int  // files copied at once
+	BufferSize int  // bytes per read/write when copying
+}
+
+// strategies are the named strategies; Workers is filled in by NewStrategy
+var strategies = map[string]Strategy{
+	StrategyReflink: {Name: StrategyReflink, Clone: true, BufferSize: 1 << 20},
+	StrategySSD:     {Name: StrategySSD, BufferSize: 1 << 20},
+	StrategyHDD:     {Name: StrategyHDD, Workers: 1, BufferSize: 4 << 20},
+	StrategyNetwork: {Name: StrategyNetwork, Workers: 16, BufferSize: 1 << 20},
+}
+
+// StrategyNames lists the names --copy-strategy accepts
+func StrategyNames() []string {
+	names := []string{StrategyAuto}
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// NewStrategy returns a named strategy
+func NewStrategy(name string) (Strategy, error) {
+	s, ok := strategies[name]
+	if !ok {
+		return s, fmt.Errorf("unknown copy strategy %q (want one of: %s)", name, strings.Join(StrategyNames(), ", "))
+	}
+	if s.Workers == 0 {
+		s.Workers = min(max(runtime.NumCPU(), 4), 16)
+	}
+	return s, nil
+}
+
+// media is what can be told about the filesystem holding a path
+type media struct {
+	dev        uint64 // device number, to tell whether two paths share a filesystem
+	network    bool
+	rotational bool
+}
+
+// ChooseStrategy picks a strategy for copying src into dst, and says why:
+// network mounts get many workers, a spinning disk on either side gets one,
+// and a shared local filesystem tries cloning first
+func ChooseStrategy(src, dst string) (Strategy, string) {
+	from, to := probeMedia(src), probeMedia(existingParent(dst))
+	var name, reason string
+	switch {
+	case from.network || to.network:
+		name, reason = StrategyNetwork, "network filesystem"
+	case from.rotational || to.rotational:
+		name, reason = StrategyHDD, "spinning disk"
+	case from.dev != 0 && from.dev == to.dev:
+		name, reason = StrategyReflink, "same filesystem"
+	default:
+		name, reason = StrategySSD, "different local filesystems"
+	}
+	s, _ := NewStrategy(name)
+	if s.Name == StrategyHDD && from.dev != 0 && from.dev == to.dev {
+		s.Clone = true // copy-on-write works on spinning disks too
+	}
+	return s, reason
+}
+
+// existingParent returns path or its closest ancestor that exists, since
+// the destination usually hasn't been created yet
+func existingParent(path string) string {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
+
+// strategy returns the options' strategy, or the one-at-a-time default
+func (o Options) strategy() Strategy {
+	if o.Strategy.Name == "" {
+		return Strategy{Name: "default", Clone: true, Workers: 1}
+	}
+	return o.Strategy
+}
+
+// copyJob is one file for copyFiles
+type copyJob struct {
+	src, dst, name string
+}
+
+// copyFiles copies jobs with the strategy's number of workers, recording
+// results in job order. Workers stop taking new files once the error
+// budget is spent; files already under way still finish.
+func copyFiles(jobs []copyJob, opts Options, result *Result) {
+	workers := opts.strategy().Workers
+	if workers <= 1 || len(jobs) <= 1 {
+		for _, j := range jobs {
+			result.add(migrateFile(j.src, j.dst, j.name, opts), opts)
+			if result.Aborted {
+				return
+			}
+		}
+		return
+	}
+
+	results := make([]*FileResult, len(jobs))
+	var next, errs atomic.Int64
+	var stop atomic.Bool
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(jobs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				i := int(next.Add(1) - 1)
+				if i >= len(jobs) {
+					return
+				}
+				fr := migrateFile(jobs[i].src, jobs[i].dst, jobs[i].name, opts)
+				results[i] = &fr
+				if fr.Error != nil {
+					n := errs.Add(1) + int64(result.Errors)
+					if ClassifyError(fr.Error) == CauseNoSpace || (opts.MaxErrors > 0 && n >= int64(opts.MaxErrors)) {
+						stop.Store(true)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, fr := range results {
+		if fr != nil {
+			result.add(*fr, opts)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CatalogDate is when the prices below were last checked against the
+// providers' published pricing
+const CatalogDate = "2026-09"
+
+// Info is a model's limits and list price
+type Info struct {
+	Context   int     // context window, tokens
+	MaxOutput int     // longest response, tokens
+	Input     float64 // USD per million input tokens
+	Output    float64 // USD per million output tokens
+}
+
+// catalog is keyed by canonical name (see canonical)
+var catalog = map[string]Info{
+	"claude-opus-3":     {200000, 4096, 15, 75},
+	"claude-sonnet-3-5": {200000, 8192, 3, 15},
+	"claude-haiku-3":    {200000, 4096, 0.25, 1.25},
+	"claude-haiku-3-5":  {200000, 8192, 0.80, 4},
+	"claude-sonnet-4":   {200000, 64000, 3, 15},
+	"claude-opus-4":     {200000, 32000, 15, 75},
+	"claude-opus-4-1":   {200000, 32000, 15, 75},
+	"claude-sonnet-4-5": {200000, 64000, 3, 15},
+	"claude-haiku-4-5":  {200000, 64000, 1, 5},
+	"claude-opus-4-5":   {200000, 64000, 5, 25},
+	"claude-sonnet-4-6": {200000, 64000, 3, 15},
+	"claude-opus-4-6":   {200000, 128000, 5, 25},
+	"gpt-3.5-turbo":     {16385, 4096, 0.50, 1.50},
+	"gpt-4":             {8192, 8192, 30, 60},
+	"gpt-4-turbo":       {128000, 4096, 10, 30},
+	"gpt-4o":            {128000, 16384, 2.50, 10},
+	"gpt-4.1":           {1047576, 32768, 2, 8},
+	"gpt-5":             {400000, 128000, 1.25, 10},
+	"gpt-5.1":           {400000, 128000, 1.25, 10},
+	"gpt-5.2":           {400000, 128000, 1.75, 14},
+}
+
+// snapshotSuffix is a dated or -latest suffix, which doesn't change the
+// model's price or limits
+var snapshotSuffix = regexp.MustCompile(`(-\d{8}|-\d{4}-\d{2}-\d{2}|-\d{4}|-latest|@\d{8})$`)
+
+// claudeOldStyle is claude-<version>-<family>, as Claude 3 models are named
+var claudeOldStyle = regexp.MustCompile(`^claude-(\d+(?:[.-]\d)?)-(sonnet|opus|haiku)$`)
+
+// canonical reduces a model name to its catalog key: no vendor prefix or
+// snapshot date, and Claude names as claude-<family>-<version> with dashes
+func canonical(model string) string {
+	name := strings.ToLower(model)
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = snapshotSuffix.ReplaceAllString(name, "")
+	if !strings.HasPrefix(name, "claude-") {
+		return name
+	}
+	if m := claudeOldStyle.FindStringSubmatch(name); m != nil {
+		name = "claude-" + m[2] + "-" + m[1]
+	}
+	return strings.ReplaceAll(name, ".", "-")
+}
+
+// Lookup returns a model's limits and price from the bundled catalog
+func Lookup(model string) (Info, bool) {
+	info, ok := catalog[canonical(model)]
+	return info, ok
+}
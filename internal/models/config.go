@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// modelKeys are where a model object ({"primary": ..., "fallbacks": [...]})
+// names its models
+var modelKeys = []string{"primary", "name", "model", "default", "fallbacks"}
+
+// Change is one place ReplaceInConfig pointed at another model. Old is ""
+// where it set a default model the config didn't have, New where
+// RevertInConfig removed it again.
+type Change struct {
+	Path string `json:"path"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Old == "":
+		return fmt.Sprintf("%s: (not set) → %s", c.Path, c.New)
+	case c.New == "":
+		return fmt.Sprintf("%s: %s → (not set)", c.Path, c.Old)
+	}
+	return fmt.Sprintf("%s: %s → %s", c.Path, c.Old, c.New)
+}
+
+// ReplaceInConfig points every reference to oldModel in a PicoClaw config
+// at newModel: the default model (string or object form, under agents or
+// the older agent key) and its fallbacks, per-agent models in agents.list,
+// and model_list entries, so a default model that names a model_list entry
+// follows the entry's update without being renamed. If the config sets no
+// default model, agents.defaults.model is created.
+func ReplaceInConfig(cfg map[string]interface{}, oldModel, newModel string) []Change {
+	return replaceIn(cfg, oldModel, newModel, nil)
+}
+
+// RevertInConfig undoes changes ReplaceInConfig made, at the paths it made
+// them and only where they still name the model it wrote, so anything
+// changed by hand since is left alone. A default model it created is
+// removed again.
+func RevertInConfig(cfg map[string]interface{}, changes []Change) []Change {
+	var reverted []Change
+	var order [][2]string
+	byModel := make(map[[2]string]map[string]bool)
+	for _, c := range changes {
+		if c.Old == "" {
+			agents, _ := cfg["agents"].(map[string]interface{})
+			if defaults, ok := agents["defaults"].(map[string]interface{}); ok && c.Path == "agents.defaults.model" && defaults["model"] == c.New {
+				delete(defaults, "model")
+				reverted = append(reverted, Change{Path: c.Path, Old: c.New})
+			}
+			continue
+		}
+		key := [2]string{c.New, c.Old}
+		if byModel[key] == nil {
+			byModel[key] = make(map[string]bool)
+			order = append(order, key)
+		}
+		byModel[key][c.Path] = true
+	}
+	for _, key := range order {
+		reverted = append(reverted, replaceIn(cfg, key[0], key[1], byModel[key])...)
+	}
+	return reverted
+}
+
+// replaceIn does ReplaceInConfig, only at the given paths if there are any
+func replaceIn(cfg map[string]interface{}, oldModel, newModel string, only map[string]bool) []Change {
+	var changes []Change
+	change := func(path string) bool {
+		if only != nil && !only[path] {
+			return false
+		}
+		changes = append(changes, Change{path, oldModel, newModel})
+		return true
+	}
+
+	list, _ := cfg["model_list"].([]interface{})
+	for i, item := range list {
+		if entry, ok := item.(map[string]interface{}); ok && entry["model"] == oldModel && change(fmt.Sprintf("model_list[%d].model", i)) {
+			entry["model"] = newModel
+		}
+	}
+
+	// Both shapes: agents.defaults.model (PicoClaw) and agent.model (older configs)
+	found := false
+	if agent, ok := cfg["agent"].(map[string]interface{}); ok {
+		found = replaceModelField(agent, "agent.model", oldModel, newModel, change) || found
+	}
+	agents, _ := cfg["agents"].(map[string]interface{})
+	if defaults, ok := agents["defaults"].(map[string]interface{}); ok {
+		found = replaceModelField(defaults, "agents.defaults.model", oldModel, newModel, change) || found
+	}
+	if named, ok := agents["list"].([]interface{}); ok {
+		for i, item := range named {
+			if a, ok := item.(map[string]interface{}); ok {
+				replaceModelField(a, fmt.Sprintf("agents.list[%d].model", i), oldModel, newModel, change)
+			}
+		}
+	}
+
+	if !found && only == nil {
+		if agents == nil {
+			agents = make(map[string]interface{})
+			cfg["agents"] = agents
+		}
+		defaults, ok := agents["defaults"].(map[string]interface{})
+		if !ok {
+			defaults = make(map[string]interface{})
+			agents["defaults"] = defaults
+		}
+		defaults["model"] = newModel
+		changes = append(changes, Change{Path: "agents.defaults.model", New: newModel})
+	}
+	return changes
+}
+
+// replaceModelField rewrites m["model"] wherever it refers to oldModel and
+// reports whether m sets a model at all. Any other value — a model_name
+// from model_list, or a model chosen since — is left as it is.
+func replaceModelField(m map[string]interface{}, path, oldModel, newModel string, change func(string) bool) bool {
+	// PicoClaw keeps the fallback chain beside the model rather than in it
+	if fallbacks, ok := m["model_fallbacks"].([]interface{}); ok {
+		parent := strings.TrimSuffix(path, ".model")
+		for i, item := range fallbacks {
+			if item == oldModel && change(fmt.Sprintf("%s.model_fallbacks[%d]", parent, i)) {
+				fallbacks[i] = newModel
+			}
+		}
+	}
+	switch v := m["model"].(type) {
+	case string:
+		if v == oldModel && change(path) {
+			m["model"] = newModel
+		}
+		return v != ""
+	case map[string]interface{}:
+		for _, key := range modelKeys {
+			switch field := v[key].(type) {
+			case string:
+				if field == oldModel && change(path+"."+key) {
+					v[key] = newModel
+				}
+			case []interface{}:
+				for i, item := range field {
+					if item == oldModel && change(fmt.Sprintf("%s.%s[%d]", path, key, i)) {
+						field[i] = newModel
+					}
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
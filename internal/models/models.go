@@ -0,0 +1,186 @@
+// Package models finds outdated model names wherever they are written
+// down — skills, cron job definitions, agent frontmatter — not just the
+// config's default model, and rewrites them
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/preserve"
+)
+
+// maxFileSize skips files too large to be hand-written text
+const maxFileSize = 1 << 20
+
+// skipDirs are never searched
+var skipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"sessions":     true, // conversation logs: history, not configuration
+}
+
+// Match is one outdated model name in a file
+type Match struct {
+	File        string // relative to the scanned root
+	Line        int    // 1-based
+	Text        string // the whole line, trimmed
+	Model       string
+	Replacement string
+}
+
+// token is a run of characters a model name can contain, vendor prefixes
+// included
+var token = regexp.MustCompile(`[A-Za-z0-9][A-Za-z0-9._/@-]*`)
+
+// Scanner finds outdated models in text, as judged by Upgrade
+type Scanner struct {
+	Keep func(model, upgrade string) bool // outdated models to leave alone, nil for none
+}
+
+// NewScanner returns a Scanner
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// find returns the byte ranges of outdated model names in a line. Each
+// whole name is judged, so openai/gpt-4o isn't read as openai/gpt-4.
+func (s *Scanner) find(line string) [][]int {
+	var found [][]int
+	for _, loc := range token.FindAllStringIndex(line, -1) {
+		// A trailing full stop or dash ends a sentence, not a version
+		for loc[1] > loc[0] && strings.ContainsRune(".-/", rune(line[loc[1]-1])) {
+			loc[1]--
+		}
+		model := line[loc[0]:loc[1]]
+		if upgrade, ok := Upgrade(model); ok && (s.Keep == nil || !s.Keep(model, upgrade)) {
+			found = append(found, loc)
+		}
+	}
+	return found
+}
+
+// Scan searches the text files under root for outdated models
+func (s *Scanner) Scan(root string) ([]Match, error) {
+	var matches []Match
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // unreadable entries are skipped, not fatal
+		}
+		if info.IsDir() {
+			if path != root && skipDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || info.Size() > maxFileSize {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		found, err := s.scanFile(path, filepath.ToSlash(rel))
+		if err != nil {
+			return nil
+		}
+		matches = append(matches, found...)
+		return nil
+	})
+	return matches, err
+}
+
+func (s *Scanner) scanFile(path, rel string) ([]Match, error) {
+	data, err := os.ReadFile(path)
+	if err != nil || bytes.IndexByte(data, 0) >= 0 {
+		return nil, err // binary files hold no model names worth rewriting
+	}
+	var matches []Match
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		for _, loc := range s.find(line) {
+			model := line[loc[0]:loc[1]]
+			matches = append(matches, Match{
+				File:        rel,
+				Line:        n,
+				Text:        strings.TrimSpace(line),
+				Model:       model,
+				Replacement: replacement(model),
+			})
+		}
+	}
+	return matches, scanner.Err()
+}
+
+// Replace returns text with every outdated model replaced
+func (s *Scanner) Replace(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range s.find(text) {
+		b.WriteString(text[last:loc[0]])
+		b.WriteString(replacement(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// Rewrite replaces the outdated models in the given files under root,
+// keeping each file's mode. Returns the files changed.
+func (s *Scanner) Rewrite(root string, files []string) ([]string, error) {
+	var changed []string
+	for _, rel := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		info, err := os.Stat(path)
+		if err != nil {
+			return changed, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return changed, err
+		}
+		lines := strings.SplitAfter(string(data), "\n")
+		for i, line := range lines {
+			lines[i] = s.Replace(line)
+		}
+		updated := strings.Join(lines, "")
+		if updated == string(data) {
+			continue
+		}
+
+		if err := preserve.Keep(path); err != nil {
+			return changed, err
+		}
+		tmp := path + ".claw-migrate.tmp"
+		if err := os.WriteFile(tmp, []byte(updated), info.Mode().Perm()); err != nil {
+			return changed, err
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			os.Remove(tmp)
+			return changed, err
+		}
+		changed = append(changed, rel)
+	}
+	return changed, nil
+}
+
+func replacement(model string) string {
+	next, _ := Upgrade(model)
+	return next
+}
+
+// Files returns the distinct files of a list of matches, in order
+func Files(matches []Match) []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		if !seen[m.File] {
+			seen[m.File] = true
+			files = append(files, m.File)
+		}
+	}
+	return files
+}
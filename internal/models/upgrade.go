@@ -0,0 +1,102 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Known maps outdated models to their recommended replacements exactly.
+// Anything not listed is matched by family and version (see Upgrade).
+var Known = map[string]string{
+	"anthropic/claude-sonnet-4-5":            "anthropic/claude-sonnet-4-6",
+	"anthropic/claude-3-5-sonnet":            "anthropic/claude-sonnet-4-6",
+	"anthropic/claude-3-opus":                "anthropic/claude-opus-4-6",
+	"openai/gpt-4":                           "openai/gpt-5.2",
+	"openai/gpt-4-turbo":                     "openai/gpt-5.2",
+	"openai/gpt-4o":                          "openai/gpt-5.2",
+	"openrouter/anthropic/claude-sonnet-4-5": "openrouter/anthropic/claude-sonnet-4-6",
+	"openrouter/anthropic/claude-3-5-sonnet": "openrouter/anthropic/claude-sonnet-4-6",
+}
+
+// version is a model family's major.minor
+type version struct{ major, minor int }
+
+func (v version) less(w version) bool {
+	return v.major < w.major || v.major == w.major && v.minor < w.minor
+}
+
+// current is the newest release of each family Upgrade knows
+var current = map[string]version{
+	"claude-sonnet": {4, 6},
+	"claude-opus":   {4, 6},
+	"claude-haiku":  {4, 5},
+	"gpt":           {5, 2},
+}
+
+// Name patterns, after any vendor prefix. Snapshot dates and -latest are
+// allowed; other variants (gpt-4o-mini, say) have no clear successor and
+// don't match.
+var (
+	// claude-3-5-sonnet-20241022, claude-3-opus
+	claudeOld = regexp.MustCompile(`^claude-(\d+)(?:([.-])(\d))?-(sonnet|opus|haiku)(-\d{8}|-latest|@\d{8})?$`)
+	// claude-sonnet-4-5, claude-opus-4.1, claude-sonnet-4-20250514
+	claudeNew = regexp.MustCompile(`^claude-(sonnet|opus|haiku)-(\d+)(?:([.-])(\d))?(-\d{8}|-latest|@\d{8})?$`)
+	// gpt-4, gpt-4o, gpt-4-turbo, gpt-3.5-turbo, gpt-4-0613
+	gptName = regexp.MustCompile(`^gpt-(\d+)(?:\.(\d+))?(o|-turbo|-turbo-preview|-32k)?(-\d{4}-\d{2}-\d{2}|-\d{4}|-preview)?$`)
+)
+
+// Upgrade returns the recommended replacement for an outdated model. Known
+// is consulted first; otherwise the name is read as a family and version
+// under any vendor prefix (anthropic/, openrouter/anthropic/ or none), so
+// snapshot names like claude-3-5-sonnet-20241022 and prefixless names are
+// caught too. The replacement keeps the prefix and the version separator.
+func Upgrade(model string) (string, bool) {
+	if next, ok := Known[model]; ok {
+		return next, true
+	}
+
+	prefix, name := "", model
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		prefix, name = model[:i+1], model[i+1:]
+	}
+
+	family, v, sep, ok := parse(name)
+	if !ok || !v.less(current[family]) {
+		return "", false
+	}
+	latest := current[family]
+	if family == "gpt" {
+		return fmt.Sprintf("%sgpt-%d.%d", prefix, latest.major, latest.minor), true
+	}
+	return fmt.Sprintf("%s%s-%d%s%d", prefix, family, latest.major, sep, latest.minor), true
+}
+
+// parse reads a model name as a family of current, its version and the
+// separator it writes versions with ("-" or ".")
+func parse(name string) (family string, v version, sep string, ok bool) {
+	name = strings.ToLower(name)
+	if m := claudeOld.FindStringSubmatch(name); m != nil {
+		return "claude-" + m[4], version{atoi(m[1]), atoi(m[3])}, orDash(m[2]), true
+	}
+	if m := claudeNew.FindStringSubmatch(name); m != nil {
+		return "claude-" + m[1], version{atoi(m[2]), atoi(m[4])}, orDash(m[3]), true
+	}
+	if m := gptName.FindStringSubmatch(name); m != nil {
+		return "gpt", version{atoi(m[1]), atoi(m[2])}, ".", true
+	}
+	return "", version{}, "", false
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func orDash(sep string) string {
+	if sep == "" {
+		return "-"
+	}
+	return sep
+}
@@ -0,0 +1,167 @@
+// Package nativehost finds the native messaging manifests that let a
+// browser extension start a local program, and points them at another
+// program or removes them
+package nativehost
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/preserve"
+)
+
+// Host is a native messaging manifest
+type Host struct {
+	Browser  string
+	Manifest string // the manifest file
+	Name     string // what the extension connects to
+	Path     string // the program the browser starts
+}
+
+// Runs reports whether the browser would start a program mentioning name.
+// A host keeps its name when it is pointed elsewhere, so the name alone
+// doesn't say which program it runs.
+func (h Host) Runs(name string) bool {
+	return strings.Contains(strings.ToLower(h.Path), name)
+}
+
+type manifestDir struct {
+	browser string
+	dir     string
+}
+
+// dirs lists where each browser looks for manifests, per user and system-wide
+func dirs(home string) []manifestDir {
+	switch runtime.GOOS {
+	case "darwin":
+		support := filepath.Join(home, "Library", "Application Support")
+		return []manifestDir{
+			{"Chrome", filepath.Join(support, "Google", "Chrome", "NativeMessagingHosts")},
+			{"Chrome Beta", filepath.Join(support, "Google", "Chrome Beta", "NativeMessagingHosts")},
+			{"Chromium", filepath.Join(support, "Chromium", "NativeMessagingHosts")},
+			{"Brave", filepath.Join(support, "BraveSoftware", "Brave-Browser", "NativeMessagingHosts")},
+			{"Edge", filepath.Join(support, "Microsoft Edge", "NativeMessagingHosts")},
+			{"Vivaldi", filepath.Join(support, "Vivaldi", "NativeMessagingHosts")},
+			{"Arc", filepath.Join(support, "Arc", "User Data", "NativeMessagingHosts")},
+			{"Firefox", filepath.Join(support, "Mozilla", "NativeMessagingHosts")},
+			{"Chrome", "/Library/Google/Chrome/NativeMessagingHosts"},
+			{"Chromium", "/Library/Application Support/Chromium/NativeMessagingHosts"},
+			{"Firefox", "/Library/Application Support/Mozilla/NativeMessagingHosts"},
+		}
+	case "linux":
+		config := filepath.Join(home, ".config")
+		return []manifestDir{
+			{"Chrome", filepath.Join(config, "google-chrome", "NativeMessagingHosts")},
+			{"Chrome Beta", filepath.Join(config, "google-chrome-beta", "NativeMessagingHosts")},
+			{"Chromium", filepath.Join(config, "chromium", "NativeMessagingHosts")},
+			{"Brave", filepath.Join(config, "BraveSoftware", "Brave-Browser", "NativeMessagingHosts")},
+			{"Edge", filepath.Join(config, "microsoft-edge", "NativeMessagingHosts")},
+			{"Vivaldi", filepath.Join(config, "vivaldi", "NativeMessagingHosts")},
+			{"Firefox", filepath.Join(home, ".mozilla", "native-messaging-hosts")},
+			{"Chrome", "/etc/opt/chrome/native-messaging-hosts"},
+			{"Chromium", "/etc/chromium/native-messaging-hosts"},
+			{"Firefox", "/usr/lib/mozilla/native-messaging-hosts"},
+			{"Firefox", "/usr/lib64/mozilla/native-messaging-hosts"},
+		}
+	}
+	return nil
+}
+
+// Find lists the manifests whose name or program mentions name
+func Find(name string) []Host {
+	home, _ := os.UserHomeDir()
+	var found []Host
+	for _, d := range dirs(home) {
+		entries, err := os.ReadDir(d.dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+				continue
+			}
+			manifest := filepath.Join(d.dir, e.Name())
+			m, err := read(manifest)
+			if err != nil {
+				continue
+			}
+			h := Host{Browser: d.browser, Manifest: manifest}
+			h.Name, _ = m["name"].(string)
+			h.Path, _ = m["path"].(string)
+			if strings.Contains(strings.ToLower(h.Name), name) || h.Runs(name) {
+				found = append(found, h)
+			}
+		}
+	}
+	return found
+}
+
+// Repoint makes the manifest start program instead, keeping its name and
+// the extensions allowed to connect
+func Repoint(h Host, program string) error {
+	m, err := read(h.Manifest)
+	if err != nil {
+		return err
+	}
+	m["path"] = program
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(h.Manifest)
+	if err != nil {
+		return err
+	}
+	if err := preserve.Keep(h.Manifest); err != nil {
+		return err
+	}
+	tmp := h.Manifest + ".claw-migrate.tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), info.Mode().Perm()); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, h.Manifest); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Remove deletes the manifest, so the browser stops trying to start its program
+func Remove(h Host) error {
+	return preserve.Remove(h.Manifest)
+}
+
+// Wrapper writes a script at path that runs binary with args followed by
+// whatever the browser passes. Manifests can't give arguments, so a host
+// that is a subcommand needs one.
+func Wrapper(path, binary string, args ...string) error {
+	quoted := []string{shellQuote(binary)}
+	for _, a := range args {
+		quoted = append(quoted, shellQuote(a))
+	}
+	script := fmt.Sprintf("#!/bin/sh\n# Native messaging host (written by claw-migrate)\nexec %s \"$@\"\n", strings.Join(quoted, " "))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(script), 0755)
+}
+
+func read(manifest string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(manifest)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", manifest, err)
+	}
+	return m, nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,142 @@
+package nix
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/secrets"
+)
+
+// mergeProgram overlays every value in the secrets file onto the config by
+// path; arrays line up by position, matching secrets.Split
+const mergeProgram = `reduce ($s[0] | paths(scalars)) as $p (.; setpath($p; $s[0] | getpath($p)))`
+
+// module is a home-manager module. %[1]s is the settings attrset, %[2]s
+// the picoclaw binary, %[3]s secrets.PatchFile and %[4]s mergeProgram.
+// The credentials are kept out of the module, and so out of the
+// world-readable Nix store, and merged in when the service starts.
+const module = `# Generated by claw-migrate: PicoClaw's config and user service for
+# home-manager. Add it to your configuration with
+#
+#   imports = [ ./picoclaw.nix ];
+#
+# API keys are not in this file. They are read from ~/%[3]s
+# (mode 0600) when the service starts, so they never reach the Nix store.
+{ config, lib, pkgs, ... }:
+
+let
+  home = config.home.homeDirectory;
+
+  # Point this at a package instead if you have one, e.g. "${pkgs.picoclaw}/bin/picoclaw"
+  picoclaw = %[2]s;
+
+  settings = %[1]s;
+
+  configFile = pkgs.writeText "picoclaw-config.json" (builtins.toJSON settings);
+
+  writeConfig = pkgs.writeShellScript "picoclaw-write-config" ''
+    set -eu
+    umask 077
+    mkdir -p "${home}/.picoclaw"
+    ${pkgs.jq}/bin/jq --slurpfile s "${home}/%[3]s" \
+      '%[4]s' \
+      ${configFile} > "${home}/.picoclaw/config.json"
+  '';
+in
+{
+  systemd.user.services.picoclaw = {
+    Unit = {
+      Description = "PicoClaw AI assistant";
+      After = [ "network-online.target" ];
+    };
+    Service = {
+      ExecStartPre = "${writeConfig}";
+      ExecStart = "${picoclaw} gateway";
+      Restart = "on-failure";
+      RestartSec = 10;
+    };
+    Install.WantedBy = [ "default.target" ];
+  };
+}
+`
+
+// Module renders the home-manager module for a credential-free config.
+// binary is the absolute path of the picoclaw executable.
+func Module(settings map[string]interface{}, binary string) string {
+	return fmt.Sprintf(module, Value(settings, 1), String(binary), secrets.PatchFile, mergeProgram)
+}
+
+// identifier matches attribute names that don't need quoting
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_'-]*$`)
+
+// reserved words can't be bare attribute names
+var reserved = map[string]bool{
+	"if": true, "then": true, "else": true, "assert": true, "with": true,
+	"let": true, "in": true, "rec": true, "inherit": true, "or": true,
+}
+
+// Value renders a decoded JSON value as a Nix expression, indented for
+// the given nesting level
+func Value(v interface{}, level int) string {
+	pad := strings.Repeat("  ", level)
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(t)
+	case string:
+		return String(t)
+	case []interface{}:
+		if len(t) == 0 {
+			return "[ ]"
+		}
+		var b strings.Builder
+		b.WriteString("[\n")
+		for _, el := range t {
+			item := Value(el, level+1)
+			if strings.HasPrefix(item, "-") {
+				item = "(" + item + ")" // or it reads as subtraction from the previous element
+			}
+			b.WriteString(pad + "  " + item + "\n")
+		}
+		b.WriteString(pad + "]")
+		return b.String()
+	case map[string]interface{}:
+		if len(t) == 0 {
+			return "{ }"
+		}
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, k := range keys {
+			b.WriteString(pad + "  " + attrName(k) + " = " + Value(t[k], level+1) + ";\n")
+		}
+		b.WriteString(pad + "}")
+		return b.String()
+	}
+	return String(fmt.Sprint(v))
+}
+
+// String quotes s as a Nix string literal
+func String(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "${", `\${`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func attrName(k string) string {
+	if identifier.MatchString(k) && !reserved[k] {
+		return k
+	}
+	return String(k)
+}
@@ -0,0 +1,68 @@
+// Package notify tells someone who isn't watching the terminal: a desktop
+// notification on this machine, or a JSON message posted to a webhook
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// Desktop shows a notification with osascript on macOS or notify-send on
+// Linux. It fails where neither is available, such as over SSH.
+func Desktop(title, body string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", quote(body), quote(title))
+		return exec.Command("osascript", "-e", script).Run()
+	case "linux":
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return fmt.Errorf("notify-send is not installed")
+		}
+		return exec.Command("notify-send", "--app-name=claw-migrate", title, body).Run()
+	}
+	return fmt.Errorf("desktop notifications aren't supported on %s", runtime.GOOS)
+}
+
+// quote makes an AppleScript string literal
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// Post sends payload to url as JSON. Payloads carry a "text" field, so
+// Slack-compatible incoming webhooks show them as they are.
+func Post(url string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+	return nil
+}
+
+// URL returns where to post: flag (--notify-url) if given, then
+// CLAW_MIGRATE_NOTIFY_URL, then the notify_url setting. Empty means nowhere.
+func URL(flag, configured string) string {
+	if flag != "" {
+		return flag
+	}
+	if env := os.Getenv("CLAW_MIGRATE_NOTIFY_URL"); env != "" {
+		return env
+	}
+	return configured
+}
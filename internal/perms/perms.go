@@ -0,0 +1,77 @@
+package perms
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Change describes a permission fix made (or proposed) by Audit
+type Change struct {
+	Path   string
+	Old    fs.FileMode
+	New    fs.FileMode
+	Reason string
+}
+
+// secretFiles must only be readable by the owner
+var secretFiles = map[string]bool{
+	"config.json":     true,
+	"config.json.bak": true,
+	"auth.json":       true,
+	".env":            true,
+}
+
+// Audit walks a PicoClaw home and tightens overly permissive modes:
+// secrets become 0600 (0700 for credential directories) and nothing stays
+// group- or world-writable. With fix false it only reports what it would change.
+func Audit(root string, fix bool) []Change {
+	var changes []Change
+
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		// Don't follow or chmod symlinks; their targets are audited separately
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		old := info.Mode().Perm()
+		want, reason := policy(root, path, d.IsDir(), old)
+		if want == old {
+			return nil
+		}
+
+		if fix {
+			if err := os.Chmod(path, want); err != nil {
+				return nil
+			}
+		}
+		changes = append(changes, Change{Path: path, Old: old, New: want, Reason: reason})
+		return nil
+	})
+
+	return changes
+}
+
+// policy returns the mode a path should have and why it differs
+func policy(root, path string, isDir bool, mode fs.FileMode) (fs.FileMode, string) {
+	rel, _ := filepath.Rel(root, path)
+	inCredentials := rel == "credentials" || strings.HasPrefix(rel, "credentials"+string(filepath.Separator))
+
+	switch {
+	case isDir && inCredentials:
+		return 0700, "credentials directory"
+	case !isDir && (inCredentials || secretFiles[filepath.Base(path)]):
+		return 0600, "contains secrets"
+	case mode&0022 != 0:
+		return mode &^ 0022, "group/world-writable"
+	}
+	return mode, ""
+}
@@ -0,0 +1,159 @@
+// Package plan saves what a dry run would do as a file, so the migration
+// can be reviewed and approved first and then carried out by apply with
+// the same flags, answers and PicoClaw release, against the same OpenClaw
+// files
+package plan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Format is the version of the plan file layout
+const Format = 2
+
+// Plan is a dry run's intended migration
+type Plan struct {
+	Format  int       `json:"format"`
+	Created time.Time `json:"created"`
+	Host    string    `json:"host"`
+	Version string    `json:"claw_migrate_version"`
+	Args    []string  `json:"args"`    // migrate flags to run with
+	Actions []string  `json:"actions"` // what the dry run said it would do
+	// PicoClaw is the release the dry run would install ("" = none)
+	PicoClaw string `json:"picoclaw_version,omitempty"`
+	// Decisions are the answers given to prompts while planning, by
+	// question; dangerous prompts are never among them
+	Decisions map[string]string `json:"decisions,omitempty"`
+	Source    Source            `json:"source"`
+}
+
+// Source is the state of OpenClaw the plan was made against
+type Source struct {
+	Home         string   `json:"home"`
+	ConfigSHA256 string   `json:"config_sha256,omitempty"`
+	Roots        []string `json:"roots"` // the workspace and other items migrated
+	Files        []File   `json:"files"` // files under Roots
+}
+
+// File is one migrated file, by its path relative to the OpenClaw home, or
+// its absolute path for a workspace kept elsewhere
+type File struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// Snapshot records the OpenClaw config and the files under roots (the
+// workspace, state, credentials, ...) as they are now. Roots that don't
+// exist are left out.
+func Snapshot(home, configPath string, roots []string) (Source, error) {
+	src := Source{Home: home, Roots: roots}
+	if configPath != "" {
+		data, err := os.ReadFile(configPath)
+		if err != nil && !os.IsNotExist(err) {
+			return src, err
+		}
+		if err == nil {
+			sum := sha256.Sum256(data)
+			src.ConfigSHA256 = hex.EncodeToString(sum[:])
+		}
+	}
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			src.Files = append(src.Files, File{Path: relPath(home, path), Size: info.Size(), ModTime: info.ModTime().UTC().Truncate(time.Second)})
+			return nil
+		})
+		if err != nil {
+			return src, err
+		}
+	}
+	sort.Slice(src.Files, func(i, j int) bool { return src.Files[i].Path < src.Files[j].Path })
+	return src, nil
+}
+
+// relPath names path relative to home when it is inside it
+func relPath(home, path string) string {
+	if rel, err := filepath.Rel(home, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+// Changes lists how now differs from the source a plan was made against:
+// the config, and migrated files added, removed or changed
+func Changes(was, now Source) []string {
+	var changes []string
+	if was.Home != now.Home {
+		changes = append(changes, fmt.Sprintf("OpenClaw is at %s, not %s", now.Home, was.Home))
+	}
+	if was.ConfigSHA256 != now.ConfigSHA256 {
+		changes = append(changes, "openclaw.json changed")
+	}
+	before := make(map[string]File, len(was.Files))
+	for _, f := range was.Files {
+		before[f.Path] = f
+	}
+	for _, f := range now.Files {
+		old, ok := before[f.Path]
+		switch {
+		case !ok:
+			changes = append(changes, "added: "+f.Path)
+		case old.Size != f.Size || !old.ModTime.Equal(f.ModTime):
+			changes = append(changes, "changed: "+f.Path)
+		}
+		delete(before, f.Path)
+	}
+	var removed []string
+	for path := range before {
+		removed = append(removed, "removed: "+path)
+	}
+	sort.Strings(removed)
+	return append(changes, removed...)
+}
+
+// Write saves a plan for review
+func Write(path string, p Plan) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal plan: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// Load reads a plan written by Write
+func Load(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if p.Format != Format {
+		return nil, fmt.Errorf("%s is a format %d plan; this claw-migrate reads format %d", path, p.Format, Format)
+	}
+	return &p, nil
+}
@@ -0,0 +1,150 @@
+// Package ports checks that the PicoClaw gateway can listen where its
+// config says: that the port is free, who holds it if not, and whether a
+// local firewall would keep other machines from reaching it
+package ports
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Where the PicoClaw gateway listens when its config doesn't say
+const (
+	DefaultHost = "0.0.0.0"
+	Default     = 18790
+)
+
+// Gateway returns the gateway's host and port from a PicoClaw config
+func Gateway(cfg map[string]interface{}) (string, int) {
+	host, port := DefaultHost, Default
+	gw, _ := cfg["gateway"].(map[string]interface{})
+	if h, ok := gw["host"].(string); ok && h != "" {
+		host = h
+	}
+	switch p := gw["port"].(type) {
+	case float64:
+		port = int(p)
+	case string:
+		if n, err := strconv.Atoi(p); err == nil {
+			port = n
+		}
+	}
+	return host, port
+}
+
+// Holder is the process listening on a port
+type Holder struct {
+	PID     int
+	Command string
+}
+
+// Free reports whether host:port can be listened on now
+func Free(host string, port int) bool {
+	l, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	l.Close()
+	return true
+}
+
+// Next returns the first free port after port, or 0 if the next hundred
+// are all taken
+func Next(host string, port int) int {
+	for p := port + 1; p <= port+100 && p < 65536; p++ {
+		if Free(host, p) {
+			return p
+		}
+	}
+	return 0
+}
+
+// ssUser is a process in ss -p output: users:(("name",pid=123,fd=4))
+var ssUser = regexp.MustCompile(`\("([^"]+)",pid=(\d+)`)
+
+// Listener finds the process listening on a TCP port, with lsof or, on
+// Linux without it, ss. ok is false if it can't be told — the tools are
+// missing, or the process belongs to another user.
+func Listener(port int) (Holder, bool) {
+	h, ok := listener(port)
+	if !ok {
+		return h, false
+	}
+	// The full command line tells an OpenClaw gateway from any other node process
+	if out, err := exec.Command("ps", "-o", "command=", "-p", strconv.Itoa(h.PID)).Output(); err == nil {
+		if cmd := strings.TrimSpace(string(out)); cmd != "" {
+			h.Command = cmd
+		}
+	}
+	return h, true
+}
+
+func listener(port int) (h Holder, ok bool) {
+	if out, err := exec.Command("lsof", "-nP", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN", "-Fpc").Output(); err == nil {
+		for _, line := range strings.Split(string(out), "\n") {
+			switch {
+			case strings.HasPrefix(line, "p") && h.PID == 0:
+				h.PID, _ = strconv.Atoi(line[1:])
+			case strings.HasPrefix(line, "c") && h.Command == "":
+				h.Command = line[1:]
+			}
+		}
+		return h, h.PID != 0
+	}
+	if runtime.GOOS != "linux" {
+		return h, false
+	}
+	out, err := exec.Command("ss", "-Hltnp", fmt.Sprintf("sport = :%d", port)).Output()
+	if err != nil {
+		return h, false
+	}
+	if m := ssUser.FindStringSubmatch(string(out)); m != nil {
+		h.Command = m[1]
+		h.PID, _ = strconv.Atoi(m[2])
+	}
+	return h, h.PID != 0
+}
+
+// Firewall says whether a local firewall is likely to block incoming
+// connections to a TCP port, with what it found. Loopback-only gateways
+// aren't affected. An empty note means no firewall could be checked.
+func Firewall(host string, port int) (blocked bool, note string) {
+	if ip := net.ParseIP(host); (ip != nil && ip.IsLoopback()) || host == "localhost" {
+		return false, ""
+	}
+	switch runtime.GOOS {
+	case "linux":
+		if out, err := exec.Command("ufw", "status").Output(); err == nil {
+			status := string(out)
+			if !strings.Contains(status, "Status: active") {
+				return false, "ufw inactive"
+			}
+			rule := regexp.MustCompile(`(?m)^` + strconv.Itoa(port) + `(/tcp)?\s+ALLOW`)
+			if rule.MatchString(status) {
+				return false, "allowed by ufw"
+			}
+			return true, fmt.Sprintf("ufw is active with no rule for %d/tcp — sudo ufw allow %d/tcp", port, port)
+		}
+		if out, err := exec.Command("firewall-cmd", "--state").Output(); err == nil && strings.TrimSpace(string(out)) == "running" {
+			q, _ := exec.Command("firewall-cmd", "--query-port", fmt.Sprintf("%d/tcp", port)).Output()
+			if strings.TrimSpace(string(q)) == "yes" {
+				return false, "allowed by firewalld"
+			}
+			return true, fmt.Sprintf("firewalld does not allow %d/tcp — sudo firewall-cmd --permanent --add-port=%d/tcp && sudo firewall-cmd --reload", port, port)
+		}
+	case "darwin":
+		out, err := exec.Command("/usr/libexec/ApplicationFirewall/socketfilterfw", "--getglobalstate").Output()
+		if err == nil && strings.Contains(string(out), "enabled") {
+			return true, "the macOS firewall is on — allow picoclaw when macOS asks, or in System Settings → Network → Firewall → Options"
+		}
+		if err == nil {
+			return false, "macOS firewall off"
+		}
+	}
+	return false, ""
+}
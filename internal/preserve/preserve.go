@@ -0,0 +1,167 @@
+// Package preserve sets files aside instead of deleting them, for
+// --no-delete: whatever a run would remove or overwrite is moved under
+// ~/.claw-migrate/preserved, at the same path it had
+package preserve
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+var (
+	// dir is this run's directory under preserved ("" = delete as usual)
+	dir string
+	// kept is everything set aside so far
+	kept []string
+)
+
+// Enable makes Remove and Keep set files aside under root/preserved, in a
+// directory for this run
+func Enable(root string) {
+	dir = filepath.Join(root, "preserved", time.Now().Format("20060102-150405"))
+}
+
+// Enabled reports whether --no-delete is in effect
+func Enabled() bool {
+	return dir != ""
+}
+
+// Dir returns this run's directory of preserved files
+func Dir() string {
+	return dir
+}
+
+// Kept lists the paths set aside so far, where they were
+func Kept() []string {
+	return kept
+}
+
+// Target returns where path goes when it is set aside. A path set aside
+// twice in one run, like a config written more than once, gets a numbered
+// name the second time rather than replacing the first.
+func Target(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	target := filepath.Join(dir, abs)
+	for n := 1; ; n++ {
+		if _, err := os.Lstat(target); os.IsNotExist(err) {
+			return target
+		}
+		target = fmt.Sprintf("%s.%d", filepath.Join(dir, abs), n)
+	}
+}
+
+// Remove deletes path and anything under it, or with --no-delete moves it
+// aside. A path that doesn't exist is not an error.
+func Remove(path string) error {
+	if !Enabled() {
+		return os.RemoveAll(path)
+	}
+	if _, err := os.Lstat(path); os.IsNotExist(err) {
+		return nil
+	}
+	target := Target(path)
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	err := os.Rename(path, target)
+	if errors.Is(err, syscall.EXDEV) {
+		// Another filesystem: copy, then remove what is now a duplicate
+		if err = copyTree(path, target); err == nil {
+			err = os.RemoveAll(path)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("set aside %s: %w", path, err)
+	}
+	kept = append(kept, path)
+	return nil
+}
+
+// SudoRemove is Remove for paths only root can change, running rm or mv
+// under sudo
+func SudoRemove(path string) error {
+	var cmd *exec.Cmd
+	if Enabled() {
+		target := Target(path)
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return err
+		}
+		cmd = exec.Command("sudo", "mv", path, target)
+	} else {
+		cmd = exec.Command("sudo", "rm", "-rf", path)
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+	if Enabled() {
+		kept = append(kept, path)
+	}
+	return nil
+}
+
+// Keep copies a file about to be overwritten aside, with --no-delete. It
+// does nothing otherwise, or if the file isn't there yet.
+func Keep(path string) error {
+	if !Enabled() {
+		return nil
+	}
+	if _, err := os.Lstat(path); os.IsNotExist(err) {
+		return nil
+	}
+	target := Target(path)
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	if err := copyTree(path, target); err != nil {
+		return fmt.Errorf("set aside a copy of %s: %w", path, err)
+	}
+	kept = append(kept, path)
+	return nil
+}
+
+// copyTree copies a file, symlink or directory, keeping modes
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case !info.Mode().IsRegular():
+			return nil // sockets, pipes and devices can't be copied
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
@@ -0,0 +1,107 @@
+// Package runs keeps the history of what claw-migrate has done — every
+// backup, migration, restore and uninstall, with when it ran and how it
+// ended — in ~/.claw-migrate/runs.json
+package runs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/journal"
+)
+
+// Run kinds, named after the commands
+const (
+	KindBackup            = "backup"
+	KindMigrate           = "migrate"
+	KindRestore           = "restore"
+	KindUninstallOpenClaw = "uninstall-openclaw"
+	KindUninstallPicoClaw = "uninstall-picoclaw"
+)
+
+// Run outcomes; a migration's are the journal's
+const (
+	OutcomeSuccess = journal.OutcomeSuccess
+	OutcomeErrors  = journal.OutcomeErrors
+	OutcomeFailed  = "failed"
+)
+
+// Run is one recorded run
+type Run struct {
+	Kind     string    `json:"kind"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Outcome  string    `json:"outcome"`
+	Backup   string    `json:"backup,omitempty"` // the backup it made, restored or relied on
+	Detail   string    `json:"detail,omitempty"` // counts, what was kept, or why it failed
+	Version  string    `json:"version,omitempty"`
+}
+
+// keep is how many runs the history holds; older ones are dropped
+const keep = 500
+
+// Path returns where the history is kept
+func Path() string {
+	return filepath.Join(journal.Dir(), "runs.json")
+}
+
+// Load reads the history, oldest run first. No history is not an error.
+func Load() ([]Run, error) {
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var runs []Run
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", Path(), err)
+	}
+	return runs, nil
+}
+
+// Add appends a run to the history
+func Add(r Run) error {
+	runs, err := Load()
+	if err != nil {
+		return err
+	}
+	runs = append(runs, r)
+	if len(runs) > keep {
+		runs = runs[len(runs)-keep:]
+	}
+	if err := os.MkdirAll(journal.Dir(), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal runs: %w", err)
+	}
+	return os.WriteFile(Path(), data, 0600)
+}
+
+// Last returns the latest run, of any kind if kind is ""
+func Last(runs []Run, kind string) (Run, bool) {
+	for i := len(runs) - 1; i >= 0; i-- {
+		if kind == "" || runs[i].Kind == kind {
+			return runs[i], true
+		}
+	}
+	return Run{}, false
+}
+
+// ForBackup returns the runs that made, relied on or restored the backup
+// at path, oldest first
+func ForBackup(runs []Run, path string) []Run {
+	var found []Run
+	for _, r := range runs {
+		if path != "" && r.Backup == path {
+			found = append(found, r)
+		}
+	}
+	return found
+}
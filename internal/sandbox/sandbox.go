@@ -0,0 +1,181 @@
+// Package sandbox tries a migration's output on PicoClaw before the real
+// ~/.picoclaw is touched: the converted config and a sample of the
+// workspace go into a temporary HOME, and the binary is asked whether it
+// accepts them
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/detect"
+	"github.com/arunbluez/claw-migrate/internal/migrate"
+)
+
+// sampleFiles and sampleSize bound the workspace files copied in — enough
+// for PicoClaw to load its prompts, without copying the whole workspace twice
+const (
+	sampleFiles = 20
+	sampleSize  = 1 << 20
+)
+
+// checkTimeout bounds each command, so a PicoClaw that starts serving
+// instead of exiting can't stall the migration
+const checkTimeout = 15 * time.Second
+
+// Check is one command run against the sandbox
+type Check struct {
+	Command string // as typed, e.g. "picoclaw status"
+	Output  string // combined output, trimmed
+	Err     error  // nil if it exited 0
+}
+
+// Prepare creates a temporary HOME holding .picoclaw/config.json converted
+// from the OpenClaw config, and the top-level files of the OpenClaw
+// workspace. Returns the HOME and the files sampled; remove the HOME when
+// done.
+func Prepare(oc detect.Installation) (string, []string, error) {
+	home, err := os.MkdirTemp("", "claw-migrate-sandbox-")
+	if err != nil {
+		return "", nil, err
+	}
+	picoHome := filepath.Join(home, ".picoclaw")
+	if err := os.MkdirAll(picoHome, 0700); err != nil {
+		os.RemoveAll(home)
+		return "", nil, err
+	}
+	if fr := migrate.MigrateConfig(oc.ConfigPath, filepath.Join(picoHome, "config.json"), true); fr.Error != nil {
+		os.RemoveAll(home)
+		return "", nil, fr.Error
+	}
+
+	sampled, err := sampleWorkspace(oc.WorkspaceDir, sandboxWorkspace(home, picoHome))
+	if err != nil {
+		os.RemoveAll(home)
+		return "", nil, err
+	}
+	return home, sampled, nil
+}
+
+// sandboxWorkspace is where PicoClaw will look for its workspace under the
+// sandbox HOME. A configured workspace outside it (an absolute path) is
+// replaced by the default, so the real one is never written to.
+func sandboxWorkspace(home, picoHome string) string {
+	ws := detect.PicoClawWorkspace(picoHome)
+	if strings.HasPrefix(ws, "~/") {
+		ws = filepath.Join(home, ws[2:])
+	}
+	if rel, err := filepath.Rel(home, ws); err != nil || strings.HasPrefix(rel, "..") {
+		ws = filepath.Join(picoHome, "workspace")
+	}
+	return ws
+}
+
+// sampleWorkspace copies up to sampleFiles top-level regular files from
+// src into dst, skipping large ones and the entries a migration skips
+func sampleWorkspace(src, dst string) ([]string, error) {
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return nil, nil // no workspace to sample
+	}
+	var sampled []string
+	for _, entry := range entries {
+		if len(sampled) == sampleFiles {
+			break
+		}
+		if migrate.SkipEntries[entry.Name()] || !entry.Type().IsRegular() {
+			continue
+		}
+		if info, err := entry.Info(); err != nil || info.Size() > sampleSize {
+			continue
+		}
+		if err := copyFile(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+			return sampled, err
+		}
+		sampled = append(sampled, entry.Name())
+	}
+	return sampled, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Run asks the binary about the sandbox with HOME pointing at it:
+// `picoclaw status`, and `picoclaw config validate` when the binary is
+// known to have a config command
+func Run(binary, home string, caps detect.Capabilities) []Check {
+	commands := [][]string{{"status"}}
+	if caps.Commands != nil && caps.HasCommand("config") {
+		commands = append(commands, []string{"config", "validate"})
+	}
+
+	var checks []Check
+	for _, args := range commands {
+		checks = append(checks, run(binary, home, args))
+	}
+	return checks
+}
+
+func run(binary, home string, args []string) Check {
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Env = withHome(os.Environ(), home)
+	cmd.Dir = home
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("no answer after %s", checkTimeout)
+	}
+	return Check{
+		Command: "picoclaw " + strings.Join(args, " "),
+		Output:  strings.TrimSpace(string(out)),
+		Err:     err,
+	}
+}
+
+// withHome returns env with HOME replaced, and the XDG directories
+// dropped so nothing is read from or written to the real ones
+func withHome(env []string, home string) []string {
+	kept := []string{"HOME=" + home}
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		if name == "HOME" || strings.HasPrefix(name, "XDG_") {
+			continue
+		}
+		kept = append(kept, kv)
+	}
+	return kept
+}
+
+// Passed reports whether every check exited cleanly
+func Passed(checks []Check) bool {
+	for _, c := range checks {
+		if c.Err != nil {
+			return false
+		}
+	}
+	return true
+}
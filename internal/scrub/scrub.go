@@ -0,0 +1,80 @@
+package scrub
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/arunbluez/claw-migrate/internal/detect"
+)
+
+// MaxSize is the largest file scanned; bigger files are copied untouched
+const MaxSize = 8 << 20
+
+// pattern is one kind of secret and how to spot it
+type pattern struct {
+	kind string
+	re   *regexp.Regexp
+}
+
+// patterns match well-known credential formats. They are deliberately
+// specific, so ordinary prose and code are left alone; more specific
+// patterns come first so e.g. an Anthropic key isn't reported as OpenAI.
+var patterns = []pattern{
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{"Anthropic key", regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{20,}`)},
+	{"OpenRouter key", regexp.MustCompile(`\bsk-or-v1-[A-Za-z0-9]{32,}`)},
+	{"OpenAI key", regexp.MustCompile(`\bsk-(?:proj-|svcacct-)?[A-Za-z0-9_-]{20,}`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{40,})`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{"Telegram bot token", regexp.MustCompile(`\b[0-9]{8,10}:AA[A-Za-z0-9_-]{33}\b`)},
+	{"Groq key", regexp.MustCompile(`\bgsk_[A-Za-z0-9]{40,}`)},
+}
+
+// Finding is one redacted secret
+type Finding struct {
+	Kind string
+	Line int
+}
+
+// Redact replaces every recognized secret in data with a "[REDACTED: kind]"
+// marker. data is returned as-is if nothing was found.
+func Redact(data []byte) ([]byte, []Finding) {
+	var findings []Finding
+	out := data
+	for _, p := range patterns {
+		locs := p.re.FindAllIndex(out, -1)
+		if len(locs) == 0 {
+			continue
+		}
+		marker := []byte("[REDACTED: " + p.kind + "]")
+		var b bytes.Buffer
+		last := 0
+		for _, loc := range locs {
+			findings = append(findings, Finding{Kind: p.kind, Line: bytes.Count(out[:loc[0]], []byte("\n")) + 1})
+			b.Write(out[last:loc[0]])
+			b.Write(marker)
+			// Keep the line count so later findings' line numbers stay right
+			b.Write(bytes.Repeat([]byte("\n"), bytes.Count(out[loc[0]:loc[1]], []byte("\n"))))
+			last = loc[1]
+		}
+		b.Write(out[last:])
+		out = b.Bytes()
+	}
+	return out, findings
+}
+
+// Scannable reports whether a file is text small enough to scan
+func Scannable(name string, size int64) bool {
+	return size <= MaxSize && !detect.IsBinaryKind(detect.Classify(name))
+}
+
+// IsText reports whether content looks like text, using the same NUL-byte
+// test as line counting
+func IsText(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) < 0
+}
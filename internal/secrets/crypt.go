@@ -0,0 +1,197 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Encryption methods for a bundle
+const (
+	MethodAge        = "age"        // age, with a passphrase or -r recipient
+	MethodGPG        = "gpg"        // gpg --symmetric, or --encrypt to a recipient
+	MethodPassphrase = "passphrase" // built in: AES-256-GCM with a PBKDF2 key
+)
+
+// magic starts a bundle written with MethodPassphrase
+const magic = "claw-migrate secrets v1\n"
+
+// pbkdf2Iterations follows the current OWASP guidance for PBKDF2-HMAC-SHA256
+const pbkdf2Iterations = 600000
+
+// ErrWrongPassphrase is returned when a built-in bundle fails to decrypt
+var ErrWrongPassphrase = errors.New("wrong passphrase or corrupted bundle")
+
+// DefaultMethod picks age if it is installed, then gpg, then the built-in cipher
+func DefaultMethod() string {
+	if _, err := exec.LookPath("age"); err == nil {
+		return MethodAge
+	}
+	if _, err := exec.LookPath("gpg"); err == nil {
+		return MethodGPG
+	}
+	return MethodPassphrase
+}
+
+// DetectMethod tells which method encrypted a bundle from its first bytes
+func DetectMethod(data []byte) (string, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte(magic)):
+		return MethodPassphrase, nil
+	case bytes.HasPrefix(data, []byte("age-encryption.org/")), bytes.HasPrefix(data, []byte("-----BEGIN AGE ENCRYPTED FILE-----")):
+		return MethodAge, nil
+	case bytes.HasPrefix(data, []byte("-----BEGIN PGP MESSAGE-----")), len(data) > 0 && data[0]&0x80 != 0:
+		return MethodGPG, nil
+	}
+	return "", errors.New("unrecognized secrets bundle (expected age, gpg or claw-migrate encryption)")
+}
+
+// EncryptExternal encrypts plaintext to path with age or gpg. Without a
+// recipient the tool asks for a passphrase on the terminal.
+func EncryptExternal(method, recipient string, plaintext []byte, path string) error {
+	var args []string
+	switch method {
+	case MethodAge:
+		if recipient != "" {
+			args = []string{"-r", recipient}
+		} else {
+			args = []string{"-p"}
+		}
+		args = append(args, "-o", path)
+	case MethodGPG:
+		if recipient != "" {
+			args = []string{"--encrypt", "--recipient", recipient}
+		} else {
+			args = []string{"--symmetric", "--cipher-algo", "AES256"}
+		}
+		args = append(args, "--yes", "--output", path)
+	default:
+		return fmt.Errorf("unknown encryption method %q", method)
+	}
+	return runTool(method, args, plaintext, nil)
+}
+
+// DecryptExternal decrypts a bundle file with age or gpg. identity is an age
+// identity file, needed for bundles encrypted to a recipient.
+func DecryptExternal(method, identity, path string) ([]byte, error) {
+	var args []string
+	switch method {
+	case MethodAge:
+		args = []string{"-d"}
+		if identity != "" {
+			args = append(args, "-i", identity)
+		}
+	case MethodGPG:
+		args = []string{"--quiet", "--decrypt"}
+	default:
+		return nil, fmt.Errorf("unknown encryption method %q", method)
+	}
+	var out bytes.Buffer
+	if err := runTool(method, append(args, path), nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// runTool runs age or gpg with the terminal attached for passphrase prompts
+func runTool(name string, args []string, stdin []byte, stdout *bytes.Buffer) error {
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("%s is not installed", name)
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = os.Stderr
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	} else {
+		cmd.Stdin = os.Stdin
+	}
+	if stdout != nil {
+		cmd.Stdout = stdout
+	} else {
+		cmd.Stdout = os.Stdout
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// Seal encrypts plaintext with a passphrase using AES-256-GCM. The output is
+// magic, a 16-byte salt, a 12-byte nonce, then the ciphertext.
+func Seal(passphrase string, plaintext []byte) ([]byte, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := append([]byte(magic), salt...)
+	header = append(header, nonce...)
+	// The header is authenticated too, so it can't be swapped
+	return gcm.Seal(append([]byte(nil), header...), nonce, plaintext, header), nil
+}
+
+// Open decrypts a bundle written by Seal
+func Open(passphrase string, data []byte) ([]byte, error) {
+	header := len(magic) + 16 + 12
+	if len(data) < header || !bytes.HasPrefix(data, []byte(magic)) {
+		return nil, errors.New("not a claw-migrate secrets bundle")
+	}
+	salt := data[len(magic) : len(magic)+16]
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, data[len(magic)+16:header], data[header:], data[:header])
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2([]byte(passphrase), salt, pbkdf2Iterations, 32))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2 derives a key with PBKDF2-HMAC-SHA256 (RFC 8018), kept here so the
+// tool stays free of dependencies outside the standard library
+func pbkdf2(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	var key []byte
+	for block := uint32(1); len(key) < keyLen; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.Write(prf, binary.BigEndian, block)
+		u := prf.Sum(nil)
+		t := append([]byte(nil), u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
@@ -0,0 +1,282 @@
+package secrets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Secret is one API key or token, addressed by its path in the PicoClaw config.
+// Array elements are addressed by name where possible, e.g.
+// model_list[openai].api_key, so the path survives a different model order.
+type Secret struct {
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// Bundle is the plaintext inside an encrypted export
+type Bundle struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	Source  string    `json:"source"` // "openclaw" or "picoclaw"
+	Secrets []Secret  `json:"secrets"`
+}
+
+// IsSecretKey reports whether a config key holds a credential
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, hint := range []string{"key", "token", "secret", "password"} {
+		if strings.HasSuffix(key, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask hides all but the last four characters of a secret
+func Mask(value string) string {
+	if len(value) > 8 {
+		return "****" + value[len(value)-4:]
+	}
+	return "****"
+}
+
+// Fingerprint identifies a secret without revealing it: the start of its
+// SHA-256, so two keys ending alike can still be told apart
+func Fingerprint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:4])
+}
+
+// Collect returns every non-empty credential in a PicoClaw-format config
+func Collect(cfg map[string]interface{}) []Secret {
+	var found []Secret
+	var walk func(prefix string, v interface{})
+	walk = func(prefix string, v interface{}) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			for k, child := range t {
+				p := k
+				if prefix != "" {
+					p = prefix + "." + k
+				}
+				if s, ok := child.(string); ok {
+					if s != "" && IsSecretKey(k) {
+						found = append(found, Secret{Path: p, Value: s})
+					}
+					continue
+				}
+				walk(p, child)
+			}
+		case []interface{}:
+			for i, child := range t {
+				walk(prefix+"["+elementKey(child, i)+"]", child)
+			}
+		case []map[string]interface{}: // as built by config.ConvertConfig
+			for i, child := range t {
+				walk(prefix+"["+elementKey(child, i)+"]", child)
+			}
+		}
+	}
+	walk("", cfg)
+	sort.Slice(found, func(i, j int) bool { return found[i].Path < found[j].Path })
+	return found
+}
+
+// Inject writes secrets into a PicoClaw-format config, creating objects as
+// needed. Secrets inside arrays are only set on elements that already exist;
+// their paths are returned as skipped.
+func Inject(cfg map[string]interface{}, secrets []Secret) (applied, skipped []string) {
+	for _, s := range secrets {
+		if set(cfg, s.Path, s.Value) {
+			applied = append(applied, s.Path)
+		} else {
+			skipped = append(skipped, s.Path)
+		}
+	}
+	return applied, skipped
+}
+
+// PatchFile is where credentials split out of a config are kept, relative
+// to the home directory, for generated configs that merge them back in
+const PatchFile = ".config/picoclaw/secrets.json"
+
+// WritePatch saves the private half of Split to path, readable only by the owner
+func WritePatch(path string, private map[string]interface{}) error {
+	data, err := json.MarshalIndent(private, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal secrets: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Split separates the credentials from a PicoClaw-format config. public is
+// the config without them; private holds only them, in the same shape.
+// Arrays keep their length in private (elements without credentials become
+// {}), so merging private back into public by position restores the config.
+func Split(cfg map[string]interface{}) (public, private map[string]interface{}, err error) {
+	// Round-trip through JSON so typed slices from ConvertConfig become []interface{}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal config: %w", err)
+	}
+	if err := json.Unmarshal(data, &public); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+	private, _ = extract(public).(map[string]interface{})
+	if private == nil {
+		private = make(map[string]interface{})
+	}
+	return public, private, nil
+}
+
+// extract removes the credentials under v and returns them in v's shape,
+// or nil if there were none
+func extract(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		found := make(map[string]interface{})
+		for k, child := range t {
+			if s, ok := child.(string); ok {
+				if s != "" && IsSecretKey(k) {
+					found[k] = s
+					delete(t, k)
+				}
+				continue
+			}
+			if sub := extract(child); sub != nil {
+				found[k] = sub
+			}
+		}
+		if len(found) == 0 {
+			return nil
+		}
+		return found
+	case []interface{}:
+		found := make([]interface{}, len(t))
+		hasSecrets := false
+		for i, child := range t {
+			found[i] = map[string]interface{}{}
+			if sub := extract(child); sub != nil {
+				found[i] = sub
+				hasSecrets = true
+			}
+		}
+		if !hasSecrets {
+			return nil
+		}
+		return found
+	}
+	return nil
+}
+
+// Lookup returns the string at a secret path, or "" if there is none
+func Lookup(cfg map[string]interface{}, path string) string {
+	node := walkTo(cfg, path, false)
+	if node == nil {
+		return ""
+	}
+	v, _ := node.parent[node.key].(string)
+	return v
+}
+
+// Marshal encodes a bundle as JSON
+func Marshal(b Bundle) ([]byte, error) {
+	return json.MarshalIndent(b, "", "  ")
+}
+
+// Unmarshal decodes a bundle produced by Marshal
+func Unmarshal(data []byte) (Bundle, error) {
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return b, fmt.Errorf("not a secrets bundle: %w", err)
+	}
+	if b.Version != 1 {
+		return b, fmt.Errorf("unsupported secrets bundle version %d", b.Version)
+	}
+	return b, nil
+}
+
+// elementKey names an array element by its model_name or name field, falling back to its index
+func elementKey(v interface{}, i int) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		for _, field := range []string{"model_name", "name"} {
+			if name, ok := m[field].(string); ok && name != "" && !strings.ContainsAny(name, ".[]") {
+				return name
+			}
+		}
+	}
+	return strconv.Itoa(i)
+}
+
+func set(cfg map[string]interface{}, path string, value string) bool {
+	node := walkTo(cfg, path, true)
+	if node == nil {
+		return false
+	}
+	node.parent[node.key] = value
+	return true
+}
+
+type leaf struct {
+	parent map[string]interface{}
+	key    string
+}
+
+// walkTo finds the object holding the last key of path. With create, missing
+// objects along the way are added; array elements are never created.
+func walkTo(cfg map[string]interface{}, path string, create bool) *leaf {
+	parts := strings.Split(path, ".")
+	current := cfg
+	for i, p := range parts {
+		name, rest, hasIndex := strings.Cut(p, "[")
+		last := i == len(parts)-1
+		if !hasIndex {
+			if last {
+				return &leaf{parent: current, key: name}
+			}
+			next, ok := current[name].(map[string]interface{})
+			if !ok {
+				if !create || current[name] != nil {
+					return nil
+				}
+				next = make(map[string]interface{})
+				current[name] = next
+			}
+			current = next
+			continue
+		}
+
+		// name[key] — find the element, then continue inside it
+		if last {
+			return nil
+		}
+		arr, ok := current[name].([]interface{})
+		if !ok {
+			return nil
+		}
+		key := strings.TrimSuffix(rest, "]")
+		var next map[string]interface{}
+		for j, el := range arr {
+			if elementKey(el, j) == key {
+				next, _ = el.(map[string]interface{})
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		current = next
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/arunbluez/claw-migrate/internal/journal"
+)
+
+// Settings holds choices that persist across claw-migrate runs
+type Settings struct {
+	ShareStats *bool  `json:"share_stats,omitempty"` // nil = never asked
+	StatsURL   string `json:"stats_url,omitempty"`   // where --share-stats submits to
+	NotifyURL  string `json:"notify_url,omitempty"`  // where migrate and watch post results and alerts
+
+	PicoClawBinary string `json:"picoclaw_binary,omitempty"` // where claw-migrate last installed picoclaw
+
+	DeclinedUpgrades map[string]string `json:"declined_upgrades,omitempty"` // model → the upgrade turned down for it
+}
+
+// Declined reports whether upgrading model to upgrade was turned down
+// before. A newer recommendation than the one declined is asked about again.
+func (s Settings) Declined(model, upgrade string) bool {
+	return s.DeclinedUpgrades[model] == upgrade
+}
+
+// Decline remembers that upgrading model to upgrade was turned down
+func (s *Settings) Decline(model, upgrade string) {
+	if s.DeclinedUpgrades == nil {
+		s.DeclinedUpgrades = make(map[string]string)
+	}
+	s.DeclinedUpgrades[model] = upgrade
+}
+
+// Path returns the location of the settings file
+func Path() string {
+	return filepath.Join(journal.Dir(), "settings.json")
+}
+
+// Load reads the settings file; a missing or unreadable file yields defaults
+func Load() Settings {
+	var s Settings
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		return s
+	}
+	json.Unmarshal(data, &s)
+	return s
+}
+
+// Save writes the settings file
+func (s Settings) Save() error {
+	if err := os.MkdirAll(journal.Dir(), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal settings: %w", err)
+	}
+	return os.WriteFile(Path(), data, 0600)
+}
@@ -0,0 +1,80 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+)
+
+// Report is the anonymous migration summary sent with --share-stats.
+// It holds counts and timings only — no paths, names, keys or config values.
+type Report struct {
+	ToolVersion string             `json:"tool_version"`
+	OS          string             `json:"os"`
+	Arch        string             `json:"arch"`
+	Outcome     string             `json:"outcome"`
+	Files       int                `json:"files"`
+	Migrated    int                `json:"migrated"`
+	Skipped     int                `json:"skipped"`
+	Errors      int                `json:"errors"`
+	ErrorCauses map[string]int     `json:"error_causes,omitempty"`
+	Providers   int                `json:"providers"`
+	Channels    int                `json:"channels"`
+	MCPServers  int                `json:"mcp_servers"`
+	Durations   map[string]float64 `json:"durations_seconds"`
+	Steps       []Timing           `json:"steps,omitempty"`
+}
+
+// Timing is how long a phase (Step 0) or one of its steps took
+type Timing struct {
+	Phase   int     `json:"phase"`
+	Step    int     `json:"step"`
+	Title   string  `json:"title"`
+	Seconds float64 `json:"seconds"`
+}
+
+// New returns a report pre-filled with platform details
+func New(toolVersion string) Report {
+	return Report{
+		ToolVersion: toolVersion,
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		Durations:   make(map[string]float64),
+	}
+}
+
+// URL returns the submission endpoint: CLAW_MIGRATE_STATS_URL, then the
+// stats_url setting. Empty means nowhere to send to.
+func URL(configured string) string {
+	if env := os.Getenv("CLAW_MIGRATE_STATS_URL"); env != "" {
+		return env
+	}
+	return configured
+}
+
+// JSON returns the exact payload that Send submits
+func (r Report) JSON() string {
+	data, _ := json.MarshalIndent(r, "", "  ")
+	return string(data)
+}
+
+// Send posts the report to url
+func Send(url string, r Report) error {
+	if url == "" {
+		return fmt.Errorf("no stats endpoint configured")
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewBufferString(r.JSON()))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("stats endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package templates holds PicoClaw starter versions of the standard
+// workspace files, for a workspace that arrives without some of them
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+)
+
+//go:embed files/*.md
+var files embed.FS
+
+// Seed fills in the templates
+type Seed struct {
+	Name  string // what the agent is called
+	Model string // its default model, "" if unknown
+}
+
+// Missing lists the standard files with a template that workspace lacks
+func Missing(workspace string) []string {
+	entries, _ := files.ReadDir("files")
+	var missing []string
+	for _, e := range entries {
+		if _, err := os.Lstat(filepath.Join(workspace, e.Name())); os.IsNotExist(err) {
+			missing = append(missing, e.Name())
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// Write renders the template for name into workspace. A file already
+// there is left alone.
+func Write(workspace, name string, seed Seed) error {
+	text, err := files.ReadFile("files/" + name)
+	if err != nil {
+		return fmt.Errorf("no template for %s", name)
+	}
+	tmpl, err := template.New(name).Parse(string(text))
+	if err != nil {
+		return err
+	}
+	if seed.Name == "" {
+		seed.Name = "PicoClaw"
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, seed); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(workspace, 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(workspace, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// AgentName finds what an OpenClaw config calls the agent, "" if it
+// doesn't say
+func AgentName(cfg map[string]interface{}) string {
+	paths := [][]string{
+		{"identity", "name"},
+		{"agent", "name"},
+		{"agents", "defaults", "name"},
+		{"ui", "assistant", "name"},
+	}
+	for _, path := range paths {
+		if name, ok := lookup(cfg, path).(string); ok && name != "" {
+			return name
+		}
+	}
+	// Multi-agent configs name each agent; the first is the default
+	agents, _ := cfg["agents"].(map[string]interface{})
+	list, _ := agents["list"].([]interface{})
+	if len(list) > 0 {
+		first, _ := list[0].(map[string]interface{})
+		for _, path := range [][]string{{"identity", "name"}, {"name"}} {
+			if name, ok := lookup(first, path).(string); ok && name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func lookup(cfg map[string]interface{}, path []string) interface{} {
+	var v interface{} = cfg
+	for _, key := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
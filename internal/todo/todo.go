@@ -0,0 +1,108 @@
+package todo
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileName is the checklist written next to the PicoClaw config
+const FileName = "MIGRATION-TODO.md"
+
+// Item is one manual-attention task
+type Item struct {
+	ID   string // stable key, e.g. "mcp" or "home:extensions", used to keep state across runs
+	Text string
+	Done bool
+}
+
+// Path returns the checklist location inside the PicoClaw home
+func Path(picoHome string) string {
+	return filepath.Join(picoHome, FileName)
+}
+
+// Load reads a checklist written by Save
+func Load(path string) ([]Item, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var items []Item
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		var done bool
+		switch {
+		case strings.HasPrefix(line, "- [ ] "):
+		case strings.HasPrefix(line, "- [x] "), strings.HasPrefix(line, "- [X] "):
+			done = true
+		default:
+			continue
+		}
+		text := line[len("- [ ] "):]
+
+		// The ID rides along in an HTML comment so the rendered file stays clean
+		id := ""
+		if i := strings.LastIndex(text, "<!-- id:"); i >= 0 && strings.HasSuffix(text, "-->") {
+			id = strings.TrimSpace(strings.TrimSuffix(text[i+len("<!-- id:"):], "-->"))
+			text = strings.TrimSpace(text[:i])
+		}
+		if id == "" {
+			id = text
+		}
+		items = append(items, Item{ID: id, Text: text, Done: done})
+	}
+	return items, scanner.Err()
+}
+
+// Save writes the checklist as Markdown
+func Save(path string, items []Item) error {
+	var b strings.Builder
+	b.WriteString("# Migration TODO\n\n")
+	b.WriteString("Things claw-migrate could not carry over from OpenClaw automatically.\n")
+	b.WriteString("Tick them off here or with `claw-migrate todo done <n>`, which re-checks each item.\n\n")
+	b.WriteString(fmt.Sprintf("_Updated %s_\n\n", time.Now().Format("2006-01-02 15:04")))
+	for _, it := range items {
+		box := "[ ]"
+		if it.Done {
+			box = "[x]"
+		}
+		b.WriteString(fmt.Sprintf("- %s %s <!-- id:%s -->\n", box, it.Text, it.ID))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// Merge carries the done state of existing items over to a freshly computed
+// list. Items no longer detected are dropped; done items stay done.
+func Merge(existing, fresh []Item) []Item {
+	done := make(map[string]bool, len(existing))
+	for _, it := range existing {
+		done[it.ID] = it.Done
+	}
+	merged := make([]Item, len(fresh))
+	for i, it := range fresh {
+		it.Done = it.Done || done[it.ID]
+		merged[i] = it
+	}
+	return merged
+}
+
+// Pending returns the items not yet done
+func Pending(items []Item) []Item {
+	var pending []Item
+	for _, it := range items {
+		if !it.Done {
+			pending = append(pending, it)
+		}
+	}
+	return pending
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// background holds the meters of transfers running behind another one,
+// drawn under whichever meter is running
+var background struct {
+	sync.Mutex
+	meters []*Meter
+}
+
+// Alongside draws m on a line of its own under every meter that runs until
+// remove is called, for a transfer going on while another is watched
+func Alongside(m *Meter) (remove func()) {
+	background.Lock()
+	background.meters = append(background.meters, m)
+	background.Unlock()
+	return func() {
+		background.Lock()
+		defer background.Unlock()
+		for i, other := range background.meters {
+			if other == m {
+				background.meters = append(background.meters[:i:i], background.meters[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// backgroundMeters returns the meters to draw under m
+func backgroundMeters(m *Meter) []*Meter {
+	background.Lock()
+	defer background.Unlock()
+	var meters []*Meter
+	for _, other := range background.meters {
+		if other != m {
+			meters = append(meters, other)
+		}
+	}
+	return meters
+}
+
+// throughput follows a meter's rate as a moving average, from when it was
+// first drawn
+type throughput struct {
+	start time.Time
+	last  int64
+	rate  float64
+}
+
+func (t *throughput) sample(cur int64, interval time.Duration) float64 {
+	sample := float64(cur-t.last) / interval.Seconds()
+	t.last = cur
+	if t.rate == 0 {
+		t.rate = sample
+	} else {
+		t.rate = 0.8*t.rate + 0.2*sample
+	}
+	return t.rate
+}
+
+// drawLines redraws a block of status lines in place and leaves the cursor
+// at the start of the first. Lines the previous draw (prev lines) used
+// and this one doesn't are cleared. It returns how many lines it drew.
+func drawLines(lines []string, prev int) int {
+	var b strings.Builder
+	n := max(len(lines), prev)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("\r\033[K")
+		if i < len(lines) {
+			b.WriteString("  " + lines[i])
+		}
+	}
+	if n > 1 {
+		fmt.Fprintf(&b, "\033[%dA\r", n-1)
+	}
+	fmt.Print(b.String())
+	return len(lines)
+}
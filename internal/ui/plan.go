@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/i18n"
+)
+
+// dryRunTag starts every message saying what a dry run would do
+const dryRunTag = "[DRY RUN]"
+
+var (
+	planning bool
+	planned  []string
+	decided  = map[string]string{} // answers given while planning, by question
+	replay   map[string]string     // answers of the plan being applied
+)
+
+// RecordPlan has Info keep what the dry run says it would do, for Planned,
+// and Confirm and Choose keep their answers, for Decisions
+func RecordPlan() {
+	planning = true
+}
+
+// Planned returns the dry-run actions printed since RecordPlan, in order
+// and without their tag
+func Planned() []string {
+	return planned
+}
+
+// Decisions returns the answers given to Confirm ("y" or "n") and Choose
+// (the option) since RecordPlan, by question
+func Decisions() map[string]string {
+	return decided
+}
+
+// ReplayDecisions has Confirm and Choose give a plan's answers to the
+// questions it recorded, and ask the rest. ConfirmDangerous is never
+// recorded, so a dangerous step still asks when the plan is applied.
+func ReplayDecisions(answers map[string]string) {
+	replay = answers
+}
+
+func recordPlanned(text string) {
+	for _, tag := range []string{dryRunTag, i18n.T(dryRunTag)} {
+		if rest, ok := strings.CutPrefix(text, tag); ok {
+			planned = append(planned, strings.TrimSpace(rest))
+			return
+		}
+	}
+}
+
+// decide keeps the answer to a question while planning
+func decide(question, answer string) {
+	if planning {
+		decided[question] = answer
+	}
+}
+
+// planAnswer prints the answer a plan gave on the user's behalf
+func planAnswer(answer string) {
+	fmt.Println(Dim + answer + " (" + i18n.T("from the plan") + ")" + Reset)
+}
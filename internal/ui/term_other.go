@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package ui
+
+// ttyWidth is not available on this platform; Width falls back to $COLUMNS
+func ttyWidth() int {
+	return 0
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/i18n"
+)
+
+// ════════════════════════════════════════════════════════════
+// Phase and step timing
+// ════════════════════════════════════════════════════════════
+
+// PhaseTime is how long a phase took, from its Phase call to the next one,
+// and how long each of its steps took
+type PhaseTime struct {
+	Number   int
+	Title    string
+	Duration time.Duration
+	Steps    []StepTime
+}
+
+// StepTime is how long a step took, from its Step call to the next Step
+// or Phase
+type StepTime struct {
+	Number   int
+	Title    string
+	Duration time.Duration
+}
+
+var (
+	timings               []PhaseTime
+	phaseStart, stepStart time.Time
+	phaseWait, stepWait   time.Duration // spent waiting for answers
+	phaseOpen, stepOpen   bool
+)
+
+func timePhase(number int, title string) {
+	now := time.Now()
+	closePhase(now)
+	timings = append(timings, PhaseTime{Number: number, Title: title})
+	phaseStart, phaseWait, phaseOpen = now, 0, true
+}
+
+// timeStep starts timing a step. Steps outside a phase, as in the direct
+// commands, aren't timed.
+func timeStep(number int, title string) {
+	now := time.Now()
+	closeStep(now)
+	if !phaseOpen {
+		return
+	}
+	p := &timings[len(timings)-1]
+	p.Steps = append(p.Steps, StepTime{Number: number, Title: title})
+	stepStart, stepWait, stepOpen = now, 0, true
+}
+
+func closeStep(now time.Time) {
+	if !stepOpen {
+		return
+	}
+	p := &timings[len(timings)-1]
+	p.Steps[len(p.Steps)-1].Duration = now.Sub(stepStart) - stepWait
+	stepOpen = false
+}
+
+func closePhase(now time.Time) {
+	closeStep(now)
+	if !phaseOpen {
+		return
+	}
+	timings[len(timings)-1].Duration = now.Sub(phaseStart) - phaseWait
+	phaseOpen = false
+}
+
+// Timings ends the running phase and returns how long each phase and step
+// took so far, not counting the time spent waiting for answers
+func Timings() []PhaseTime {
+	closePhase(time.Now())
+	return timings
+}
+
+// promptTimeout is how long a prompt waits before taking its safe answer;
+// 0 waits forever (set by --prompt-timeout)
+var promptTimeout time.Duration
+
+// Timed prompts read stdin on a goroutine, one line per request on want.
+// A read left waiting by a timed-out prompt (pending) hands its late
+// answer to the next prompt instead of a second read racing it.
+var (
+	want, lines = make(chan struct{}), make(chan string)
+	startLines  sync.Once
+	pending     bool
+)
+
+// SetPromptTimeout makes prompts give up after d and take their safe
+// answer, so semi-attended runs don't hang on an unexpected question
+func SetPromptTimeout(d time.Duration) {
+	promptTimeout = d
+}
+
+// timedOut prints the answer taken when nobody answered in time
+func timedOut(answer string) {
+	fmt.Println(Dim + answer + " " + i18n.T("(no answer after %s)", FormatDuration(promptTimeout)) + Reset)
+}
+
+// readLine reads an answer, keeping the wait out of the timings. With a
+// prompt timeout, ok is false when nobody answered in time.
+func readLine() (input string, ok bool) {
+	start := time.Now()
+	defer func() {
+		waited := time.Since(start)
+		phaseWait += waited
+		stepWait += waited
+	}()
+	if promptTimeout <= 0 {
+		input, _ = reader.ReadString('\n')
+		return input, true
+	}
+	startLines.Do(func() {
+		go func() {
+			for range want {
+				line, _ := reader.ReadString('\n')
+				lines <- line
+			}
+		}()
+	})
+	if !pending {
+		want <- struct{}{}
+		pending = true
+	}
+	timer := time.NewTimer(promptTimeout)
+	defer timer.Stop()
+	select {
+	case input = <-lines:
+		pending = false
+		return input, true
+	case <-timer.C:
+		return "", false
+	}
+}
+
+// FormatDuration renders a phase or step duration, to a tenth of a second
+// when it is short
+func FormatDuration(d time.Duration) string {
+	if d < 10*time.Second {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return formatETA(d)
+}
@@ -4,8 +4,12 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/i18n"
 )
 
 // ANSI color codes
@@ -30,87 +34,189 @@ const (
 
 var reader = bufio.NewReader(os.Stdin)
 
+// bannerWidth is the inner width of the start and completion banners
+// on a terminal wide enough to hold them
+const bannerWidth = 59
+
+// ruleWidth is the length of phase underlines and dividers
+const ruleWidth = 55
+
+// labelWidth is the label column used by Found, NotFound and FileStatus
+const labelWidth = 25
+
+// Width returns the terminal width in columns: the size of the terminal on
+// stdout, then $COLUMNS, then 80 when output is piped
+func Width() int {
+	if w := ttyWidth(); w > 0 {
+		return w
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return 80
+}
+
+// fit returns preferred, shrunk so that margin extra columns still fit on
+// the terminal, but never below min
+func fit(preferred, margin, min int) int {
+	w := Width() - margin
+	if w > preferred {
+		return preferred
+	}
+	if w < min {
+		return min
+	}
+	return w
+}
+
+// assumeYes answers every prompt with "yes" or its default (set by --yes)
+var assumeYes bool
+
+// SetAssumeYes makes prompts answer themselves, for unattended runs
+func SetAssumeYes(v bool) {
+	assumeYes = v
+}
+
+// AssumeYes reports whether prompts are being answered automatically
+func AssumeYes() bool {
+	return assumeYes
+}
+
+// autoAnswer prints the answer chosen on the user's behalf
+func autoAnswer(answer string) {
+	fmt.Println(Dim + answer + " (--yes)" + Reset)
+}
+
 // Banner prints the CLI banner
 func Banner() {
-	fmt.Println()
-	fmt.Println(Cyan + Bold + "  ╔═══════════════════════════════════════════════════════════╗" + Reset)
-	fmt.Println(Cyan + Bold + "  ║                                                           ║" + Reset)
-	fmt.Println(Cyan + Bold + "  ║" + Reset + "   🦞 → 🦐  " + Bold + "claw-migrate" + Reset + "                                  " + Cyan + Bold + "║" + Reset)
-	fmt.Println(Cyan + Bold + "  ║" + Reset + "   " + Dim + "OpenClaw → PicoClaw Migration Wizard" + Reset + "                   " + Cyan + Bold + "║" + Reset)
-	fmt.Println(Cyan + Bold + "  ║                                                           ║" + Reset)
-	fmt.Println(Cyan + Bold + "  ╚═══════════════════════════════════════════════════════════╝" + Reset)
-	fmt.Println()
+	banner(Cyan, []string{
+		"🦞 → 🦐  " + Bold + "claw-migrate" + Reset,
+		Dim + i18n.T("OpenClaw → PicoClaw Migration Wizard") + Reset,
+	})
 }
 
 // Phase prints a phase header
 func Phase(number int, title string) {
+	timePhase(number, title)
 	fmt.Println()
-	fmt.Printf(Bold+BgBlue+White+" PHASE %d "+Reset+Bold+" %s"+Reset+"\n", number, title)
-	fmt.Println(Blue + "  " + strings.Repeat("─", 55) + Reset)
+	fmt.Printf(Bold+BgBlue+White+" %s "+Reset+Bold+" %s"+Reset+"\n", i18n.T("PHASE %d", number), i18n.T(title))
+	fmt.Println(Blue + "  " + strings.Repeat("─", fit(ruleWidth, 4, 10)) + Reset)
 }
 
 // Step prints a numbered step
 func Step(number int, text string) {
-	fmt.Printf("\n  "+Cyan+Bold+"[%d]"+Reset+" %s\n", number, text)
+	timeStep(number, text)
+	fmt.Printf("\n  "+Cyan+Bold+"[%d]"+Reset+" %s\n", number, i18n.T(text))
 }
 
 // Info prints an info message
 func Info(msg string) {
-	fmt.Println("  " + Dim + "ℹ  " + msg + Reset)
+	text := i18n.T(msg)
+	if planning {
+		recordPlanned(text)
+	}
+	fmt.Println("  " + Dim + "ℹ  " + text + Reset)
 }
 
 // Success prints a success message
 func Success(msg string) {
-	fmt.Println("  " + Green + "✅ " + msg + Reset)
+	fmt.Println("  " + Green + "✅ " + i18n.T(msg) + Reset)
 }
 
 // Warn prints a warning message
 func Warn(msg string) {
-	fmt.Println("  " + Yellow + "⚠️  " + msg + Reset)
+	fmt.Println("  " + Yellow + "⚠️  " + i18n.T(msg) + Reset)
 }
 
 // Error prints an error message
 func Error(msg string) {
-	fmt.Println("  " + Red + "❌ " + msg + Reset)
+	fmt.Println("  " + Red + "❌ " + i18n.T(msg) + Reset)
+}
+
+// onFatal run before Fatal exits (added by OnFatal)
+var onFatal []func(msg string)
+
+// OnFatal has Fatal call fn with its message before exiting, so a run can
+// report how it ended. Each call adds to those already set.
+func OnFatal(fn func(msg string)) {
+	onFatal = append(onFatal, fn)
 }
 
 // Fatal prints error and exits
 func Fatal(msg string) {
 	Error(msg)
+	for _, fn := range onFatal {
+		fn(i18n.T(msg))
+	}
 	os.Exit(1)
 }
 
 // Found prints a detection result
 func Found(label, value string) {
-	fmt.Printf("  "+Green+"✓"+Reset+" %-25s %s\n", label, Bold+value+Reset)
+	fmt.Printf("  "+Green+"✓"+Reset+" %s %s\n", padRight(i18n.T(label), labelColumn()), Bold+value+Reset)
 }
 
 // NotFound prints a missing detection result
 func NotFound(label string) {
-	fmt.Printf("  "+Red+"✗"+Reset+" %-25s %s\n", label, Dim+"not found"+Reset)
+	fmt.Printf("  "+Red+"✗"+Reset+" %s %s\n", padRight(i18n.T(label), labelColumn()), Dim+i18n.T("not found")+Reset)
 }
 
 // FileStatus prints file migration status
-func FileStatus(name string, exists bool, lines int) {
+func FileStatus(name string, exists bool, detail string) {
 	if exists {
-		fmt.Printf("  "+Green+"  ✓"+Reset+" %-25s %s\n", name, Dim+fmt.Sprintf("(%d lines)", lines)+Reset)
+		fmt.Printf("  "+Green+"  ✓"+Reset+" %s %s\n", padRight(name, labelColumn()), Dim+"("+detail+")"+Reset)
 	} else {
-		fmt.Printf("  "+Yellow+"  ○"+Reset+" %-25s %s\n", name, Dim+"skipped (not found in source)"+Reset)
+		fmt.Printf("  "+Yellow+"  ○"+Reset+" %s %s\n", padRight(name, labelColumn()), Dim+i18n.T("skipped (not found in source)")+Reset)
 	}
 }
 
+// labelColumn narrows the label column on small terminals so values
+// aren't pushed onto the next line
+func labelColumn() int {
+	return fit(labelWidth, 45, 12)
+}
+
 // Confirm asks a yes/no question, returns true for yes
 func Confirm(question string) bool {
-	fmt.Printf("\n  "+Yellow+"?"+Reset+" %s "+Dim+"[Y/n]"+Reset+" ", question)
-	input, _ := reader.ReadString('\n')
+	fmt.Printf("\n  "+Yellow+"?"+Reset+" %s "+Dim+"[Y/n]"+Reset+" ", i18n.T(question))
+	if answer, ok := replay[question]; ok {
+		planAnswer(answer)
+		return answer == "y"
+	}
+	if assumeYes {
+		autoAnswer("y")
+		decide(question, "y")
+		return true
+	}
+	input, ok := readLine()
+	if !ok {
+		timedOut("y")
+		return true
+	}
 	input = strings.TrimSpace(strings.ToLower(input))
-	return input == "" || input == "y" || input == "yes"
+	yes := input == "" || input == "y" || input == "yes"
+	if yes {
+		decide(question, "y")
+	} else {
+		decide(question, "n")
+	}
+	return yes
 }
 
-// ConfirmDangerous asks a yes/no question defaulting to no
+// ConfirmDangerous asks a yes/no question defaulting to no. --yes answers
+// no as well: a destructive step runs unattended only through a flag of
+// its own, checked by the caller.
 func ConfirmDangerous(question string) bool {
-	fmt.Printf("\n  "+Red+"⚠"+Reset+" %s "+Dim+"[y/N]"+Reset+" ", question)
-	input, _ := reader.ReadString('\n')
+	fmt.Printf("\n  "+Red+"⚠"+Reset+" %s "+Dim+"[y/N]"+Reset+" ", i18n.T(question))
+	if assumeYes {
+		autoAnswer("n")
+		return false
+	}
+	input, ok := readLine()
+	if !ok {
+		timedOut("n")
+		return false
+	}
 	input = strings.TrimSpace(strings.ToLower(input))
 	return input == "y" || input == "yes"
 }
@@ -118,11 +224,19 @@ func ConfirmDangerous(question string) bool {
 // Prompt asks for text input
 func Prompt(question string, defaultVal string) string {
 	if defaultVal != "" {
-		fmt.Printf("\n  "+Yellow+"?"+Reset+" %s "+Dim+"[%s]"+Reset+" ", question, defaultVal)
+		fmt.Printf("\n  "+Yellow+"?"+Reset+" %s "+Dim+"[%s]"+Reset+" ", i18n.T(question), defaultVal)
 	} else {
-		fmt.Printf("\n  "+Yellow+"?"+Reset+" %s ", question)
+		fmt.Printf("\n  "+Yellow+"?"+Reset+" %s ", i18n.T(question))
+	}
+	if assumeYes {
+		autoAnswer(defaultVal)
+		return defaultVal
+	}
+	input, ok := readLine()
+	if !ok {
+		timedOut(defaultVal)
+		return defaultVal
 	}
-	input, _ := reader.ReadString('\n')
 	input = strings.TrimSpace(input)
 	if input == "" {
 		return defaultVal
@@ -132,32 +246,67 @@ func Prompt(question string, defaultVal string) string {
 
 // PromptSecret asks for secret input (shows dots)
 func PromptSecret(question string) string {
-	fmt.Printf("\n  "+Yellow+"🔑"+Reset+" %s: ", question)
-	input, _ := reader.ReadString('\n')
+	fmt.Printf("\n  "+Yellow+"🔑"+Reset+" %s: ", i18n.T(question))
+	if assumeYes {
+		autoAnswer(i18n.T("skipped"))
+		return ""
+	}
+	input, ok := readLine()
+	if !ok {
+		timedOut(i18n.T("skipped"))
+		return ""
+	}
 	return strings.TrimSpace(input)
 }
 
-// Choose presents numbered options and returns the selection index
-func Choose(question string, options []string) int {
-	fmt.Printf("\n  "+Yellow+"?"+Reset+" %s\n", question)
+// Choose presents numbered options and returns the selection index. --yes
+// takes the first, the recommended one; a prompt nobody answers in time
+// takes safe, or returns -1 to cancel if safe is -1.
+func Choose(question string, options []string, safe int) int {
+	fmt.Printf("\n  "+Yellow+"?"+Reset+" %s\n", i18n.T(question))
 	for i, opt := range options {
-		fmt.Printf("    "+Cyan+"%d)"+Reset+" %s\n", i+1, opt)
+		fmt.Printf("    "+Cyan+"%d)"+Reset+" %s\n", i+1, i18n.T(opt))
+	}
+	if answer, ok := replay[question]; ok {
+		for i, opt := range options {
+			if opt == answer {
+				fmt.Printf("  "+Dim+"  %s"+Reset+" ", i18n.T("Enter choice [1-%d]:", len(options)))
+				planAnswer(strconv.Itoa(i + 1))
+				return i
+			}
+		}
+	}
+	if assumeYes {
+		fmt.Printf("  "+Dim+"  %s"+Reset+" ", i18n.T("Enter choice [1-%d]:", len(options)))
+		autoAnswer("1")
+		decide(question, options[0])
+		return 0
 	}
 	for {
-		fmt.Printf("  "+Dim+"  Enter choice [1-%d]:"+Reset+" ", len(options))
-		input, _ := reader.ReadString('\n')
+		fmt.Printf("  "+Dim+"  %s"+Reset+" ", i18n.T("Enter choice [1-%d]:", len(options)))
+		input, ok := readLine()
+		if !ok {
+			if safe < 0 {
+				timedOut(i18n.T("cancelled"))
+				return -1
+			}
+			timedOut(strconv.Itoa(safe + 1))
+			return safe
+		}
 		input = strings.TrimSpace(input)
 		var choice int
 		if _, err := fmt.Sscanf(input, "%d", &choice); err == nil && choice >= 1 && choice <= len(options) {
+			decide(question, options[choice-1])
 			return choice - 1
 		}
-		fmt.Println("  " + Red + "  Invalid choice, try again" + Reset)
+		fmt.Println("  " + Red + "  " + i18n.T("Invalid choice, try again") + Reset)
 	}
 }
 
 // Progress prints a progress bar
 func Progress(current, total int, label string) {
-	width := 30
+	label = truncate(label, fit(40, 20, 10))
+	width := fit(30, 14+displayWidth(label), 10)
 	filled := (current * width) / total
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
 	pct := (current * 100) / total
@@ -190,54 +339,378 @@ func SpinnerRun(label string, fn func() error) error {
 		select {
 		case err := <-done:
 			// Clear spinner line and show result
-			fmt.Printf("\r  %-60s\r", "")
+			fmt.Printf("\r  %s\r", strings.Repeat(" ", fit(60, 3, 10)))
+			return err
+		case <-ticker.C:
+			fmt.Printf("\r  %s %s", SpinnerFrame(tick), truncate(i18n.T(label), fit(58, 5, 10)))
+			tick++
+		}
+	}
+}
+
+// Meter shows a progress bar with throughput and an estimated time remaining
+// for a transfer of known size. Add and Set may be called from any goroutine
+// while Run is rendering.
+type Meter struct {
+	label string
+	done  atomic.Int64
+	total atomic.Int64
+}
+
+// NewMeter returns a meter for a transfer of total bytes (0 if not yet known)
+func NewMeter(label string, total int64) *Meter {
+	m := &Meter{label: label}
+	m.total.Store(total)
+	return m
+}
+
+// Add records n more bytes transferred
+func (m *Meter) Add(n int) {
+	m.done.Add(int64(n))
+}
+
+// Set records the bytes transferred so far and the total, for callers that
+// only learn the size once the transfer starts (total <= 0 = unknown)
+func (m *Meter) Set(done, total int64) {
+	m.done.Store(done)
+	if total > 0 {
+		m.total.Store(total)
+	}
+}
+
+// Run runs fn while redrawing the meter, with any meters shown Alongside
+// on the lines below. The ETA starts once a second of throughput has been
+// measured and follows a moving average after that.
+func (m *Meter) Run(fn func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	const interval = 200 * time.Millisecond
+	rates := map[*Meter]*throughput{m: {start: time.Now()}}
+	drawn, tick := 0, 0
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-done:
+			drawLines(nil, drawn)
 			return err
 		case <-ticker.C:
-			fmt.Printf("\r  %s %s", SpinnerFrame(tick), label)
+			var lines []string
+			for _, meter := range append([]*Meter{m}, backgroundMeters(m)...) {
+				cur := meter.done.Load()
+				t := rates[meter]
+				if t == nil {
+					t = &throughput{start: time.Now(), last: cur}
+					rates[meter] = t
+				}
+				rate := t.sample(cur, interval)
+				lines = append(lines, SpinnerFrame(tick)+" "+meter.line(cur, rate, time.Since(t.start)))
+			}
+			drawn = drawLines(lines, drawn)
 			tick++
 		}
 	}
 }
 
+// line renders the meter's status text to fit the terminal
+func (m *Meter) line(cur int64, rate float64, elapsed time.Duration) string {
+	total := m.total.Load()
+	status := formatBytes(cur)
+	if rate > 0 {
+		status += "  " + formatBytes(int64(rate)) + "/s"
+	}
+	eta := i18n.T("estimating…")
+	if elapsed >= time.Second && rate > 0 && total > cur {
+		eta = i18n.T("%s left", formatETA(time.Duration(float64(total-cur)/rate*float64(time.Second))))
+	}
+
+	label := i18n.T(m.label)
+	if total <= 0 {
+		return truncate(label+"  "+Dim+status+Reset, fit(76, 5, 10))
+	}
+
+	pct := int(cur * 100 / total)
+	if pct > 99 {
+		pct = 99 // sizes are estimates; 100% means finished
+	}
+	text := fmt.Sprintf("%3d%%  %s  %s", pct, status, eta)
+	// Reserve a fixed width for the text so the bar doesn't jitter as it changes
+	barWidth := fit(20, 12+displayWidth(label)+40, 0)
+	if barWidth < 5 {
+		return truncate(label+"  "+text, fit(76, 5, 10))
+	}
+	filled := pct * barWidth / 100
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	return label + " " + Cyan + "[" + bar + "]" + Reset + " " + Dim + text + Reset
+}
+
+// formatETA renders a remaining duration at a sensible precision
+func formatETA(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()+0.5))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// formatBytes formats a byte count like detect.FormatSize
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // Divider prints a thin divider
 func Divider() {
-	fmt.Println("  " + Dim + strings.Repeat("─", 55) + Reset)
+	fmt.Println("  " + Dim + strings.Repeat("─", fit(ruleWidth, 4, 10)) + Reset)
 }
 
 // Summary prints a key-value summary line
 func Summary(key, value string) {
-	fmt.Printf("  %-28s %s\n", Dim+key+Reset, value)
+	fmt.Printf("  %s %s\n", padRight(Dim+i18n.T(key)+Reset, 28), value)
 }
 
 // Box prints text in a box
 func Box(title string, lines []string) {
-	maxLen := len(title)
+	// Wrap anything wider than the terminal: 2 indent + 2 borders + 2 padding
+	limit := Width() - 6
+	title = truncate(i18n.T(title), limit)
+	var wrapped []string
 	for _, l := range lines {
-		if len(l) > maxLen {
-			maxLen = len(l)
+		wrapped = append(wrapped, wrap(l, limit)...)
+	}
+	lines = wrapped
+
+	maxLen := displayWidth(title)
+	for _, l := range lines {
+		if displayWidth(l) > maxLen {
+			maxLen = displayWidth(l)
 		}
 	}
-	w := maxLen + 4
+	w := maxLen + 2
+	if w+6 < Width()-2 {
+		w += 2 // a little breathing room when there's space for it
+	}
 	fmt.Println()
 	fmt.Println("  " + Dim + "┌" + strings.Repeat("─", w) + "┐" + Reset)
-	fmt.Printf("  "+Dim+"│"+Reset+" "+Bold+"%-*s"+Reset+" "+Dim+"│"+Reset+"\n", w-2, title)
+	fmt.Println("  " + Dim + "│" + Reset + " " + Bold + padRight(title, w-2) + Reset + " " + Dim + "│" + Reset)
 	fmt.Println("  " + Dim + "├" + strings.Repeat("─", w) + "┤" + Reset)
 	for _, l := range lines {
-		fmt.Printf("  "+Dim+"│"+Reset+" %-*s "+Dim+"│"+Reset+"\n", w-2, l)
+		fmt.Println("  " + Dim + "│" + Reset + " " + padRight(l, w-2) + " " + Dim + "│" + Reset)
 	}
 	fmt.Println("  " + Dim + "└" + strings.Repeat("─", w) + "┘" + Reset)
 }
 
 // CompletionBanner prints the final success banner
 func CompletionBanner() {
+	banner(Green, []string{
+		"🦐  " + Bold + Green + i18n.T("Migration Complete!") + Reset,
+		"",
+		i18n.T("Your PicoClaw assistant is ready to go."),
+		i18n.T("Run: %s to start!", Cyan+"picoclaw gateway"+Reset),
+	})
+}
+
+// banner prints lines inside a double-line frame sized to the terminal.
+// Lines that don't fit are word-wrapped; on very narrow terminals the frame
+// is dropped altogether.
+func banner(color string, lines []string) {
 	fmt.Println()
-	fmt.Println(Green + Bold + "  ╔═══════════════════════════════════════════════════════════╗" + Reset)
-	fmt.Println(Green + Bold + "  ║                                                           ║" + Reset)
-	fmt.Println(Green + Bold + "  ║" + Reset + "   🦐  " + Bold + Green + "Migration Complete!" + Reset + "                                " + Green + Bold + "║" + Reset)
-	fmt.Println(Green + Bold + "  ║                                                           ║" + Reset)
-	fmt.Println(Green + Bold + "  ║" + Reset + "   Your PicoClaw assistant is ready to go.                 " + Green + Bold + "║" + Reset)
-	fmt.Println(Green + Bold + "  ║" + Reset + "   Run: " + Cyan + "picoclaw gateway" + Reset + " to start!                       " + Green + Bold + "║" + Reset)
-	fmt.Println(Green + Bold + "  ║                                                           ║" + Reset)
-	fmt.Println(Green + Bold + "  ╚═══════════════════════════════════════════════════════════╝" + Reset)
+	w := fit(bannerWidth, 5, 0)
+	if w < 24 {
+		for _, l := range lines {
+			if l != "" {
+				fmt.Println("  " + l + Reset)
+			}
+		}
+		fmt.Println()
+		return
+	}
+
+	edge := color + Bold + "  ║" + Reset
+	blank := color + Bold + "  ║" + strings.Repeat(" ", w) + "║" + Reset
+	fmt.Println(color + Bold + "  ╔" + strings.Repeat("═", w) + "╗" + Reset)
+	fmt.Println(blank)
+	for _, l := range lines {
+		if l == "" {
+			fmt.Println(blank)
+			continue
+		}
+		for _, part := range wrap(l, w-4) {
+			fmt.Println(edge + padRight("   "+part+Reset, w) + color + Bold + "║" + Reset)
+		}
+	}
+	fmt.Println(blank)
+	fmt.Println(color + Bold + "  ╚" + strings.Repeat("═", w) + "╝" + Reset)
 	fmt.Println()
-}
\ No newline at end of file
+}
+// padRight pads s with spaces to the given display width. Unlike %-*s it
+// ignores color codes and counts CJK characters as two columns, so
+// translated labels still line up.
+func padRight(s string, width int) string {
+	if n := displayWidth(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// displayWidth returns the number of terminal columns s occupies
+func displayWidth(s string) int {
+	n := 0
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case inEscape:
+			if r == 'm' {
+				inEscape = false
+			}
+		case r == '\033':
+			inEscape = true
+		case isWide(r):
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// isWide reports whether r is an East Asian wide or fullwidth character
+func isWide(r rune) bool {
+	return (r >= 0x1100 && r <= 0x115F) || // Hangul Jamo
+		(r >= 0x2E80 && r <= 0xA4CF) || // CJK radicals … Yi
+		(r >= 0xAC00 && r <= 0xD7A3) || // Hangul syllables
+		(r >= 0xF900 && r <= 0xFAFF) || // CJK compatibility ideographs
+		(r >= 0xFE30 && r <= 0xFE4F) || // CJK compatibility forms
+		(r >= 0xFF00 && r <= 0xFF60) || // fullwidth forms
+		(r >= 0xFFE0 && r <= 0xFFE6) ||
+		(r >= 0x1F300 && r <= 0x1FAFF) // emoji
+}
+
+// truncate shortens s to at most width columns, ending it with "…"
+func truncate(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	n := 0
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case inEscape:
+			b.WriteRune(r)
+			if r == 'm' {
+				inEscape = false
+			}
+			continue
+		case r == '\033':
+			b.WriteRune(r)
+			inEscape = true
+			continue
+		}
+		rw := 1
+		if isWide(r) {
+			rw = 2
+		}
+		if n+rw > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		n += rw
+	}
+	return b.String() + "…" + Reset
+}
+
+// wrap breaks s into lines of at most width columns, preferring to break at
+// spaces. Color codes are carried over so a wrapped colored line stays colored.
+func wrap(s string, width int) []string {
+	if width < 1 || displayWidth(s) <= width {
+		return []string{s}
+	}
+
+	var lines []string
+	var line, word strings.Builder
+	lineW, wordW := 0, 0
+	active := "" // color codes in effect, replayed at the start of each new line
+
+	flush := func() {
+		lines = append(lines, line.String()+Reset)
+		line.Reset()
+		line.WriteString(active)
+		lineW = 0
+	}
+	addWord := func() {
+		if lineW > 0 && lineW+wordW > width {
+			flush()
+		}
+		line.WriteString(word.String())
+		lineW += wordW
+		word.Reset()
+		wordW = 0
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\033' {
+			j := i
+			for j < len(runes) && runes[j] != 'm' {
+				j++
+			}
+			code := string(runes[i:min(j+1, len(runes))])
+			if code == Reset {
+				active = ""
+			} else {
+				active += code
+			}
+			word.WriteString(code)
+			i = j
+			continue
+		}
+
+		rw := 1
+		if isWide(r) {
+			rw = 2
+		}
+		switch {
+		case r == ' ':
+			addWord()
+			if lineW > 0 && lineW < width {
+				line.WriteRune(' ')
+				lineW++
+			}
+		case isWide(r):
+			// CJK text has no spaces; any character boundary is a break point
+			addWord()
+			word.WriteRune(r)
+			wordW = rw
+			addWord()
+		default:
+			if wordW+rw > width {
+				addWord() // a single word longer than the line is split
+				flush()
+			}
+			word.WriteRune(r)
+			wordW += rw
+		}
+	}
+	addWord()
+	if lineW > 0 {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
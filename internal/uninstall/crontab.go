@@ -0,0 +1,103 @@
+package uninstall
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════
+// The user's crontab
+// ════════════════════════════════════════════════════════════
+
+// CronLine is an entry of the user's crontab that runs or refers to OpenClaw
+type CronLine struct {
+	Line      int    // 1-based
+	Text      string // the entry as it is
+	Rewritten string // the entry for PicoClaw, "" if there's no equivalent
+}
+
+// schedule matches the schedule of a crontab entry: five time fields, or
+// a nickname like @reboot
+var schedule = regexp.MustCompile(`^\s*(@\w+|(?:\S+\s+){4}\S+)\s+`)
+
+// ReadCrontab returns the user's crontab, a line per entry. Having no
+// crontab, or no crontab command, reads as an empty one.
+func ReadCrontab() ([]string, error) {
+	if _, err := exec.LookPath("crontab"); err != nil {
+		return nil, nil
+	}
+	out, err := exec.Command("crontab", "-l").Output()
+	if err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			return nil, nil // "no crontab for <user>"
+		}
+		return nil, err
+	}
+	return strings.Split(strings.TrimSuffix(string(out), "\n"), "\n"), nil
+}
+
+// FindCronLines lists the active entries that mention OpenClaw. fix
+// rewrites an entry's command for PicoClaw, or reports there is no
+// equivalent; variable lines like PATH=... are given to it whole.
+func FindCronLines(crontab []string, fix func(command string) (string, bool)) []CronLine {
+	var found []CronLine
+	for i, text := range crontab {
+		trimmed := strings.TrimSpace(text)
+		if strings.HasPrefix(trimmed, "#") || !strings.Contains(strings.ToLower(trimmed), "openclaw") {
+			continue
+		}
+		prefix, command := "", text
+		if loc := schedule.FindStringIndex(text); loc != nil {
+			prefix, command = text[:loc[1]], text[loc[1]:]
+		}
+		line := CronLine{Line: i + 1, Text: text}
+		if fixed, ok := fix(command); ok && fixed != command {
+			line.Rewritten = prefix + fixed
+		}
+		found = append(found, line)
+	}
+	return found
+}
+
+// FixCrontab returns the crontab with the given entries rewritten for
+// PicoClaw where they can be (if rewrite is set) and commented out otherwise
+func FixCrontab(crontab []string, lines []CronLine, rewrite bool) []string {
+	fixed := append([]string{}, crontab...)
+	for _, l := range lines {
+		if rewrite && l.Rewritten != "" {
+			fixed[l.Line-1] = l.Rewritten
+		} else {
+			fixed[l.Line-1] = disabledPrefix + l.Text
+		}
+	}
+	return fixed
+}
+
+// WriteCrontab installs crontab as the user's, first saving the one it
+// replaces to backupPath unless that is "". It fails if the crontab
+// changed since it was read.
+func WriteCrontab(crontab, was []string, backupPath string) error {
+	current, err := ReadCrontab()
+	if err != nil {
+		return err
+	}
+	if strings.Join(current, "\n") != strings.Join(was, "\n") {
+		return fmt.Errorf("the crontab changed since it was checked")
+	}
+	if backupPath != "" {
+		if err := os.WriteFile(backupPath, []byte(strings.Join(was, "\n")+"\n"), 0600); err != nil {
+			return err
+		}
+	}
+	cmd := exec.Command("crontab", "-")
+	cmd.Stdin = strings.NewReader(strings.Join(crontab, "\n") + "\n")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("crontab: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package uninstall
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════
+// OpenClaw in Docker
+// ════════════════════════════════════════════════════════════
+
+// dockerKeywords pick out OpenClaw's images, containers and compose
+// projects: a name, or an image path segment, that is one of these or
+// starts with one followed by - or _
+var dockerKeywords = []string{"openclaw", "clawdbot"}
+
+// DockerItem is a container, image or volume
+type DockerItem struct {
+	ID   string
+	Name string
+}
+
+// DockerArtifacts is what a containerized OpenClaw left in Docker
+type DockerArtifacts struct {
+	Projects   []string // compose projects
+	Containers []DockerItem
+	Images     []DockerItem
+	Volumes    []DockerItem
+}
+
+// Empty reports whether nothing was found
+func (a DockerArtifacts) Empty() bool {
+	return len(a.Projects)+len(a.Containers)+len(a.Images)+len(a.Volumes) == 0
+}
+
+// FindDocker lists OpenClaw's Docker artifacts. A container is OpenClaw's
+// if its image or its name is. A compose project is only taken down whole
+// if it is OpenClaw's by name, or all of its services run OpenClaw images;
+// in a shared stack only the OpenClaw containers go. Volumes are those
+// OpenClaw's containers mount and those of OpenClaw's projects — never
+// one that merely has a similar name. Without a docker CLI there is
+// nothing to find.
+func FindDocker() (DockerArtifacts, error) {
+	var a DockerArtifacts
+	if _, err := exec.LookPath("docker"); err != nil {
+		return a, nil
+	}
+
+	rows, err := dockerRows("ps", "-a", "--format", `{{.ID}}\t{{.Names}}\t{{.Image}}\t{{.Label "com.docker.compose.project"}}`)
+	if err != nil {
+		return a, err
+	}
+	ours := map[string]bool{}  // projects with an OpenClaw service
+	mixed := map[string]bool{} // projects with any other service
+	var ids []string
+	for _, r := range rows {
+		if len(r) < 4 {
+			continue
+		}
+		if !ownImage(r[2]) && !ownName(r[1]) {
+			mixed[r[3]] = true
+			continue
+		}
+		a.Containers = append(a.Containers, DockerItem{r[0], r[1]})
+		ids = append(ids, r[0])
+		ours[r[3]] = true
+	}
+
+	projects := map[string]bool{}
+	addProject := func(p string) {
+		if p != "" && !projects[p] {
+			projects[p] = true
+			a.Projects = append(a.Projects, p)
+		}
+	}
+	for _, r := range rows {
+		if len(r) == 4 && (ownName(r[3]) || ours[r[3]] && !mixed[r[3]]) {
+			addProject(r[3])
+		}
+	}
+	// Stopped projects have no containers left to find them by
+	if out, err := exec.Command("docker", "compose", "ls", "-a", "--format", "json").Output(); err == nil {
+		var listed []struct{ Name string }
+		if json.Unmarshal(out, &listed) == nil {
+			for _, p := range listed {
+				if ownName(p.Name) {
+					addProject(p.Name)
+				}
+			}
+		}
+	}
+
+	rows, err = dockerRows("images", "--format", `{{.ID}}\t{{.Repository}}:{{.Tag}}`)
+	if err != nil {
+		return a, err
+	}
+	for _, r := range rows {
+		if len(r) == 2 && ownImage(r[1]) {
+			a.Images = append(a.Images, DockerItem{r[0], r[1]})
+		}
+	}
+
+	mounted, err := mountedVolumes(ids)
+	if err != nil {
+		return a, err
+	}
+	rows, err = dockerRows("volume", "ls", "--format", `{{.Name}}\t{{.Label "com.docker.compose.project"}}`)
+	if err != nil {
+		return a, err
+	}
+	for _, r := range rows {
+		if len(r) == 2 && (mounted[r[0]] || projects[r[1]]) {
+			a.Volumes = append(a.Volumes, DockerItem{r[0], r[0]})
+		}
+	}
+	return a, nil
+}
+
+// mountedVolumes returns the named volumes the containers mount
+func mountedVolumes(ids []string) (map[string]bool, error) {
+	mounted := map[string]bool{}
+	if len(ids) == 0 {
+		return mounted, nil
+	}
+	args := append([]string{"inspect", "--format", `{{range .Mounts}}{{if eq .Type "volume"}}{{.Name}} {{end}}{{end}}`}, ids...)
+	out, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		return nil, dockerError(err)
+	}
+	for _, name := range strings.Fields(string(out)) {
+		mounted[name] = true
+	}
+	return mounted, nil
+}
+
+// RemoveDockerContainers takes down the compose projects, then removes
+// the remaining containers and the images. Volumes are left alone.
+func RemoveDockerContainers(a DockerArtifacts) []error {
+	var errs []error
+	for _, p := range a.Projects {
+		if err := docker("compose", "-p", p, "down", "--remove-orphans"); err != nil {
+			errs = append(errs, fmt.Errorf("compose project %s: %w", p, err))
+		}
+	}
+	for _, c := range a.Containers {
+		if err := docker("rm", "-f", c.ID); err != nil && !strings.Contains(err.Error(), "No such container") {
+			errs = append(errs, fmt.Errorf("container %s: %w", c.Name, err))
+		}
+	}
+	for _, img := range a.Images {
+		if err := docker("rmi", img.ID); err != nil {
+			errs = append(errs, fmt.Errorf("image %s: %w", img.Name, err))
+		}
+	}
+	return errs
+}
+
+// RemoveDockerVolumes deletes the volumes and the data in them
+func RemoveDockerVolumes(a DockerArtifacts) []error {
+	var errs []error
+	for _, v := range a.Volumes {
+		if err := docker("volume", "rm", v.Name); err != nil {
+			errs = append(errs, fmt.Errorf("volume %s: %w", v.Name, err))
+		}
+	}
+	return errs
+}
+
+// mentions reports whether any field mentions OpenClaw anywhere in it
+func mentions(fields ...string) bool {
+	for _, f := range fields {
+		f = strings.ToLower(f)
+		for _, kw := range dockerKeywords {
+			if strings.Contains(f, kw) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ownName reports whether a container or project name is OpenClaw's
+func ownName(name string) bool {
+	name = strings.ToLower(name)
+	for _, kw := range dockerKeywords {
+		if name == kw || strings.HasPrefix(name, kw+"-") || strings.HasPrefix(name, kw+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// ownImage reports whether an image reference is OpenClaw's: a segment of
+// its repository path, e.g. ghcr.io/openclaw/gateway, is an OpenClaw name
+func ownImage(image string) bool {
+	repo, _, _ := strings.Cut(image, "@")
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+	for _, segment := range strings.Split(repo, "/") {
+		if ownName(segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// dockerRows runs a docker listing and splits its tab-separated lines
+func dockerRows(args ...string) ([][]string, error) {
+	out, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		return nil, dockerError(err)
+	}
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			rows = append(rows, strings.Split(line, "\t"))
+		}
+	}
+	return rows, nil
+}
+
+func docker(args ...string) error {
+	_, err := exec.Command("docker", args...).Output()
+	return dockerError(err)
+}
+
+// dockerError carries docker's own message, e.g. that the daemon isn't
+// running or an image is still in use
+func dockerError(err error) error {
+	if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+		return fmt.Errorf("%s", strings.TrimSpace(string(ee.Stderr)))
+	}
+	return err
+}
@@ -0,0 +1,173 @@
+package uninstall
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/nativehost"
+)
+
+// ════════════════════════════════════════════════════════════
+// Verifying OpenClaw is gone
+// ════════════════════════════════════════════════════════════
+
+// Kinds of leftover
+const (
+	LeftoverBinary  = "binary"
+	LeftoverData    = "data"
+	LeftoverPackage = "npm"
+	LeftoverService = "service"
+	LeftoverProcess = "process"
+	LeftoverDocker  = "docker"
+	LeftoverShell   = "shell"
+	LeftoverBrowser = "browser"
+	LeftoverCron    = "cron"
+)
+
+// Leftover is something of OpenClaw's still on the machine after an
+// uninstall, with a command that removes it
+type Leftover struct {
+	Kind string
+	What string
+	Fix  string
+}
+
+// VerifyRemoved looks for everything an OpenClaw install leaves behind —
+// the binary and data directory, global npm/pnpm packages, launchd and
+// systemd units, running processes and pm2 or forever entries, browser
+// native messaging hosts, Docker objects, shell startup lines and crontab
+// entries — and
+// returns what is still there
+func VerifyRemoved() []Leftover {
+	home, _ := os.UserHomeDir()
+	var left []Leftover
+
+	if path, err := exec.LookPath("openclaw"); err == nil {
+		left = append(left, Leftover{LeftoverBinary, path, "rm " + path})
+	}
+	dataDir := filepath.Join(home, ".openclaw")
+	if _, err := os.Stat(dataDir); err == nil {
+		left = append(left, Leftover{LeftoverData, dataDir, "rm -rf " + dataDir})
+	}
+
+	for _, pm := range []struct{ name, fix string }{
+		{"npm", "npm uninstall -g openclaw"},
+		{"pnpm", "pnpm remove -g openclaw"},
+	} {
+		out, err := exec.Command(pm.name, "root", "-g").Output()
+		if err != nil {
+			continue
+		}
+		pkg := filepath.Join(strings.TrimSpace(string(out)), "openclaw")
+		if _, err := os.Stat(pkg); err == nil {
+			left = append(left, Leftover{LeftoverPackage, pkg, pm.fix})
+		}
+	}
+
+	left = append(left, services(home)...)
+	left = append(left, processes()...)
+	for _, s := range FindSupervised("openclaw") {
+		switch s.Manager {
+		case ManagerPM2:
+			left = append(left, Leftover{LeftoverProcess, "pm2 app " + s.Name, "pm2 delete " + s.Target + " && pm2 save"})
+		case ManagerForever:
+			left = append(left, Leftover{LeftoverProcess, "forever script " + s.Name, "forever stop " + s.Target})
+		}
+	}
+
+	for _, h := range nativehost.Find("openclaw") {
+		if h.Runs("openclaw") {
+			left = append(left, Leftover{LeftoverBrowser, h.Browser + " native messaging host " + h.Name, "rm '" + h.Manifest + "'"})
+		}
+	}
+
+	if a, err := FindDocker(); err == nil {
+		for _, p := range a.Projects {
+			left = append(left, Leftover{LeftoverDocker, "compose project " + p, "docker compose -p " + p + " down"})
+		}
+		for _, c := range a.Containers {
+			left = append(left, Leftover{LeftoverDocker, "container " + c.Name, "docker rm -f " + c.Name})
+		}
+		for _, img := range a.Images {
+			left = append(left, Leftover{LeftoverDocker, "image " + img.Name, "docker rmi " + img.Name})
+		}
+		for _, v := range a.Volumes {
+			left = append(left, Leftover{LeftoverDocker, "volume " + v.Name, "docker volume rm " + v.Name})
+		}
+	}
+
+	for _, l := range FindShellLines(home) {
+		left = append(left, Leftover{
+			LeftoverShell,
+			fmt.Sprintf("%s:%d: %s", l.File, l.Line, strings.TrimSpace(l.Text)),
+			fmt.Sprintf("comment out line %d of %s", l.Line, l.File),
+		})
+	}
+	if crontab, err := ReadCrontab(); err == nil {
+		for _, l := range FindCronLines(crontab, func(c string) (string, bool) { return c, true }) {
+			left = append(left, Leftover{
+				LeftoverCron,
+				fmt.Sprintf("crontab:%d: %s", l.Line, strings.TrimSpace(l.Text)),
+				fmt.Sprintf("crontab -e, then comment out line %d", l.Line),
+			})
+		}
+	}
+	return left
+}
+
+// services finds launchd agents and daemons and systemd units for OpenClaw
+func services(home string) []Leftover {
+	var left []Leftover
+	dirs := []struct{ dir, fix string }{
+		{filepath.Join(home, "Library", "LaunchAgents"), "launchctl unload %s && rm %s"},
+		{"/Library/LaunchAgents", "sudo launchctl unload %s && sudo rm %s"},
+		{"/Library/LaunchDaemons", "sudo launchctl unload %s && sudo rm %s"},
+		{filepath.Join(home, ".config", "systemd", "user"), "systemctl --user disable --now %s && rm %s"},
+		{"/etc/systemd/system", "sudo systemctl disable --now %s && sudo rm %s"},
+	}
+	for _, d := range dirs {
+		entries, err := os.ReadDir(d.dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if !mentions(e.Name()) {
+				continue
+			}
+			path := filepath.Join(d.dir, e.Name())
+			unit := path // launchctl takes the plist path
+			if strings.Contains(d.dir, "systemd") {
+				unit = e.Name()
+			}
+			left = append(left, Leftover{LeftoverService, path, fmt.Sprintf(d.fix, unit, path)})
+		}
+	}
+	return left
+}
+
+// processes finds running OpenClaw processes other than this one and the
+// one that started it, whose command lines can mention OpenClaw too
+func processes() []Leftover {
+	out, err := exec.Command("ps", "-axo", "pid=,command=").Output()
+	if err != nil {
+		return nil
+	}
+	var left []Leftover
+	for _, line := range strings.Split(string(out), "\n") {
+		pidStr, command, ok := strings.Cut(strings.TrimSpace(line), " ")
+		pid, err := strconv.Atoi(pidStr)
+		if !ok || err != nil || pid == os.Getpid() || pid == os.Getppid() || !mentions(command) {
+			continue
+		}
+		command = strings.TrimSpace(command)
+		if len(command) > 80 {
+			command = command[:77] + "..."
+		}
+		left = append(left, Leftover{LeftoverProcess, fmt.Sprintf("pid %d: %s", pid, command), "kill " + pidStr})
+	}
+	return left
+}
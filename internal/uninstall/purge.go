@@ -0,0 +1,142 @@
+package uninstall
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/journal"
+)
+
+// ════════════════════════════════════════════════════════════
+// Deleting OpenClaw's data later (--purge-after)
+// ════════════════════════════════════════════════════════════
+
+// ScheduledPurge is OpenClaw's data left in place after an uninstall,
+// to be deleted by purge-due once it is due
+type ScheduledPurge struct {
+	Path      string    `json:"path"`
+	Scheduled time.Time `json:"scheduled"`
+	Due       time.Time `json:"due"`
+	Backup    string    `json:"backup,omitempty"` // the verified backup when it was scheduled
+	State     DataState `json:"state"`            // the data as it was left, to tell if it is used again
+}
+
+// DataState sums up a data directory, so a later look can tell whether
+// anything in it was added, removed or written since
+type DataState struct {
+	Files   int       `json:"files"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"` // newest file or directory
+}
+
+// ReadDataState sums up the directory at path as it is now
+func ReadDataState(path string) (DataState, error) {
+	var s DataState
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(s.ModTime) {
+			s.ModTime = info.ModTime()
+		}
+		if d.Type().IsRegular() {
+			s.Files++
+			s.Size += info.Size()
+		}
+		return nil
+	})
+	s.ModTime = s.ModTime.UTC().Truncate(time.Second)
+	return s, err
+}
+
+// purgeCommand marks the crontab entry that runs purge-due
+const purgeCommand = "purge-due"
+
+// PurgePath returns where a scheduled purge is recorded
+func PurgePath() string {
+	return filepath.Join(journal.Dir(), "purge.json")
+}
+
+// LoadPurge reads the scheduled purge, nil if there is none
+func LoadPurge() (*ScheduledPurge, error) {
+	data, err := os.ReadFile(PurgePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p ScheduledPurge
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", PurgePath(), err)
+	}
+	return &p, nil
+}
+
+// SchedulePurge records a purge, replacing any scheduled before
+func SchedulePurge(p ScheduledPurge) error {
+	if err := os.MkdirAll(journal.Dir(), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal purge: %w", err)
+	}
+	return os.WriteFile(PurgePath(), data, 0600)
+}
+
+// CancelPurge forgets the scheduled purge and removes its crontab entry
+func CancelPurge() error {
+	if err := os.Remove(PurgePath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return RemovePurgeCron()
+}
+
+// AddPurgeCron adds a daily crontab entry running exe purge-due, so the
+// purge happens without anyone remembering it. --purge is its consent to
+// delete unattended; --yes alone would keep the data.
+func AddPurgeCron(exe string) error {
+	if _, err := exec.LookPath("crontab"); err != nil {
+		return fmt.Errorf("no crontab command")
+	}
+	crontab, err := ReadCrontab()
+	if err != nil {
+		return err
+	}
+	for _, line := range crontab {
+		if strings.Contains(line, purgeCommand) {
+			return nil
+		}
+	}
+	entry := fmt.Sprintf("17 10 * * * '%s' %s --yes --purge >/dev/null 2>&1", exe, purgeCommand)
+	return WriteCrontab(append(append([]string{}, crontab...), entry), crontab, "")
+}
+
+// RemovePurgeCron removes the entry AddPurgeCron made, if there is one
+func RemovePurgeCron() error {
+	crontab, err := ReadCrontab()
+	if err != nil {
+		return err
+	}
+	var kept []string
+	for _, line := range crontab {
+		if !strings.Contains(line, purgeCommand) || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			kept = append(kept, line)
+		}
+	}
+	if len(kept) == len(crontab) {
+		return nil
+	}
+	return WriteCrontab(kept, crontab, "")
+}
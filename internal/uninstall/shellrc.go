@@ -0,0 +1,269 @@
+package uninstall
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/arunbluez/claw-migrate/internal/preserve"
+)
+
+// ════════════════════════════════════════════════════════════
+// Shell startup files
+// ════════════════════════════════════════════════════════════
+
+// rcFiles are the shell startup files checked, relative to home
+var rcFiles = []string{
+	".bashrc", ".bash_profile", ".bash_aliases", ".profile",
+	".zshrc", ".zprofile", ".zshenv",
+	filepath.Join(".config", "fish", "config.fish"),
+}
+
+// disabledPrefix marks lines commented out by claw-migrate, so they can
+// be told apart from the user's own comments
+const disabledPrefix = "# disabled by claw-migrate: "
+
+// Kinds of shell line
+const (
+	LineAlias      = "alias"
+	LineCompletion = "completion"
+	LinePath       = "path"
+	LineOther      = "other"
+)
+
+// ShellLine is a line of a shell startup file that refers to OpenClaw
+type ShellLine struct {
+	File string
+	Line int // 1-based
+	Text string
+	Kind string
+	// Standalone lines (an alias, export or source statement of their
+	// own, outside any if, loop, function or continued line) can be
+	// commented out without breaking the file; the rest are only reported
+	Standalone bool
+}
+
+var (
+	aliasLine      = regexp.MustCompile(`^\s*alias\s`)
+	completionLine = regexp.MustCompile(`completion|compdef|complete\s`)
+	pathLine       = regexp.MustCompile(`\bPATH\b|fish_add_path`)
+	// statements safe to disable on their own: aliases, exports (fish's
+	// set -x and fish_add_path too) and sourcing, eval'd completion included
+	statementLine = regexp.MustCompile(`^\s*(alias|export|source|\.|fish_add_path|set\s+-[a-zA-Z]*x[a-zA-Z]*|eval)\s|\|\s*source\s*$`)
+)
+
+// Words that open and close a block, in sh and fish
+var (
+	blockOpen  = map[string]bool{"if": true, "case": true, "for": true, "while": true, "until": true, "select": true, "function": true, "switch": true, "begin": true, "{": true}
+	blockClose = map[string]bool{"fi": true, "esac": true, "done": true, "end": true, "}": true}
+)
+
+// FindShellLines lists the active lines of the shell startup files in home
+// that mention OpenClaw: aliases, completion sourcing, PATH additions and
+// anything else, marking which stand alone
+func FindShellLines(home string) []ShellLine {
+	var found []ShellLine
+	for _, rel := range rcFiles {
+		path := filepath.Join(home, rel)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		depth, continued := 0, false
+		for i, text := range strings.Split(string(data), "\n") {
+			trimmed := strings.TrimSpace(text)
+			inside, wasContinued := depth > 0, continued
+			continued = strings.HasSuffix(trimmed, "\\")
+			if strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			depth = max(depth+blockDepth(trimmed), 0)
+			if !mentions(trimmed) {
+				continue
+			}
+			kind := LineOther
+			switch {
+			case aliasLine.MatchString(text):
+				kind = LineAlias
+			case completionLine.MatchString(text):
+				kind = LineCompletion
+			case pathLine.MatchString(text):
+				kind = LinePath
+			}
+			standalone := !inside && depth == 0 && !wasContinued && !continued && statementLine.MatchString(text)
+			found = append(found, ShellLine{path, i + 1, text, kind, standalone})
+		}
+	}
+	return found
+}
+
+// blockDepth is how many blocks a line opens, less those it closes, going
+// by its words: "if x; then" opens one, "if x; then y; fi" none
+func blockDepth(line string) int {
+	depth := 0
+	for _, word := range strings.FieldsFunc(line, func(r rune) bool { return r == ' ' || r == '\t' || r == ';' }) {
+		switch {
+		case blockOpen[word], strings.HasSuffix(word, "(){"):
+			depth++
+		case blockClose[word]:
+			depth--
+		}
+	}
+	return depth
+}
+
+// CommentOut disables the standalone lines in their files, keeping each
+// file's mode and, before its first edit, a copy as <file>.claw-migrate.bak.
+// Lines that don't stand alone are left as they are.
+func CommentOut(lines []ShellLine) error {
+	byFile := map[string][]ShellLine{}
+	var order []string
+	for _, l := range lines {
+		if !l.Standalone {
+			continue
+		}
+		if byFile[l.File] == nil {
+			order = append(order, l.File)
+		}
+		byFile[l.File] = append(byFile[l.File], l)
+	}
+
+	for _, file := range order {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		text := strings.Split(string(data), "\n")
+		for _, l := range byFile[file] {
+			// The file may have changed since it was read; only touch the same line
+			if l.Line > len(text) || text[l.Line-1] != l.Text {
+				return fmt.Errorf("%s changed since it was checked", file)
+			}
+			text[l.Line-1] = disabledPrefix + l.Text
+		}
+		if err := keepOriginal(file, data, info.Mode().Perm()); err != nil {
+			return err
+		}
+		if err := writeAtomic(file, []byte(strings.Join(text, "\n")), info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PicoClawLines suggests what to add for PicoClaw in a startup file that
+// had OpenClaw lines: the same aliases pointing at picoclaw, and completion
+// for the file's shell if picoclaw can generate it
+func PicoClawLines(file string, lines []ShellLine) []string {
+	var add []string
+	seen := map[string]bool{}
+	completion := false
+	for _, l := range lines {
+		if l.File != file {
+			continue
+		}
+		switch l.Kind {
+		case LineAlias:
+			alias := strings.TrimSpace(strings.ReplaceAll(l.Text, "openclaw", "picoclaw"))
+			if !seen[alias] {
+				seen[alias] = true
+				add = append(add, alias)
+			}
+		case LineCompletion:
+			completion = true
+		}
+	}
+	if completion {
+		shell := shellOf(file)
+		if exec.Command("picoclaw", "completion", shell).Run() == nil {
+			switch shell {
+			case "fish":
+				add = append(add, "picoclaw completion fish | source")
+			case "zsh":
+				add = append(add, "source <(picoclaw completion zsh)")
+			default:
+				add = append(add, `eval "$(picoclaw completion bash)"`)
+			}
+		}
+	}
+	return add
+}
+
+// AppendLines adds lines to the end of a startup file under a comment,
+// keeping a copy first as CommentOut does
+func AppendLines(file string, lines []string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if err := keepOriginal(file, data, info.Mode().Perm()); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	block := "\n# PicoClaw (added by claw-migrate)\n" + strings.Join(lines, "\n") + "\n"
+	if _, err := f.WriteString(block); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// shellOf tells which shell reads a startup file
+func shellOf(file string) string {
+	base := filepath.Base(file)
+	switch {
+	case strings.HasSuffix(base, ".fish"):
+		return "fish"
+	case strings.HasPrefix(base, ".z"):
+		return "zsh"
+	}
+	return "bash"
+}
+
+// keepOriginal writes data, a startup file's contents, to
+// <file>.claw-migrate.bak beside the real file, unless an earlier edit
+// already did: the first copy is the one from before claw-migrate
+func keepOriginal(file string, data []byte, mode os.FileMode) error {
+	if real, err := filepath.EvalSymlinks(file); err == nil {
+		file = real
+	}
+	bak := file + ".claw-migrate.bak"
+	if _, err := os.Lstat(bak); err == nil {
+		return nil
+	}
+	return os.WriteFile(bak, data, mode)
+}
+
+// writeAtomic replaces a file's contents, through a symlink if it is one
+// (dotfiles managers link startup files into home)
+func writeAtomic(path string, data []byte, mode os.FileMode) error {
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real
+	}
+	if err := preserve.Keep(path); err != nil {
+		return err
+	}
+	tmp := path + ".claw-migrate.tmp"
+	if err := os.WriteFile(tmp, data, mode); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
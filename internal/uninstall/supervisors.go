@@ -0,0 +1,290 @@
+package uninstall
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════
+// Process managers and terminal multiplexers
+// ════════════════════════════════════════════════════════════
+
+// Process managers that keep a gateway running
+const (
+	ManagerPM2     = "pm2"
+	ManagerForever = "forever"
+	ManagerTmux    = "tmux"
+	ManagerScreen  = "screen"
+)
+
+// Supervised is a gateway kept running by a process manager, which would
+// restart it if it were only killed, or left running in a tmux or screen
+// session
+type Supervised struct {
+	Manager string
+	Name    string // what to show: app name, script or session
+	Target  string // what the manager's stop command takes
+	Command string // the gateway's command line, to confirm before stopping it
+
+	root    int    // the pane's or session's process
+	program string // the program searched for
+}
+
+// FindSupervised finds the named program run by pm2 or forever, or running
+// in the foreground of a tmux pane or screen session. Apps and panes that
+// only mention name in their arguments don't count.
+func FindSupervised(name string) []Supervised {
+	var found []Supervised
+	found = append(found, pm2Apps(name)...)
+	found = append(found, foreverApps(name)...)
+
+	procs := processTable()
+	found = append(found, tmuxPanes(name, procs)...)
+	found = append(found, screenSessions(name, procs)...)
+	return found
+}
+
+// StopSupervised stops a supervised process for good: pm2 apps are
+// deleted and the saved list updated so they don't come back at boot,
+// forever scripts are stopped, and the gateway in a tmux pane or screen
+// session gets Ctrl-C, leaving the session and its shell alone
+func StopSupervised(s Supervised) error {
+	switch s.Manager {
+	case ManagerPM2:
+		if err := run("pm2", "delete", s.Target); err != nil {
+			return err
+		}
+		return run("pm2", "save", "--force")
+	case ManagerForever:
+		return run("forever", "stop", s.Target)
+	case ManagerTmux, ManagerScreen:
+		// Whatever is in the foreground gets the keys, so check it is
+		// still the gateway
+		if _, ok := foregroundGateway(s.root, s.program, processTable()); !ok {
+			return fmt.Errorf("the gateway is no longer in the foreground")
+		}
+		if s.Manager == ManagerTmux {
+			return run("tmux", "send-keys", "-t", s.Target, "C-c")
+		}
+		return run("screen", "-S", s.Target, "-X", "stuff", "\x03")
+	}
+	return fmt.Errorf("unknown process manager %s", s.Manager)
+}
+
+func pm2Apps(name string) []Supervised {
+	out, err := exec.Command("pm2", "jlist").Output()
+	if err != nil {
+		return nil
+	}
+	var apps []struct {
+		Name string `json:"name"`
+		ID   int    `json:"pm_id"`
+		Env  struct {
+			ExecPath    string      `json:"pm_exec_path"`
+			Interpreter string      `json:"exec_interpreter"`
+			Args        interface{} `json:"args"`
+		} `json:"pm2_env"`
+	}
+	// pm2 may print a banner before the JSON
+	if i := strings.Index(string(out), "["); i >= 0 {
+		out = out[i:]
+	}
+	if json.Unmarshal(out, &apps) != nil {
+		return nil
+	}
+	var found []Supervised
+	for _, app := range apps {
+		// pm2 runs a script with its interpreter, or a binary directly ("none")
+		command := app.Env.ExecPath
+		if app.Env.Interpreter != "" && app.Env.Interpreter != "none" {
+			command = app.Env.Interpreter + " " + command
+		}
+		switch args := app.Env.Args.(type) {
+		case []interface{}:
+			for _, a := range args {
+				command += " " + fmt.Sprint(a)
+			}
+		case string:
+			command += " " + args
+		}
+		if runsProgram(name, command) {
+			found = append(found, Supervised{Manager: ManagerPM2, Name: app.Name, Target: strconv.Itoa(app.ID), Command: command})
+		}
+	}
+	return found
+}
+
+// foreverLine is a row of forever list: "data:    [0] abcd node /path/script.js ..."
+var foreverLine = regexp.MustCompile(`\[(\d+)\]\s+\S+\s+(.*)`)
+
+func foreverApps(name string) []Supervised {
+	out, err := exec.Command("forever", "list", "--no-colors").Output()
+	if err != nil {
+		return nil
+	}
+	var found []Supervised
+	for _, line := range strings.Split(string(out), "\n") {
+		m := foreverLine.FindStringSubmatch(line)
+		if m != nil && runsProgram(name, m[2]) {
+			script := strings.Fields(m[2])
+			command := strings.Join(script[:min(len(script), 2)], " ")
+			found = append(found, Supervised{Manager: ManagerForever, Name: command, Target: m[1], Command: command})
+		}
+	}
+	return found
+}
+
+// process is a row of the process table
+type process struct {
+	ppid    int
+	pgid    int // process group
+	tpgid   int // foreground process group of its terminal (0 or -1 without one)
+	command string
+}
+
+func processTable() map[int]process {
+	out, err := exec.Command("ps", "-axo", "pid=,ppid=,pgid=,tpgid=,command=").Output()
+	if err != nil {
+		return nil
+	}
+	procs := map[int]process{}
+	for _, line := range strings.Split(string(out), "\n") {
+		f := strings.Fields(line)
+		if len(f) < 5 {
+			continue
+		}
+		var ids [4]int
+		ok := true
+		for i := range ids {
+			n, err := strconv.Atoi(f[i])
+			ids[i], ok = n, ok && err == nil
+		}
+		if ok {
+			procs[ids[0]] = process{ids[1], ids[2], ids[3], strings.Join(f[4:], " ")}
+		}
+	}
+	return procs
+}
+
+// interpreters run a gateway from a script rather than a binary of its own
+var interpreters = map[string]bool{"node": true, "nodejs": true, "bun": true, "deno": true}
+
+// runsProgram reports whether a command line runs the named program: its
+// executable is name (or name-something), or an interpreter runs a script
+// of that name or from a directory of that name, as npm installs it. A
+// command that only mentions name in its arguments, like an editor open
+// on ~/.openclaw/openclaw.json, doesn't count.
+func runsProgram(name, command string) bool {
+	f := strings.Fields(command)
+	if len(f) == 0 {
+		return false
+	}
+	if isProgram(name, f[0]) {
+		return true
+	}
+	if !interpreters[filepath.Base(f[0])] {
+		return false
+	}
+	for _, arg := range f[1:] {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if isProgram(name, arg) {
+			return true
+		}
+		for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(arg)), "/") {
+			if strings.EqualFold(dir, name) {
+				return true
+			}
+		}
+		return false // only the script counts, not its arguments
+	}
+	return false
+}
+
+// isProgram reports whether path names the program itself
+func isProgram(name, path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	return base == name || strings.HasPrefix(base, name+"-")
+}
+
+// foregroundGateway finds a process running the named program that
+// descends from root and is in the foreground of its terminal, where
+// Ctrl-C reaches it. This process and its parent don't count:
+// claw-migrate uninstall-openclaw may itself be running in a tmux pane.
+func foregroundGateway(root int, name string, procs map[int]process) (string, bool) {
+	for pid, p := range procs {
+		if pid == os.Getpid() || pid == os.Getppid() || p.tpgid <= 0 || p.pgid != p.tpgid || !runsProgram(name, p.command) {
+			continue
+		}
+		for up, hops := pid, 0; up > 1 && hops < 32; up, hops = procs[up].ppid, hops+1 {
+			if up == root {
+				return p.command, true
+			}
+		}
+	}
+	return "", false
+}
+
+func tmuxPanes(name string, procs map[int]process) []Supervised {
+	out, err := exec.Command("tmux", "list-panes", "-a", "-F", "#{pane_id}\t#{pane_pid}\t#{session_name}:#{window_index}.#{pane_index}").Output()
+	if err != nil {
+		return nil
+	}
+	var found []Supervised
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		f := strings.Split(line, "\t")
+		if len(f) != 3 {
+			continue
+		}
+		if f[0] == os.Getenv("TMUX_PANE") {
+			continue // the pane claw-migrate is running in
+		}
+		pid, err := strconv.Atoi(f[1])
+		if err != nil {
+			continue
+		}
+		if command, ok := foregroundGateway(pid, name, procs); ok {
+			found = append(found, Supervised{Manager: ManagerTmux, Name: f[2], Target: f[0], Command: command, root: pid, program: name})
+		}
+	}
+	return found
+}
+
+// screenSession is a line of screen -ls: "	12345.openclaw	(Detached)"
+var screenSession = regexp.MustCompile(`^\s+((\d+)\.\S+)`)
+
+// screenSessions finds sessions running the gateway in the foreground. A
+// session's name proves nothing about what runs in it.
+func screenSessions(name string, procs map[int]process) []Supervised {
+	// screen -ls exits 1 when there are sessions on some systems
+	out, _ := exec.Command("screen", "-ls").Output()
+	var found []Supervised
+	for _, line := range strings.Split(string(out), "\n") {
+		m := screenSession.FindStringSubmatch(line)
+		if m == nil || m[1] == os.Getenv("STY") {
+			continue
+		}
+		pid, _ := strconv.Atoi(m[2])
+		if command, ok := foregroundGateway(pid, name, procs); ok {
+			found = append(found, Supervised{Manager: ManagerScreen, Name: m[1], Target: m[1], Command: command, root: pid, program: name})
+		}
+	}
+	return found
+}
+
+// run runs a command, returning its own error message if it fails
+func run(name string, args ...string) error {
+	_, err := exec.Command(name, args...).Output()
+	if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+		return fmt.Errorf("%s: %s", name, strings.TrimSpace(string(ee.Stderr)))
+	}
+	return err
+}
@@ -1,10 +1,15 @@
 package uninstall
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+
+	"github.com/arunbluez/claw-migrate/internal/preserve"
 )
 
 // ════════════════════════════════════════════════════════════
@@ -14,13 +19,16 @@ import (
 // StopOpenClaw kills any running OpenClaw processes
 func StopOpenClaw() error {
 	exec.Command("openclaw", "daemon", "stop").Run()
-	exec.Command("pkill", "-f", "openclaw gateway").Run()
-	exec.Command("pkill", "-f", "openclaw").Run()
+	killMatching("openclaw")
 	return nil
 }
 
-// RemoveBinary uninstalls the OpenClaw npm package
+// RemoveBinary uninstalls the OpenClaw npm package. With --no-delete the
+// package and its command are moved aside instead.
 func RemoveBinary() error {
+	if preserve.Enabled() {
+		return preserveBinary()
+	}
 	cmd := exec.Command("npm", "uninstall", "-g", "openclaw")
 	if err := cmd.Run(); err != nil {
 		cmd = exec.Command("pnpm", "remove", "-g", "openclaw")
@@ -33,35 +41,42 @@ func RemoveBinary() error {
 
 // RemoveData removes a directory (e.g. ~/.openclaw or ~/.picoclaw)
 func RemoveData(dir string) error {
-	return os.RemoveAll(dir)
-}
-
-// RemoveLaunchAgents removes macOS launch agents matching a keyword
-func RemoveLaunchAgents() []string {
-	return removeLaunchAgentsMatching("openclaw", "clawdbot")
+	return preserve.Remove(dir)
 }
 
-// VerifyRemoved checks that OpenClaw is fully removed
-func VerifyRemoved() (binaryGone, dataGone, agentsGone bool) {
-	_, err := exec.LookPath("openclaw")
-	binaryGone = err != nil
-
-	home, _ := os.UserHomeDir()
-	_, err = os.Stat(filepath.Join(home, ".openclaw"))
-	dataGone = os.IsNotExist(err)
-
-	launchDir := filepath.Join(home, "Library", "LaunchAgents")
-	entries, _ := os.ReadDir(launchDir)
-	agentsGone = true
-	for _, entry := range entries {
-		name := strings.ToLower(entry.Name())
-		if strings.Contains(name, "openclaw") || strings.Contains(name, "clawdbot") {
-			agentsGone = false
-			break
+// preserveBinary sets aside the global npm or pnpm package and the
+// openclaw command on PATH
+func preserveBinary() error {
+	found := false
+	for _, pm := range []string{"npm", "pnpm"} {
+		out, err := exec.Command(pm, "root", "-g").Output()
+		if err != nil {
+			continue
+		}
+		pkg := filepath.Join(strings.TrimSpace(string(out)), "openclaw")
+		if _, err := os.Stat(pkg); err != nil {
+			continue
+		}
+		if err := preserve.Remove(pkg); err != nil {
+			return err
 		}
+		found = true
 	}
+	if path, err := exec.LookPath("openclaw"); err == nil {
+		if err := preserve.Remove(path); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("no openclaw package or command found")
+	}
+	return nil
+}
 
-	return
+// RemoveLaunchAgents removes macOS launch agents matching a keyword
+func RemoveLaunchAgents() []string {
+	return removeLaunchAgentsMatching("openclaw", "clawdbot")
 }
 
 // ════════════════════════════════════════════════════════════
@@ -71,29 +86,51 @@ func VerifyRemoved() (binaryGone, dataGone, agentsGone bool) {
 // StopPicoClaw kills any running PicoClaw processes
 func StopPicoClaw() error {
 	exec.Command("picoclaw", "daemon", "stop").Run()
-	exec.Command("pkill", "-f", "picoclaw gateway").Run()
-	exec.Command("pkill", "-f", "picoclaw").Run()
+	killMatching("picoclaw")
 	return nil
 }
 
-// RemovePicoClawBinary removes the picoclaw binary
-func RemovePicoClawBinary() error {
-	path, err := exec.LookPath("picoclaw")
-	if err != nil {
-		return nil // not installed, nothing to do
+// PicoClawBinaries lists every picoclaw binary on this machine: where
+// claw-migrate recorded installing it, the one on PATH, the usual install
+// locations (release installs, bundle imports, make install, go install),
+// and the previous binaries kept by upgrades
+func PicoClawBinaries(recorded string) []string {
+	home, _ := os.UserHomeDir()
+	candidates := []string{recorded}
+	if path, err := exec.LookPath("picoclaw"); err == nil {
+		candidates = append(candidates, path)
 	}
+	candidates = append(candidates,
+		"/usr/local/bin/picoclaw",
+		filepath.Join(home, ".local", "bin", "picoclaw"),
+		filepath.Join(home, "go", "bin", "picoclaw"),
+	)
+
+	var found []string
+	seen := map[string]bool{}
+	for _, c := range candidates {
+		for _, path := range []string{c, c + ".previous"} {
+			if c == "" || seen[path] {
+				continue
+			}
+			seen[path] = true
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				found = append(found, path)
+			}
+		}
+	}
+	return found
+}
 
+// RemovePicoClawBinary removes a picoclaw binary, with sudo if need be
+func RemovePicoClawBinary(path string) error {
 	// Try direct removal
-	if err := os.Remove(path); err == nil {
+	if err := preserve.Remove(path); err == nil {
 		return nil
 	}
 
 	// Fall back to sudo
-	cmd := exec.Command("sudo", "rm", "-f", path)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	return preserve.SudoRemove(path)
 }
 
 // RemovePicoClawLaunchAgents removes macOS launch agents for PicoClaw
@@ -101,13 +138,52 @@ func RemovePicoClawLaunchAgents() []string {
 	return removeLaunchAgentsMatching("picoclaw")
 }
 
-// VerifyPicoClawRemoved checks that PicoClaw is fully removed
-func VerifyPicoClawRemoved() (binaryGone, dataGone, agentsGone bool) {
-	_, err := exec.LookPath("picoclaw")
-	binaryGone = err != nil
+// RemovePicoClawServices stops, disables and removes PicoClaw's systemd
+// units, user and system-wide, returning the unit files removed
+func RemovePicoClawServices() []string {
+	var removed []string
+	for _, path := range PicoClawServices() {
+		unit := filepath.Base(path)
+		var err error
+		if strings.HasPrefix(path, "/etc/") {
+			exec.Command("sudo", "systemctl", "disable", "--now", unit).Run()
+			err = preserve.SudoRemove(path)
+		} else {
+			exec.Command("systemctl", "--user", "disable", "--now", unit).Run()
+			err = preserve.Remove(path)
+		}
+		if err == nil {
+			removed = append(removed, path)
+		}
+	}
+	if len(removed) > 0 {
+		exec.Command("systemctl", "--user", "daemon-reload").Run()
+	}
+	return removed
+}
+
+// PicoClawServices lists PicoClaw's systemd unit files
+func PicoClawServices() []string {
+	home, _ := os.UserHomeDir()
+	var units []string
+	for _, dir := range []string{filepath.Join(home, ".config", "systemd", "user"), "/etc/systemd/system"} {
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			if strings.Contains(strings.ToLower(e.Name()), "picoclaw") && !e.IsDir() {
+				units = append(units, filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+	return units
+}
+
+// VerifyPicoClawRemoved checks that PicoClaw is fully removed, given where
+// claw-migrate recorded installing it
+func VerifyPicoClawRemoved(recorded string) (binaryGone, dataGone, agentsGone bool) {
+	binaryGone = len(PicoClawBinaries(recorded)) == 0
 
 	home, _ := os.UserHomeDir()
-	_, err = os.Stat(filepath.Join(home, ".picoclaw"))
+	_, err := os.Stat(filepath.Join(home, ".picoclaw"))
 	dataGone = os.IsNotExist(err)
 
 	launchDir := filepath.Join(home, "Library", "LaunchAgents")
@@ -119,6 +195,7 @@ func VerifyPicoClawRemoved() (binaryGone, dataGone, agentsGone bool) {
 			break
 		}
 	}
+	agentsGone = agentsGone && len(PicoClawServices()) == 0
 
 	return
 }
@@ -127,6 +204,22 @@ func VerifyPicoClawRemoved() (binaryGone, dataGone, agentsGone bool) {
 // Shared helpers
 // ════════════════════════════════════════════════════════════
 
+// killMatching stops the processes whose command line mentions name,
+// except this one and the shell that started it — "claw-migrate
+// uninstall-openclaw" mentions it too
+func killMatching(name string) {
+	out, _ := exec.Command("pgrep", "-f", name).Output()
+	for _, field := range strings.Fields(string(out)) {
+		pid, err := strconv.Atoi(field)
+		if err != nil || pid == os.Getpid() || pid == os.Getppid() {
+			continue
+		}
+		if p, err := os.FindProcess(pid); err == nil {
+			p.Signal(syscall.SIGTERM)
+		}
+	}
+}
+
 func removeLaunchAgentsMatching(keywords ...string) []string {
 	home, _ := os.UserHomeDir()
 	launchDir := filepath.Join(home, "Library", "LaunchAgents")
@@ -152,7 +245,7 @@ func removeLaunchAgentsMatching(keywords ...string) []string {
 
 		fullPath := filepath.Join(launchDir, entry.Name())
 		exec.Command("launchctl", "unload", fullPath).Run()
-		if err := os.Remove(fullPath); err == nil {
+		if err := preserve.Remove(fullPath); err == nil {
 			removed = append(removed, entry.Name())
 		}
 	}
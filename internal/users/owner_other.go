@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package users
+
+// owner is not available on this platform, so no accounts are found
+func owner(path string) (uid, gid int, ok bool) {
+	return 0, 0, false
+}
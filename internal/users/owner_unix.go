@@ -0,0 +1,21 @@
+//go:build linux || darwin
+
+package users
+
+import (
+	"os"
+	"syscall"
+)
+
+// owner returns the uid and gid that own path
+func owner(path string) (uid, gid int, ok bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, 0, false
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return int(st.Uid), int(st.Gid), true
+}
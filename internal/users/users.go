@@ -0,0 +1,83 @@
+package users
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Account is a local user whose home holds an OpenClaw install
+type Account struct {
+	Name string
+	Home string
+	UID  int
+	GID  int
+}
+
+// homeRoots are the directories that hold user homes on this platform
+func homeRoots() []string {
+	if runtime.GOOS == "darwin" {
+		return []string{"/Users"}
+	}
+	return []string{"/home"}
+}
+
+// WithOpenClaw lists the users that have ~/.openclaw, sorted by name. The
+// account's owner is taken from its home directory.
+func WithOpenClaw() []Account {
+	var accounts []Account
+	for _, root := range homeRoots() {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			home := filepath.Join(root, entry.Name())
+			if info, err := os.Stat(filepath.Join(home, ".openclaw")); err != nil || !info.IsDir() {
+				continue
+			}
+			uid, gid, ok := owner(home)
+			if !ok {
+				continue
+			}
+			accounts = append(accounts, Account{Name: entry.Name(), Home: home, UID: uid, GID: gid})
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Name < accounts[j].Name })
+	return accounts
+}
+
+// Env returns the environment for running as the account: the current one
+// with HOME, USER and LOGNAME replaced
+func (a Account) Env() []string {
+	env := []string{"HOME=" + a.Home, "USER=" + a.Name, "LOGNAME=" + a.Name}
+	for _, kv := range os.Environ() {
+		switch {
+		case hasKey(kv, "HOME"), hasKey(kv, "USER"), hasKey(kv, "LOGNAME"):
+			continue
+		}
+		env = append(env, kv)
+	}
+	return env
+}
+
+// Chown gives path and everything under it to the account. Symlinks are
+// changed themselves, not followed. A missing path is not an error.
+func (a Account) Chown(path string) error {
+	if _, err := os.Lstat(path); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Lchown(p, a.UID, a.GID)
+	})
+}
+
+func hasKey(kv, key string) bool {
+	return strings.HasPrefix(kv, key+"=")
+}
@@ -0,0 +1,139 @@
+// Package watch checks on a freshly migrated PicoClaw: that its status
+// command succeeds, its gateway answers, and its chat channels' bot tokens
+// still reach their platforms
+package watch
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arunbluez/claw-migrate/internal/ports"
+	"github.com/arunbluez/claw-migrate/internal/webhooks"
+)
+
+// Interval is how often a watch probes
+const Interval = time.Minute
+
+// Check is the outcome of one probe
+type Check struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+var client = &http.Client{Timeout: 5 * time.Second}
+
+// Probe runs every check once against the binary and a PicoClaw config
+func Probe(binary string, cfg map[string]interface{}) []Check {
+	checks := []Check{status(binary), gateway(cfg)}
+	return append(checks, channels(cfg)...)
+}
+
+// status runs picoclaw status
+func status(binary string) Check {
+	c := Check{Name: "picoclaw status"}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, binary, "status").CombinedOutput()
+	if err != nil {
+		c.Detail = firstLine(string(out))
+		if c.Detail == "" {
+			c.Detail = err.Error()
+		}
+		return c
+	}
+	c.OK = true
+	return c
+}
+
+// gateway asks the gateway's /health endpoint. A gateway without one
+// still counts as up if it answers below 500.
+func gateway(cfg map[string]interface{}) Check {
+	host, port := ports.Gateway(cfg)
+	if host == "" || host == ports.DefaultHost || host == "::" {
+		host = "127.0.0.1"
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	c := Check{Name: "gateway " + addr}
+	resp, err := client.Get("http://" + addr + "/health")
+	if err != nil {
+		c.Detail = "not answering"
+		return c
+	}
+	resp.Body.Close()
+	c.Detail = resp.Status
+	c.OK = resp.StatusCode < 500
+	return c
+}
+
+// channels checks the bot token of each enabled channel that can be asked
+func channels(cfg map[string]interface{}) []Check {
+	chans, _ := cfg["channels"].(map[string]interface{})
+	var names []string
+	for name := range chans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var checks []Check
+	for _, name := range names {
+		ch, _ := chans[name].(map[string]interface{})
+		if enabled, ok := ch["enabled"].(bool); ok && !enabled {
+			continue
+		}
+		token := webhooks.Token(ch)
+		if token == "" || (name != "telegram" && name != "discord") {
+			continue
+		}
+		c := Check{Name: name, OK: true}
+		if err := webhooks.Ping(name, token); err != nil {
+			c.OK, c.Detail = false, err.Error()
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// Changes returns the checks in now whose state differs from before; on
+// the first probe (before is nil) that is every failing check
+func Changes(before, now []Check) []Check {
+	was := make(map[string]bool)
+	for _, c := range before {
+		was[c.Name] = c.OK
+	}
+	var changed []Check
+	for _, c := range now {
+		ok, seen := was[c.Name]
+		if (!seen && !c.OK) || (seen && ok != c.OK) {
+			changed = append(changed, c)
+		}
+	}
+	return changed
+}
+
+// Describe says what a check found, for alerts
+func Describe(c Check) string {
+	state := "up"
+	if !c.OK {
+		state = "down"
+	}
+	if c.Detail == "" {
+		return fmt.Sprintf("%s: %s", c.Name, state)
+	}
+	return fmt.Sprintf("%s: %s (%s)", c.Name, state, c.Detail)
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
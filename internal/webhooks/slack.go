@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SlackManifestFile is written next to the PicoClaw config
+const SlackManifestFile = "slack-manifest.json"
+
+// Scopes and events PicoClaw's Slack channel uses to read and answer
+// messages in channels, private channels and DMs
+var (
+	slackScopes = []string{
+		"app_mentions:read", "channels:history", "channels:read", "chat:write",
+		"files:read", "files:write", "groups:history", "groups:read",
+		"im:history", "im:read", "im:write", "mpim:history", "mpim:read",
+		"reactions:read", "reactions:write", "users:read",
+	}
+	slackEvents = []string{
+		"app_mention", "message.channels", "message.groups", "message.im", "message.mpim",
+	}
+)
+
+// SlackManifest builds a Slack app manifest for the migrated Slack channel.
+// Without a request URL the app uses Socket Mode, which PicoClaw connects
+// to with the channel's app token; with one, events and slash commands are
+// delivered to requestURL instead. The slash command comes from the
+// channel's slash_command, if it has one enabled.
+func SlackManifest(channel map[string]interface{}, requestURL string) ([]byte, error) {
+	name := "PicoClaw"
+	if s, ok := channel["name"].(string); ok && s != "" {
+		name = s
+	}
+	scopes := append([]string(nil), slackScopes...)
+
+	events := map[string]interface{}{"bot_events": slackEvents}
+	interactivity := map[string]interface{}{"is_enabled": true}
+	if requestURL != "" {
+		events["request_url"] = requestURL
+		interactivity["request_url"] = requestURL
+	}
+	features := map[string]interface{}{
+		"app_home": map[string]interface{}{
+			"messages_tab_enabled":           true,
+			"messages_tab_read_only_enabled": false,
+		},
+		"bot_user": map[string]interface{}{"display_name": name, "always_online": true},
+	}
+	if cmd := slackCommand(channel); cmd != "" {
+		scopes = append(scopes, "commands")
+		command := map[string]interface{}{
+			"command":       "/" + cmd,
+			"description":   "Talk to " + name,
+			"should_escape": false,
+		}
+		if requestURL != "" {
+			command["url"] = requestURL
+		}
+		features["slash_commands"] = []interface{}{command}
+	}
+
+	manifest := map[string]interface{}{
+		"display_information": map[string]interface{}{"name": name},
+		"features":            features,
+		"oauth_config": map[string]interface{}{
+			"scopes": map[string]interface{}{"bot": scopes},
+		},
+		"settings": map[string]interface{}{
+			"event_subscriptions":    events,
+			"interactivity":          interactivity,
+			"org_deploy_enabled":     false,
+			"socket_mode_enabled":    requestURL == "",
+			"token_rotation_enabled": false,
+		},
+	}
+	return json.MarshalIndent(manifest, "", "  ")
+}
+
+// SlackAppURL is where a Slack app's manifest is edited: the app's own
+// page when the config knows its ID, else the list of apps
+func SlackAppURL(channel map[string]interface{}) string {
+	if id, ok := channel["app_id"].(string); ok && id != "" {
+		return "https://api.slack.com/apps/" + id + "/app-manifest"
+	}
+	return "https://api.slack.com/apps"
+}
+
+// slackCommand is the enabled slash command's name, without the slash
+func slackCommand(channel map[string]interface{}) string {
+	cmd, ok := channel["slash_command"].(map[string]interface{})
+	if !ok || cmd["enabled"] != true {
+		return ""
+	}
+	name, _ := cmd["name"].(string)
+	return strings.TrimPrefix(name, "/")
+}
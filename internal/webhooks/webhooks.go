@@ -0,0 +1,227 @@
+// Package webhooks finds the callback URLs chat platforms still deliver to
+// on OpenClaw's behalf — a Telegram bot's webhook, a Discord application's
+// interactions endpoint — and moves them, so the bots keep working once
+// OpenClaw is gone. PicoClaw's Telegram and Discord channels connect out
+// (long polling, the Discord gateway), so by default the callbacks are
+// removed; with a URL they are pointed at whatever fronts PicoClaw instead.
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// API roots, variables so they can be pointed at a local server
+var (
+	TelegramAPI = "https://api.telegram.org"
+	DiscordAPI  = "https://discord.com/api/v10"
+)
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// Hook is a callback a platform delivers to
+type Hook struct {
+	Channel string // config channel name: telegram or discord
+	Token   string // bot token
+	URL     string // where the platform delivers now, "" if nowhere
+	Pending int    // Telegram updates queued for the webhook
+	Err     error  // the platform couldn't be asked
+}
+
+// Command is an entry of a Telegram bot's command menu
+type Command struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// Token returns a channel's bot token from a PicoClaw config, or "" if it
+// has none or only refers to an environment variable
+func Token(channel map[string]interface{}) string {
+	for _, k := range []string{"token", "bot_token"} {
+		if s, ok := channel[k].(string); ok && s != "" && !strings.Contains(s, "$") {
+			return s
+		}
+	}
+	return ""
+}
+
+// Commands returns the custom command menu a channel config defines
+func Commands(channel map[string]interface{}) []Command {
+	items, _ := channel["custom_commands"].([]interface{})
+	var cmds []Command
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := m["command"].(string)
+		desc, _ := m["description"].(string)
+		name = strings.TrimPrefix(name, "/")
+		if name != "" && desc != "" {
+			cmds = append(cmds, Command{name, desc})
+		}
+	}
+	return cmds
+}
+
+// Configured returns the enabled channels with a bot token, whose
+// platforms Find would ask, without asking them
+func Configured(cfg map[string]interface{}) []string {
+	channels, _ := cfg["channels"].(map[string]interface{})
+	var names []string
+	for _, name := range []string{"telegram", "discord"} {
+		channel, ok := channels[name].(map[string]interface{})
+		if ok && channel["enabled"] != false && Token(channel) != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Find asks each platform with a bot token in the config where it delivers
+func Find(cfg map[string]interface{}) []Hook {
+	channels, _ := cfg["channels"].(map[string]interface{})
+	var hooks []Hook
+	for _, name := range Configured(cfg) {
+		channel := channels[name].(map[string]interface{})
+		token := Token(channel)
+		hook := Hook{Channel: name, Token: token}
+		if name == "telegram" {
+			hook.URL, hook.Pending, hook.Err = telegramWebhook(token)
+		} else {
+			hook.URL, hook.Err = discordEndpoint(token)
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks
+}
+
+// Move points a hook at target, or removes it if target is ""
+func Move(h Hook, target string) error {
+	if target != "" {
+		u, err := url.Parse(target)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("%s is not an https URL", target)
+		}
+	}
+	if h.Channel == "telegram" {
+		if target == "" {
+			return telegram(h.Token, "deleteWebhook", nil, nil) // queued updates go to polling
+		}
+		return telegram(h.Token, "setWebhook", url.Values{"url": {target}}, nil)
+	}
+	var endpoint interface{} // null clears it
+	if target != "" {
+		endpoint = target
+	}
+	return discord(h.Token, "PATCH", "/applications/@me", map[string]interface{}{"interactions_endpoint_url": endpoint}, nil)
+}
+
+// SetCommands replaces a Telegram bot's command menu
+func SetCommands(token string, cmds []Command) error {
+	data, err := json.Marshal(cmds)
+	if err != nil {
+		return err
+	}
+	return telegram(token, "setMyCommands", url.Values{"commands": {string(data)}}, nil)
+}
+
+// Ping checks a bot's token with its platform: Telegram's getMe, or the
+// Discord user the token belongs to. Other channels can't be asked.
+func Ping(channel, token string) error {
+	switch channel {
+	case "telegram":
+		return telegram(token, "getMe", nil, nil)
+	case "discord":
+		return discord(token, "GET", "/users/@me", nil, nil)
+	}
+	return fmt.Errorf("%s: no way to check it", channel)
+}
+
+func telegramWebhook(token string) (string, int, error) {
+	var info struct {
+		URL     string `json:"url"`
+		Pending int    `json:"pending_update_count"`
+	}
+	err := telegram(token, "getWebhookInfo", nil, &info)
+	return info.URL, info.Pending, err
+}
+
+// telegram calls a Bot API method and decodes its result into out
+func telegram(token, method string, params url.Values, out interface{}) error {
+	resp, err := client.PostForm(TelegramAPI+"/bot"+token+"/"+method, params)
+	if err != nil {
+		return fmt.Errorf("telegram: %s", redact(err, token))
+	}
+	defer resp.Body.Close()
+	var body struct {
+		OK          bool            `json:"ok"`
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("telegram: unexpected answer (status %d)", resp.StatusCode)
+	}
+	if !body.OK {
+		return fmt.Errorf("telegram: %s", body.Description)
+	}
+	if out != nil {
+		return json.Unmarshal(body.Result, out)
+	}
+	return nil
+}
+
+func discordEndpoint(token string) (string, error) {
+	var app struct {
+		URL string `json:"interactions_endpoint_url"`
+	}
+	err := discord(token, "GET", "/applications/@me", nil, &app)
+	return app.URL, err
+}
+
+// discord calls the Discord API as the bot
+func discord(token, method, path string, in, out interface{}) error {
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, DiscordAPI+path, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bot "+token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("discord: %s", redact(err, token))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		var e struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&e)
+		if e.Message == "" {
+			e.Message = resp.Status
+		}
+		return fmt.Errorf("discord: %s", e.Message)
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+// redact keeps the token, which is part of Telegram's URLs, out of errors
+func redact(err error, token string) string {
+	return strings.ReplaceAll(err.Error(), token, "<token>")
+}
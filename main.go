@@ -1,52 +1,308 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/arunbluez/claw-migrate/internal/assist"
 	"github.com/arunbluez/claw-migrate/internal/backup"
+	"github.com/arunbluez/claw-migrate/internal/bundle"
+	"github.com/arunbluez/claw-migrate/internal/compat"
+	"github.com/arunbluez/claw-migrate/internal/config"
 	"github.com/arunbluez/claw-migrate/internal/detect"
+	"github.com/arunbluez/claw-migrate/internal/docker"
+	"github.com/arunbluez/claw-migrate/internal/dotfiles"
+	"github.com/arunbluez/claw-migrate/internal/envfile"
+	"github.com/arunbluez/claw-migrate/internal/i18n"
 	"github.com/arunbluez/claw-migrate/internal/install"
+	"github.com/arunbluez/claw-migrate/internal/iolimit"
+	"github.com/arunbluez/claw-migrate/internal/journal"
+	"github.com/arunbluez/claw-migrate/internal/k8s"
+	"github.com/arunbluez/claw-migrate/internal/lint"
+	"github.com/arunbluez/claw-migrate/internal/localmodels"
 	"github.com/arunbluez/claw-migrate/internal/migrate"
+	"github.com/arunbluez/claw-migrate/internal/models"
+	"github.com/arunbluez/claw-migrate/internal/nativehost"
+	"github.com/arunbluez/claw-migrate/internal/nix"
+	"github.com/arunbluez/claw-migrate/internal/notify"
+	"github.com/arunbluez/claw-migrate/internal/perms"
+	"github.com/arunbluez/claw-migrate/internal/plan"
+	"github.com/arunbluez/claw-migrate/internal/ports"
+	"github.com/arunbluez/claw-migrate/internal/preserve"
+	"github.com/arunbluez/claw-migrate/internal/runs"
+	"github.com/arunbluez/claw-migrate/internal/sandbox"
+	"github.com/arunbluez/claw-migrate/internal/secrets"
+	"github.com/arunbluez/claw-migrate/internal/settings"
+	"github.com/arunbluez/claw-migrate/internal/stats"
+	"github.com/arunbluez/claw-migrate/internal/templates"
+	"github.com/arunbluez/claw-migrate/internal/todo"
 	"github.com/arunbluez/claw-migrate/internal/ui"
 	"github.com/arunbluez/claw-migrate/internal/uninstall"
+	"github.com/arunbluez/claw-migrate/internal/users"
+	"github.com/arunbluez/claw-migrate/internal/watch"
+	"github.com/arunbluez/claw-migrate/internal/webhooks"
 )
 
 var version = "dev"
 
-// Known outdated models and their recommended replacements
-var modelUpgrades = map[string]string{
-	"anthropic/claude-sonnet-4-5":              "anthropic/claude-sonnet-4-6",
-	"anthropic/claude-3-5-sonnet":              "anthropic/claude-sonnet-4-6",
-	"anthropic/claude-3-opus":                  "anthropic/claude-opus-4-6",
-	"openai/gpt-4":                             "openai/gpt-5.2",
-	"openai/gpt-4-turbo":                       "openai/gpt-5.2",
-	"openai/gpt-4o":                            "openai/gpt-5.2",
-	"openrouter/anthropic/claude-sonnet-4-5":   "openrouter/anthropic/claude-sonnet-4-6",
-	"openrouter/anthropic/claude-3-5-sonnet":   "openrouter/anthropic/claude-sonnet-4-6",
+// options holds the command-line flags shared by all commands
+type options struct {
+	dryRun        bool
+	skipInstall   bool
+	skipUninstall bool
+	maxErrors     int              // abort the workspace copy after this many failures
+	ioLimit       *iolimit.Limiter // throttles backup and workspace copy IO
+	fsync         string           // which copied files to fsync (migrate.Sync*)
+	move          bool             // delete sources as they are copied
+	scrub         bool             // redact secrets in workspace copies (never the backup)
+	assist        bool             // ask a configured model to map config sections the converter doesn't know
+	sandbox       bool             // try the converted config on PicoClaw under a temporary HOME first
+	offline       bool             // lint: don't try to reach api_base URLs
+	webhookURL    string           // point Telegram/Discord callbacks at <url>/<channel> instead of removing them
+	openclawData  string           // uninstall: dataKeep or dataPurge to settle ~/.openclaw without asking
+	copyStrategy  string           // migrate.Strategy* name, "" = auto
+	backupFormat  string           // backup.FormatTarGz or backup.FormatZip
+	stdout        *os.File         // backup: stream the archive here instead of writing a file
+	encrypt       string           // export-secrets: age, gpg or passphrase
+	recipient     string           // export-secrets: age/gpg public-key recipient
+	identity      string           // import-secrets: age identity file
+	toDocker      string           // migrate into a container layout in this directory instead of the host
+	toK8s         string           // migrate into Kubernetes manifests in this directory instead of the host
+	toNix         string           // write a home-manager module for the config and service into this directory
+	output        string           // --target-os/--target-arch: directory to provision into
+	prefer        string           // config.Prefer*: which side wins a key both configs set, "" = ask once
+	force         bool             // uninstall: delete ~/.openclaw unattended even without a verified backup
+	purgeAfter    int              // uninstall: leave ~/.openclaw for this many days, then purge-due deletes it
+	notifyURL     string           // migrate: post the result here as JSON; watch: post alerts here
+	noDesktop     bool             // no desktop notifications when long phases end or watch alerts
+	planFile      string           // migrate --dry-run: write what it would do here, for apply
+	noPrefetch    bool             // migrate: download PicoClaw in phase 3, not alongside the backup
 }
 
 func main() {
-	dryRun := false
-	skipInstall := false
-	skipUninstall := false
+	opts := options{maxErrors: 50}
 	subcommand := ""
+	showHelp := false
+	refresh := false
+	noDelete := false
+	allUsers := false
+	toStdout := false
+	fromPlan := ""
+	i18n.SetLang(i18n.Detect())
 
 	args := []string{}
-	for _, arg := range os.Args[1:] {
-		switch arg {
+	argv := os.Args[1:]
+	for i := 0; i < len(argv); i++ {
+		arg := argv[i]
+
+		// Support both "--flag value" and "--flag=value"
+		name, inline, hasInline := arg, "", false
+		if strings.HasPrefix(arg, "--") {
+			name, inline, hasInline = strings.Cut(arg, "=")
+		}
+		value := func() string {
+			if hasInline {
+				return inline
+			}
+			if i+1 >= len(argv) {
+				ui.Fatal(i18n.T("%s requires a value", name))
+			}
+			i++
+			return argv[i]
+		}
+
+		switch name {
 		case "--dry-run":
-			dryRun = true
+			opts.dryRun = true
+		case "--yes", "-y":
+			ui.SetAssumeYes(true)
 		case "--skip-install":
-			skipInstall = true
+			opts.skipInstall = true
 		case "--skip-uninstall":
-			skipUninstall = true
+			opts.skipUninstall = true
+		case "--move":
+			opts.move = true
+		case "--no-delete":
+			noDelete = true
+		case "--share-stats", "--no-share-stats":
+			share := name == "--share-stats"
+			s := settings.Load()
+			s.ShareStats = &share
+			if err := s.Save(); err != nil {
+				ui.Warn(i18n.T("Could not save stats preference: %v", err))
+			}
+		case "--reset-decisions":
+			s := settings.Load()
+			if n := len(s.DeclinedUpgrades); n > 0 {
+				s.DeclinedUpgrades = nil
+				if err := s.Save(); err != nil {
+					ui.Warn(i18n.T("Could not save settings: %v", err))
+				} else {
+					ui.Info(i18n.T("Forgot %d declined model upgrade(s)", n))
+				}
+			}
+		case "--prompt-timeout":
+			raw := value()
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				d, err = time.ParseDuration(raw + "s") // bare seconds
+			}
+			if err != nil || d <= 0 {
+				ui.Fatal("--prompt-timeout takes a duration, e.g. --prompt-timeout 300s")
+			}
+			ui.SetPromptTimeout(d)
+		case "--max-errors":
+			n, err := strconv.Atoi(value())
+			if err != nil || n < 0 {
+				ui.Fatal("--max-errors expects a non-negative number")
+			}
+			opts.maxErrors = n
+		case "--format":
+			opts.backupFormat = value()
+			if opts.backupFormat != backup.FormatTarGz && opts.backupFormat != backup.FormatZip {
+				ui.Fatal("--format expects tar.gz or zip")
+			}
+		case "--stdout":
+			toStdout = true
+		case "--include-prereleases":
+			install.IncludePrereleases = true
+		case "--target-os":
+			install.TargetOS = value()
+			if _, err := install.ReleaseFilename(install.TargetOS, "arm64"); err != nil {
+				ui.Fatal("--target-os expects one of: linux, darwin, freebsd")
+			}
+		case "--target-arch":
+			install.TargetArch = value()
+			if _, err := install.ReleaseFilename("linux", install.TargetArch); err != nil {
+				ui.Fatal("--target-arch expects one of: amd64, arm64, arm, mips64, riscv64")
+			}
+		case "--prefer":
+			opts.prefer = value()
+			switch opts.prefer {
+			case config.PreferIncoming, config.PreferExisting, config.PreferAsk:
+			default:
+				ui.Fatal("--prefer expects one of: existing, incoming, ask")
+			}
+		case "--output", "-o":
+			opts.output = value()
+		case "--copy-strategy":
+			opts.copyStrategy = value()
+			if opts.copyStrategy != migrate.StrategyAuto {
+				if _, err := migrate.NewStrategy(opts.copyStrategy); err != nil {
+					ui.Fatal(err.Error())
+				}
+			}
+		case "--assist":
+			opts.assist = true
+		case "--sandbox":
+			opts.sandbox = true
+		case "--offline":
+			opts.offline = true
+		case "--webhook-url":
+			opts.webhookURL = value()
+		case "--notify-url":
+			opts.notifyURL = value()
+		case "--no-desktop-notify":
+			opts.noDesktop = true
+		case "--keep-data", "--purge":
+			choice := dataKeep
+			if name == "--purge" {
+				choice = dataPurge
+			}
+			if opts.openclawData != "" && opts.openclawData != choice {
+				ui.Fatal("--keep-data and --purge can't be used together")
+			}
+			opts.openclawData = choice
+		case "--force":
+			opts.force = true
+		case "--purge-after":
+			days, err := strconv.Atoi(strings.TrimSuffix(value(), "d"))
+			if err != nil || days < 1 {
+				ui.Fatal("--purge-after takes a number of days, e.g. --purge-after 7")
+			}
+			opts.purgeAfter = days
+		case "--scrub":
+			opts.scrub = true
+		case "--fsync":
+			opts.fsync = value()
+			switch opts.fsync {
+			case migrate.SyncKeyFiles, migrate.SyncAll, migrate.SyncNone:
+			default:
+				ui.Fatal("--fsync expects one of: key, all, none")
+			}
+		case "--io-limit":
+			rate, err := iolimit.ParseRate(value())
+			if err != nil {
+				ui.Fatal(err.Error())
+			}
+			opts.ioLimit = iolimit.New(rate)
+		case "--net-limit":
+			rate, err := iolimit.ParseRate(value())
+			if err != nil {
+				ui.Fatal(err.Error())
+			}
+			install.LimitDownloads(iolimit.New(rate))
+		case "--no-prefetch":
+			opts.noPrefetch = true
+		case "--encrypt":
+			opts.encrypt = value()
+			switch opts.encrypt {
+			case secrets.MethodAge, secrets.MethodGPG, secrets.MethodPassphrase:
+			default:
+				ui.Fatal("--encrypt expects one of: age, gpg, passphrase")
+			}
+		case "--recipient":
+			opts.recipient = value()
+		case "--identity":
+			opts.identity = value()
+		case "--lang":
+			if err := i18n.SetLang(value()); err != nil {
+				ui.Fatal(err.Error())
+			}
+		case "--to-docker":
+			opts.toDocker = "picoclaw-docker"
+			if hasInline {
+				opts.toDocker = inline
+			}
+		case "--to-k8s":
+			opts.toK8s = "picoclaw-k8s"
+			if hasInline {
+				opts.toK8s = inline
+			}
+		case "--to-nix":
+			opts.toNix = "picoclaw-nix"
+			if hasInline {
+				opts.toNix = inline
+			}
+		case "--plan":
+			opts.planFile = "plan.json"
+			if hasInline {
+				opts.planFile = inline
+			}
+		case "--from-plan":
+			fromPlan = value()
+		case "--refresh":
+			refresh = true
+		case "--all-users":
+			allUsers = true
 		case "--help", "-h":
-			printHelp()
-			return
+			showHelp = true
 		case "--version", "-v":
 			fmt.Printf("claw-migrate %s\n", version)
 			return
@@ -57,23 +313,122 @@ func main() {
 		}
 	}
 
+	// Printed after parsing so a later --lang still applies
+	if showHelp {
+		printHelp()
+		return
+	}
+	detect.UseCache(filepath.Join(journal.Dir(), "cache"), refresh)
+	install.UseCache(filepath.Join(journal.Dir(), "cache"), refresh)
+	if opts.purgeAfter > 0 && opts.openclawData == dataKeep {
+		ui.Fatal("--keep-data keeps ~/.openclaw, so it can't be used with --purge-after")
+	}
+	if noDelete {
+		if opts.move {
+			ui.Fatal("--move deletes sources as they are copied, so it can't be used with --no-delete")
+		}
+		preserve.Enable(journal.Dir())
+	}
+	opts.notifyURL = notify.URL(opts.notifyURL, settings.Load().NotifyURL)
+
 	if len(args) > 0 {
 		subcommand = args[0]
 	}
+	if opts.planFile != "" && (!opts.dryRun || (subcommand != "" && subcommand != "migrate")) {
+		ui.Fatal("--plan only works with: claw-migrate migrate --dry-run")
+	}
+	if fromPlan != "" {
+		if subcommand != "migrate" || opts.dryRun {
+			ui.Fatal("--from-plan only works with: claw-migrate migrate")
+		}
+		p, err := plan.Load(fromPlan)
+		if err != nil {
+			ui.Fatal(i18n.T("Could not read the plan: %v", err))
+		}
+		ui.ReplayDecisions(p.Decisions)
+		if p.PicoClaw != "" {
+			install.LatestVersion, install.LatestSource = p.PicoClaw, install.SourcePlan
+		}
+	}
+
+	if install.CrossTarget() || opts.output != "" {
+		switch subcommand {
+		case "migrate", "install-picoclaw":
+			if opts.output == "" {
+				goos, goarch := install.Platform()
+				opts.output = "picoclaw-" + goos + "-" + goarch
+			}
+		case "export":
+		default:
+			ui.Fatal("--target-os, --target-arch and --output only work with: migrate, install-picoclaw, export")
+		}
+	}
+
+	// The archive owns stdout, so everything the UI prints goes to stderr
+	if toStdout {
+		if subcommand != "backup" || len(args) > 1 || allUsers {
+			ui.Fatal("--stdout only works with: claw-migrate backup")
+		}
+		if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			ui.Fatal("Refusing to write the backup archive to a terminal — pipe or redirect it")
+		}
+		opts.stdout = os.Stdout
+		os.Stdout = os.Stderr
+	}
+	if allUsers {
+		runAllUsers(subcommand, os.Args[1:], opts)
+		return
+	}
 
 	switch subcommand {
 	case "migrate":
-		runMigrate(dryRun, skipInstall, skipUninstall)
+		runMigrate(opts)
 	case "backup":
-		runBackup()
+		runBackup(args[1:], opts)
 	case "restore":
 		runRestore()
+	case "restore-file":
+		runRestoreFile(args[1:])
+	case "install-picoclaw":
+		runInstallPicoClaw(opts)
+	case "upgrade-picoclaw":
+		runUpgradePicoClaw(opts)
+	case "export":
+		runExport(opts)
+	case "import":
+		runImport(args[1:], opts)
+	case "export-secrets":
+		runExportSecrets(args[1:], opts)
+	case "import-secrets":
+		runImportSecrets(args[1:], opts)
+	case "retry":
+		runRetry(opts)
+	case "apply":
+		runApply(args[1:], opts)
+	case "undo":
+		runUndo(args[1:], opts)
+	case "status":
+		runStatus()
+	case "history":
+		runHistory(args[1:])
+	case "todo":
+		runTodo(args[1:])
+	case "diff-config":
+		runDiffConfig(args[1:])
+	case "lint":
+		runLint(args[1:], opts)
+	case "dotfiles":
+		runDotfiles(args[1:], opts)
 	case "uninstall":
-		runUninstallMenu()
+		runUninstallMenu(opts)
 	case "uninstall-openclaw":
-		runUninstallOpenClaw()
+		runUninstallOpenClaw(opts)
 	case "uninstall-picoclaw":
 		runUninstallPicoClaw()
+	case "purge-due":
+		runPurgeDue(args[1:], opts)
+	case "watch":
+		runWatch(args[1:], opts)
 	case "":
 		// Interactive menu
 		ui.Banner()
@@ -82,52 +437,253 @@ func main() {
 			"Backup    — Create a backup of OpenClaw",
 			"Restore   — Restore OpenClaw from a backup",
 			"Uninstall — Remove OpenClaw or PicoClaw",
-		})
+		}, -1)
 		switch choice {
 		case 0:
-			runMigrate(dryRun, skipInstall, skipUninstall)
+			runMigrate(opts)
 		case 1:
-			runBackup()
+			runBackup(nil, opts)
 		case 2:
 			runRestore()
 		case 3:
-			runUninstallMenu()
+			runUninstallMenu(opts)
 		}
 	default:
-		ui.Error(fmt.Sprintf("Unknown command: %s", subcommand))
+		ui.Error(i18n.T("Unknown command: %s", subcommand))
 		printHelp()
 		os.Exit(1)
 	}
+	reportPreserved()
+}
+
+// reportPreserved says where --no-delete put what would have been removed
+// or overwritten
+func reportPreserved() {
+	if kept := preserve.Kept(); len(kept) > 0 {
+		fmt.Println()
+		ui.Info(i18n.T("Nothing was deleted (--no-delete): %d item(s) set aside in %s", len(kept), preserve.Dir()))
+	}
 }
 
 func printHelp() {
-	fmt.Println("Usage: claw-migrate [command] [flags]")
+	fmt.Println(i18n.T("Usage: claw-migrate [command] [flags]"))
 	fmt.Println()
-	fmt.Println("Commands:")
-	fmt.Println("  migrate     Full OpenClaw → PicoClaw migration (default)")
-	fmt.Println("  backup      Create a backup of ~/.openclaw/")
-	fmt.Println("  restore     Restore OpenClaw from a backup")
-	fmt.Println("  uninstall   Remove OpenClaw or PicoClaw")
+	fmt.Println(i18n.T("Commands:"))
+	for _, c := range [][2]string{
+		{"migrate", "Full OpenClaw → PicoClaw migration (default)"},
+		{"backup", "Create a backup of ~/.openclaw/ (or: backup list | show FILE | extract FILE PATH [DEST])"},
+		{"restore", "Restore OpenClaw from a backup"},
+		{"restore-file", "Restore one file from the newest backup (restore-file PATH [openclaw|picoclaw])"},
+		{"retry", "Re-copy only the files that failed in the last migration"},
+		{"apply [FILE]", "Carry out a plan written by migrate --dry-run --plan (default plan.json), if OpenClaw hasn't changed since"},
+		{"undo model-upgrade", "Put back the model the last migration upgraded, leaving the rest of the migration in place"},
+		{"undo webhooks", "Point the Telegram and Discord webhooks moved off OpenClaw back where they delivered"},
+		{"status", "Show installations, backups, last migration and rollback options"},
+		{"history [N]", "List past backups, migrations, restores and uninstalls, newest first (last N, default 20)"},
+		{"watch [HOURS]", "After migrating, check every minute for HOURS (default 24) that PicoClaw stays up, alerting if not"},
+		{"todo", "List or tick off items needing manual attention (todo done N)"},
+		{"diff-config", "Show which OpenClaw settings were carried over, transformed or dropped"},
+		{"lint [FILE]", "Check a PicoClaw config (default ~/.picoclaw/config.json) for problems"},
+		{"dotfiles", "Add ~/.picoclaw (minus keys and bulky data) to chezmoi or a git repo (dotfiles [REPO])"},
+		{"export", "Package the converted config, workspace, binary and an install script for another machine (--output FILE)"},
+		{"import", "Check a bundle from export, install its binary, config and workspace, and verify (import FILE)"},
+		{"export-secrets", "Write API keys and tokens to an encrypted bundle (export-secrets [FILE])"},
+		{"import-secrets", "Add the keys from a bundle to the PicoClaw config (import-secrets FILE)"},
+		{"install-picoclaw", "Install PicoClaw only, for a fresh start without migrating"},
+		{"upgrade-picoclaw", "Back up ~/.picoclaw, install the latest PicoClaw release and re-check it"},
+		{"uninstall", "Remove OpenClaw or PicoClaw"},
+		{"purge-due", "Delete OpenClaw's data once a --purge-after grace period is over (purge-due cancel keeps it)"},
+	} {
+		fmt.Printf("  %-16s %s\n", c[0], i18n.T(c[1]))
+	}
+	fmt.Println()
+	fmt.Println(i18n.T("Flags:"))
+	for _, f := range [][2]string{
+		{"--dry-run", "Preview without making changes"},
+		{"--plan[=FILE]", "With --dry-run: write every intended action and the flags to FILE (default plan.json) for apply"},
+		{"--from-plan FILE", "Give a plan's recorded answers and PicoClaw release (used by apply)"},
+		{"--yes, -y", "Answer yes to every prompt but dangerous ones (unattended runs)"},
+		{"--prompt-timeout D", "Take the safe answer to a prompt nobody answers within D, e.g. 300s (no to dangerous ones)"},
+		{"--skip-install", "Use existing PicoClaw installation"},
+		{"--skip-uninstall", "Keep OpenClaw installed"},
+		{"--move", "Delete each source file once copied (for low disk space)"},
+		{"--no-delete", "Never delete: move anything that would be removed or overwritten to ~/.claw-migrate/preserved"},
+		{"--prefer SIDE", "Which value wins a config key OpenClaw and PicoClaw both set: incoming, existing or ask (per key)"},
+		{"--assist", "Ask a model from your config to map unrecognized config sections (review before applying)"},
+		{"--offline", "lint: don't check that api_base URLs are reachable"},
+		{"--sandbox", "Before migrating, check PicoClaw accepts the converted config under a temporary HOME"},
+		{"--keep-data", "uninstall-openclaw: remove the binary and services without prompting, keeping ~/.openclaw"},
+		{"--purge", "uninstall-openclaw: remove everything without prompting, including ~/.openclaw and Docker volumes; purge-due: delete without prompting"},
+		{"--force", "Delete ~/.openclaw and OpenClaw's Docker volumes under --yes or --purge even without a verified backup"},
+		{"--purge-after DAYS", "Uninstall: keep ~/.openclaw for DAYS more, then delete it with purge-due"},
+		{"--webhook-url <url>", "Point Telegram/Discord webhooks left by OpenClaw at <url>/<channel> instead of removing them"},
+		{"--notify-url <url>", "POST the migration result, and watch's alerts, to <url> as JSON (Slack-compatible)"},
+		{"--no-desktop-notify", "Don't show desktop notifications when long phases finish or watch alerts"},
+		{"--scrub", "Redact API keys and private keys found in migrated workspace files (the backup keeps them)"},
+		{"--format FORMAT", "Backup archive format: tar.gz (default) or zip"},
+		{"--stdout", "Stream the backup archive to stdout for piping (messages go to stderr)"},
+		{"--target-os OS", "Provision for another machine: linux, darwin or freebsd (with --target-arch)"},
+		{"--target-arch ARCH", "Provision for another machine: amd64, arm64, arm, mips64 or riscv64"},
+		{"--output DIR", "Where --target-os/--target-arch put the binary, config and workspace"},
+		{"--include-prereleases", "Install or upgrade to the newest release even if it is a pre-release"},
+		{"--copy-strategy S", "How to copy files: auto (default), reflink, ssd, hdd, network"},
+		{"--fsync MODE", "Flush copied files to disk: key (default), all, none"},
+		{"--io-limit RATE", "Throttle backup and copy IO, e.g. 50MB/s"},
+		{"--net-limit RATE", "Throttle the PicoClaw download, e.g. 5MB/s"},
+		{"--no-prefetch", "Download PicoClaw in phase 3 instead of while the backup runs"},
+		{"--max-errors N", "Abort the workspace copy after N failed files (default 50, 0 = never)"},
+		{"--reset-decisions", "Ask again about model upgrades declined in earlier runs"},
+		{"--share-stats", "Opt in to anonymous migration stats (remembered; --no-share-stats to opt out)"},
+		{"--encrypt METHOD", "Secrets bundle encryption: age, gpg, passphrase (default: first available)"},
+		{"--recipient ID", "Encrypt the secrets bundle to an age or gpg public key"},
+		{"--identity FILE", "age identity file for importing a bundle encrypted to a recipient"},
+		{"--to-docker[=DIR]", "Migrate into a Dockerfile + volume in DIR (default ./picoclaw-docker), not the host"},
+		{"--to-k8s[=DIR]", "Migrate into Kubernetes manifests + workspace tarball in DIR (default ./picoclaw-k8s)"},
+		{"--to-nix[=DIR]", "Declare the config and user service as a home-manager module in DIR (default ./picoclaw-nix)"},
+		{"--refresh", "Rescan everything instead of reusing ~/.claw-migrate/cache"},
+		{"--all-users", "As root, run migrate or backup for every user with ~/.openclaw"},
+		{"--lang LANG", "Interface language: en, zh-CN (default: from $LANG)"},
+		{"--version", "Show version"},
+		{"--help", "Show this help"},
+	} {
+		fmt.Printf("  %-18s %s\n", f[0], i18n.T(f[1]))
+	}
 	fmt.Println()
-	fmt.Println("Flags:")
-	fmt.Println("  --dry-run          Preview without making changes")
-	fmt.Println("  --skip-install     Use existing PicoClaw installation")
-	fmt.Println("  --skip-uninstall   Keep OpenClaw installed")
-	fmt.Println("  --version          Show version")
-	fmt.Println("  --help             Show this help")
+	fmt.Println(i18n.T("Run without arguments for interactive mode."))
+}
+
+// ════════════════════════════════════════════════════════════
+// Multi-user
+// ════════════════════════════════════════════════════════════
+
+// runAllUsers runs migrate or backup once for each user with ~/.openclaw,
+// as a child process with that user's HOME, then gives everything the run
+// created back to the user
+func runAllUsers(subcommand string, argv []string, opts options) {
+	childArgs := []string{}
+	if subcommand == "" {
+		subcommand = "migrate"
+		childArgs = append(childArgs, subcommand)
+	}
+	switch subcommand {
+	case "migrate", "backup":
+	default:
+		ui.Fatal("--all-users works with migrate and backup")
+	}
+	if os.Geteuid() != 0 {
+		ui.Fatal("--all-users must be run as root (e.g. with sudo)")
+	}
+
+	ui.Banner()
+	accounts := users.WithOpenClaw()
+	if len(accounts) == 0 {
+		ui.Info("No users with ~/.openclaw were found")
+		return
+	}
+	names := make([]string, len(accounts))
+	for i, a := range accounts {
+		names[i] = a.Name
+	}
+	ui.Info(i18n.T("Users with OpenClaw: %s", strings.Join(names, ", ")))
+	if opts.dryRun {
+		ui.Warn("DRY RUN mode — no changes will be made")
+	}
+	if !ui.Confirm(i18n.T("Run %s for each of these %d users in turn?", subcommand, len(accounts))) {
+		return
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		ui.Fatal(i18n.T("Cannot find the claw-migrate binary: %v", err))
+	}
+	for _, arg := range argv {
+		if arg != "--all-users" {
+			childArgs = append(childArgs, arg)
+		}
+	}
+
+	failed := 0
+	outcomes := make([]string, len(accounts))
+	for i, a := range accounts {
+		ui.Phase(i+1, i18n.T("User %s (%s)", a.Name, a.Home))
+		cmd := exec.Command(self, childArgs...)
+		cmd.Env = a.Env()
+		cmd.Dir = a.Home
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			failed++
+			outcomes[i] = i18n.T("failed: %v", err)
+		} else {
+			outcomes[i] = i18n.T("done")
+		}
+		if !opts.dryRun {
+			if err := chownUserFiles(a); err != nil {
+				ui.Warn(i18n.T("Could not give %s's files back to them: %v", a.Name, err))
+			}
+		}
+	}
+
 	fmt.Println()
-	fmt.Println("Run without arguments for interactive mode.")
+	for i, a := range accounts {
+		ui.Summary(a.Name, outcomes[i])
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// chownUserFiles hands what a root run created in a user's home to the user:
+// the PicoClaw home and workspace, claw-migrate's state and the backups
+func chownUserFiles(a users.Account) error {
+	picoHome := filepath.Join(a.Home, ".picoclaw")
+	paths := []string{picoHome, detect.PicoClawWorkspace(picoHome), filepath.Join(a.Home, ".claw-migrate")}
+	for _, pattern := range []string{"openclaw-backup-*", "picoclaw-backup-*"} {
+		matches, _ := filepath.Glob(filepath.Join(a.Home, pattern))
+		paths = append(paths, matches...)
+	}
+	for _, p := range paths {
+		if err := a.Chown(p); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ════════════════════════════════════════════════════════════
 // Standalone: Backup
 // ════════════════════════════════════════════════════════════
 
-func runBackup() {
+func runBackup(args []string, opts options) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "list":
+			runBackupList()
+		case "show":
+			if len(args) < 2 {
+				ui.Fatal("Usage: claw-migrate backup show FILE")
+			}
+			runBackupShow(args[1])
+		case "extract":
+			if len(args) < 3 {
+				ui.Fatal("Usage: claw-migrate backup extract FILE PATH [DEST]")
+			}
+			dest := ""
+			if len(args) > 3 {
+				dest = args[3]
+			}
+			runBackupExtract(args[1], args[2], dest)
+		default:
+			ui.Error(i18n.T("Unknown backup command: %s", args[0]))
+			printHelp()
+			os.Exit(1)
+		}
+		return
+	}
+
 	ui.Banner()
 	ui.Phase(1, "Backup OpenClaw")
 
-	oc := detect.DetectOpenClaw()
+	oc := detectOpenClaw()
 	if !oc.Found {
 		ui.Error("OpenClaw installation not found at ~/.openclaw/")
 		os.Exit(1)
@@ -136,733 +692,5183 @@ func runBackup() {
 	ui.Found("Directory", oc.HomeDir)
 	totalSize := detect.DirSize(oc.HomeDir)
 	ui.Found("Size", detect.FormatSize(totalSize))
-	doBackup(oc, false)
+	doBackup(oc, opts, "Migration cancelled.")
 
 	ui.Success("Done!")
 }
 
-// ════════════════════════════════════════════════════════════
-// Standalone: Restore
-// ════════════════════════════════════════════════════════════
-
-func runRestore() {
-	ui.Banner()
-	ui.Phase(1, "Restore OpenClaw from backup")
-
+// runBackupList prints every backup, newest first
+func runBackupList() {
 	backups := backup.ListBackups()
 	if len(backups) == 0 {
-		ui.Error("No backup files found (looking for ~/openclaw-backup-*.tar.gz)")
-		os.Exit(1)
+		ui.Info("No backup files found (looking for ~/openclaw-backup-*.tar.gz or .zip)")
+		return
 	}
+	for _, b := range backups {
+		ui.Found(backupTime(b), fmt.Sprintf("%-9s %s", backup.FormatSize(b.Size), b.Filename))
+	}
+}
 
-	ui.Step(1, fmt.Sprintf("Found %d backup(s)", len(backups)))
+// runBackupShow describes a backup's contents and the config inside it, without restoring
+func runBackupShow(file string) {
+	b := findBackup(file)
+	entries, err := backup.Contents(b.Path)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
 
-	options := make([]string, len(backups))
-	for i, b := range backups {
-		options[i] = fmt.Sprintf("%s (%s)", b.Filename, backup.FormatSize(b.Size))
+	ui.Step(1, b.Filename)
+	ui.Found("Created", backupTime(b))
+	ui.Found("Archive size", backup.FormatSize(b.Size))
+
+	// Group sizes by the first two path levels, e.g. .openclaw/workspace
+	var files int
+	var total int64
+	groups := map[string]int64{}
+	for _, e := range entries {
+		if e.IsDir {
+			continue
+		}
+		files++
+		total += e.Size
+		parts := strings.SplitN(e.Name, "/", 3)
+		key := parts[0]
+		if len(parts) == 3 {
+			key = parts[0] + "/" + parts[1] + "/"
+		} else if len(parts) == 2 {
+			key = e.Name
+		}
+		groups[key] += e.Size
 	}
+	ui.Found("Contents", i18n.T("%d files (%s uncompressed)", files, backup.FormatSize(total)))
 
-	choice := ui.Choose("Which backup do you want to restore?", options)
-	selected := backups[choice]
+	ui.Step(2, "Contents")
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		ui.Summary(k, backup.FormatSize(groups[k]))
+	}
 
-	ui.Warn(fmt.Sprintf("This will replace ~/.openclaw with the contents of %s", selected.Filename))
-	if !ui.ConfirmDangerous("Proceed with restore?") {
-		ui.Info("Restore cancelled.")
+	ui.Step(3, "Configuration")
+	data, err := backup.ReadFile(b.Path, ".openclaw/openclaw.json")
+	if err != nil {
+		ui.NotFound("Config file")
+		return
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		ui.Warn(i18n.T("Could not parse openclaw.json: %v", err))
 		return
 	}
+	summary := detect.SummarizeConfig(cfg)
+	if summary.DefaultModel != "" {
+		ui.Found("Default model", summary.DefaultModel)
+	}
+	if providers := detect.GetProviderKeys(cfg); len(providers) > 0 {
+		ui.Found("Providers", strings.Join(providers, ", "))
+	}
+	if channels := detect.GetConfiguredChannels(cfg); len(channels) > 0 {
+		ui.Found("Channels", strings.Join(channels, ", "))
+	}
+	if servers := detect.GetMCPServers(cfg); len(servers) > 0 {
+		ui.Found("MCP Servers", strings.Join(servers, ", "))
+	}
+	if summary.WorkspacePath != "" {
+		ui.Found("Workspace (from config)", summary.WorkspacePath)
+	}
+}
 
-	// Verify
-	ui.Step(2, "Verifying backup integrity")
-	verifyErr := ui.SpinnerRun("Verifying backup...", func() error {
-		return backup.VerifyBackup(selected.Path)
-	})
-	if verifyErr != nil {
-		ui.Error(fmt.Sprintf("Backup is corrupted: %v", verifyErr))
-		os.Exit(1)
+// runBackupExtract pulls one file out of a backup. The path may be given
+// relative to ~/.openclaw (workspace/SOUL.md) or as stored (.openclaw/workspace/SOUL.md).
+func runBackupExtract(file, name, dest string) {
+	b := findBackup(file)
+	entries, err := backup.Contents(b.Path)
+	if err != nil {
+		ui.Fatal(err.Error())
 	}
-	ui.Success("Backup verified")
 
-	// Restore
-	ui.Step(3, "Restoring")
-	restoreErr := ui.SpinnerRun("Restoring OpenClaw...", func() error {
-		return backup.RestoreBackup(selected.Path)
-	})
-	if restoreErr != nil {
-		ui.Error(fmt.Sprintf("Restore failed: %v", restoreErr))
-		os.Exit(1)
+	name = strings.TrimPrefix(name, "./")
+	stored := ""
+	for _, e := range entries {
+		if !e.IsDir && (e.Name == name || e.Name == ".openclaw/"+name) {
+			stored = e.Name
+			break
+		}
+	}
+	if stored == "" {
+		ui.Fatal(i18n.T("%s not found in %s — see: claw-migrate backup show %s", name, b.Filename, b.Filename))
 	}
 
-	ui.Success("OpenClaw restored from backup!")
-	ui.Info("Run: openclaw status")
-}
+	if dest == "" {
+		dest = filepath.Base(stored)
+	} else if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		dest = filepath.Join(dest, filepath.Base(stored))
+	}
+	if _, err := os.Stat(dest); err == nil {
+		if !ui.ConfirmDangerous(i18n.T("%s exists. Overwrite?", dest)) {
+			ui.Info("Cancelled.")
+			return
+		}
+	}
 
-// ════════════════════════════════════════════════════════════
-// Standalone: Uninstall
-// ════════════════════════════════════════════════════════════
+	if err := backup.ExtractFile(b.Path, stored, dest); err != nil {
+		ui.Fatal(i18n.T("Extraction failed: %v", err))
+	}
+	ui.Success(i18n.T("Extracted %s → %s", stored, dest))
+}
 
-func runUninstallMenu() {
-	ui.Banner()
+// runRestoreFile restores a single file from the newest backup into OpenClaw
+// or, for files the migration copied, into the matching PicoClaw location
+func runRestoreFile(args []string) {
+	if len(args) == 0 {
+		ui.Fatal("Usage: claw-migrate restore-file PATH [openclaw|picoclaw]")
+	}
+	name := strings.TrimPrefix(args[0], "./")
+	target := ""
+	if len(args) > 1 {
+		target = strings.ToLower(args[1])
+		if target != "openclaw" && target != "picoclaw" {
+			ui.Fatal("Target must be openclaw or picoclaw")
+		}
+	}
 
-	choice := ui.Choose("What do you want to uninstall?", []string{
-		"OpenClaw  — Remove OpenClaw (binary + data)",
-		"PicoClaw  — Remove PicoClaw (binary + data) for a fresh start",
-	})
+	backups := backup.ListBackups()
+	if len(backups) == 0 {
+		ui.Fatal("No backup files found (looking for ~/openclaw-backup-*.tar.gz or .zip)")
+	}
+	b := backups[0]
+	entries, err := backup.Contents(b.Path)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
 
-	switch choice {
+	// Accept the stored path, a path relative to ~/.openclaw or its workspace, or a bare file name
+	var matches []string
+	for _, e := range entries {
+		if e.IsDir {
+			continue
+		}
+		if e.Name == name || e.Name == ".openclaw/"+name || e.Name == ".openclaw/workspace/"+name {
+			matches = []string{e.Name}
+			break
+		}
+		if strings.HasSuffix(e.Name, "/"+name) {
+			matches = append(matches, e.Name)
+		}
+	}
+	var stored string
+	switch len(matches) {
 	case 0:
-		runUninstallOpenClaw()
+		ui.Fatal(i18n.T("%s not found in %s — see: claw-migrate backup show latest", name, b.Filename))
 	case 1:
-		runUninstallPicoClaw()
+		stored = matches[0]
+	default:
+		choice := ui.Choose(i18n.T("%d files in the backup match %s. Which one?", len(matches), name), matches, -1)
+		if choice < 0 {
+			ui.Info("Cancelled.")
+			return
+		}
+		stored = matches[choice]
 	}
-}
 
-func runUninstallOpenClaw() {
-	oc := detect.DetectOpenClaw()
-	if !oc.Found && oc.BinaryPath == "" {
-		ui.Error("OpenClaw installation not found")
-		os.Exit(1)
+	home, _ := os.UserHomeDir()
+	openclawDest := filepath.Join(home, filepath.FromSlash(stored))
+	picoDest := picoClawPathFor(stored)
+
+	dest := openclawDest
+	switch {
+	case target == "picoclaw":
+		if picoDest == "" {
+			ui.Fatal(i18n.T("%s is not migrated to PicoClaw — restore it to openclaw instead", stored))
+		}
+		dest = picoDest
+	case target == "" && picoDest != "":
+		choices := []string{
+			i18n.T("PicoClaw — %s", picoDest),
+			i18n.T("OpenClaw — %s", openclawDest),
+		}
+		switch ui.Choose("Restore to:", choices, -1) {
+		case -1:
+			ui.Info("Cancelled.")
+			return
+		case 0:
+			dest = picoDest
+		}
 	}
 
-	// Offer backup first
-	if oc.Found {
-		ui.Warn("It's recommended to create a backup before uninstalling.")
-		if ui.Confirm("Create a backup first?") {
-			doBackup(oc, false)
+	ui.Info(i18n.T("Restoring %s from %s (%s)", stored, b.Filename, backupTime(b)))
+	if _, err := os.Stat(dest); err == nil {
+		if !ui.ConfirmDangerous(i18n.T("Overwrite %s?", dest)) {
+			ui.Info("Cancelled.")
+			return
 		}
 	}
-
-	phase6Uninstall(oc, false)
-	ui.Success("Done!")
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		ui.Fatal(i18n.T("Restore failed: %v", err))
+	}
+	if err := backup.ExtractFile(b.Path, stored, dest); err != nil {
+		ui.Fatal(i18n.T("Restore failed: %v", err))
+	}
+	ui.Success(i18n.T("Restored %s", dest))
 }
 
-func runUninstallPicoClaw() {
-	home, _ := os.UserHomeDir()
-	picoHome := filepath.Join(home, ".picoclaw")
-
-	pc := detect.DetectPicoClaw()
-	if !pc.Found && pc.BinaryPath == "" {
-		ui.Error("PicoClaw installation not found")
-		os.Exit(1)
+// picoClawPathFor maps a file stored in a backup to where the migration put
+// it in PicoClaw, or "" if it wasn't copied there as a file
+func picoClawPathFor(stored string) string {
+	rel, ok := strings.CutPrefix(stored, ".openclaw/")
+	if !ok {
+		return ""
 	}
+	if _, err := os.Stat(picoClawHome()); err != nil {
+		return ""
+	}
+	picoWorkspace := detect.PicoClawWorkspace(picoClawHome())
 
-	ui.Phase(1, "Uninstall PicoClaw")
-
-	if pc.BinaryPath != "" {
-		ui.Found("Binary", pc.BinaryPath)
+	item, rest, _ := strings.Cut(rel, "/")
+	if item == "workspace" && rest != "" {
+		return filepath.Join(picoWorkspace, filepath.FromSlash(rest))
 	}
-	if pc.Found {
-		ui.Found("Data", picoHome)
-		totalSize := detect.DirSize(picoHome)
-		ui.Found("Size", detect.FormatSize(totalSize))
+	rule := migrate.RuleFor(item)
+	if rule.Action != migrate.ActionCopy {
+		return ""
 	}
+	return filepath.Join(picoWorkspace, rule.Dest, filepath.FromSlash(rest))
+}
 
-	ui.Warn("This will remove PicoClaw completely so you can start fresh.")
-	if !ui.ConfirmDangerous("Uninstall PicoClaw?") {
-		ui.Info("Cancelled.")
-		return
+// findBackup resolves a backup given as "latest", a file name in ~ or a path
+func findBackup(file string) backup.BackupInfo {
+	backups := backup.ListBackups()
+	if file == "latest" && len(backups) > 0 {
+		return backups[0]
 	}
-
-	// Stop processes
-	ui.Step(1, "Stopping PicoClaw processes")
-	uninstall.StopPicoClaw()
-	ui.Success("Processes stopped")
-
-	// Remove binary
-	if pc.BinaryPath != "" {
-		ui.Step(2, "Removing binary")
-		if err := uninstall.RemovePicoClawBinary(); err != nil {
-			ui.Warn(fmt.Sprintf("Could not remove binary: %v", err))
-			ui.Info("You may need to manually delete: " + pc.BinaryPath)
-		} else {
-			ui.Success("Binary removed")
+	for _, b := range backups {
+		if b.Filename == file || b.Path == file {
+			return b
 		}
 	}
+	if info, err := os.Stat(file); err == nil && !info.IsDir() {
+		abs, _ := filepath.Abs(file)
+		return backup.BackupInfo{Path: abs, Filename: filepath.Base(file), Size: info.Size(),
+			Timestamp: backup.Timestamp(filepath.Base(file))}
+	}
+	ui.Fatal(i18n.T("Backup not found: %s — see: claw-migrate backup list", file))
+	return backup.BackupInfo{}
+}
 
-	// Remove launch agents (macOS)
-	ui.Step(3, "Removing launch agents")
-	if removed := uninstall.RemovePicoClawLaunchAgents(); len(removed) > 0 {
-		ui.Success(fmt.Sprintf("Removed %d launch agent(s)", len(removed)))
-	} else {
-		ui.Info("No launch agents found")
-	}
+// ════════════════════════════════════════════════════════════
+// Standalone: Install
+// ════════════════════════════════════════════════════════════
 
-	// Remove data
-	if pc.Found {
-		ui.Step(4, "Removing data directory")
-		ui.Warn(fmt.Sprintf("About to delete: %s", picoHome))
+// runInstallPicoClaw installs PicoClaw without looking for OpenClaw
+func runInstallPicoClaw(opts options) {
+	ui.Banner()
+	if opts.dryRun {
+		ui.Warn("DRY RUN mode — no changes will be made")
+	}
 
-		if !ui.ConfirmDangerous("Delete all PicoClaw data?") {
-			ui.Info("Data directory preserved at " + picoHome)
-		} else {
-			if err := uninstall.RemoveData(picoHome); err != nil {
-				ui.Error(fmt.Sprintf("Could not remove data: %v", err))
-			} else {
-				ui.Success("PicoClaw data removed")
-			}
-		}
+	if opts.output != "" {
+		phaseProvision(1, detect.Installation{}, opts)
+		return
 	}
 
-	// Verify
-	ui.Step(5, "Verifying removal")
-	binaryGone, dataGone, _ := uninstall.VerifyPicoClawRemoved()
-	if binaryGone && dataGone {
-		ui.Success("PicoClaw completely removed")
-	} else {
-		if !binaryGone {
-			ui.Warn("Binary still found — try: sudo rm " + pc.BinaryPath)
-		}
-		if !dataGone {
-			ui.Warn("Data still found — try: rm -rf " + picoHome)
-		}
+	pc := detect.DetectPicoClaw()
+	sys := detect.GetSystemInfo()
+	ui.Phase(1, "Install PicoClaw")
+	installPicoClaw(detect.Installation{}, pc, sys, opts.dryRun)
+	if opts.dryRun {
+		return
 	}
 
-	fmt.Println()
-	ui.Info("You can now run a fresh migration with: ./claw-migrate migrate")
+	ui.Box("PicoClaw is ready", []string{
+		i18n.T("Config:    %s", filepath.Join(pc.HomeDir, "config.json")),
+		i18n.T("Workspace: %s", detect.PicoClawWorkspace(pc.HomeDir)),
+		"",
+		i18n.T("Coming from OpenClaw later? Run: claw-migrate migrate --skip-install"),
+	})
 }
 
-// ════════════════════════════════════════════════════════════
-// Full migration flow
-// ════════════════════════════════════════════════════════════
-
-func runMigrate(dryRun, skipInstall, skipUninstall bool) {
+// runUpgradePicoClaw brings an installed PicoClaw up to the latest release,
+// backing up ~/.picoclaw first and keeping the old binary for rollback
+func runUpgradePicoClaw(opts options) {
 	ui.Banner()
-
-	if dryRun {
+	if opts.dryRun {
 		ui.Warn("DRY RUN mode — no changes will be made")
 	}
+	ui.Phase(1, "Upgrade PicoClaw")
 
-	// Phase 1: Detect
-	phase1Detect()
-	oc := detect.DetectOpenClaw()
 	pc := detect.DetectPicoClaw()
-	sys := detect.GetSystemInfo()
-
-	if !oc.Found {
-		ui.Error("OpenClaw installation not found at ~/.openclaw/")
-		ui.Info("Make sure OpenClaw is installed and has been initialized.")
-		os.Exit(1)
+	if pc.BinaryPath == "" {
+		ui.Fatal("PicoClaw is not installed — run: claw-migrate install-picoclaw")
 	}
 
-	showDetectionResults(oc, pc, sys)
+	ui.Step(1, "Checking versions")
+	var latest string
+	ui.SpinnerRun("Fetching latest version...", func() error {
+		latest = install.FetchLatestVersion()
+		return nil
+	})
+	installed := install.ParseVersion(pc.Version)
+	ui.Found("Installed", i18n.T("%s (%s)", pc.Version, pc.BinaryPath))
+	showLatestVersion(latest)
+	if install.LatestSource == install.SourceFallback {
+		// Comparing against the built-in version would say nothing useful
+		ui.Error("Could not find out the latest release — try again later or set GITHUB_TOKEN")
+		return
+	}
 
-	if !ui.Confirm("Ready to begin migration?") {
-		ui.Info("Migration cancelled. No changes made.")
+	switch {
+	case installed == "":
+		ui.Warn("Could not tell which version is installed")
+		if !ui.Confirm(i18n.T("Install v%s anyway?", latest)) {
+			return
+		}
+	case install.CompareVersions(installed, latest) >= 0:
+		ui.Success("PicoClaw is up to date")
 		return
+	default:
+		if !ui.Confirm(i18n.T("Upgrade PicoClaw v%s → v%s?", installed, latest)) {
+			ui.Info("Cancelled.")
+			return
+		}
 	}
 
-	// Phase 2: Backup
-	phase2Backup(oc, dryRun)
+	if opts.dryRun {
+		ui.Info("[DRY RUN] Would back up ~/.picoclaw to ~/picoclaw-backup-YYYYMMDD-HHMMSS.tar.gz")
+		url, _, _ := install.GetDownloadURL()
+		ui.Info(i18n.T("[DRY RUN] Would download: %s", url))
+		ui.Info(i18n.T("[DRY RUN] Would replace %s", pc.BinaryPath))
+		return
+	}
 
-	// Phase 3: Install PicoClaw
-	if !skipInstall {
-		phase3Install(pc, sys, dryRun)
+	ui.Step(2, "Backing up ~/.picoclaw")
+	backupOpts := backup.Options{Limiter: opts.ioLimit, Prefix: "picoclaw-backup"}
+	meter := ui.NewMeter("Creating backup", detect.DirSize(pc.HomeDir))
+	backupOpts.Progress = meter.Add
+	var result backup.Result
+	err := meter.Run(func() error {
+		result = backup.CreateBackup(pc.HomeDir, backupOpts)
+		if !result.Success {
+			return result.Error
+		}
+		return backup.VerifyBackup(result.Path)
+	})
+	if err != nil {
+		ui.Error(i18n.T("Backup failed: %v", err))
+		if !ui.ConfirmDangerous("Continue WITHOUT backup? (not recommended)") {
+			ui.Info("Upgrade cancelled.")
+			os.Exit(1)
+		}
 	} else {
-		ui.Phase(3, "Install PicoClaw (skipped)")
-		ui.Info("--skip-install flag set")
+		ui.Success(i18n.T("Backup created: %s (%s)", result.Path, backup.FormatSize(result.Size)))
 	}
 
-	pc = detect.DetectPicoClaw()
+	ui.Step(3, "Downloading PicoClaw binary")
+	binaryPath, archivePath := downloadRelease()
+	defer install.CleanupArchive(archivePath)
 
-	// Phase 4: Migrate
-	phase4Migrate(oc, pc, dryRun)
+	ui.Step(4, "Replacing binary")
+	previous, err := install.ReplaceBinary(binaryPath, pc.BinaryPath)
+	if err != nil {
+		ui.Fatal(i18n.T("Install failed: %v", err))
+	}
+	ui.Success(i18n.T("Installed v%s at %s", latest, pc.BinaryPath))
+	rememberBinary(pc.BinaryPath)
 
-	// Phase 5: Verify
-	phase5Verify()
+	ui.Step(5, "Checking PicoClaw")
+	if doctorPicoClaw(pc.HomeDir, latest) {
+		preserve.Remove(previous)
+		ui.Success("PicoClaw upgraded")
+		return
+	}
 
-	// Phase 6: Uninstall
-	if !skipUninstall {
-		phase6Uninstall(oc, dryRun)
+	if ui.ConfirmDangerous(i18n.T("Checks failed. Roll back to the previous binary (%s)?", pc.Version)) {
+		if err := install.InstallBinaryTo(previous, pc.BinaryPath); err != nil {
+			ui.Fatal(i18n.T("Rollback failed: %v — the previous binary is at %s", err, previous))
+		}
+		preserve.Remove(previous)
+		ui.Success("Previous binary restored")
+		if result.Success {
+			ui.Info(i18n.T("~/.picoclaw backup kept at %s", result.Path))
+		}
+		return
+	}
+	ui.Info(i18n.T("Previous binary kept at %s", previous))
+}
+
+// doctorPicoClaw runs health checks on an installed PicoClaw: that the
+// binary runs and reports the expected version, the config parses, and the
+// workspace and permissions are in order. Returns false if any check fails.
+func doctorPicoClaw(picoHome, wantVersion string) bool {
+	ok := true
+	pc := detect.DetectPicoClaw()
+
+	switch got := install.ParseVersion(pc.Version); {
+	case pc.Version == "":
+		ui.Error("picoclaw --version did not run")
+		ok = false
+	case wantVersion != "" && got != wantVersion:
+		ui.Error(i18n.T("picoclaw reports %s, expected v%s", pc.Version, wantVersion))
+		ok = false
+	default:
+		ui.Success(i18n.T("Binary runs: %s", pc.Version))
+	}
+
+	configPath := filepath.Join(picoHome, "config.json")
+	if _, err := config.ReadConfig(configPath); err != nil {
+		ui.Error(i18n.T("Config not readable: %v", err))
+		ok = false
 	} else {
-		ui.Phase(6, "Uninstall OpenClaw (skipped)")
-		ui.Info("--skip-uninstall flag set. You can uninstall later with:")
-		ui.Info("  npm uninstall -g openclaw && rm -rf ~/.openclaw")
+		ui.Success("Configuration file exists")
 	}
 
-	ui.CompletionBanner()
+	if _, err := os.Stat(detect.PicoClawWorkspace(picoHome)); err != nil {
+		ui.Warn("PicoClaw workspace not found!")
+	} else {
+		ui.Success("Workspace directory exists")
+	}
+
+	auditPermissions(picoHome, true)
+	return ok
 }
 
 // ════════════════════════════════════════════════════════════
-// Phase 1: Detect
+// Standalone: Export bundle
 // ════════════════════════════════════════════════════════════
 
-func dirExists(path string) bool {
-	info, err := os.Stat(path)
-	return err == nil && info.IsDir()
-}
+// runExport prepares a migration for another machine as one archive: the
+// PicoClaw binary for --target-os/--target-arch, the converted config and
+// workspace, and an install script to run there, offline if need be
+func runExport(opts options) {
+	ui.Banner()
+	if opts.dryRun {
+		ui.Warn("DRY RUN mode — no changes will be made")
+	}
+	ui.Phase(1, "Export migration bundle")
 
-func phase1Detect() {
-	ui.Phase(1, "Detecting installations")
-}
+	goos, goarch := install.Platform()
+	output := opts.output
+	if output == "" {
+		output = fmt.Sprintf("picoclaw-bundle-%s-%s.tar.gz", goos, goarch)
+	}
+	output, err := filepath.Abs(output)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
 
-func showDetectionResults(oc, pc detect.Installation, sys detect.SystemInfo) {
-	ui.Step(1, "System information")
-	ui.Found("Platform", fmt.Sprintf("%s/%s", sys.OS, sys.Arch))
+	oc := detectOpenClaw()
+	if !oc.Found {
+		ui.Fatal("OpenClaw installation not found at ~/.openclaw/")
+	}
+	ui.Found("OpenClaw", oc.HomeDir)
+	ui.Found("Target", goos+"/"+goarch)
+	ui.Found("Bundle", output)
+	if bundle.Zstd(output) && !opts.dryRun {
+		if _, err := exec.LookPath("zstd"); err != nil {
+			ui.Fatal("zstd is not installed — install it or name the bundle .tar.gz")
+		}
+	}
+	if _, err := os.Stat(output); err == nil && !opts.dryRun {
+		if !ui.ConfirmDangerous(i18n.T("%s exists. Overwrite?", output)) {
+			ui.Info("Cancelled.")
+			return
+		}
+	}
 
-	ui.Step(2, "OpenClaw installation")
-	ui.Found("Directory", oc.HomeDir)
-	if oc.BinaryPath != "" {
-		ui.Found("Binary", oc.BinaryPath)
+	if opts.dryRun {
+		provisionInto(bundle.Dir, oc, opts)
+		ui.Info(i18n.T("[DRY RUN] Would write %s", output))
+		return
 	}
-	if oc.Version != "" {
-		ui.Found("Version", oc.Version)
+
+	stage, err := os.MkdirTemp("", "claw-migrate-export-")
+	if err != nil {
+		ui.Fatal(err.Error())
 	}
+	defer os.RemoveAll(stage)
 
-	// Config summary
-	ui.Step(3, "Configuration")
-	if oc.Config != nil {
-		ui.Found("Config file", fmt.Sprintf("%s (%s)", oc.ConfigPath, detect.FormatSize(oc.ConfigSummary.ConfigFileSize)))
+	result := provisionInto(stage, oc, opts)
+	if result.Aborted {
+		ui.Error("Not bundling an incomplete workspace")
+		return
+	}
 
-		if oc.ConfigSummary.DefaultModel != "" {
-			// Check if model is outdated
-			if upgrade, found := modelUpgrades[oc.ConfigSummary.DefaultModel]; found {
-				ui.Warn(fmt.Sprintf("Default model          %s (outdated → %s available)", oc.ConfigSummary.DefaultModel, upgrade))
-			} else {
-				ui.Found("Default model", oc.ConfigSummary.DefaultModel)
-			}
-		}
-		if oc.ConfigSummary.MaxTokens > 0 {
-			ui.Found("Max tokens", fmt.Sprintf("%d", oc.ConfigSummary.MaxTokens))
-		}
+	ui.Step(4, "Packing the bundle")
+	manifest := bundle.Manifest{
+		ToolVersion:     version,
+		PicoClawVersion: install.LatestVersion,
+		OS:              goos,
+		Arch:            goarch,
+		Created:         time.Now(),
+		Data:            true,
+	}
+	if err := bundle.WriteFiles(stage, manifest); err != nil {
+		ui.Error(i18n.T("Could not write the install script: %v", err))
+		return
+	}
+	var size int64
+	err = ui.SpinnerRun("Compressing...", func() error {
+		var packErr error
+		size, packErr = bundle.Pack(stage, output)
+		return packErr
+	})
+	if err != nil {
+		ui.Error(err.Error())
+		return
+	}
+	ui.Success(i18n.T("Bundle written: %s (%s)", output, detect.FormatSize(size)))
+	if sum, err := bundle.HashFile(output); err == nil {
+		ui.Found("SHA-256", sum)
+	}
 
-		providers := detect.GetProviderKeys(oc.Config)
-		if len(providers) > 0 {
-			ui.Found("Providers", strings.Join(providers, ", "))
-		}
+	unpack := "tar -xzf " + filepath.Base(output)
+	if bundle.Zstd(output) {
+		unpack = "zstd -dc " + filepath.Base(output) + " | tar -xf -"
+	}
+	ui.Box("Bundle ready", []string{
+		i18n.T("Copy it to the %s/%s machine, then run there:", goos, goarch),
+		"  " + unpack,
+		"  ./" + bundle.Dir + "/" + bundle.ScriptName,
+		i18n.T("or, where claw-migrate is available: claw-migrate import %s", filepath.Base(output)),
+		"",
+		i18n.T("The bundle holds your API keys — keep it private"),
+	})
+}
 
-		channels := detect.GetConfiguredChannels(oc.Config)
-		if len(channels) > 0 {
-			ui.Found("Channels", strings.Join(channels, ", "))
+// runImport applies a bundle made by export on this machine: the checksums
+// and platform are checked before anything is installed, and PicoClaw's
+// health checks run at the end
+func runImport(args []string, opts options) {
+	if len(args) == 0 {
+		ui.Fatal("Usage: claw-migrate import FILE")
+	}
+	ui.Banner()
+	if opts.dryRun {
+		ui.Warn("DRY RUN mode — no changes will be made")
+	}
+	ui.Phase(1, "Import migration bundle")
+
+	ui.Step(1, "Checking the bundle")
+	path := args[0]
+	sum, err := bundle.HashFile(path)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	ui.Found("Bundle", path)
+	ui.Found("SHA-256", sum)
+
+	stage, err := os.MkdirTemp("", "claw-migrate-import-")
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	defer os.RemoveAll(stage)
+	var manifest bundle.Manifest
+	err = ui.SpinnerRun("Unpacking and checking files...", func() error {
+		root, err := bundle.Unpack(path, stage)
+		if err != nil {
+			return err
 		}
+		manifest, err = bundle.Verify(root)
+		return err
+	})
+	if err != nil {
+		ui.Error(i18n.T("Bundle check failed: %v", err))
+		return
+	}
+	root := filepath.Join(stage, bundle.Dir)
+	ui.Success("Every file matches its checksum")
+	ui.Found("PicoClaw", "v"+manifest.PicoClawVersion)
+	ui.Found("Target", manifest.OS+"/"+manifest.Arch)
+	ui.Found("Created", i18n.T("%s by claw-migrate %s", manifest.Created.Local().Format("2006-01-02 15:04"), manifest.ToolVersion))
+	if manifest.OS != runtime.GOOS || manifest.Arch != runtime.GOARCH {
+		ui.Error(i18n.T("This bundle is for %s/%s, but this machine is %s/%s", manifest.OS, manifest.Arch, runtime.GOOS, runtime.GOARCH))
+		ui.Info(i18n.T("Export again with: --target-os %s --target-arch %s", runtime.GOOS, runtime.GOARCH))
+		return
+	}
 
-		mcpServers := detect.GetMCPServers(oc.Config)
-		if len(mcpServers) > 0 {
-			ui.Found("MCP Servers", strings.Join(mcpServers, ", "))
+	pc := detect.DetectPicoClaw()
+	binary := filepath.Join(root, ".local", "bin", "picoclaw")
+	data := filepath.Join(root, ".picoclaw")
+	if opts.dryRun {
+		if pc.BinaryPath != "" {
+			ui.Info(i18n.T("[DRY RUN] Would replace %s", pc.BinaryPath))
+		} else {
+			ui.Info("[DRY RUN] Would install /usr/local/bin/picoclaw")
+		}
+		if manifest.Data {
+			ui.Info(i18n.T("[DRY RUN] Would copy the config and workspace into %s", pc.HomeDir))
 		}
+		return
+	}
+	if !ui.Confirm("Install this bundle?") {
+		ui.Info("Cancelled.")
+		return
+	}
 
-		if oc.ConfigSummary.HeartbeatEnabled {
-			ui.Found("Heartbeat", fmt.Sprintf("enabled (every %d min)", oc.ConfigSummary.HeartbeatInterval))
+	ui.Step(2, "Installing binary")
+	if pc.BinaryPath != "" {
+		previous, err := install.ReplaceBinary(binary, pc.BinaryPath)
+		if err != nil {
+			ui.Fatal(i18n.T("Install failed: %v", err))
 		}
+		ui.Success(i18n.T("Installed v%s at %s", manifest.PicoClawVersion, pc.BinaryPath))
+		ui.Info(i18n.T("Previous binary kept at %s", previous))
+		rememberBinary(pc.BinaryPath)
 	} else {
-		ui.NotFound("Config file")
+		ui.Info("Installing to /usr/local/bin/picoclaw (may require sudo)")
+		if err := install.InstallBinary(binary); err != nil {
+			ui.Fatal(i18n.T("Install failed: %v", err))
+		}
+		ui.Success("PicoClaw installed")
+		rememberBinary("/usr/local/bin/picoclaw")
 	}
 
-	// Workspace — standard agent files
-	ui.Step(4, "Workspace — agent files")
-	standardFileList := []string{"SOUL.md", "IDENTITY.md", "AGENTS.md", "USER.md", "TOOLS.md", "HEARTBEAT.md"}
-	foundCount := 0
-	for _, f := range standardFileList {
-		exists := oc.WorkspaceFiles[f]
-		lines := 0
-		if exists {
-			lines = detect.CountFileLines(filepath.Join(oc.WorkspaceDir, f))
-			foundCount++
+	if manifest.Data {
+		ui.Step(3, "Installing config and workspace")
+		if _, err := os.Stat(pc.ConfigPath); err == nil {
+			ui.Warn(i18n.T("%s already has a config", pc.HomeDir))
+			if !ui.ConfirmDangerous("Replace it with the bundle's config (the current one is kept as config.json.bak)?") {
+				ui.Info("Config and workspace left as they are")
+				return
+			}
+			if err := os.Rename(pc.ConfigPath, pc.ConfigPath+".bak"); err != nil {
+				ui.Fatal(err.Error())
+			}
+		}
+		copyOpts := migrate.Options{Force: true, MaxErrors: opts.maxErrors, Limiter: opts.ioLimit, Sync: opts.fsync}
+		result := migrate.MigrateWorkspace(data, pc.HomeDir, copyOpts)
+		os.Chmod(pc.HomeDir, 0700)
+		os.Chmod(pc.ConfigPath, 0600)
+		if result.Errors > 0 {
+			ui.Error(i18n.T("Copied %d files, %d failed", result.Migrated, result.Errors))
+			for _, fr := range result.Failed() {
+				ui.Info(i18n.T("%s: %v", fr.Name, fr.Error))
+			}
+		} else {
+			ui.Success(i18n.T("Copied %d files into %s", result.Migrated, pc.HomeDir))
 		}
-		ui.FileStatus(f, exists, lines)
 	}
 
-	// Extra files
-	if len(oc.ExtraFiles) > 0 {
-		ui.Step(5, fmt.Sprintf("Workspace — custom files (%d)", len(oc.ExtraFiles)))
-		for _, f := range oc.ExtraFiles {
-			lines := detect.CountFileLines(filepath.Join(oc.WorkspaceDir, f))
-			ui.FileStatus(f, true, lines)
+	ui.Step(4, "Checking PicoClaw")
+	if doctorPicoClaw(pc.HomeDir, manifest.PicoClawVersion) {
+		ui.Success("Bundle imported")
+		if manifest.Data {
+			ui.Info("Start it with: picoclaw gateway")
+		} else {
+			ui.Info("Set it up with: picoclaw onboard")
 		}
 	}
+}
 
-	// Standard directories
-	ui.Step(6, "Workspace — standard directories")
-	stdDirs := []struct {
-		name string
-		has  bool
-	}{
-		{"memory", oc.HasMemory},
-		{"skills", oc.HasSkills},
-		{"scripts", dirExists(filepath.Join(oc.WorkspaceDir, "scripts"))},
-		{"cron", oc.HasCron},
-		{"sessions", oc.HasSessions},
+// ════════════════════════════════════════════════════════════
+// Standalone: Secrets
+// ════════════════════════════════════════════════════════════
+
+// runExportSecrets writes every API key and token to an encrypted bundle
+func runExportSecrets(args []string, opts options) {
+	ui.Banner()
+	ui.Phase(1, "Export secrets")
+
+	// OpenClaw keys are converted to PicoClaw paths first, so the bundle
+	// imports the same way whichever side it came from
+	var found []secrets.Secret
+	source := "picoclaw"
+	if ocConfig, from, err := loadOpenClawConfig(); err == nil {
+		found = secrets.Collect(config.ConvertConfig(ocConfig))
+		source = "openclaw"
+		ui.Found("OpenClaw config", from)
 	}
-	for _, d := range stdDirs {
-		if d.has {
-			dirPath := filepath.Join(oc.WorkspaceDir, d.name)
-			count := detect.CountDirFiles(dirPath)
-			size := detect.DirSize(dirPath)
-			ui.Found(d.name+"/", fmt.Sprintf("%d files (%s)", count, detect.FormatSize(size)))
-		} else {
-			ui.NotFound(d.name + "/")
+	picoPath := filepath.Join(picoClawHome(), "config.json")
+	if picoConfig, err := config.ReadConfig(picoPath); err == nil {
+		ui.Found("PicoClaw config", picoPath)
+		have := make(map[string]bool, len(found))
+		for _, s := range found {
+			have[s.Path] = true
+		}
+		for _, s := range secrets.Collect(picoConfig) {
+			if !have[s.Path] {
+				found = append(found, s)
+			}
 		}
 	}
+	if len(found) == 0 {
+		ui.Fatal("No API keys or tokens found in the OpenClaw or PicoClaw config")
+	}
 
-	// Project directories
-	if len(oc.ExtraDirs) > 0 {
-		ui.Step(7, fmt.Sprintf("Workspace — project directories (%d)", len(oc.ExtraDirs)))
-		for _, d := range oc.ExtraDirs {
-			dirPath := filepath.Join(oc.WorkspaceDir, d)
-			count := detect.CountDirFiles(dirPath)
-			size := detect.DirSize(dirPath)
-			ui.Found(d+"/", fmt.Sprintf("%d files (%s)", count, detect.FormatSize(size)))
-		}
+	ui.Step(1, i18n.T("%d secret(s)", len(found)))
+	for _, s := range found {
+		ui.Summary(s.Path, secrets.Mask(s.Value))
 	}
 
-	// Summary totals
-	totalFiles := foundCount + len(oc.ExtraFiles)
-	totalDirs := len(oc.ExtraDirs)
-	for _, d := range stdDirs {
-		if d.has {
-			totalDirs++
+	method := opts.encrypt
+	if method == "" {
+		method = secrets.DefaultMethod()
+	}
+	if opts.recipient != "" && method == secrets.MethodPassphrase {
+		ui.Fatal("--recipient needs --encrypt age or --encrypt gpg")
+	}
+	path := "claw-migrate-secrets." + map[string]string{
+		secrets.MethodAge: "age", secrets.MethodGPG: "gpg", secrets.MethodPassphrase: "enc",
+	}[method]
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if _, err := os.Stat(path); err == nil {
+		if !ui.ConfirmDangerous(i18n.T("%s exists. Overwrite?", path)) {
+			ui.Info("Cancelled.")
+			return
 		}
 	}
-	totalSize := detect.DirSize(oc.WorkspaceDir)
-	fmt.Println()
-	ui.Info(fmt.Sprintf("Total: %d files, %d directories (%s)",
-		totalFiles, totalDirs, detect.FormatSize(totalSize)))
 
-	// PicoClaw status
-	nextStep := 7
-	if len(oc.ExtraDirs) > 0 {
-		nextStep = 8
+	plaintext, err := secrets.Marshal(secrets.Bundle{Version: 1, Created: time.Now().UTC(), Source: source, Secrets: found})
+	if err != nil {
+		ui.Fatal(err.Error())
 	}
-	ui.Step(nextStep, "PicoClaw installation")
-	if pc.Found {
-		ui.Found("Directory", pc.HomeDir)
-		if pc.BinaryPath != "" {
-			ui.Found("Binary", pc.BinaryPath)
+
+	ui.Step(2, i18n.T("Encrypting with %s", method))
+	if method == secrets.MethodPassphrase {
+		pass := ui.PromptSecret("Passphrase for the bundle")
+		if pass == "" {
+			ui.Fatal("A passphrase is required")
 		}
-		if pc.Version != "" {
-			ui.Found("Version", pc.Version)
+		if ui.PromptSecret("Repeat the passphrase") != pass {
+			ui.Fatal("Passphrases do not match")
 		}
-	} else {
-		ui.NotFound("PicoClaw")
-		ui.Info("PicoClaw will be installed in the next phase")
+		data, err := secrets.Seal(pass, plaintext)
+		if err == nil {
+			err = os.WriteFile(path, data, 0600)
+		}
+		if err != nil {
+			ui.Fatal(i18n.T("Export failed: %v", err))
+		}
+	} else if err := secrets.EncryptExternal(method, opts.recipient, plaintext, path); err != nil {
+		ui.Fatal(i18n.T("Export failed: %v", err))
 	}
+	os.Chmod(path, 0600)
+
+	ui.Success(i18n.T("Wrote %d secret(s) to %s", len(found), path))
+	ui.Info(i18n.T("On the new machine: claw-migrate import-secrets %s", filepath.Base(path)))
 }
 
-// ════════════════════════════════════════════════════════════
-// Phase 2: Backup
-// ════════════════════════════════════════════════════════════
+// runImportSecrets decrypts a bundle and adds its secrets to the PicoClaw config
+func runImportSecrets(args []string, opts options) {
+	if len(args) == 0 {
+		ui.Fatal("Usage: claw-migrate import-secrets FILE")
+	}
+	ui.Banner()
+	ui.Phase(1, "Import secrets")
 
-func phase2Backup(oc detect.Installation, dryRun bool) {
-	ui.Phase(2, "Backup OpenClaw")
-	doBackup(oc, dryRun)
-}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	method, err := secrets.DetectMethod(data)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
 
-func doBackup(oc detect.Installation, dryRun bool) {
-	ui.Step(1, "Creating full backup of ~/.openclaw/")
+	var plaintext []byte
+	if method == secrets.MethodPassphrase {
+		plaintext, err = secrets.Open(ui.PromptSecret("Passphrase for the bundle"), data)
+	} else {
+		plaintext, err = secrets.DecryptExternal(method, opts.identity, args[0])
+	}
+	if err != nil {
+		ui.Fatal(i18n.T("Could not decrypt %s: %v", args[0], err))
+	}
+	bundle, err := secrets.Unmarshal(plaintext)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	ui.Found("Bundle", i18n.T("%d secret(s) from %s, %s", len(bundle.Secrets), bundle.Source, bundle.Created.Local().Format("2006-01-02 15:04")))
 
-	if dryRun {
-		ui.Info("[DRY RUN] Would create backup: ~/openclaw-backup-YYYYMMDD-HHMMSS.tar.gz")
+	picoPath := filepath.Join(picoClawHome(), "config.json")
+	picoConfig, err := config.ReadConfig(picoPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			ui.Fatal(i18n.T("Could not read PicoClaw config: %v", err))
+		}
+		ui.Warn("No PicoClaw config yet — keys for model_list entries need one (run: picoclaw onboard)")
+		picoConfig = make(map[string]interface{})
+	}
+
+	ui.Step(1, "Changes")
+	var changes []secrets.Secret
+	for _, s := range bundle.Secrets {
+		switch current := secrets.Lookup(picoConfig, s.Path); current {
+		case s.Value:
+			ui.Summary(s.Path, i18n.T("already set"))
+		case "":
+			ui.Summary(s.Path, i18n.T("add %s", secrets.Mask(s.Value)))
+			changes = append(changes, s)
+		default:
+			ui.Summary(s.Path, i18n.T("replace %s with %s", secrets.Mask(current), secrets.Mask(s.Value)))
+			changes = append(changes, s)
+		}
+	}
+	if len(changes) == 0 {
+		ui.Success("PicoClaw already has every secret in the bundle")
+		return
+	}
+	if !ui.Confirm(i18n.T("Write %d secret(s) to %s?", len(changes), picoPath)) {
+		ui.Info("Cancelled.")
 		return
 	}
 
-	var result backup.Result
-	err := ui.SpinnerRun("Creating backup (this may take a minute)...", func() error {
-		result = backup.CreateBackup(oc.HomeDir)
-		if !result.Success {
-			return result.Error
+	applied, skipped := secrets.Inject(picoConfig, changes)
+	if old, err := os.ReadFile(picoPath); err == nil {
+		if err := os.WriteFile(picoPath+".bak", old, 0600); err != nil {
+			ui.Fatal(i18n.T("Could not back up %s: %v", picoPath, err))
 		}
-		return nil
-	})
+		ui.Info("Previous config backed up to config.json.bak")
+	}
+	if err := os.MkdirAll(filepath.Dir(picoPath), 0700); err != nil {
+		ui.Fatal(err.Error())
+	}
+	if err := config.WriteConfig(picoConfig, picoPath); err != nil {
+		ui.Fatal(i18n.T("Could not write PicoClaw config: %v", err))
+	}
 
-	if err != nil {
-		ui.Error(fmt.Sprintf("Backup failed: %v", err))
-		if !ui.ConfirmDangerous("Continue WITHOUT backup? (not recommended)") {
-			ui.Info("Migration cancelled.")
-			os.Exit(1)
+	ui.Success(i18n.T("Imported %d secret(s)", len(applied)))
+	for _, p := range skipped {
+		ui.Warn(i18n.T("Skipped %s — no matching entry in the PicoClaw config", p))
+	}
+}
+
+// ════════════════════════════════════════════════════════════
+// Standalone: Restore
+// ════════════════════════════════════════════════════════════
+
+func runRestore() {
+	ui.Banner()
+	ui.Phase(1, "Restore OpenClaw from backup")
+
+	backups := backup.ListBackups()
+	if len(backups) == 0 {
+		ui.Error("No backup files found (looking for ~/openclaw-backup-*.tar.gz or .zip)")
+		os.Exit(1)
+	}
+
+	ui.Step(1, i18n.T("Found %d backup(s)", len(backups)))
+
+	// Say which run each backup was made before, to pick the right one
+	history, _ := runs.Load()
+	options := make([]string, len(backups))
+	for i, b := range backups {
+		options[i] = fmt.Sprintf("%s (%s)", b.Filename, backup.FormatSize(b.Size))
+		if use := backupUse(history, b.Path); use != "" {
+			options[i] += " — " + use
 		}
+	}
+
+	choice := ui.Choose("Which backup do you want to restore?", options, -1)
+	if choice < 0 {
+		ui.Info("Restore cancelled.")
 		return
 	}
+	selected := backups[choice]
 
-	ui.Success(fmt.Sprintf("Backup created: %s (%s)", result.Path, backup.FormatSize(result.Size)))
+	ui.Warn(i18n.T("This will replace ~/.openclaw with the contents of %s", selected.Filename))
+	if !ui.ConfirmDangerous("Proceed with restore?") {
+		ui.Info("Restore cancelled.")
+		return
+	}
+	run := startRun(runs.KindRestore)
+	run.Backup = selected.Path
 
 	// Verify
 	ui.Step(2, "Verifying backup integrity")
-	verifyErr := ui.SpinnerRun("Verifying...", func() error {
-		return backup.VerifyBackup(result.Path)
+	verifyErr := ui.SpinnerRun("Verifying backup...", func() error {
+		return backup.VerifyBackup(selected.Path)
 	})
 	if verifyErr != nil {
-		ui.Warn(fmt.Sprintf("Backup verification warning: %v", verifyErr))
-	} else {
-		ui.Success("Backup verified successfully")
+		ui.Fatal(i18n.T("Backup is corrupted: %v", verifyErr))
+	}
+	ui.Success("Backup verified")
+
+	// Restore
+	ui.Step(3, "Restoring")
+	restoreErr := ui.SpinnerRun("Restoring OpenClaw...", func() error {
+		return backup.RestoreBackup(selected.Path)
+	})
+	if restoreErr != nil {
+		ui.Fatal(i18n.T("Restore failed: %v", restoreErr))
+	}
+
+	ui.Success("OpenClaw restored from backup!")
+	endRun(run, runs.OutcomeSuccess, "")
+	// A purge scheduled by --purge-after would delete what was just restored
+	if p, _ := uninstall.LoadPurge(); p != nil && uninstall.CancelPurge() == nil {
+		ui.Info("The scheduled purge of ~/.openclaw was cancelled")
 	}
+	ui.Info("Run: openclaw status")
 }
 
 // ════════════════════════════════════════════════════════════
-// Phase 3: Install PicoClaw
+// Standalone: Retry failed files
 // ════════════════════════════════════════════════════════════
 
-func phase3Install(pc detect.Installation, sys detect.SystemInfo, dryRun bool) {
-	ui.Phase(3, "Install PicoClaw")
+func runRetry(opts options) {
+	ui.Banner()
+	ui.Phase(1, "Retry failed files")
 
-	// Fetch latest version
-	ui.Step(1, "Checking latest PicoClaw release")
-	var fetchedVersion string
-	ui.SpinnerRun("Fetching latest version...", func() error {
-		fetchedVersion = install.FetchLatestVersion()
+	j, err := journal.Load()
+	if err != nil {
+		ui.Error("No migration journal found — run 'claw-migrate migrate' first")
+		os.Exit(1)
+	}
+
+	failed := j.Failed()
+	ui.Found("Last migration", j.Started.Format("2006-01-02 15:04:05"))
+	ui.Found("Outcome", j.Outcome)
+	// An aborted run never journaled the files it didn't get to
+	aborted := j.Outcome == journal.OutcomeAborted
+	if len(failed) == 0 {
+		ui.Success("No failed files recorded — nothing to retry")
+		if aborted {
+			ui.Warn("The last run was aborted before every file was copied — run 'claw-migrate migrate' again for the rest")
+		}
+		return
+	}
+
+	ui.Step(1, i18n.T("%d file(s) failed in the last run", len(failed)))
+	previous := migrate.Result{}
+	for _, f := range failed {
+		previous.Files = append(previous.Files, migrate.FileResult{
+			Source: f.Source,
+			Dest:   f.Dest,
+			Name:   f.Name,
+			Error:  errors.New(f.Error),
+		})
+	}
+	if j.Move {
+		ui.Info("The last run used move mode — sources will be deleted once copied")
+	}
+
+	if opts.dryRun {
+		for _, fr := range previous.Files {
+			ui.Info(i18n.T("[DRY RUN] Would retry %s", fr.Name))
+		}
+		return
+	}
+
+	if !ui.Confirm(i18n.T("Retry %d file(s)?", len(failed))) {
+		ui.Info("Retry cancelled.")
+		return
+	}
+
+	ui.Step(2, "Copying")
+	// Redacted secrets would survive nowhere if the originals went too
+	move := j.Move
+	if move && opts.scrub {
+		ui.Warn("--scrub keeps the unredacted originals — copying instead of moving")
+		move = false
+	}
+	var result migrate.Result
+	ui.SpinnerRun("Retrying failed files...", func() error {
+		retryOpts := migrate.Options{Force: true, MaxErrors: opts.maxErrors, Limiter: opts.ioLimit, Sync: opts.fsync, Scrub: opts.scrub, Move: move,
+			Strategy: copyStrategy(opts.copyStrategy, j.SourceDir, j.DestDir)}
+		if move {
+			retryOpts.BeforeRemove = appendMoved
+		}
+		result = migrate.RetryFailed(previous, retryOpts)
 		return nil
 	})
-	ui.Found("Latest version", "v"+fetchedVersion)
 
-	// Already installed?
-	if pc.BinaryPath != "" {
-		ui.Success(fmt.Sprintf("PicoClaw already installed: %s", pc.BinaryPath))
-		if pc.Version != "" {
-			ui.Info(fmt.Sprintf("Version: %s", pc.Version))
+	j.Record(journalEntries(result))
+	if len(j.Failed()) == 0 && !aborted {
+		j.Outcome = journal.OutcomeSuccess
+	}
+	if err := j.Save(); err != nil {
+		ui.Warn(i18n.T("Could not update migration journal: %v", err))
+	}
+	if aborted {
+		ui.Warn("The last run was aborted before every file was copied — run 'claw-migrate migrate' again for the rest")
+	}
+
+	if result.Errors > 0 {
+		ui.Warn(i18n.T("%d migrated, %d still failing", result.Migrated, result.Errors))
+		showErrorSummary(result)
+		os.Exit(1)
+	}
+	ui.Success(i18n.T("All %d file(s) migrated", result.Migrated))
+}
+
+// ════════════════════════════════════════════════════════════
+// Standalone: Run history
+// ════════════════════════════════════════════════════════════
+
+// startRun begins recording a run in the history; endRun records it. A
+// run stopped by ui.Fatal is recorded as failed, with the message.
+func startRun(kind string) *runs.Run {
+	r := &runs.Run{Kind: kind, Started: time.Now(), Version: version}
+	ui.OnFatal(func(msg string) { endRun(r, runs.OutcomeFailed, msg) })
+	return r
+}
+
+// endRun records a run once; later calls for the same run do nothing
+func endRun(r *runs.Run, outcome, detail string) {
+	if r == nil || !r.Finished.IsZero() {
+		return
+	}
+	r.Finished, r.Outcome, r.Detail = time.Now(), outcome, detail
+	if err := runs.Add(*r); err != nil {
+		ui.Warn(i18n.T("Could not record the run in %s: %v", runs.Path(), err))
+	}
+}
+
+// runHistory lists past runs, newest first: history, or history N for
+// the last N
+func runHistory(args []string) {
+	limit := 20
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			ui.Fatal("Usage: claw-migrate history [N]")
 		}
-		if ui.Confirm("Skip installation and use existing PicoClaw?") {
-			if !pc.Found {
-				ui.Step(2, "Initializing PicoClaw workspace")
-				if !dryRun {
-					install.RunOnboard()
-				} else {
-					ui.Info("[DRY RUN] Would run: picoclaw onboard")
-				}
+		limit = n
+	}
+	all, err := runs.Load()
+	if err != nil {
+		ui.Fatal(i18n.T("Could not read the run history: %v", err))
+	}
+	if len(all) == 0 {
+		ui.Info("No runs recorded yet")
+		return
+	}
+
+	shown := all[max(0, len(all)-limit):]
+	for i := len(shown) - 1; i >= 0; i-- {
+		printRun(shown[i])
+	}
+	fmt.Println()
+	if len(shown) < len(all) {
+		ui.Info(i18n.T("Showing the last %d of %d runs — claw-migrate history %d lists them all", len(shown), len(all), len(all)))
+	}
+	ui.Info(i18n.T("Recorded in %s", runs.Path()))
+}
+
+// printRun shows one run: when, what, how it ended and how long it took
+func printRun(r runs.Run) {
+	color := ui.Green
+	switch r.Outcome {
+	case runs.OutcomeErrors:
+		color = ui.Yellow
+	case runs.OutcomeFailed, journal.OutcomeAborted:
+		color = ui.Red
+	}
+	fmt.Printf("  %s  "+ui.Bold+"%-19s"+ui.Reset+" "+color+"%-22s"+ui.Reset+" %s\n",
+		r.Started.Local().Format("2006-01-02 15:04:05"), r.Kind, i18n.T(r.Outcome), ui.FormatDuration(r.Finished.Sub(r.Started)))
+	if r.Detail != "" {
+		fmt.Println("      " + ui.Dim + r.Detail + ui.Reset)
+	}
+	if r.Backup != "" {
+		fmt.Println("      " + ui.Dim + i18n.T("backup: %s", r.Backup) + ui.Reset)
+	}
+}
+
+// backupUse says which migration or uninstall a backup was made before,
+// or ""
+func backupUse(all []runs.Run, path string) string {
+	for _, r := range runs.ForBackup(all, path) {
+		if r.Kind == runs.KindMigrate || r.Kind == runs.KindUninstallOpenClaw {
+			return i18n.T("before %s on %s, %s", r.Kind, r.Started.Local().Format("2006-01-02 15:04"), i18n.T(r.Outcome))
+		}
+	}
+	return ""
+}
+
+// ════════════════════════════════════════════════════════════
+// Plans: migrate --dry-run --plan, then apply
+// ════════════════════════════════════════════════════════════
+
+// planFlags are the flags a plan doesn't keep: its answers stand in for
+// --yes, and apply adds --from-plan itself
+var planFlags = map[string]bool{"--dry-run": true, "--plan": true, "--yes": true, "-y": true, "--reset-decisions": true, "--all-users": true, "--from-plan": true}
+
+// writePlan saves what the dry run said it would do, the flags it ran
+// with, the answers it was given, the PicoClaw release it would install and
+// the OpenClaw files it saw, for apply
+func writePlan(oc detect.Installation, opts options) {
+	var args []string
+	for _, arg := range os.Args[1:] {
+		name, _, _ := strings.Cut(arg, "=")
+		if !planFlags[name] && arg != "migrate" {
+			args = append(args, arg)
+		}
+	}
+	source, err := plan.Snapshot(oc.HomeDir, oc.ConfigPath, planRoots(oc))
+	if err != nil {
+		ui.Warn(i18n.T("Could not write the plan: %v", err))
+		return
+	}
+	host, _ := os.Hostname()
+	p := plan.Plan{Format: plan.Format, Created: time.Now(), Host: host, Version: version, Args: args, Actions: ui.Planned(),
+		PicoClaw: install.LatestVersion, Decisions: ui.Decisions(), Source: source}
+	if err := plan.Write(opts.planFile, p); err != nil {
+		ui.Warn(i18n.T("Could not write the plan: %v", err))
+		return
+	}
+	fmt.Println()
+	ui.Success(i18n.T("Plan written to %s (%d actions, %d files)", opts.planFile, len(p.Actions), len(source.Files)))
+	ui.Info(i18n.T("Review it, then carry it out with: claw-migrate apply %s", opts.planFile))
+}
+
+// planRoots lists what a migration reads besides the config: the workspace
+// and the home items it copies or merges (state, media, credentials, .env)
+func planRoots(oc detect.Installation) []string {
+	roots := []string{oc.WorkspaceDir}
+	for _, item := range oc.HomeItems {
+		switch migrate.RuleFor(item.Name).Action {
+		case migrate.ActionCopy, migrate.ActionSecret:
+			roots = append(roots, filepath.Join(oc.HomeDir, item.Name))
+		case migrate.ActionHandled:
+			if item.Name == ".env" {
+				roots = append(roots, filepath.Join(oc.HomeDir, item.Name))
+			}
+		}
+	}
+	return roots
+}
+
+// runApply carries out a plan: it checks the OpenClaw files the migration
+// reads are as they were when the plan was made, shows the plan's actions,
+// and runs migrate with the plan's flags and PicoClaw release. Prompts the
+// plan answered get the same answers; the rest, and every dangerous one,
+// are asked.
+func runApply(args []string, opts options) {
+	path := "plan.json"
+	if len(args) > 0 {
+		path = args[0]
+	}
+	ui.Banner()
+	ui.Phase(1, "Apply a migration plan")
+
+	p, err := plan.Load(path)
+	if err != nil {
+		ui.Fatal(i18n.T("Could not read the plan: %v", err))
+	}
+	ui.Found("Plan", path)
+	ui.Found("Made", i18n.T("%s on %s", p.Created.Local().Format("2006-01-02 15:04:05"), p.Host))
+	flags := strings.Join(p.Args, " ")
+	if flags == "" {
+		flags = i18n.T("(none)")
+	}
+	ui.Found("Flags", flags)
+	if p.Version != version {
+		ui.Warn(i18n.T("The plan was made by claw-migrate %s; this is %s, which may act differently", p.Version, version))
+	}
+
+	ui.Step(1, "Checking OpenClaw is unchanged")
+	oc := detectOpenClaw()
+	if !oc.Found {
+		ui.Fatal("OpenClaw installation not found at ~/.openclaw/")
+	}
+	now, err := plan.Snapshot(oc.HomeDir, oc.ConfigPath, planRoots(oc))
+	if err != nil {
+		ui.Fatal(i18n.T("Could not read OpenClaw's files: %v", err))
+	}
+	if changes := plan.Changes(p.Source, now); len(changes) > 0 {
+		ui.Error(i18n.T("OpenClaw has changed since the plan was made (%d difference(s)):", len(changes)))
+		for i, c := range changes {
+			if i == 10 {
+				ui.Info(i18n.T("    ... and %d more", len(changes)-10))
+				break
 			}
+			fmt.Println("    " + ui.Yellow + "•" + ui.Reset + " " + c)
+		}
+		ui.Fatal("Make a new plan with: claw-migrate migrate --dry-run --plan")
+	}
+	ui.Success(i18n.T("%d file(s) and the config are as planned", len(now.Files)))
+
+	ui.Step(2, "Planned actions")
+	for _, a := range p.Actions {
+		fmt.Println("    " + ui.Yellow + "•" + ui.Reset + " " + a)
+	}
+	if p.PicoClaw != "" {
+		ui.Found("PicoClaw", "v"+p.PicoClaw)
+	}
+	ui.Info(i18n.T("%d answer(s) from the plan are given again; anything else, and every dangerous step, still asks", len(p.Decisions)))
+	if opts.dryRun {
+		ui.Info("[DRY RUN] Would run the migration with the plan's flags")
+		return
+	}
+	if !ui.Confirm(i18n.T("Carry out these %d action(s)?", len(p.Actions))) {
+		ui.Info("Migration cancelled. No changes made.")
+		return
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		ui.Fatal(i18n.T("Cannot find the claw-migrate binary: %v", err))
+	}
+	cmd := exec.Command(self, append(append([]string{"migrate"}, p.Args...), "--from-plan", path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		ui.Fatal(i18n.T("The migration failed: %v", err))
+	}
+}
+
+// ════════════════════════════════════════════════════════════
+// Standalone: Undo one change of the last migration
+// ════════════════════════════════════════════════════════════
+
+// runUndo reverts one change the last migration made, without a full
+// rollback. undo model-upgrade puts back the model the upgrade replaced,
+// where the config still names the new one, for when the new model turns
+// out not to be available on the user's plan. undo webhooks points moved
+// chat webhooks back where they delivered before.
+func runUndo(args []string, opts options) {
+	if len(args) == 1 && args[0] == "webhooks" {
+		undoWebhooks(opts)
+		return
+	}
+	if len(args) != 1 || args[0] != "model-upgrade" {
+		ui.Fatal("Usage: claw-migrate undo model-upgrade|webhooks")
+	}
+	ui.Banner()
+	ui.Phase(1, "Undo the model upgrade")
+
+	j, err := journal.Load()
+	if err != nil {
+		ui.Fatal("No migration journal found — run 'claw-migrate migrate' first")
+	}
+	u := j.ModelUpgrade
+	if u == nil {
+		ui.Info("The last migration didn't upgrade the model — nothing to undo")
+		return
+	}
+	ui.Found("Upgrade", fmt.Sprintf("%s → %s", u.From, u.To))
+	ui.Found("Changed", u.Changed.Format("2006-01-02 15:04:05"))
+	ui.Found("Config", u.Config)
+
+	cfg, err := config.ReadConfig(u.Config)
+	if err != nil {
+		ui.Fatal(i18n.T("Could not read %s: %v", u.Config, err))
+	}
+	reverted := models.RevertInConfig(cfg, u.Changes)
+	if len(reverted) == 0 {
+		ui.Info(i18n.T("The config no longer names %s where the upgrade put it — nothing to undo", u.To))
+		return
+	}
+	for _, c := range reverted {
+		fmt.Println("    " + ui.Dim + c.String() + ui.Reset)
+	}
+	if len(reverted) < len(u.Changes) {
+		ui.Info(i18n.T("%d place(s) changed since the upgrade are left as they are", len(u.Changes)-len(reverted)))
+	}
+	if opts.dryRun {
+		ui.Info(i18n.T("[DRY RUN] Would put %s back", u.From))
+		return
+	}
+	if !ui.Confirm(i18n.T("Put %s back?", u.From)) {
+		ui.Info("Undo cancelled.")
+		return
+	}
+
+	if err := config.WriteConfig(cfg, u.Config); err != nil {
+		ui.Fatal(i18n.T("Could not update the config: %v", err))
+	}
+	j.ModelUpgrade = nil
+	if err := j.Save(); err != nil {
+		ui.Warn(i18n.T("Could not update migration journal: %v", err))
+	}
+	ui.Success(i18n.T("Model set back to %s", u.From))
+	rememberDeclined(u.From, u.To)
+	ui.Info("Restart PicoClaw to use it")
+}
+
+// undoWebhooks points the webhooks moved off OpenClaw back at what they
+// delivered to, where the platform still delivers where they were moved
+func undoWebhooks(opts options) {
+	ui.Banner()
+	ui.Phase(1, "Undo the webhook moves")
+
+	j, err := journal.Load()
+	if err != nil || len(j.Webhooks) == 0 {
+		ui.Info("No moved webhooks recorded — nothing to undo")
+		return
+	}
+	cfg, err := config.ReadConfig(filepath.Join(picoClawHome(), "config.json"))
+	if err != nil {
+		ui.Fatal(i18n.T("Could not read the PicoClaw config for the bot tokens: %v", err))
+	}
+	hooks := make(map[string]webhooks.Hook)
+	for _, h := range webhooks.Find(cfg) {
+		hooks[h.Channel] = h
+	}
+
+	var left []journal.WebhookMove
+	for _, m := range j.Webhooks {
+		hook, ok := hooks[m.Channel]
+		ui.Found(m.Channel, fmt.Sprintf("%s → %s", m.From, orRemoved(m.To)))
+		switch {
+		case !ok:
+			ui.Warn(i18n.T("%s has no bot token in the PicoClaw config — can't put it back", m.Channel))
+			left = append(left, m)
+			continue
+		case hook.Err != nil:
+			ui.Warn(i18n.T("Could not ask %s where it delivers: %v", m.Channel, hook.Err))
+			left = append(left, m)
+			continue
+		case hook.URL != m.To:
+			ui.Info(i18n.T("%s now delivers to %s, changed since — left as it is", m.Channel, orRemoved(hook.URL)))
+			continue
+		case opts.dryRun:
+			ui.Info(i18n.T("[DRY RUN] Would point %s back at %s", m.Channel, m.From))
+			left = append(left, m)
+			continue
+		}
+		if !ui.Confirm(i18n.T("Point %s back at %s?", m.Channel, m.From)) {
+			left = append(left, m)
+			continue
+		}
+		if err := webhooks.Move(hook, m.From); err != nil {
+			ui.Error(i18n.T("Could not update the %s webhook: %v", m.Channel, err))
+			left = append(left, m)
+			continue
+		}
+		ui.Success(i18n.T("%s delivers to %s again", m.Channel, m.From))
+	}
+	if opts.dryRun {
+		return
+	}
+	j.Webhooks = left
+	if err := j.Save(); err != nil {
+		ui.Warn(i18n.T("Could not update migration journal: %v", err))
+	}
+}
+
+// orRemoved shows a webhook URL, or that there is none
+func orRemoved(url string) string {
+	if url == "" {
+		return i18n.T("(removed)")
+	}
+	return url
+}
+
+// ════════════════════════════════════════════════════════════
+// Standalone: Status
+// ════════════════════════════════════════════════════════════
+
+func runStatus() {
+	ui.Banner()
+	ui.Phase(1, "Migration status")
+
+	ui.Step(1, "Installations")
+	oc := detect.DetectOpenClaw()
+	if oc.Found {
+		ui.Found("OpenClaw", oc.HomeDir)
+	} else {
+		ui.NotFound("OpenClaw")
+	}
+	pc := detect.DetectPicoClaw()
+	if pc.Found {
+		ui.Found("PicoClaw", pc.HomeDir)
+	} else {
+		ui.NotFound("PicoClaw")
+	}
+
+	ui.Step(2, "Backups")
+	backups := backup.ListBackups()
+	if len(backups) > 0 {
+		latest := backups[0]
+		ui.Found("Last backup", i18n.T("%s (%s)", backupTime(latest), backup.FormatSize(latest.Size)))
+		ui.Found("Backups", fmt.Sprintf("%d", len(backups)))
+	} else {
+		ui.NotFound("Last backup")
+	}
+
+	ui.Step(3, "Last migration")
+	j, err := journal.Load()
+	if err != nil {
+		ui.Info("No migration has been run yet")
+	} else {
+		ui.Found("Started", j.Started.Format("2006-01-02 15:04:05"))
+		ui.Found("Outcome", j.Outcome)
+		ui.Found("Files", i18n.T("%d migrated, %d failed", len(j.Migrated()), len(j.Failed())))
+		if len(j.Failed()) > 0 {
+			ui.Info("Retry the failures with: claw-migrate retry")
+		}
+	}
+	history, _ := runs.Load()
+	if last, ok := runs.Last(history, ""); ok {
+		ui.Found("Last run", i18n.T("%s — %s (%s)", last.Kind, i18n.T(last.Outcome), last.Started.Local().Format("2006-01-02 15:04:05")))
+		ui.Info("See every run with: claw-migrate history")
+	}
+
+	ui.Step(4, "Manual attention")
+	// The saved checklist knows what's been done; fall back to detection
+	items, err := todo.Load(todo.Path(picoClawHome()))
+	if err == nil {
+		items = todo.Pending(items)
+	} else if oc.Found {
+		items = manualAttentionItems(oc)
+	}
+	if len(items) > 0 {
+		for _, item := range items {
+			fmt.Printf("    "+ui.Yellow+"•"+ui.Reset+" %s\n", item.Text)
+		}
+	} else {
+		ui.Success("No manual items")
+	}
+
+	ui.Step(5, "Rollback")
+	switch {
+	case len(backups) > 0:
+		ui.Success(i18n.T("Possible — claw-migrate restore (%s)", backups[0].Filename))
+		if use := backupUse(history, backups[0].Path); use != "" {
+			ui.Info(i18n.T("It was made %s", use))
+		}
+	case oc.Found && (j == nil || !j.Move):
+		ui.Info("No backup, but OpenClaw is still installed — nothing to roll back yet")
+	default:
+		ui.Warn("Not possible — no backup found")
+	}
+	if p, _ := uninstall.LoadPurge(); p != nil {
+		ui.Info(i18n.T("%s will be deleted on %s — keep it with: claw-migrate purge-due cancel", p.Path, p.Due.Format("2006-01-02")))
+	}
+}
+
+// ════════════════════════════════════════════════════════════
+// Standalone: Watch the migrated agent
+// ════════════════════════════════════════════════════════════
+
+// watchAlert is what watch posts to --notify-url
+type watchAlert struct {
+	Text   string        `json:"text"`
+	Event  string        `json:"event"`
+	Host   string        `json:"host"`
+	Checks []watch.Check `json:"checks"`
+}
+
+// runWatch probes the migrated PicoClaw every minute for the given number
+// of hours (default 24): picoclaw status, the gateway's health endpoint
+// and the channels' bot tokens. Whenever a check goes down or comes back,
+// it alerts with a desktop notification and, with --notify-url, a webhook.
+func runWatch(args []string, opts options) {
+	hours := 24.0
+	if len(args) > 0 {
+		h, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "h"), 64)
+		if err != nil || h <= 0 {
+			ui.Fatal("Usage: claw-migrate watch [HOURS]")
+		}
+		hours = h
+	}
+	ui.Banner()
+	ui.Phase(1, "Watching PicoClaw")
+
+	pc := detect.DetectPicoClaw()
+	if pc.BinaryPath == "" {
+		ui.Fatal("PicoClaw is not installed")
+	}
+	configPath := filepath.Join(picoClawHome(), "config.json")
+	cfg, err := config.ReadConfig(configPath)
+	if err != nil {
+		ui.Fatal(i18n.T("Could not read %s: %v", configPath, err))
+	}
+	deadline := time.Now().Add(time.Duration(hours * float64(time.Hour)))
+	ui.Info(i18n.T("Checking every minute until %s — Ctrl-C stops", deadline.Format("Jan 2 15:04")))
+	if opts.notifyURL != "" {
+		ui.Info(i18n.T("Alerts also go to %s", opts.notifyURL))
+	}
+	fmt.Println()
+
+	var last []watch.Check
+	downs := 0
+	for {
+		// The config may change while we watch, e.g. a moved gateway port
+		if latest, err := config.ReadConfig(configPath); err == nil {
+			cfg = latest
+		}
+		checks := watch.Probe(pc.BinaryPath, cfg)
+		if last == nil {
+			for _, c := range checks {
+				if c.OK {
+					ui.Success(watch.Describe(c))
+				} else {
+					ui.Error(watch.Describe(c))
+				}
+			}
+		}
+		changed := watch.Changes(last, checks)
+		for _, c := range changed {
+			if !c.OK {
+				downs++
+			}
+			if last != nil {
+				color := ui.Green
+				if !c.OK {
+					color = ui.Red
+				}
+				fmt.Printf("  %s  %s%s%s\n", time.Now().Format("15:04"), color, watch.Describe(c), ui.Reset)
+			}
+		}
+		if len(changed) > 0 {
+			alertWatch(changed, opts)
+		}
+		last = checks
+
+		if time.Now().Add(watch.Interval).After(deadline) {
+			break
+		}
+		time.Sleep(watch.Interval)
+	}
+
+	fmt.Println()
+	if downs == 0 {
+		ui.Success(i18n.T("No problems in %g hour(s) of watching", hours))
+	} else {
+		ui.Warn(i18n.T("%d problem(s) seen in %g hour(s) of watching", downs, hours))
+	}
+}
+
+// alertWatch tells whoever isn't looking at the terminal that checks
+// went down or came back
+func alertWatch(changed []watch.Check, opts options) {
+	title := i18n.T("PicoClaw recovered")
+	var lines []string
+	for _, c := range changed {
+		if !c.OK {
+			title = i18n.T("PicoClaw is down")
+		}
+		lines = append(lines, watch.Describe(c))
+	}
+	body := strings.Join(lines, "\n")
+	if !opts.noDesktop {
+		notify.Desktop(title, body) // best effort; there may be no desktop
+	}
+
+	if opts.notifyURL == "" {
+		return
+	}
+	host, _ := os.Hostname()
+	alert := watchAlert{Text: title + " (" + host + ")\n" + body, Event: "watch", Host: host, Checks: changed}
+	if err := notify.Post(opts.notifyURL, alert); err != nil {
+		ui.Warn(i18n.T("Could not post the alert: %v", err))
+	}
+}
+
+// ════════════════════════════════════════════════════════════
+// Standalone: Manual-attention checklist
+// ════════════════════════════════════════════════════════════
+
+// runTodo lists the checklist, or marks an item done/undone:
+// todo, todo done N, todo undo N
+func runTodo(args []string) {
+	picoHome := picoClawHome()
+	path := todo.Path(picoHome)
+	items, err := todo.Load(path)
+	if err != nil {
+		ui.Error(i18n.T("No checklist found at %s — it is written by 'claw-migrate migrate'", path))
+		os.Exit(1)
+	}
+
+	if len(args) >= 2 && (args[0] == "done" || args[0] == "undo") {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 || n > len(items) {
+			ui.Fatal(i18n.T("No item %s — run 'claw-migrate todo' to see the list", args[1]))
+		}
+		item := &items[n-1]
+		if args[0] == "done" {
+			if problem := validateTodo(*item, picoHome); problem != "" {
+				ui.Warn(problem)
+				if !ui.ConfirmDangerous("Mark it done anyway?") {
+					return
+				}
+			}
+			item.Done = true
+		} else {
+			item.Done = false
+		}
+		if err := todo.Save(path, items); err != nil {
+			ui.Fatal(i18n.T("Could not write %s: %v", todo.FileName, err))
+		}
+	} else if len(args) > 0 {
+		ui.Fatal("Usage: claw-migrate todo [done N | undo N]")
+	}
+
+	for i, item := range items {
+		mark := ui.Yellow + "○" + ui.Reset
+		text := item.Text
+		if item.Done {
+			mark = ui.Green + "✓" + ui.Reset
+			text = ui.Dim + text + ui.Reset
+		}
+		fmt.Printf("  %s %2d. %s\n", mark, i+1, text)
+	}
+	if pending := len(todo.Pending(items)); pending > 0 {
+		ui.Info(i18n.T("%d of %d item(s) left — mark one done with: claw-migrate todo done N", pending, len(items)))
+	} else {
+		ui.Success("All manual items done")
+	}
+}
+
+// validateTodo re-checks an item before it is ticked off. It returns a
+// description of what still looks wrong, or "" if the item checks out or
+// can't be checked automatically.
+func validateTodo(item todo.Item, picoHome string) string {
+	switch item.ID {
+	case "mcp":
+		picoConfig, err := config.ReadConfig(filepath.Join(picoHome, "config.json"))
+		if err != nil {
+			return i18n.T("Could not read PicoClaw config: %v", err)
+		}
+		have := map[string]bool{}
+		for _, name := range detect.GetMCPServers(picoConfig) {
+			have[name] = true
+		}
+		if len(have) == 0 {
+			return "No MCP servers are configured in PicoClaw yet"
+		}
+		// If OpenClaw is still around, every one of its servers should be present
+		if oc := detect.DetectOpenClaw(); oc.Found && oc.Config != nil {
+			var missing []string
+			for _, name := range detect.GetMCPServers(oc.Config) {
+				if !have[name] {
+					missing = append(missing, name)
+				}
+			}
+			if len(missing) > 0 {
+				return i18n.T("MCP servers missing from PicoClaw: %s", strings.Join(missing, ", "))
+			}
+		}
+	case "cron":
+		out, err := install.ListCronJobs()
+		if err != nil {
+			return i18n.T("Could not list PicoClaw cron jobs: %v", err)
+		}
+		if strings.TrimSpace(out) == "" {
+			return "PicoClaw has no cron jobs yet"
+		}
+	}
+	return ""
+}
+
+// ════════════════════════════════════════════════════════════
+// Standalone: Config diff
+// ════════════════════════════════════════════════════════════
+
+// runDiffConfig traces every OpenClaw setting into the PicoClaw config.
+// An optional argument filters settings by path, e.g. "diff-config temperature".
+func runDiffConfig(args []string) {
+	ui.Banner()
+	ui.Phase(1, "Compare configurations")
+
+	ui.Step(1, "Sources")
+	ocConfig, source, err := loadOpenClawConfig()
+	if err != nil {
+		ui.Error(err.Error())
+		os.Exit(1)
+	}
+	ui.Found("OpenClaw config", source)
+
+	picoPath := filepath.Join(picoClawHome(), "config.json")
+	picoConfig, err := config.ReadConfig(picoPath)
+	if err != nil {
+		ui.Error(i18n.T("Could not read PicoClaw config: %v", err))
+		os.Exit(1)
+	}
+	ui.Found("PicoClaw config", picoPath)
+
+	filter := ""
+	if len(args) > 0 {
+		filter = strings.ToLower(args[0])
+	}
+
+	ui.Step(2, "Settings")
+	counts := map[string]int{}
+	for _, e := range config.Diff(ocConfig, picoConfig) {
+		if filter != "" && !strings.Contains(strings.ToLower(e.Path), filter) {
+			continue
+		}
+		counts[e.Status]++
+		value := formatSetting(e.Path, e.Value)
+		switch e.Status {
+		case config.DiffCarried:
+			fmt.Printf("  "+ui.Green+"="+ui.Reset+" %s  %s\n", e.Path, ui.Dim+value+ui.Reset)
+		case config.DiffTransformed:
+			fmt.Printf("  "+ui.Cyan+"→"+ui.Reset+" %s  %s\n", e.Path, ui.Dim+value+ui.Reset)
+			fmt.Printf("      "+ui.Dim+"→ %s"+ui.Reset+"\n", strings.Join(e.Dest, ", "))
+		case config.DiffChanged:
+			fmt.Printf("  "+ui.Yellow+"≠"+ui.Reset+" %s  %s\n", e.Path, ui.Dim+value+ui.Reset)
+			fmt.Printf("      "+ui.Dim+"→ %s = %s"+ui.Reset+"\n", e.Dest[0], formatSetting(e.Dest[0], e.Now))
+		case config.DiffDropped:
+			fmt.Printf("  "+ui.Red+"✗"+ui.Reset+" %s  %s\n", e.Path, ui.Dim+value+ui.Reset)
+		}
+	}
+
+	if len(counts) == 0 {
+		if filter != "" {
+			ui.Info(i18n.T("No OpenClaw settings match %q", args[0]))
+		} else {
+			ui.Info("OpenClaw config has no settings")
+		}
+		return
+	}
+	fmt.Println()
+	ui.Info(i18n.T("%d carried over (=), %d transformed (→), %d changed since migration (≠), %d dropped (✗)",
+		counts[config.DiffCarried], counts[config.DiffTransformed], counts[config.DiffChanged], counts[config.DiffDropped]))
+}
+
+// ════════════════════════════════════════════════════════════
+// Standalone: Config lint
+// ════════════════════════════════════════════════════════════
+
+// runLint checks a PicoClaw config, by default ~/.picoclaw/config.json, and
+// exits non-zero if it has errors so it can gate scripts and CI
+func runLint(args []string, opts options) {
+	ui.Banner()
+
+	path := filepath.Join(picoClawHome(), "config.json")
+	if len(args) > 0 {
+		path = args[0]
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		ui.Error(i18n.T("Could not read %s: %v", path, err))
+		os.Exit(1)
+	}
+	ui.Found("Config", path)
+	fmt.Println()
+
+	findings := lint.Check(data, lint.Options{Deprecated: modelUpgrade, Network: !opts.offline})
+	for _, f := range findings {
+		mark := ui.Yellow + "⚠" + ui.Reset
+		if f.Severity == lint.Error {
+			mark = ui.Red + "✗" + ui.Reset
+		}
+		if f.Path == "" {
+			fmt.Printf("  %s %s\n", mark, f.Message)
+		} else {
+			fmt.Printf("  %s %s  %s\n", mark, f.Path, ui.Dim+f.Message+ui.Reset)
+		}
+	}
+
+	errs := lint.Errors(findings)
+	switch {
+	case len(findings) == 0:
+		ui.Success("No problems found")
+	case errs > 0:
+		fmt.Println()
+		ui.Error(i18n.T("%d error(s), %d warning(s)", errs, len(findings)-errs))
+		os.Exit(1)
+	default:
+		fmt.Println()
+		ui.Warn(i18n.T("%d warning(s)", len(findings)))
+	}
+}
+
+// loadOpenClawConfig reads openclaw.json, falling back to the newest backup
+// once OpenClaw has been uninstalled. It also returns where the config came from.
+func loadOpenClawConfig() (map[string]interface{}, string, error) {
+	oc := detect.DetectOpenClaw()
+	if oc.Config != nil {
+		return oc.Config, oc.ConfigPath, nil
+	}
+
+	backups := backup.ListBackups()
+	if len(backups) == 0 {
+		return nil, "", errors.New(i18n.T("No OpenClaw config found at ~/.openclaw/openclaw.json and no backup to read it from"))
+	}
+	data, err := backup.ReadFile(backups[0].Path, ".openclaw/openclaw.json")
+	if err != nil {
+		return nil, "", err
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, "", fmt.Errorf("parse openclaw.json from %s: %w", backups[0].Filename, err)
+	}
+	return cfg, i18n.T("%s (from backup)", backups[0].Filename), nil
+}
+
+// formatSetting renders a config value for display, masking anything that looks like a secret
+func formatSetting(path string, v interface{}) string {
+	if v == nil {
+		return "(not set)"
+	}
+	data, _ := json.Marshal(v)
+	s := string(data)
+
+	if secrets.IsSecretKey(path[strings.LastIndex(path, ".")+1:]) {
+		if str, ok := v.(string); ok {
+			return `"` + secrets.Mask(str) + `"`
+		}
+		return `"****"`
+	}
+	return s
+}
+
+// picoClawHome returns the PicoClaw home directory (~/.picoclaw)
+func picoClawHome() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".picoclaw")
+}
+
+// backupTime formats a backup's timestamp for display, falling back to the raw value
+func backupTime(b backup.BackupInfo) string {
+	t, err := time.ParseInLocation("20060102-150405", b.Timestamp, time.Local)
+	if err != nil {
+		return b.Timestamp
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// ════════════════════════════════════════════════════════════
+// Standalone: Dotfiles
+// ════════════════════════════════════════════════════════════
+
+// runDotfiles adds the migrated ~/.picoclaw, minus credentials and bulky
+// data, to chezmoi's source directory or to a plain git dotfiles repo
+// (dotfiles [REPO]), and makes the first commit
+func runDotfiles(args []string, opts options) {
+	ui.Banner()
+	picoHome := picoClawHome()
+	if _, err := os.Stat(filepath.Join(picoHome, "config.json")); err != nil {
+		ui.Fatal("No PicoClaw config found — run a migration first")
+	}
+
+	repo, useChezmoi := "", false
+	if len(args) > 0 {
+		abs, err := filepath.Abs(args[0])
+		if err != nil {
+			ui.Fatal(err.Error())
+		}
+		repo = abs
+	} else if source, err := dotfiles.ChezmoiSource(); err == nil {
+		repo, useChezmoi = source, true
+	} else {
+		ui.Fatal("chezmoi is not set up — pass a git dotfiles repo: claw-migrate dotfiles REPO")
+	}
+
+	ui.Step(1, "Choosing files")
+	if useChezmoi {
+		ui.Summary("chezmoi source", repo)
+	} else {
+		ui.Summary("Repository", repo)
+	}
+	files, skipped := dotfiles.Select(picoHome)
+	ui.Summary("Files", i18n.T("%d, plus config.json", len(files)))
+	if len(skipped) > 0 {
+		names := make([]string, 0, len(skipped))
+		for rel, reason := range skipped {
+			names = append(names, i18n.T("%s (%s)", rel, i18n.T(reason)))
+		}
+		sort.Strings(names)
+		ui.Summary("Left out", previewList(names, 5))
+	}
+
+	cfg, err := config.ReadConfig(filepath.Join(picoHome, "config.json"))
+	if err != nil {
+		ui.Fatal(i18n.T("Cannot read PicoClaw config: %v", err))
+	}
+	public, private, err := secrets.Split(cfg)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	home, _ := os.UserHomeDir()
+	patchPath := filepath.Join(home, secrets.PatchFile)
+	if n := len(secrets.Collect(private)); n > 0 {
+		if useChezmoi {
+			ui.Summary("Credentials", i18n.T("%d, read from %s by the config.json template", n, patchPath))
+		} else {
+			ui.Summary("Credentials", i18n.T("%d, removed from the committed config.json and kept in %s", n, patchPath))
+		}
+	}
+
+	if opts.dryRun {
+		ui.Info(i18n.T("[DRY RUN] Would add %d file(s) to %s", len(files)+1, repo))
+		return
+	}
+	if !ui.Confirm("Add these files to your dotfiles?") {
+		return
+	}
+
+	ui.Step(2, "Writing files")
+	if err := secrets.WritePatch(patchPath, private); err != nil {
+		ui.Fatal(i18n.T("Could not write %s: %v", patchPath, err))
+	}
+	var written []string
+	if useChezmoi {
+		written, err = dotfiles.WriteChezmoi(repo, picoHome, files, public, private)
+	} else {
+		written, err = dotfiles.WriteGit(repo, picoHome, files, public)
+	}
+	if err != nil {
+		ui.Fatal(i18n.T("Could not write dotfiles: %v", err))
+	}
+	ui.Success(i18n.T("Wrote %d file(s) and the ignore rules", len(written)-1))
+
+	ui.Step(3, "Committing")
+	if !dotfiles.IsGitRepo(repo) {
+		ui.Info(i18n.T("%s is not a git repository — commit the files yourself", repo))
+		return
+	}
+	if !ui.Confirm("Commit them now?") {
+		return
+	}
+	if err := dotfiles.Commit(repo, written, "Add PicoClaw config migrated from OpenClaw"); err != nil {
+		ui.Error(err.Error())
+		return
+	}
+	ui.Success("Committed")
+	if useChezmoi {
+		ui.Info(i18n.T("On another machine, put the keys in ~/%s before chezmoi apply", secrets.PatchFile))
+	} else {
+		ui.Info(i18n.T("Keys aren't in the repo — bring them over with export-secrets / import-secrets"))
+	}
+}
+
+// ════════════════════════════════════════════════════════════
+// Standalone: Uninstall
+// ════════════════════════════════════════════════════════════
+
+func runUninstallMenu(opts options) {
+	ui.Banner()
+
+	choice := ui.Choose("What do you want to uninstall?", []string{
+		"OpenClaw  — Remove OpenClaw (binary + data)",
+		"PicoClaw  — Remove PicoClaw (binary + data) for a fresh start",
+	}, -1)
+
+	switch choice {
+	case 0:
+		runUninstallOpenClaw(opts)
+	case 1:
+		runUninstallPicoClaw()
+	}
+}
+
+func runUninstallOpenClaw(opts options) {
+	// Saying what happens to the data answers every other question
+	if opts.openclawData != "" {
+		ui.SetAssumeYes(true)
+	}
+
+	oc := detect.DetectOpenClaw()
+	if !oc.Found && oc.BinaryPath == "" {
+		// npm never saw a containerized install, but Docker did
+		if a, err := uninstall.FindDocker(); err == nil && !a.Empty() {
+			ui.Phase(1, "Uninstall OpenClaw")
+			run := startRun(runs.KindUninstallOpenClaw)
+			removeDockerArtifacts(oc, backup.Result{}, opts)
+			endRun(run, runs.OutcomeSuccess, "Docker containers and images only")
+			return
+		}
+		ui.Error("OpenClaw installation not found")
+		os.Exit(1)
+	}
+
+	// Offer backup first; there's no need when the data stays
+	var backupResult backup.Result
+	if oc.Found && opts.openclawData != dataKeep {
+		ui.Warn("It's recommended to create a backup before uninstalling.")
+		if ui.Confirm("Create a backup first?") {
+			backupResult = doBackup(oc, opts, "Uninstall cancelled. Nothing was removed.")
+		}
+	}
+
+	phase6Uninstall(oc, false, backupResult, opts)
+	ui.Success("Done!")
+}
+
+// runPurgeDue deletes OpenClaw's data once the grace period --purge-after
+// gave it is over: purge-due, or purge-due cancel to keep the data
+func runPurgeDue(args []string, opts options) {
+	p, err := uninstall.LoadPurge()
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	if p == nil {
+		ui.Info("No purge is scheduled")
+		return
+	}
+
+	if len(args) > 0 && args[0] == "cancel" {
+		if err := uninstall.CancelPurge(); err != nil {
+			ui.Fatal(i18n.T("Could not cancel the purge: %v", err))
+		}
+		ui.Success(i18n.T("Purge cancelled — %s stays", p.Path))
+		return
+	}
+	if len(args) > 0 {
+		ui.Fatal(i18n.T("Unknown purge-due action: %s (use: purge-due, purge-due cancel)", args[0]))
+	}
+
+	if _, err := os.Stat(p.Path); os.IsNotExist(err) {
+		ui.Info(i18n.T("%s is already gone", p.Path))
+		uninstall.CancelPurge()
+		return
+	}
+	if time.Now().Before(p.Due) {
+		days := int(time.Until(p.Due).Hours()/24) + 1
+		ui.Info(i18n.T("%s is due for deletion on %s, in %d day(s)", p.Path, p.Due.Format("2006-01-02"), days))
+		return
+	}
+
+	ui.Warn(i18n.T("About to delete: %s", p.Path))
+
+	// Days have passed: the backup has to still be there and readable, and
+	// the data as it was left, not reinstalled or used again since
+	question := "Delete all OpenClaw data?"
+	safe := true
+	backupErr := fmt.Errorf("none was recorded")
+	if p.Backup != "" {
+		if _, backupErr = os.Stat(p.Backup); backupErr == nil {
+			backupErr = backup.VerifyBackup(p.Backup)
+		}
+	}
+	switch {
+	case backupErr == nil:
+		ui.Info(i18n.T("Verified backup: %s", p.Backup))
+	case opts.force:
+		ui.Warn(i18n.T("No verified backup (%v) — deleting anyway (--force)", backupErr))
+	default:
+		ui.Warn(i18n.T("No verified backup (%v) — once deleted, this data can't be restored", backupErr))
+		question = "Delete all OpenClaw data without a backup?"
+		safe = false
+	}
+	if now, err := uninstall.ReadDataState(p.Path); err != nil || now != p.State {
+		ui.Warn(i18n.T("%s changed since the uninstall — OpenClaw may have been reinstalled or used again", p.Path))
+		safe = false
+	}
+
+	// Unattended (the crontab entry), only --purge deletes, and only safely
+	unattended := ui.AssumeYes() || opts.openclawData == dataPurge
+	switch {
+	case unattended && !safe:
+		ui.Error("Refusing to delete OpenClaw's data unattended — run claw-migrate purge-due to decide")
+		ui.Info("Data directory preserved.")
+		return
+	case opts.openclawData == dataPurge:
+		ui.Info(i18n.T("Deleting %s (--purge)", p.Path))
+	case !ui.ConfirmDangerous(question):
+		ui.Info("Data directory preserved.")
+		return
+	}
+	if err := uninstall.RemoveData(p.Path); err != nil {
+		ui.Fatal(i18n.T("Could not remove data: %v", err))
+	}
+	if err := uninstall.CancelPurge(); err != nil {
+		ui.Warn(i18n.T("Could not update the crontab: %v", err))
+	}
+	ui.Success("OpenClaw data removed")
+}
+
+func runUninstallPicoClaw() {
+	home, _ := os.UserHomeDir()
+	picoHome := filepath.Join(home, ".picoclaw")
+
+	pc := detect.DetectPicoClaw()
+	recorded := settings.Load().PicoClawBinary
+	binaries := uninstall.PicoClawBinaries(recorded)
+	services := uninstall.PicoClawServices()
+	if !pc.Found && len(binaries) == 0 && len(services) == 0 {
+		ui.Error("PicoClaw installation not found")
+		os.Exit(1)
+	}
+
+	ui.Phase(1, "Uninstall PicoClaw")
+
+	for _, b := range binaries {
+		ui.Found("Binary", b)
+	}
+	for _, unit := range services {
+		ui.Found("Service", unit)
+	}
+	if pc.Found {
+		ui.Found("Data", picoHome)
+		totalSize := detect.DirSize(picoHome)
+		ui.Found("Size", detect.FormatSize(totalSize))
+	}
+
+	ui.Warn("This will remove PicoClaw completely so you can start fresh.")
+	if !ui.ConfirmDangerous("Uninstall PicoClaw?") {
+		ui.Info("Cancelled.")
+		return
+	}
+	run := startRun(runs.KindUninstallPicoClaw)
+
+	// Stop processes
+	ui.Step(1, "Stopping PicoClaw processes")
+	stopSupervised("picoclaw")
+	uninstall.StopPicoClaw()
+	ui.Success("Processes stopped")
+
+	// Remove binaries, wherever they were installed
+	if len(binaries) > 0 {
+		ui.Step(2, "Removing binary")
+		for _, b := range binaries {
+			if err := uninstall.RemovePicoClawBinary(b); err != nil {
+				ui.Warn(i18n.T("Could not remove binary: %v", err))
+				ui.Info(i18n.T("You may need to manually delete: %s", b))
+			} else {
+				ui.Success(i18n.T("Removed %s", b))
+			}
+		}
+	}
+
+	// Remove launch agents (macOS) and systemd units (Linux)
+	ui.Step(3, "Removing launch agents and services")
+	removedAgents := uninstall.RemovePicoClawLaunchAgents()
+	if len(removedAgents) > 0 {
+		ui.Success(i18n.T("Removed %d launch agent(s)", len(removedAgents)))
+	}
+	removedUnits := uninstall.RemovePicoClawServices()
+	if len(removedUnits) > 0 {
+		ui.Success(i18n.T("Stopped and removed %d systemd unit(s)", len(removedUnits)))
+	}
+	if len(removedAgents)+len(removedUnits) == 0 {
+		ui.Info("No launch agents or services found")
+	}
+
+	// Remove data
+	if pc.Found {
+		ui.Step(4, "Removing data directory")
+		ui.Warn(i18n.T("About to delete: %s", picoHome))
+
+		if !ui.ConfirmDangerous("Delete all PicoClaw data?") {
+			ui.Info(i18n.T("Data directory preserved at %s", picoHome))
+		} else {
+			if err := uninstall.RemoveData(picoHome); err != nil {
+				ui.Error(i18n.T("Could not remove data: %v", err))
+			} else {
+				ui.Success("PicoClaw data removed")
+			}
+		}
+	}
+
+	// Verify
+	ui.Step(5, "Verifying removal")
+	binaryGone, dataGone, servicesGone := uninstall.VerifyPicoClawRemoved(recorded)
+	if binaryGone && recorded != "" {
+		rememberBinary("")
+	}
+	if binaryGone && dataGone && servicesGone {
+		ui.Success("PicoClaw completely removed")
+		endRun(run, runs.OutcomeSuccess, "")
+	} else {
+		endRun(run, runs.OutcomeErrors, fmt.Sprintf("binary removed: %t, data removed: %t, services removed: %t", binaryGone, dataGone, servicesGone))
+		for _, b := range uninstall.PicoClawBinaries(recorded) {
+			ui.Warn(i18n.T("Binary still found — try: sudo rm %s", b))
+		}
+		for _, unit := range uninstall.PicoClawServices() {
+			ui.Warn(i18n.T("Service still found — try: systemctl disable --now %s && rm %s", filepath.Base(unit), unit))
+		}
+		if !dataGone {
+			ui.Warn(i18n.T("Data still found — try: rm -rf %s", picoHome))
+		}
+	}
+
+	fmt.Println()
+	ui.Info("You can now run a fresh migration with: ./claw-migrate migrate")
+}
+
+// ════════════════════════════════════════════════════════════
+// Full migration flow
+// ════════════════════════════════════════════════════════════
+
+func runMigrate(opts options) {
+	dryRun := opts.dryRun
+	ui.Banner()
+
+	if dryRun {
+		ui.Warn("DRY RUN mode — no changes will be made")
+	}
+
+	if opts.notifyURL != "" && !dryRun {
+		ui.OnFatal(func(msg string) { notifyFailure(opts.notifyURL, msg) })
+	}
+	if opts.planFile != "" {
+		ui.RecordPlan()
+	}
+
+	// Phase 1: Detect
+	phase1Detect()
+	oc := detectOpenClaw()
+	pc := detect.DetectPicoClaw()
+	sys := detect.GetSystemInfo()
+
+	if !oc.Found {
+		ui.Error("OpenClaw installation not found at ~/.openclaw/")
+		ui.Info("Make sure OpenClaw is installed and has been initialized.")
+		if !dryRun {
+			notifyFailure(opts.notifyURL, i18n.T("OpenClaw installation not found at ~/.openclaw/"))
+		}
+		os.Exit(1)
+	}
+
+	showDetectionResults(oc, pc, sys)
+	showPlan(oc, pc, opts)
+
+	if !ui.Confirm("Ready to begin migration?") {
+		ui.Info("Migration cancelled. No changes made.")
+		return
+	}
+	if opts.planFile != "" {
+		defer writePlan(oc, opts)
+	}
+
+	var run *runs.Run
+	if !dryRun {
+		run = startRun(runs.KindMigrate)
+	}
+	report := stats.New(version)
+	timed := func(phase string, fn func()) {
+		start := time.Now()
+		fn()
+		took := time.Since(start)
+		report.Durations[phase] = took.Seconds()
+		notifyPhaseDone(phase, took, opts)
+	}
+
+	// Phase 2: Backup, with the PicoClaw download running alongside
+	startPrefetch(pc, opts)
+	var backupResult backup.Result
+	timed("backup", func() { backupResult = phase2Backup(oc, opts) })
+	reportPrefetch()
+
+	// Containerized: phase 3 builds the image layout or manifests instead of touching the host
+	if opts.toK8s != "" {
+		timed("migrate", func() { phaseKubernetes(oc, opts) })
+		printTimings(ui.Timings())
+		if run != nil {
+			run.Backup = backupResult.Path
+			endRun(run, runs.OutcomeSuccess, "Kubernetes manifests in "+opts.toK8s)
+		}
+		return
+	}
+	if opts.toNix != "" {
+		var result migrate.Result
+		timed("migrate", func() { result = phaseNix(oc, pc, opts) })
+		finishRun(run, report, oc, result, backupResult, opts)
+		return
+	}
+	if opts.toDocker != "" {
+		var result migrate.Result
+		timed("migrate", func() { result = phaseDocker(oc, backupResult, opts) })
+		finishRun(run, report, oc, result, backupResult, opts)
+		return
+	}
+	if opts.output != "" {
+		var result migrate.Result
+		timed("migrate", func() { result = phaseProvision(3, oc, opts) })
+		finishRun(run, report, oc, result, backupResult, opts)
+		return
+	}
+
+	// Phase 3: Install PicoClaw
+	onboarded := !install.NeedsOnboard(picoClawHome())
+	if !opts.skipInstall {
+		timed("install", func() { phase3Install(oc, pc, sys, dryRun) })
+	} else {
+		ui.Phase(3, "Install PicoClaw (skipped)")
+		ui.Info("--skip-install flag set")
+	}
+
+	pc = detect.DetectPicoClaw()
+	pc.Capabilities = probePicoClaw(pc)
+
+	// Phase 4: Migrate
+	var result migrate.Result
+	timed("migrate", func() { result = phase4Migrate(oc, pc, backupResult, opts, onboarded) })
+	detect.Forget(oc.HomeDir) // --move empties the sources; don't answer from the pre-copy scan
+	detect.Forget(oc.WorkspaceDir)
+
+	// Phase 5: Verify
+	timed("verify", func() { phase5Verify(dryRun) })
+
+	// Phase 6: Uninstall
+	if !opts.skipUninstall {
+		timed("uninstall", func() { phase6Uninstall(oc, dryRun, backupResult, opts) })
+	} else {
+		ui.Phase(6, "Uninstall OpenClaw (skipped)")
+		ui.Info("--skip-uninstall flag set. You can uninstall later with:")
+		ui.Info("  npm uninstall -g openclaw && rm -rf ~/.openclaw")
+		webhooksLeft()
+	}
+
+	finishRun(run, report, oc, result, backupResult, opts)
+
+	ui.CompletionBanner()
+}
+
+// finishRun prints where the time went and, unless it was a dry run,
+// records the run in the history, fills in the stats report with the
+// timings, shares it and posts the result to --notify-url
+func finishRun(run *runs.Run, report stats.Report, oc detect.Installation, result migrate.Result, backupResult backup.Result, opts options) {
+	timings := ui.Timings()
+	printTimings(timings)
+	if opts.dryRun {
+		return
+	}
+	fillReport(&report, oc, result)
+	run.Backup = backupResult.Path
+	endRun(run, report.Outcome, fmt.Sprintf("%d of %d files migrated, %d skipped, %d errors", report.Migrated, report.Files, report.Skipped, report.Errors))
+	for _, p := range timings {
+		report.Steps = append(report.Steps, stats.Timing{Phase: p.Number, Title: p.Title, Seconds: p.Duration.Seconds()})
+		for _, st := range p.Steps {
+			report.Steps = append(report.Steps, stats.Timing{Phase: p.Number, Step: st.Number, Title: st.Title, Seconds: st.Duration.Seconds()})
+		}
+	}
+	shareStats(report)
+	notifyResult(opts.notifyURL, report, backupResult)
+}
+
+// printTimings shows where the run's time went, phase by phase with the
+// steps that took a noticeable share of it
+func printTimings(timings []ui.PhaseTime) {
+	var total time.Duration
+	for _, p := range timings {
+		total += p.Duration
+	}
+	if total <= 0 {
+		return
+	}
+	share := func(d time.Duration) string {
+		return fmt.Sprintf("%3.0f%%", float64(d)/float64(total)*100)
+	}
+
+	fmt.Println()
+	ui.Info("Time taken, not counting time waiting for answers:")
+	for _, p := range timings {
+		fmt.Printf("    "+ui.Bold+"%-52s"+ui.Reset+" %8s  %s\n", i18n.T("PHASE %d", p.Number)+"  "+i18n.T(p.Title), ui.FormatDuration(p.Duration), share(p.Duration))
+		for _, st := range p.Steps {
+			if st.Duration < total/100 {
+				continue // under 1% — not where the time went
+			}
+			fmt.Printf("      "+ui.Dim+"[%d]"+ui.Reset+" %-46s %8s  %s\n", st.Number, i18n.T(st.Title), ui.FormatDuration(st.Duration), share(st.Duration))
+		}
+	}
+	fmt.Printf("    %-52s %8s\n", i18n.T("Total"), ui.FormatDuration(total))
+}
+
+// copyWorkspaceTo copies the workspace, other known OpenClaw data and
+// credential files into a PicoClaw home other than the host's ~/.picoclaw.
+// Sources are always kept, whatever --move says.
+func copyWorkspaceTo(oc detect.Installation, picoHome, picoWorkspace string, opts options) migrate.Result {
+	var result migrate.Result
+	copyOpts := migrate.Options{Force: true, MaxErrors: opts.maxErrors, Limiter: opts.ioLimit, Sync: opts.fsync, Scrub: opts.scrub}
+	copyOpts.Strategy = copyStrategy(opts.copyStrategy, oc.WorkspaceDir, picoWorkspace)
+	meter := ui.NewMeter("Copying workspace files", detect.DirSize(oc.WorkspaceDir))
+	copyOpts.Progress = meter.Add
+	// An aborted step (disk full, too many errors) stops the ones after it
+	meter.Run(func() error {
+		result = migrate.MigrateWorkspace(oc.WorkspaceDir, picoWorkspace, copyOpts)
+		if !result.Aborted {
+			mergeResult(&result, migrate.MigrateHomeItems(oc.HomeDir, picoWorkspace, homeItemNames(oc), copyOpts))
+		}
+		return nil
+	})
+	copyOpts.Progress = nil
+	if !result.Aborted {
+		reportSecretCollisions(oc)
+		mergeResult(&result, migrate.MigrateSecrets(oc.HomeDir, picoHome, homeItemNames(oc), copyOpts))
+	}
+	if result.Aborted {
+		ui.Error(i18n.T("Workspace copy aborted after %d errors: %v", result.Errors, result.AbortReason))
+	} else {
+		ui.Success(i18n.T("Migrated %d files (%d skipped, %d errors)", result.Migrated, result.Skipped, result.Errors))
+	}
+	reportRedacted(result)
+	return result
+}
+
+// copyStrategy resolves --copy-strategy for copying src into dst, picking
+// one from the filesystems when it is auto or unset, and says which
+func copyStrategy(name, src, dst string) migrate.Strategy {
+	var s migrate.Strategy
+	reason := "--copy-strategy"
+	if name == "" || name == migrate.StrategyAuto {
+		s, reason = migrate.ChooseStrategy(src, dst)
+	} else {
+		s, _ = migrate.NewStrategy(name) // validated with the flags
+	}
+	ui.Info(i18n.T("Copy strategy: %s (%s) — %d file(s) at once, %s buffers", s.Name, i18n.T(reason), s.Workers, detect.FormatSize(int64(s.BufferSize))))
+	return s
+}
+
+// reportRedacted lists the copied files that had secrets scrubbed out
+func reportRedacted(result migrate.Result) {
+	var names []string
+	total := 0
+	for _, fr := range result.Files {
+		if fr.Redacted > 0 {
+			names = append(names, fr.Name)
+			total += fr.Redacted
+		}
+	}
+	if total > 0 {
+		ui.Warn(i18n.T("Redacted %d secret(s) in %d file(s): %s", total, len(names), previewList(names, 5)))
+		ui.Info("The originals are unchanged in ~/.openclaw — move any keys you still need into the config")
+	}
+}
+
+// phaseDocker converts the config and copies the workspace into a data
+// directory next to a generated Dockerfile, and optionally builds the image.
+// The host's PicoClaw and OpenClaw are left alone.
+func phaseDocker(oc detect.Installation, backupResult backup.Result, opts options) migrate.Result {
+	ui.Phase(3, "Build container layout")
+	var result migrate.Result
+
+	dir, err := filepath.Abs(opts.toDocker)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	dataHome := filepath.Join(dir, docker.DataDir)
+	dataWorkspace := filepath.Join(dataHome, "workspace")
+	ui.Summary("Output", dir)
+	ui.Summary("Volume", i18n.T("%s → %s", dataHome, docker.ContainerHome))
+
+	if opts.dryRun {
+		ui.Info(i18n.T("[DRY RUN] Would copy %s to %s", oc.WorkspaceDir, dataWorkspace))
+		ui.Info(i18n.T("[DRY RUN] Would write %s", filepath.Join(dataHome, "config.json")))
+		ui.Info(i18n.T("[DRY RUN] Would write Dockerfile, docker-compose.yml and .dockerignore in %s", dir))
+		return result
+	}
+
+	ui.Step(1, "Copying workspace into the volume")
+	result = copyWorkspaceTo(oc, dataHome, dataWorkspace, opts)
+
+	ui.Step(2, "Converting config")
+	cfg := migrate.MigrateConfig(oc.ConfigPath, filepath.Join(dataHome, "config.json"), true)
+	result.Files = append(result.Files, cfg)
+	if cfg.Error != nil {
+		ui.Error(i18n.T("Config conversion failed: %v", cfg.Error))
+	} else {
+		os.Chmod(cfg.Dest, 0600)
+		ui.Success("Config converted")
+	}
+
+	ui.Step(3, "Writing Dockerfile")
+	written, err := docker.WriteFiles(dir, install.FetchLatestVersion(), install.BaseURL)
+	warnVersionFallback()
+	if err != nil {
+		ui.Error(err.Error())
+		return result
+	}
+	for _, path := range written {
+		ui.FileStatus(filepath.Base(path), true, fileDetail(path))
+	}
+
+	j := journal.New(oc.WorkspaceDir, dataWorkspace)
+	j.BackupPath = backupResult.Path
+	j.BackupVerified = backupResult.Verified
+	j.Record(journalEntries(result))
+	j.Outcome = migrationOutcome(result)
+	if err := j.Save(); err != nil {
+		ui.Warn(i18n.T("Could not save the migration journal: %v", err))
+	}
+
+	built := false
+	if docker.Available() {
+		ui.Step(4, "Building the image")
+		if ui.Confirm(i18n.T("Build the %s image now?", docker.DefaultTag)) {
+			if err := docker.Build(dir, docker.DefaultTag); err != nil {
+				ui.Error(err.Error())
+			} else {
+				built = true
+				ui.Success(i18n.T("Built image %s", docker.DefaultTag))
+			}
+		}
+	} else {
+		ui.Info("docker is not installed here — copy the directory to a Docker host to build it")
+	}
+
+	lines := []string{i18n.T("Start the agent with:"), "  " + docker.RunHint(dir)}
+	if !built {
+		lines = append(lines, i18n.T("(docker compose builds the image on first start)"))
+	}
+	lines = append(lines, "", i18n.T("Keys are in %s — keep it out of version control", dataHome))
+	ui.Box("Container ready", lines)
+	return result
+}
+
+// phaseProvision prepares another machine's PicoClaw in a directory laid
+// out like its home: the release binary for --target-os/--target-arch in
+// .local/bin, and, when migrating, the converted config and workspace in
+// .picoclaw. rsync the directory into the target's home to install it.
+func phaseProvision(phase int, oc detect.Installation, opts options) migrate.Result {
+	goos, goarch := install.Platform()
+	ui.Phase(phase, i18n.T("Provision PicoClaw for %s/%s", goos, goarch))
+
+	dir, err := filepath.Abs(opts.output)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	ui.Summary("Output", dir)
+	result := provisionInto(dir, oc, opts)
+	if opts.dryRun {
+		return result
+	}
+
+	lines := []string{
+		i18n.T("Copy it into the target's home directory:"),
+		"  rsync -a " + dir + "/ USER@HOST:~/",
+		"",
+		i18n.T("Then on the target:"),
+	}
+	if oc.Found {
+		lines = append(lines, "  ~/.local/bin/picoclaw gateway", "", i18n.T("Keys are in %s — keep it out of version control", filepath.Join(dir, ".picoclaw")))
+	} else {
+		lines = append(lines, "  ~/.local/bin/picoclaw onboard")
+	}
+	ui.Box(i18n.T("Ready for %s/%s", goos, goarch), lines)
+	return result
+}
+
+// provisionInto downloads the release binary for the target platform into
+// dir/.local/bin and, when oc is found, copies the workspace and converts
+// the config into dir/.picoclaw
+func provisionInto(dir string, oc detect.Installation, opts options) migrate.Result {
+	var result migrate.Result
+	binDir := filepath.Join(dir, ".local", "bin")
+	picoHome := filepath.Join(dir, ".picoclaw")
+	picoWorkspace := filepath.Join(picoHome, "workspace")
+
+	if opts.dryRun {
+		url, _, err := install.GetDownloadURL()
+		if err != nil {
+			ui.Fatal(i18n.T("Unsupported platform: %v", err))
+		}
+		ui.Info(i18n.T("[DRY RUN] Would download: %s", url))
+		ui.Info(i18n.T("[DRY RUN] Would write %s", filepath.Join(binDir, "picoclaw")))
+		if oc.Found {
+			ui.Info(i18n.T("[DRY RUN] Would copy %s to %s", oc.WorkspaceDir, picoWorkspace))
+			ui.Info(i18n.T("[DRY RUN] Would write %s", filepath.Join(picoHome, "config.json")))
+		}
+		return result
+	}
+
+	ui.Step(1, "Downloading PicoClaw binary")
+	showLatestVersion(install.FetchLatestVersion())
+	binaryPath, archivePath := downloadRelease()
+	dest := filepath.Join(binDir, "picoclaw")
+	if err := install.InstallBinaryTo(binaryPath, dest); err != nil {
+		ui.Fatal(i18n.T("Install failed: %v", err))
+	}
+	install.CleanupArchive(archivePath)
+	os.Remove(binaryPath)
+	ui.FileStatus(dest, true, fileDetail(dest))
+
+	if oc.Found {
+		ui.Step(2, "Copying workspace")
+		result = copyWorkspaceTo(oc, picoHome, picoWorkspace, opts)
+
+		ui.Step(3, "Converting config")
+		cfg := migrate.MigrateConfig(oc.ConfigPath, filepath.Join(picoHome, "config.json"), true)
+		result.Files = append(result.Files, cfg)
+		if cfg.Error != nil {
+			ui.Error(i18n.T("Config conversion failed: %v", cfg.Error))
+		} else {
+			os.Chmod(cfg.Dest, 0600)
+			ui.Success("Config converted")
+		}
+	}
+	return result
+}
+
+// phaseKubernetes writes a ConfigMap/Secret/StatefulSet manifest from the
+// converted config, with the credentials split into the Secret, and the
+// workspace as a tarball to unpack into the StatefulSet's volume
+func phaseKubernetes(oc detect.Installation, opts options) {
+	ui.Phase(3, "Generate Kubernetes manifests")
+
+	dir, err := filepath.Abs(opts.toK8s)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	manifestPath := filepath.Join(dir, k8s.Name+".yaml")
+	tarballPath := filepath.Join(dir, "workspace.tar.gz")
+	ui.Summary("Output", dir)
+
+	ocConfig, err := config.ReadConfig(oc.ConfigPath)
+	if err != nil {
+		ui.Error(i18n.T("Cannot read OpenClaw config: %v", err))
+		return
+	}
+	public, private, err := secrets.Split(config.ConvertConfig(ocConfig))
+	if err != nil {
+		ui.Error(err.Error())
+		return
+	}
+	ui.Summary("Secrets", i18n.T("%d moved out of the ConfigMap", len(secrets.Collect(private))))
+
+	if opts.dryRun {
+		ui.Info(i18n.T("[DRY RUN] Would write %s", manifestPath))
+		ui.Info(i18n.T("[DRY RUN] Would archive %s to %s", oc.WorkspaceDir, tarballPath))
+		return
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		ui.Error(err.Error())
+		return
+	}
+
+	ui.Step(1, "Writing manifests")
+	manifest, err := k8s.Manifests(public, private, docker.DefaultTag)
+	if err == nil {
+		// Holds the Secret's plaintext, so owner-only like config.json
+		err = os.WriteFile(manifestPath, []byte(manifest), 0600)
+	}
+	if err != nil {
+		ui.Error(i18n.T("Could not write manifests: %v", err))
+		return
+	}
+	ui.FileStatus(filepath.Base(manifestPath), true, i18n.T("ConfigMap, Secret, StatefulSet"))
+
+	ui.Step(2, "Archiving workspace")
+	var files int
+	err = ui.SpinnerRun("Archiving workspace", func() error {
+		var archiveErr error
+		files, archiveErr = k8s.WriteWorkspaceTarball(oc.WorkspaceDir, tarballPath)
+		return archiveErr
+	})
+	if err != nil {
+		ui.Error(i18n.T("Could not archive the workspace: %v", err))
+		return
+	}
+	ui.FileStatus(filepath.Base(tarballPath), true, i18n.T("%d files, %s", files, detect.FormatSize(detect.DirSize(tarballPath))))
+
+	pod := k8s.Name + "-0"
+	ui.Box("Manifests ready", []string{
+		i18n.T("Apply them, then load the workspace into the volume:"),
+		"  cd " + dir,
+		"  kubectl apply -f " + filepath.Base(manifestPath),
+		"  kubectl cp workspace.tar.gz " + pod + ":" + k8s.MountPath + "/",
+		"  kubectl exec " + pod + " -- tar -xzf " + k8s.MountPath + "/workspace.tar.gz -C " + k8s.MountPath,
+		"",
+		i18n.T("config.json is rebuilt from the ConfigMap and Secret on every start —"),
+		i18n.T("edit those, not the file in the pod. Keep %s out of version control.", filepath.Base(manifestPath)),
+	})
+}
+
+// phaseNix writes a home-manager module declaring the converted config and
+// a systemd user service. Credentials go to a 0600 file outside the Nix
+// store, and the workspace — data, not configuration — is copied as usual.
+func phaseNix(oc detect.Installation, pc detect.Installation, opts options) migrate.Result {
+	ui.Phase(3, "Generate home-manager module")
+	var result migrate.Result
+
+	dir, err := filepath.Abs(opts.toNix)
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	home, _ := os.UserHomeDir()
+	modulePath := filepath.Join(dir, "picoclaw.nix")
+	secretsPath := filepath.Join(home, secrets.PatchFile)
+	picoHome := picoClawHome()
+	picoWorkspace := filepath.Join(picoHome, "workspace")
+
+	binary := pc.BinaryPath
+	if binary == "" {
+		binary = filepath.Join(home, ".nix-profile", "bin", "picoclaw")
+		ui.Warn(i18n.T("PicoClaw is not installed; the module assumes %s — edit it if yours is elsewhere", binary))
+	}
+
+	ocConfig, err := config.ReadConfig(oc.ConfigPath)
+	if err != nil {
+		ui.Error(i18n.T("Cannot read OpenClaw config: %v", err))
+		return result
+	}
+	public, private, err := secrets.Split(config.ConvertConfig(ocConfig))
+	if err != nil {
+		ui.Error(err.Error())
+		return result
+	}
+
+	if opts.dryRun {
+		ui.Info(i18n.T("[DRY RUN] Would write %s", modulePath))
+		ui.Info(i18n.T("[DRY RUN] Would write %d credential(s) to %s", len(secrets.Collect(private)), secretsPath))
+		ui.Info(i18n.T("[DRY RUN] Would copy %s to %s", oc.WorkspaceDir, picoWorkspace))
+		return result
+	}
+
+	ui.Step(1, "Writing module")
+	if err := os.MkdirAll(dir, 0755); err == nil {
+		err = os.WriteFile(modulePath, []byte(nix.Module(public, binary)), 0644)
+	}
+	if err != nil {
+		ui.Error(i18n.T("Could not write the module: %v", err))
+		return result
+	}
+	ui.FileStatus(filepath.Base(modulePath), true, fileDetail(modulePath))
+
+	ui.Step(2, "Storing credentials outside the Nix store")
+	if err := secrets.WritePatch(secretsPath, private); err != nil {
+		ui.Error(i18n.T("Could not write %s: %v", secretsPath, err))
+		return result
+	}
+	ui.Success(i18n.T("%d credential(s) written to %s (0600)", len(secrets.Collect(private)), secretsPath))
+
+	ui.Step(3, "Copying workspace")
+	result = copyWorkspaceTo(oc, picoHome, picoWorkspace, opts)
+
+	ui.Box("Module ready", []string{
+		i18n.T("Add it to your home-manager configuration:"),
+		"  imports = [ " + modulePath + " ];",
+		i18n.T("then run: home-manager switch"),
+		"",
+		i18n.T("~/.picoclaw/config.json is generated on each service start — change the module, not the file."),
+	})
+	return result
+}
+
+// fillReport adds anonymous counts from the migration to a stats report
+func fillReport(report *stats.Report, oc detect.Installation, result migrate.Result) {
+	report.Outcome = migrationOutcome(result)
+	report.Files = result.TotalFiles
+	report.Migrated = result.Migrated
+	report.Skipped = result.Skipped
+	report.Errors = result.Errors
+	report.ErrorCauses = make(map[string]int)
+	for cause, files := range result.ErrorsByCause() {
+		report.ErrorCauses[cause] = len(files)
+	}
+	report.Providers = len(detect.GetProviderKeys(oc.Config))
+	report.Channels = len(detect.GetConfiguredChannels(oc.Config))
+	report.MCPServers = len(detect.GetMCPServers(oc.Config))
+}
+
+// shareStats submits the report if the user opted in, printing exactly what is sent
+func shareStats(report stats.Report) {
+	s := settings.Load()
+	if s.ShareStats == nil || !*s.ShareStats {
+		return
+	}
+
+	fmt.Println()
+	ui.Info("Sharing anonymous migration stats (opted in with --share-stats):")
+	for _, line := range strings.Split(report.JSON(), "\n") {
+		fmt.Println("    " + ui.Dim + line + ui.Reset)
+	}
+
+	url := stats.URL(s.StatsURL)
+	if url == "" {
+		ui.Info(i18n.T("No stats endpoint configured (set stats_url in %s) — nothing sent", settings.Path()))
+		return
+	}
+	if err := stats.Send(url, report); err != nil {
+		ui.Warn(i18n.T("Could not send stats: %v", err))
+		return
+	}
+	ui.Success("Stats sent — thank you!")
+}
+
+// longPhase is how long a phase has to run before its end is worth a
+// desktop notification: long enough that the user may have tabbed away
+const longPhase = time.Minute
+
+// phaseDone titles the desktop notification for each phase that can run long
+var phaseDone = map[string]string{
+	"backup":  "Backup complete",
+	"install": "PicoClaw installed",
+	"migrate": "Migration finished",
+}
+
+// notifyPhaseDone tells a user who tabbed away during a long phase that
+// claw-migrate may be waiting on them again
+func notifyPhaseDone(phase string, took time.Duration, opts options) {
+	title, ok := phaseDone[phase]
+	if !ok || took < longPhase || opts.noDesktop || opts.dryRun {
+		return
+	}
+	notify.Desktop(i18n.T(title), i18n.T("Took %s — claw-migrate may need you for the next step", ui.FormatDuration(took))) // best effort
+}
+
+// migrationNotice is what migrate posts to --notify-url when it ends
+type migrationNotice struct {
+	Text    string        `json:"text"`
+	Event   string        `json:"event"`
+	Host    string        `json:"host"`
+	Outcome string        `json:"outcome"`
+	Error   string        `json:"error,omitempty"`
+	Backup  string        `json:"backup,omitempty"`
+	Summary *stats.Report `json:"summary,omitempty"`
+}
+
+// notifyResult posts how the migration went, for whoever watches a fleet
+// of unattended runs
+func notifyResult(url string, report stats.Report, backupResult backup.Result) {
+	if url == "" {
+		return
+	}
+	host, _ := os.Hostname()
+	text := i18n.T("claw-migrate on %s: %s — %d of %d files migrated, %d skipped, %d errors", host, report.Outcome, report.Migrated, report.Files, report.Skipped, report.Errors)
+	postNotice(url, migrationNotice{Text: text, Host: host, Outcome: report.Outcome, Backup: backupResult.Path, Summary: &report})
+}
+
+// notifyFailure posts that the migration stopped before finishing
+func notifyFailure(url, reason string) {
+	if url == "" {
+		return
+	}
+	host, _ := os.Hostname()
+	text := i18n.T("claw-migrate on %s failed: %s", host, reason)
+	postNotice(url, migrationNotice{Text: text, Host: host, Outcome: "failed", Error: reason})
+}
+
+func postNotice(url string, n migrationNotice) {
+	n.Event = "migration"
+	if err := notify.Post(url, n); err != nil {
+		ui.Warn(i18n.T("Could not post the result: %v", err))
+		return
+	}
+	ui.Info(i18n.T("Result posted to %s", url))
+}
+
+// ════════════════════════════════════════════════════════════
+// Phase 1: Detect
+// ════════════════════════════════════════════════════════════
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func phase1Detect() {
+	ui.Phase(1, "Detecting installations")
+}
+
+// detectOpenClaw runs OpenClaw detection behind a spinner. Its scan of a
+// large workspace can take a while, so Ctrl-C stops it and exits cleanly.
+func detectOpenClaw() detect.Installation {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var oc detect.Installation
+	err := ui.SpinnerRun("Scanning OpenClaw files...", func() error {
+		var err error
+		oc, err = detect.DetectOpenClawContext(ctx)
+		return err
+	})
+	if err != nil {
+		ui.Warn("Scan cancelled — no changes made")
+		os.Exit(130)
+	}
+	return oc
+}
+
+// fileDetail describes a file by its line count, or by its kind and size
+// when it is an image, audio or other binary file, or too large to count
+func fileDetail(path string) string {
+	if kind := detect.Classify(path); detect.IsBinaryKind(kind) {
+		return i18n.T("%s, %s", i18n.T(kind), detect.FormatSize(detect.DirSize(path)))
+	}
+	lc := detect.CountFileLines(path)
+	switch {
+	case lc.Binary:
+		return i18n.T("binary, %s", detect.FormatSize(lc.Size))
+	case lc.Capped:
+		return i18n.T("%s, too large to count lines", detect.FormatSize(lc.Size))
+	default:
+		return i18n.T("%d lines", lc.Lines)
+	}
+}
+
+func showDetectionResults(oc, pc detect.Installation, sys detect.SystemInfo) {
+	ui.Step(1, "System information")
+	ui.Found("Platform", fmt.Sprintf("%s/%s", sys.OS, sys.Arch))
+
+	ui.Step(2, "OpenClaw installation")
+	ui.Found("Directory", oc.HomeDir)
+	if oc.IsCustomWorkspace() {
+		ui.Found("Workspace (from config)", oc.WorkspaceDir)
+	}
+	if oc.BinaryPath != "" {
+		ui.Found("Binary", oc.BinaryPath)
+	}
+	if oc.Version != "" {
+		ui.Found("Version", oc.Version)
+	}
+	for _, h := range nativehost.Find("openclaw") {
+		ui.Found("Browser host", fmt.Sprintf("%s (%s)", h.Name, h.Browser))
+	}
+
+	// Config summary
+	ui.Step(3, "Configuration")
+	if oc.Config != nil {
+		ui.Found("Config file", fmt.Sprintf("%s (%s)", oc.ConfigPath, detect.FormatSize(oc.ConfigSummary.ConfigFileSize)))
+
+		if oc.ConfigSummary.DefaultModel != "" {
+			// Check if model is outdated
+			if upgrade, found := modelUpgrade(oc.ConfigSummary.DefaultModel); found {
+				ui.Warn(i18n.T("Default model          %s (outdated → %s available)", oc.ConfigSummary.DefaultModel, upgrade))
+			} else {
+				ui.Found("Default model", oc.ConfigSummary.DefaultModel)
+			}
+		}
+		if oc.ConfigSummary.MaxTokens > 0 {
+			ui.Found("Max tokens", fmt.Sprintf("%d", oc.ConfigSummary.MaxTokens))
+		}
+
+		providers := detect.GetProviderKeys(oc.Config)
+		if len(providers) > 0 {
+			ui.Found("Providers", strings.Join(providers, ", "))
+		}
+
+		channels := detect.GetConfiguredChannels(oc.Config)
+		if len(channels) > 0 {
+			ui.Found("Channels", strings.Join(channels, ", "))
+		}
+
+		mcpServers := detect.GetMCPServers(oc.Config)
+		if len(mcpServers) > 0 {
+			ui.Found("MCP Servers", strings.Join(mcpServers, ", "))
+		}
+
+		if oc.ConfigSummary.HeartbeatEnabled {
+			ui.Found("Heartbeat", i18n.T("enabled (every %d min)", oc.ConfigSummary.HeartbeatInterval))
+		}
+	} else {
+		ui.NotFound("Config file")
+	}
+
+	// Workspace — standard agent files
+	ui.Step(4, "Workspace — agent files")
+	standardFileList := []string{"SOUL.md", "IDENTITY.md", "AGENTS.md", "USER.md", "TOOLS.md", "HEARTBEAT.md"}
+	foundCount := 0
+	for _, f := range standardFileList {
+		exists := oc.WorkspaceFiles[f]
+		detail := ""
+		if exists {
+			detail = fileDetail(filepath.Join(oc.WorkspaceDir, f))
+			foundCount++
+		}
+		ui.FileStatus(f, exists, detail)
+	}
+
+	// Extra files
+	if len(oc.ExtraFiles) > 0 {
+		ui.Step(5, i18n.T("Workspace — custom files (%d)", len(oc.ExtraFiles)))
+		for _, f := range oc.ExtraFiles {
+			ui.FileStatus(f, true, fileDetail(filepath.Join(oc.WorkspaceDir, f)))
+		}
+	}
+
+	// Standard directories
+	ui.Step(6, "Workspace — standard directories")
+	stdDirs := []struct {
+		name string
+		has  bool
+	}{
+		{"memory", oc.HasMemory},
+		{"skills", oc.HasSkills},
+		{"scripts", dirExists(filepath.Join(oc.WorkspaceDir, "scripts"))},
+		{"cron", oc.HasCron},
+		{"sessions", oc.HasSessions},
+	}
+	for _, d := range stdDirs {
+		if d.has {
+			dirPath := filepath.Join(oc.WorkspaceDir, d.name)
+			count := detect.CountDirFiles(dirPath)
+			size := detect.DirSize(dirPath)
+			ui.Found(d.name+"/", i18n.T("%d files (%s)", count, detect.FormatSize(size)))
+		} else {
+			ui.NotFound(d.name + "/")
+		}
+	}
+
+	// Project directories
+	if len(oc.ExtraDirs) > 0 {
+		ui.Step(7, i18n.T("Workspace — project directories (%d)", len(oc.ExtraDirs)))
+		for _, d := range oc.ExtraDirs {
+			dirPath := filepath.Join(oc.WorkspaceDir, d)
+			count := detect.CountDirFiles(dirPath)
+			size := detect.DirSize(dirPath)
+			ui.Found(d+"/", i18n.T("%d files (%s)", count, detect.FormatSize(size)))
+
+			// Largest subdirectories, so a big project shows where its bulk is
+			var nested []detect.ExtraItem
+			for _, item := range oc.Extras {
+				if item.IsDir && item.Depth > 1 && strings.HasPrefix(item.Path, d+"/") {
+					nested = append(nested, item)
+				}
+			}
+			sort.SliceStable(nested, func(i, j int) bool { return nested[i].Size > nested[j].Size })
+			for i, item := range nested {
+				if i == 5 {
+					ui.Summary("", i18n.T("... and %d more subdirectories", len(nested)-5))
+					break
+				}
+				ui.Summary(strings.Repeat("  ", item.Depth-1)+item.Path+"/", i18n.T("%d files (%s)", item.Files, detect.FormatSize(item.Size)))
+			}
+		}
+	}
+
+	// Summary totals
+	totalFiles := foundCount + len(oc.ExtraFiles)
+	totalDirs := len(oc.ExtraDirs)
+	for _, d := range stdDirs {
+		if d.has {
+			totalDirs++
+		}
+	}
+	totalSize := detect.DirSize(oc.WorkspaceDir)
+	fmt.Println()
+	ui.Info(i18n.T("Total: %d files, %d directories (%s)",
+		totalFiles, totalDirs, detect.FormatSize(totalSize)))
+
+	nextStep := 7
+	if len(oc.ExtraDirs) > 0 {
+		nextStep = 8
+	}
+
+	// What the workspace is made of, by file kind
+	if kinds := detect.Composition(oc.WorkspaceDir); len(kinds) > 0 {
+		ui.Step(nextStep, "Workspace — composition")
+		for _, k := range kinds {
+			pct := 0
+			if totalSize > 0 {
+				pct = int(k.Size * 100 / totalSize)
+			}
+			ui.Found(i18n.T(detect.KindLabel(k.Kind)), i18n.T("%d files (%s, %d%%)", k.Files, detect.FormatSize(k.Size), pct))
+		}
+		nextStep++
+	}
+
+	// Dotfiles and anything that looks like it holds credentials, at any depth
+	var hidden []detect.ExtraItem
+	for _, item := range oc.Extras {
+		if item.Hidden || len(item.Secrets) > 0 {
+			hidden = append(hidden, item)
+		}
+	}
+	if len(hidden) > 0 {
+		ui.Step(nextStep, i18n.T("Workspace — hidden and sensitive items (%d)", len(hidden)))
+		for _, item := range hidden {
+			label := item.Path
+			if item.IsDir {
+				label += "/"
+			}
+			if len(item.Secrets) > 0 {
+				ui.Warn(i18n.T("%s holds secrets (%s) — it is copied as-is", label, strings.Join(item.Secrets, ", ")))
+				continue
+			}
+			ui.Found(label, i18n.T("%d files (%s)", item.Files, detect.FormatSize(item.Size)))
+		}
+		nextStep++
+	}
+
+	// Everything else in ~/.openclaw
+	if len(oc.HomeItems) > 0 {
+		ui.Step(nextStep, i18n.T("OpenClaw home — other data (%d)", len(oc.HomeItems)))
+		for _, item := range oc.HomeItems {
+			rule := migrate.RuleFor(item.Name)
+			label := item.Name
+			if item.IsDir {
+				label += "/"
+			}
+			ui.Found(label, fmt.Sprintf("%s — %s: %s", detect.FormatSize(item.Size), rule.Action, rule.Description))
+		}
+		nextStep++
+	}
+
+	// PicoClaw status
+	ui.Step(nextStep, "PicoClaw installation")
+	if pc.Found {
+		ui.Found("Directory", pc.HomeDir)
+		if pc.BinaryPath != "" {
+			ui.Found("Binary", pc.BinaryPath)
+		}
+		if pc.Version != "" {
+			ui.Found("Version", pc.Version)
+		}
+	} else {
+		ui.NotFound("PicoClaw")
+		ui.Info("PicoClaw will be installed in the next phase")
+	}
+
+	ui.Step(nextStep+1, "Compatibility with PicoClaw")
+	showCompatibility(compat.Assess(oc))
+}
+
+// showPlan sums up what the migration is about to do, so the go-ahead is
+// given for the plan rather than for the detection results above it
+func showPlan(oc, pc detect.Installation, opts options) {
+	var lines []string
+	add := func(label, value string) {
+		lines = append(lines, i18n.T(label)+": "+value)
+	}
+
+	// Backup
+	format := opts.backupFormat
+	if format == "" {
+		format = backup.FormatTarGz
+	}
+	size := detect.DirSize(oc.HomeDir)
+	if oc.IsCustomWorkspace() && !strings.HasPrefix(oc.WorkspaceDir, oc.HomeDir+string(filepath.Separator)) {
+		size += detect.DirSize(oc.WorkspaceDir)
+	}
+	if opts.stdout != nil {
+		add("Backup", i18n.T("streamed to stdout, %s before compression", detect.FormatSize(size)))
+	} else {
+		add("Backup", i18n.T("~/openclaw-backup-<date>.%s, %s before compression", format, detect.FormatSize(size)))
+	}
+
+	// Where PicoClaw goes
+	switch {
+	case opts.toDocker != "":
+		add("Target", i18n.T("Dockerfile and volume in %s", opts.toDocker))
+	case opts.toK8s != "":
+		add("Target", i18n.T("Kubernetes manifests in %s", opts.toK8s))
+	case opts.toNix != "":
+		add("Target", i18n.T("home-manager module in %s", opts.toNix))
+	case opts.output != "":
+		goos, goarch := install.Platform()
+		add("Target", i18n.T("%s/%s home directory in %s", goos, goarch, opts.output))
+	case opts.skipInstall:
+		add("Install", i18n.T("skipped (--skip-install)"))
+	default:
+		var latest string
+		ui.SpinnerRun("Fetching latest version...", func() error {
+			latest = install.FetchLatestVersion()
+			return nil
+		})
+		if pc.BinaryPath != "" {
+			add("Install", i18n.T("keep %s %s, or replace it with v%s", pc.BinaryPath, pc.Version, latest))
+		} else {
+			add("Install", i18n.T("pre-built v%s download, or build from source", latest))
+		}
+	}
+
+	// Workspace
+	files := detect.CountDirFiles(oc.WorkspaceDir)
+	copied := i18n.T("%d files (%s) copied to ~/.picoclaw/workspace", files, detect.FormatSize(detect.DirSize(oc.WorkspaceDir)))
+	if opts.move {
+		copied = i18n.T("%d files (%s) moved to ~/.picoclaw/workspace (--move)", files, detect.FormatSize(detect.DirSize(oc.WorkspaceDir)))
+	}
+	add("Files", copied)
+
+	// Config
+	if oc.Config != nil {
+		var parts []string
+		if n := len(detect.GetProviderKeys(oc.Config)); n > 0 {
+			parts = append(parts, i18n.T("%d provider(s) → model_list", n))
+		}
+		if n := len(detect.GetConfiguredChannels(oc.Config)); n > 0 {
+			parts = append(parts, i18n.T("%d channel(s)", n))
+		}
+		if n := len(detect.GetMCPServers(oc.Config)); n > 0 {
+			parts = append(parts, i18n.T("%d MCP server(s)", n))
+		}
+		if oc.ConfigSummary.HeartbeatEnabled {
+			parts = append(parts, i18n.T("heartbeat"))
+		}
+		if upgrade, found := modelUpgrade(oc.ConfigSummary.DefaultModel); found {
+			parts = append(parts, i18n.T("offer %s → %s", oc.ConfigSummary.DefaultModel, upgrade))
+		}
+		converted := i18n.T("openclaw.json → config.json")
+		if len(parts) > 0 {
+			converted += " (" + strings.Join(parts, ", ") + ")"
+		}
+		if _, err := os.Stat(filepath.Join(picoClawHome(), "config.json")); err == nil {
+			converted += i18n.T(" (merged into the existing one)")
+		}
+		add("Config", converted)
+	}
+
+	// Uninstall
+	if opts.toDocker == "" && opts.toK8s == "" && opts.toNix == "" && opts.output == "" {
+		switch {
+		case opts.skipUninstall:
+			add("Uninstall", i18n.T("no (--skip-uninstall)"))
+		case opts.purgeAfter > 0:
+			add("Uninstall", i18n.T("OpenClaw, asking first; ~/.openclaw is deleted after %d days", opts.purgeAfter))
+		case opts.openclawData == dataKeep:
+			add("Uninstall", i18n.T("OpenClaw, asking first; ~/.openclaw is kept"))
+		case opts.openclawData == dataPurge:
+			add("Uninstall", i18n.T("OpenClaw, asking first; ~/.openclaw is deleted if the backup verifies"))
+		default:
+			add("Uninstall", i18n.T("OpenClaw, asking first, including about ~/.openclaw"))
+		}
+	}
+
+	ui.Box("Migration plan", lines)
+}
+
+// showCompatibility prints how much of each area carries over and the
+// overall verdict
+func showCompatibility(r compat.Report) {
+	for _, a := range r.Areas {
+		ui.Found(i18n.T(a.Name), i18n.T("%d of %d carry over (%d%%)", a.OK, a.Total, a.Percent()))
+		for _, note := range a.Notes {
+			ui.Summary("", note)
+		}
+	}
+	fmt.Println()
+	if r.Verdict() == compat.VerdictSafe {
+		ui.Success(i18n.T("Compatibility %d%% — %s", r.Score, i18n.T(r.Verdict())))
+		return
+	}
+	ui.Warn(i18n.T("Compatibility %d%% — %s", r.Score, i18n.T(r.Verdict())))
+	if len(r.Blockers) > 0 {
+		ui.Info(i18n.T("Won't work after migrating: %s", strings.Join(r.Blockers, "; ")))
+	}
+}
+
+// ════════════════════════════════════════════════════════════
+// Phase 2: Backup
+// ════════════════════════════════════════════════════════════
+
+func phase2Backup(oc detect.Installation, opts options) backup.Result {
+	ui.Phase(2, "Backup OpenClaw")
+	return doBackup(oc, opts, "Migration cancelled.")
+}
+
+// doBackup backs up ~/.openclaw, giving up with cancelled when it fails and
+// the user won't go on without one
+func doBackup(oc detect.Installation, opts options, cancelled string) backup.Result {
+	ui.Step(1, "Creating full backup of ~/.openclaw/")
+
+	if opts.dryRun {
+		format := opts.backupFormat
+		if format == "" {
+			format = backup.FormatTarGz
+		}
+		if opts.stdout != nil {
+			ui.Info(i18n.T("[DRY RUN] Would stream a %s backup to stdout", format))
+		} else {
+			ui.Info(i18n.T("[DRY RUN] Would create backup: ~/openclaw-backup-YYYYMMDD-HHMMSS.%s", format))
+		}
+		return backup.Result{}
+	}
+
+	run := startRun(runs.KindBackup)
+	backupOpts := backup.Options{Limiter: opts.ioLimit, Format: opts.backupFormat}
+	if opts.stdout != nil {
+		backupOpts.Writer = opts.stdout
+	}
+	if oc.IsCustomWorkspace() && !strings.HasPrefix(oc.WorkspaceDir, oc.HomeDir+string(filepath.Separator)) {
+		backupOpts.ExtraDirs = []string{oc.WorkspaceDir}
+	}
+
+	// Sizes from detection give the ETA something to work against
+	total := detect.DirSize(oc.HomeDir)
+	for _, dir := range backupOpts.ExtraDirs {
+		total += detect.DirSize(dir)
+	}
+	meter := ui.NewMeter("Creating backup", total)
+	backupOpts.Progress = meter.Add
+
+	var result backup.Result
+	err := meter.Run(func() error {
+		result = backup.CreateBackup(oc.HomeDir, backupOpts)
+		if !result.Success {
+			return result.Error
+		}
+		return nil
+	})
+
+	if err != nil {
+		ui.Error(i18n.T("Backup failed: %v", err))
+		endRun(run, runs.OutcomeFailed, err.Error())
+		if !ui.ConfirmDangerous("Continue WITHOUT backup? (not recommended)") {
+			ui.Fatal(cancelled)
+		}
+		return result
+	}
+
+	if opts.stdout != nil {
+		ui.Success(i18n.T("Backup streamed to stdout (%s)", backup.FormatSize(result.Size)))
+	} else {
+		ui.Success(i18n.T("Backup created: %s (%s)", result.Path, backup.FormatSize(result.Size)))
+	}
+	for _, dir := range result.Skipped {
+		ui.Warn(i18n.T("Not included in backup (outside your home directory): %s", dir))
+	}
+	if opts.stdout != nil {
+		// Nothing was kept locally to read back
+		check := "tar -tzf backup.tar.gz > /dev/null"
+		if opts.backupFormat == backup.FormatZip {
+			check = "unzip -t backup.zip"
+		}
+		ui.Info(i18n.T("Verify the copy where it landed, e.g.: %s", check))
+		endRun(run, runs.OutcomeSuccess, fmt.Sprintf("streamed to stdout, %s", backup.FormatSize(result.Size)))
+		return result
+	}
+
+	// Verify
+	ui.Step(2, "Verifying backup integrity")
+	verifyErr := ui.SpinnerRun("Verifying...", func() error {
+		return backup.VerifyBackup(result.Path)
+	})
+	run.Backup = result.Path
+	if verifyErr != nil {
+		ui.Warn(i18n.T("Backup verification warning: %v", verifyErr))
+		endRun(run, runs.OutcomeErrors, fmt.Sprintf("%s, not verified: %v", backup.FormatSize(result.Size), verifyErr))
+	} else {
+		result.Verified = true
+		ui.Success("Backup verified successfully")
+		endRun(run, runs.OutcomeSuccess, fmt.Sprintf("%s, verified", backup.FormatSize(result.Size)))
+	}
+	return result
+}
+
+// ════════════════════════════════════════════════════════════
+// Phase 3: Install PicoClaw
+// ════════════════════════════════════════════════════════════
+
+// showLatestVersion prints the latest PicoClaw version and where it came from
+func showLatestVersion(version string) {
+	switch install.LatestSource {
+	case install.SourceAPI:
+		ui.Found("Latest version", "v"+version)
+	default:
+		ui.Found("Latest version", i18n.T("v%s (%s)", version, i18n.T(install.LatestSource)))
+	}
+	warnVersionFallback()
+}
+
+// warnVersionFallback says so when the latest version isn't GitHub's current
+// answer, rather than quietly installing something older
+func warnVersionFallback() {
+	if install.LatestError == nil {
+		return
+	}
+	ui.Warn(i18n.T("Could not check the latest PicoClaw release: %v", install.LatestError))
+	if install.LatestSource == install.SourceFallback {
+		ui.Warn(i18n.T("Using the built-in version v%s, which may be out of date", install.LatestVersion))
+	} else {
+		ui.Warn(i18n.T("Using v%s from an earlier check, which may be out of date", install.LatestVersion))
+	}
+}
+
+func phase3Install(oc, pc detect.Installation, sys detect.SystemInfo, dryRun bool) {
+	ui.Phase(3, "Install PicoClaw")
+	installPicoClaw(oc, pc, sys, dryRun)
+}
+
+// installPicoClaw resolves the latest release, installs it and runs onboard.
+// oc seeds unattended onboarding and may be empty for a fresh install.
+func installPicoClaw(oc, pc detect.Installation, sys detect.SystemInfo, dryRun bool) {
+	// Fetch latest version
+	ui.Step(1, "Checking latest PicoClaw release")
+	var fetchedVersion string
+	ui.SpinnerRun("Fetching latest version...", func() error {
+		fetchedVersion = install.FetchLatestVersion()
+		return nil
+	})
+	showLatestVersion(fetchedVersion)
+
+	// Already installed?
+	if pc.BinaryPath != "" {
+		ui.Success(i18n.T("PicoClaw already installed: %s", pc.BinaryPath))
+		if pc.Version != "" {
+			ui.Info(i18n.T("Version: %s", pc.Version))
+		}
+		if ui.Confirm("Skip installation and use existing PicoClaw?") {
+			ui.Step(2, "Initializing PicoClaw workspace")
+			runOnboard(oc, pc.HomeDir, dryRun)
+			return
+		}
+	}
+
+	method := ui.Choose("How would you like to install PicoClaw?", []string{
+		i18n.T("Download pre-built binary (%s, recommended)", install.VersionTag()),
+		"Build from source (latest features, requires Go 1.21+)",
+	}, 0)
+
+	if dryRun {
+		if method == 0 {
+			// Nothing is installed, but the archive is kept for the real run
+			ui.Info("[DRY RUN] Downloading the release into the cache, so the real run can skip it")
+			if _, err := fetchRelease(); err != nil {
+				ui.Warn(err.Error())
+			}
+		} else {
+			ui.Info("[DRY RUN] Would clone and build from source")
+		}
+		runOnboard(oc, pc.HomeDir, dryRun)
+		return
+	}
+
+	if method == 0 {
+		installFromRelease(sys)
+	} else {
+		installFromSource()
+	}
+
+	// Initialize
+	ui.Step(3, "Initializing PicoClaw")
+	runOnboard(oc, pc.HomeDir, dryRun)
+}
+
+// runOnboard runs `picoclaw onboard` only if it hasn't run yet. With --yes
+// it runs unattended, seeded with the converted OpenClaw agent defaults.
+func runOnboard(oc detect.Installation, picoHome string, dryRun bool) {
+	if !install.NeedsOnboard(picoHome) {
+		ui.Success("PicoClaw already initialized — skipping onboard")
+		return
+	}
+	if dryRun {
+		ui.Info("[DRY RUN] Would run: picoclaw onboard")
+		return
+	}
+
+	if !ui.AssumeYes() {
+		ui.Info("Running: picoclaw onboard")
+		if err := install.RunOnboard(); err != nil {
+			ui.Warn(i18n.T("Onboard had issues: %v", err))
+			ui.Info("You may need to run 'picoclaw onboard' manually after migration")
+		} else {
+			ui.Success("PicoClaw initialized")
+		}
+		return
+	}
+
+	var defaults map[string]interface{}
+	if agents, ok := config.ConvertConfig(oc.Config)["agents"].(map[string]interface{}); ok {
+		defaults, _ = agents["defaults"].(map[string]interface{})
+	}
+
+	var out string
+	err := ui.SpinnerRun("Running picoclaw onboard (non-interactive)...", func() error {
+		var runErr error
+		out, runErr = install.RunOnboardNonInteractive(install.OnboardEnv(defaults))
+		return runErr
+	})
+	switch {
+	case errors.Is(err, install.ErrNoUnattendedOnboard):
+		ui.Warn("This PicoClaw's onboard has no non-interactive mode — skipped under --yes")
+		ui.Info("Run 'picoclaw onboard' after the migration to finish setting it up")
+	case err != nil:
+		ui.Warn(i18n.T("Onboard had issues: %v", err))
+		if out = strings.TrimSpace(out); out != "" {
+			ui.Info(out)
+		}
+		ui.Info("You may need to run 'picoclaw onboard' manually after migration")
+	default:
+		ui.Success("PicoClaw initialized (non-interactive)")
+	}
+}
+
+func installFromRelease(sys detect.SystemInfo) {
+	ui.Step(1, "Downloading PicoClaw binary")
+	binaryPath, archivePath := downloadRelease()
+
+	ui.Step(2, "Installing binary")
+	ui.Info("Installing to /usr/local/bin/picoclaw (may require sudo)")
+	if err := install.InstallBinary(binaryPath); err != nil {
+		ui.Fatal(i18n.T("Install failed: %v", err))
+	}
+	ui.Success("PicoClaw installed")
+	rememberBinary("/usr/local/bin/picoclaw")
+
+	install.CleanupArchive(archivePath)
+}
+
+// rememberBinary records where picoclaw was installed, so uninstall finds
+// it even when it isn't the one on PATH
+func rememberBinary(path string) {
+	s := settings.Load()
+	s.PicoClawBinary = path
+	if err := s.Save(); err != nil {
+		ui.Warn(i18n.T("Could not save settings: %v", err))
+	}
+}
+
+// downloadRelease downloads, verifies and unpacks the latest release,
+// returning the extracted binary and the archive it came from
+func downloadRelease() (string, string) {
+	archivePath, err := fetchRelease()
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+
+	// A directory of our own, so nothing already in the shared temp dir is written through
+	extractDir, err := os.MkdirTemp(os.TempDir(), "picoclaw-release-")
+	if err != nil {
+		ui.Fatal(err.Error())
+	}
+	binaryPath, err := install.Extract(archivePath, extractDir)
+	if err != nil {
+		ui.Fatal(i18n.T("Extraction failed: %v", err))
+	}
+	return binaryPath, archivePath
+}
+
+// prefetch is the release download started alongside the backup, if any
+var prefetch *releasePrefetch
+
+// releasePrefetch downloads the latest release into the download cache in
+// the background, where phase 3 finds it
+type releasePrefetch struct {
+	meter *ui.Meter
+	done  chan struct{}
+	err   error
+}
+
+// startPrefetch starts downloading the latest release while the backup
+// runs, when phase 3 will likely install it: PicoClaw isn't installed, or
+// is older. The two share no files, so the install no longer waits on the
+// download. The download is throttled by --net-limit and the backup by
+// --io-limit.
+func startPrefetch(pc detect.Installation, opts options) {
+	if opts.noPrefetch || opts.dryRun || opts.skipInstall || opts.toDocker != "" || opts.toK8s != "" || opts.toNix != "" || opts.output != "" {
+		return
+	}
+	latest := install.FetchLatestVersion()
+	if pc.BinaryPath != "" && install.CompareVersions(install.ParseVersion(pc.Version), latest) >= 0 {
+		return
+	}
+	filename, err := install.ReleaseFilename(install.Platform())
+	if err != nil {
+		return // phase 3 says why
+	}
+	url := install.ReleaseURL(latest, filename)
+
+	p := &releasePrefetch{meter: ui.NewMeter("Downloading PicoClaw", 0), done: make(chan struct{})}
+	remove := ui.Alongside(p.meter)
+	go func() {
+		defer close(p.done)
+		defer remove()
+		_, p.err = install.Download(url, install.ArchivePath(latest, filename), p.meter.Set)
+	}()
+	prefetch = p
+}
+
+// reportPrefetch says how the download alongside the backup is going
+func reportPrefetch() {
+	if prefetch == nil {
+		return
+	}
+	select {
+	case <-prefetch.done:
+		if prefetch.err == nil {
+			ui.Success(i18n.T("PicoClaw v%s downloaded during the backup", install.FetchLatestVersion()))
+		}
+	default:
+		ui.Info("PicoClaw is still downloading — the install picks it up when it's done")
+	}
+}
+
+// awaitPrefetch waits for the download started alongside the backup, so
+// fetchRelease finds the archive in the cache rather than fetching it again
+func awaitPrefetch() {
+	p := prefetch
+	if p == nil {
+		return
+	}
+	prefetch = nil
+	select {
+	case <-p.done:
+	default:
+		p.meter.Run(func() error {
+			<-p.done
+			return nil
+		})
+	}
+	if p.err != nil {
+		ui.Warn(i18n.T("Download during the backup failed: %v — trying again", p.err))
+	}
+}
+
+// fetchRelease downloads the latest release archive into the download
+// cache, or reuses the one already there, and verifies its checksum
+func fetchRelease() (string, error) {
+	awaitPrefetch()
+	filename, err := install.ReleaseFilename(install.Platform())
+	if err != nil {
+		return "", errors.New(i18n.T("Unsupported platform: %v", err))
+	}
+
+	// One version for the URL, the cache path and the checksums
+	version := install.FetchLatestVersion()
+	url := install.ReleaseURL(version, filename)
+	ui.Info(i18n.T("URL: %s", url))
+	archivePath := install.ArchivePath(version, filename)
+
+	var reused bool
+	meter := ui.NewMeter("Downloading", 0)
+	dlErr := meter.Run(func() error {
+		var err error
+		reused, err = install.Download(url, archivePath, meter.Set)
+		return err
+	})
+	if dlErr != nil {
+		return "", errors.New(i18n.T("Download failed: %v", dlErr))
+	}
+	if reused {
+		ui.Success(i18n.T("Using the archive downloaded earlier: %s", archivePath))
+	} else {
+		ui.Success("Download complete")
+	}
+
+	switch err := install.VerifyChecksum(archivePath, version, filename); {
+	case err == nil:
+		ui.Success("Checksum verified")
+	case errors.Is(err, install.ErrNoChecksums):
+		ui.Warn("Release publishes no checksum for this archive — skipping verification")
+	default:
+		install.DiscardArchive(archivePath)
+		return "", errors.New(i18n.T("Checksum verification failed: %v", err))
+	}
+	return archivePath, nil
+}
+
+func installFromSource() {
+	ui.Step(1, "Building PicoClaw from source")
+	tmpDir := os.TempDir()
+
+	err := ui.SpinnerRun("Cloning and building (this may take a few minutes)...", func() error {
+		return install.BuildFromSource(tmpDir)
+	})
+	if err != nil {
+		ui.Fatal(i18n.T("Build failed: %v", err))
+	}
+	ui.Success("PicoClaw built and installed from source")
+}
+
+// ════════════════════════════════════════════════════════════
+// Phase 4: Migrate data
+// ════════════════════════════════════════════════════════════
+
+// onboarded says PicoClaw was set up before this run, so what its workspace
+// and config hold is the user's rather than fresh from onboarding
+func phase4Migrate(oc, pc detect.Installation, backupResult backup.Result, opts options, onboarded bool) migrate.Result {
+	dryRun := opts.dryRun
+	ui.Phase(4, "Migrate data")
+
+	var copied migrate.Result
+	var scriptIssues []migrate.ScriptIssue
+
+	home, _ := os.UserHomeDir()
+	picoHome := filepath.Join(home, ".picoclaw")
+	picoWorkspace := detect.PicoClawWorkspace(picoHome)
+
+	// With --yes nobody saw the failure, so don't carry on past it
+	if opts.sandbox && !sandboxVerify(oc, pc, dryRun) {
+		if ui.AssumeYes() || !ui.ConfirmDangerous("PicoClaw did not accept the converted config — migrate into ~/.picoclaw anyway?") {
+			ui.Fatal("Stopped before ~/.picoclaw was touched. Your backup and OpenClaw are unchanged.")
+		}
+	}
+
+	// Settle what PicoClaw already has before anything is copied over it
+	picoConfigPath := filepath.Join(picoHome, "config.json")
+	var keep map[string]bool
+	if onboarded {
+		keep = reviewConflicts(oc, picoWorkspace, picoConfigPath, opts.prefer, dryRun)
+	}
+
+	// Step 1: Check built-in migration tool
+	ui.Step(1, "Checking for PicoClaw's built-in migration tool")
+
+	builtInAvailable := pc.Capabilities.HasCommand("migrate")
+	useBuiltIn := false
+	if builtInAvailable && (len(keep) > 0 || (onboarded && migrate.KeepExisting != nil)) {
+		// It overwrites everything, which would undo the review
+		ui.Info("Skipping 'picoclaw migrate' — it would overwrite what you chose to keep")
+	} else if builtInAvailable {
+		ui.Success("Built-in 'picoclaw migrate' command is available")
+		useBuiltIn = ui.Confirm("Use PicoClaw's built-in migration tool? (recommended)")
+	}
+
+	builtInConverted := false
+	if useBuiltIn {
+		if dryRun {
+			ui.Info("[DRY RUN] Would run: picoclaw migrate --force")
+		} else {
+			builtInConverted = runBuiltInMigrate(picoConfigPath)
+		}
+	}
+
+	// Step 2: Migrate workspace — condensed output
+	ui.Step(2, "Migrating workspace (all files and directories)")
+
+	var j *journal.Journal // this run's, for everything later steps record
+
+	if dryRun {
+		fileCount := 0
+		dirCount := 0
+		entries, _ := os.ReadDir(oc.WorkspaceDir)
+		for _, entry := range entries {
+			if migrate.SkipEntries[entry.Name()] {
+				continue
+			}
+			if entry.IsDir() {
+				dirCount++
+				dirPath := filepath.Join(oc.WorkspaceDir, entry.Name())
+				fileCount += detect.CountDirFiles(dirPath)
+			} else {
+				fileCount++
+			}
+		}
+		ui.Info(i18n.T("[DRY RUN] Would migrate %d files across %d directories", fileCount, dirCount))
+		for _, item := range oc.HomeItems {
+			if rule := migrate.RuleFor(item.Name); rule.Action == migrate.ActionCopy {
+				ui.Info(i18n.T("[DRY RUN] Would copy ~/.openclaw/%s → workspace/%s", item.Name, rule.Dest))
+			}
+		}
+		rewriteWorkspacePaths(oc, picoHome, picoWorkspace, dryRun)
+		_, scriptIssues = fixWorkspaceScripts(oc, pc, picoHome, picoWorkspace, dryRun)
+	} else {
+		copyOpts := migrate.Options{Force: true, MaxErrors: opts.maxErrors, Limiter: opts.ioLimit, Sync: opts.fsync, Scrub: opts.scrub, Move: opts.move, Keep: keep}
+		copyOpts.Strategy = copyStrategy(opts.copyStrategy, oc.WorkspaceDir, picoWorkspace)
+
+		// Moving deletes the originals, so only allow it with a verified backup to roll back to
+		if copyOpts.Move && !backupResult.Verified {
+			ui.Warn("Move mode needs a verified backup to roll back from — copying instead")
+			copyOpts.Move = false
+		}
+		// Redacted secrets would survive nowhere if the originals went too
+		if copyOpts.Move && copyOpts.Scrub {
+			ui.Warn("--scrub keeps the unredacted originals — copying instead of moving")
+			copyOpts.Move = false
+		}
+
+		// The journal is on disk before anything is copied, and a moved
+		// file's entry before its source is deleted: a run cut short still
+		// says what it moved. Until the copy finishes it reads as aborted.
+		j = journal.New(oc.WorkspaceDir, picoWorkspace)
+		j.Move = copyOpts.Move
+		j.BackupPath = backupResult.Path
+		j.BackupVerified = backupResult.Verified
+		j.Outcome = journal.OutcomeAborted
+		if err := j.Save(); err != nil {
+			ui.Warn(i18n.T("Could not write migration journal: %v", err))
+			if copyOpts.Move {
+				ui.Warn("Move mode needs the journal to record what it deletes — copying instead")
+				copyOpts.Move, j.Move = false, false
+			}
+		}
+		if copyOpts.Move {
+			copyOpts.BeforeRemove = appendMoved
+		}
+
+		label := "Copying workspace files"
+		if copyOpts.Move {
+			label = "Moving workspace files"
+		}
+		total := detect.DirSize(oc.WorkspaceDir)
+		for _, item := range oc.HomeItems {
+			if migrate.RuleFor(item.Name).Action == migrate.ActionCopy {
+				total += item.Size
+			}
+		}
+		meter := ui.NewMeter(label, total)
+		copyOpts.Progress = meter.Add
+
+		// Known non-workspace data (state, media, ...) goes into the workspace
+		// too. An aborted step (disk full, too many errors) stops the ones after it.
+		var result, homeResult, secretResult migrate.Result
+		meter.Run(func() error {
+			result = migrate.MigrateWorkspace(oc.WorkspaceDir, picoWorkspace, copyOpts)
+			if !result.Aborted {
+				homeResult = migrate.MigrateHomeItems(oc.HomeDir, picoWorkspace, homeItemNames(oc), copyOpts)
+			}
+			return nil
+		})
+		copyOpts.Progress = nil
+		if homeResult.TotalFiles > 0 {
+			ui.Success(i18n.T("Copied %d file(s) of other OpenClaw data (%d errors)", homeResult.Migrated, homeResult.Errors))
+		}
+		mergeResult(&result, homeResult)
+
+		// Credentials go to the PicoClaw home, readable only by the owner
+		if !result.Aborted {
+			reportSecretCollisions(oc)
+			secretResult = migrate.MigrateSecrets(oc.HomeDir, picoHome, homeItemNames(oc), copyOpts)
+		}
+		if secretResult.TotalFiles > 0 {
+			ui.Success(i18n.T("Migrated %d credential file(s) with 0600 permissions", secretResult.Migrated))
+		}
+		if exposed := migrate.ExposedSecrets(oc.HomeDir, homeItemNames(oc)); len(exposed) > 0 {
+			ui.Warn(i18n.T("These secret files were readable by other users in ~/.openclaw: %s", previewList(exposed, 5)))
+			ui.Info("Their PicoClaw copies are 0600 — consider rotating the keys if this machine is shared")
+		}
+		mergeResult(&result, secretResult)
+
+		j.Record(journalEntries(result))
+		j.Outcome = migrationOutcome(result)
+
+		if result.Aborted {
+			ui.Error(i18n.T("Workspace copy aborted after %d errors: %v", result.Errors, result.AbortReason))
+		} else {
+			ui.Success(i18n.T("Migrated %d files (%d skipped, %d errors)",
+				result.Migrated, result.Skipped, result.Errors))
+		}
+		if result.Cloned > 0 {
+			ui.Info(i18n.T("%d file(s) cloned copy-on-write (no extra disk space used)", result.Cloned))
+		}
+		reportRedacted(result)
+		if copyOpts.Move {
+			ui.Info(i18n.T("Sources were removed as they were copied. To roll back, restore %s", filepath.Base(backupResult.Path)))
+		}
+
+		// Only show individual files if there were errors
+		if result.Errors > 0 {
+			showErrorSummary(result)
+			if ui.Confirm(i18n.T("Retry the %d failed file(s)?", result.Errors)) {
+				retry := migrate.RetryFailed(result, copyOpts)
+				j.Record(journalEntries(retry))
+				if len(j.Failed()) == 0 && !result.Aborted {
+					j.Outcome = journal.OutcomeSuccess
+				}
+				ui.Info(i18n.T("Retry: %d migrated, %d still failing", retry.Migrated, retry.Errors))
+				if retry.Errors > 0 {
+					showErrorSummary(retry)
+				}
+			}
+			if len(j.Failed()) > 0 {
+				ui.Info("You can retry the failed files later with: claw-migrate retry")
+			}
+		}
+
+		refreshJournal(j, rewriteWorkspacePaths(oc, picoHome, picoWorkspace, dryRun))
+		var fixed []string
+		fixed, scriptIssues = fixWorkspaceScripts(oc, pc, picoHome, picoWorkspace, dryRun)
+		refreshJournal(j, fixed)
+
+		if err := j.Save(); err != nil {
+			ui.Warn(i18n.T("Could not write migration journal: %v", err))
+		}
+		copied = result
+	}
+	offerStarterFiles(oc, picoWorkspace, dryRun)
+
+	// Step 3: Migrate config
+	ui.Step(3, "Converting configuration")
+
+	if dryRun {
+		ui.Info("[DRY RUN] Would convert: openclaw.json → config.json")
+	} else if builtInConverted {
+		// picoclaw migrate already converted the config; don't convert twice
+		fr, added := migrate.SupplementConfig(oc.ConfigPath, picoConfigPath)
+		switch {
+		case fr.Error != nil:
+			ui.Error(i18n.T("Config supplement failed: %v", fr.Error))
+		case len(added) > 0:
+			ui.Success(i18n.T("Config converted by picoclaw migrate; added %s", strings.Join(added, ", ")))
+		default:
+			ui.Success("Config converted by picoclaw migrate — nothing to add")
+		}
+	} else {
+		fr := migrate.MigrateConfig(oc.ConfigPath, picoConfigPath, true)
+		if fr.Error != nil {
+			ui.Error(i18n.T("Config migration failed: %v", fr.Error))
+		} else {
+			ui.Success("Configuration converted and written")
+			if fr.BackedUp {
+				ui.Info("Previous config backed up to config.json.bak")
+			}
+		}
+	}
+
+	if oc.Config != nil {
+		assistUnmapped(oc.Config, pc, picoConfigPath, opts.assist, dryRun)
+	}
+	migrateEnvFiles(oc, picoHome, picoConfigPath, opts.prefer, dryRun)
+	if !dryRun {
+		checkLocalModels(picoConfigPath)
+		writeSlackManifest(picoConfigPath, opts.webhookURL)
+		migrateNativeHosts(pc, picoHome)
+	}
+
+	// Step 4: Model version check
+	ui.Step(4, "Checking model version")
+	checkModelVersion(oc, picoHome, j, dryRun)
+	if dryRun {
+		auditWorkspaceModels(oc.WorkspaceDir, dryRun)
+	} else {
+		auditWorkspaceModels(picoWorkspace, dryRun)
+	}
+
+	// Step 5: Version control
+	ui.Step(5, "Version control for the workspace")
+	offerWorkspaceRepo(oc, picoWorkspace, dryRun)
+
+	// Step 6: Manual items
+	ui.Step(6, "Items requiring manual attention")
+
+	manualItems := manualAttentionItems(oc)
+	for _, issue := range scriptIssues {
+		manualItems = append(manualItems, todo.Item{
+			ID:   fmt.Sprintf("script:%s:%d", issue.File, issue.Line),
+			Text: i18n.T("%s:%d runs openclaw with no PicoClaw equivalent: %s", issue.File, issue.Line, issue.Text),
+		})
+	}
+	if len(manualItems) > 0 {
+		ui.Warn("The following items need manual attention:")
+		for _, item := range manualItems {
+			fmt.Printf("    "+ui.Yellow+"•"+ui.Reset+" %s\n", item.Text)
+		}
+	} else {
+		ui.Success("No manual items — everything migrated automatically!")
+	}
+
+	// Keep the list beyond the scrollback, preserving anything already ticked off
+	if !dryRun && len(manualItems) > 0 {
+		path := todo.Path(picoHome)
+		existing, _ := todo.Load(path)
+		if err := todo.Save(path, todo.Merge(existing, manualItems)); err != nil {
+			ui.Warn(i18n.T("Could not write %s: %v", todo.FileName, err))
+		} else {
+			ui.Info(i18n.T("Saved to %s — track progress with: claw-migrate todo", path))
+		}
+	}
+
+	return copied
+}
+
+// migrateEnvFiles merges the variables set in ~/.openclaw/.env and the
+// workspace's .env into PicoClaw's, warning where the files disagree with
+// each other or with the credentials in the config. Which side wins a
+// variable PicoClaw's .env already sets follows --prefer.
+func migrateEnvFiles(oc detect.Installation, picoHome, picoConfigPath, prefer string, dryRun bool) {
+	rules := migrate.PathRules(oc.HomeDir, oc.WorkspaceDir, picoHome, detect.PicoClawWorkspace(picoHome))
+	var incoming []envfile.Var
+	var sources, skipped []string
+	index := make(map[string]int)
+	for _, path := range []string{filepath.Join(oc.HomeDir, envfile.Name), filepath.Join(oc.WorkspaceDir, envfile.Name)} {
+		vars, err := envfile.Read(path)
+		if err != nil {
+			continue
+		}
+		sources = append(sources, path)
+		for _, v := range vars {
+			if strings.HasPrefix(v.Key, "OPENCLAW_") {
+				skipped = append(skipped, v.Key)
+				continue
+			}
+			v.Value = migrate.ReplacePaths(v.Value, rules)
+			if i, ok := index[v.Key]; ok {
+				if incoming[i].Value != v.Value {
+					ui.Warn(i18n.T("%s is set differently in %s and %s — keeping the first", v.Key, sources[0], path))
+				}
+				continue
+			}
+			index[v.Key] = len(incoming)
+			incoming = append(incoming, v)
+		}
+	}
+	if len(sources) == 0 {
+		return
+	}
+	ui.Info(i18n.T("%d variable(s) in %s", len(incoming), strings.Join(sources, ", ")))
+	if len(skipped) > 0 {
+		ui.Info(i18n.T("Not carried over, as they only configure OpenClaw: %s", strings.Join(skipped, ", ")))
+	}
+
+	// Credentials the config sets too; PicoClaw would end up with two
+	var cfg map[string]interface{}
+	if dryRun {
+		if oc.Config != nil {
+			cfg = config.ConvertConfig(oc.Config)
+		}
+	} else {
+		cfg, _ = config.ReadConfig(picoConfigPath)
+	}
+	for _, m := range envfile.CheckConfig(incoming, cfg) {
+		ui.Warn(i18n.T("%s in .env is %s, but %s in the config is %s", m.Key, formatSetting(m.Key, m.Env), m.Path, formatSetting(m.Path, m.Config)))
+	}
+	if len(incoming) == 0 {
+		return
+	}
+
+	dst := filepath.Join(picoHome, envfile.Name)
+	existing, err := os.ReadFile(dst)
+	if err != nil && !os.IsNotExist(err) {
+		ui.Warn(i18n.T("Could not read %s: %v", dst, err))
+		return
+	}
+	_, conflicts := envfile.Merge(existing, incoming, nil)
+	if len(conflicts) > 0 {
+		ui.Info(i18n.T("Variables PicoClaw's .env sets differently (PicoClaw's → OpenClaw's):"))
+		for _, c := range conflicts {
+			fmt.Printf("    %s: %s → %s\n", c.Key, formatSetting(c.Key, c.Existing), formatSetting(c.Key, c.Incoming))
+		}
+	}
+	if dryRun {
+		ui.Info(i18n.T("[DRY RUN] Would merge %d variable(s) into %s", len(incoming), dst))
+		return
+	}
+
+	kept := map[string]bool{}
+	if len(conflicts) > 0 {
+		switch prefer {
+		case config.PreferIncoming:
+		case config.PreferExisting:
+			for _, c := range conflicts {
+				kept[c.Key] = true
+			}
+		case config.PreferAsk:
+			for _, c := range conflicts {
+				if ui.Choose(i18n.T("Keep which value of %s?", c.Key), []string{i18n.T("OpenClaw's"), i18n.T("PicoClaw's")}, 1) == 1 {
+					kept[c.Key] = true
+				}
+			}
+		default:
+			if !ui.Confirm(i18n.T("Let OpenClaw's .env replace these %d value(s)?", len(conflicts))) {
+				for _, c := range conflicts {
+					kept[c.Key] = true
+				}
+			}
+		}
+	}
+	merged, _ := envfile.Merge(existing, incoming, func(key string) bool { return kept[key] })
+	if err := envfile.Write(dst, merged); err != nil {
+		ui.Error(i18n.T("Could not write %s: %v", dst, err))
+		return
+	}
+	ui.Success(i18n.T("Environment variables merged into %s", dst))
+}
+
+// rewriteWorkspacePaths offers to point references to ~/.openclaw in the
+// workspace's markdown at the matching PicoClaw locations, showing each
+// line before and after. Returns the files rewritten.
+func rewriteWorkspacePaths(oc detect.Installation, picoHome, picoWorkspace string, dryRun bool) []string {
+	workspace := picoWorkspace
+	if dryRun {
+		workspace = oc.WorkspaceDir
+	}
+	rules := migrate.PathRules(oc.HomeDir, oc.WorkspaceDir, picoHome, picoWorkspace)
+	matches, err := migrate.FindPaths(workspace, rules, migrate.IsMarkdown)
+	if err != nil {
+		ui.Warn(i18n.T("Could not search the workspace for OpenClaw paths: %v", err))
+		return nil
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	files := migrate.PathFiles(matches)
+	ui.Info(i18n.T("%d line(s) in %d markdown file(s) refer to OpenClaw's locations:", len(matches), len(files)))
+	const preview = 20
+	for i, m := range matches {
+		if i == preview {
+			ui.Info(i18n.T("...and more in %s", previewList(files, 5)))
+			break
+		}
+		fmt.Printf("    "+ui.Cyan+"%s:%d"+ui.Reset+"\n", m.File, m.Line)
+		fmt.Printf("      "+ui.Red+"- %s"+ui.Reset+"\n", m.Text)
+		fmt.Printf("      "+ui.Green+"+ %s"+ui.Reset+"\n", m.Rewritten)
+	}
+
+	if dryRun {
+		ui.Info(i18n.T("[DRY RUN] Would offer to rewrite %d file(s)", len(files)))
+		return nil
+	}
+	if !ui.Confirm(i18n.T("Point these at PicoClaw's locations in all %d file(s)?", len(files))) {
+		ui.Info("Workspace files left as they are")
+		return nil
+	}
+	changed, err := migrate.RewritePaths(workspace, files, rules)
+	if err != nil {
+		ui.Error(i18n.T("Could not rewrite paths: %v", err))
+	}
+	if len(changed) > 0 {
+		ui.Success(i18n.T("Updated paths in %d file(s)", len(changed)))
+	}
+	return changed
+}
+
+// fixWorkspaceScripts offers to rewrite the openclaw commands and OpenClaw
+// paths in the workspace's scripts, showing each line before and after.
+// Returns the files rewritten and the lines running openclaw commands
+// PicoClaw has no equivalent for.
+func fixWorkspaceScripts(oc, pc detect.Installation, picoHome, picoWorkspace string, dryRun bool) ([]string, []migrate.ScriptIssue) {
+	workspace := picoWorkspace
+	if dryRun {
+		workspace = oc.WorkspaceDir
+	}
+	fixer := migrate.ScriptFixer{
+		Rules: migrate.PathRules(oc.HomeDir, oc.WorkspaceDir, picoHome, picoWorkspace),
+		Has: func(command string) bool {
+			// Without a binary to ask, as in a dry run, assume the mapping holds
+			return pc.Capabilities.Source == "" || pc.Capabilities.HasCommand(command)
+		},
+	}
+	matches, issues, err := fixer.FixScripts(workspace)
+	if err != nil {
+		ui.Warn(i18n.T("Could not search the workspace scripts: %v", err))
+		return nil, nil
+	}
+	if len(issues) > 0 {
+		ui.Warn(i18n.T("%d line(s) in scripts run openclaw commands PicoClaw has no equivalent for — listed under manual attention", len(issues)))
+	}
+	if len(matches) == 0 {
+		return nil, issues
+	}
+
+	files := migrate.PathFiles(matches)
+	ui.Info(i18n.T("%d line(s) in %d script(s) run openclaw or use OpenClaw's locations:", len(matches), len(files)))
+	const preview = 20
+	for i, m := range matches {
+		if i == preview {
+			ui.Info(i18n.T("...and more in %s", previewList(files, 5)))
+			break
+		}
+		fmt.Printf("    "+ui.Cyan+"%s:%d"+ui.Reset+"\n", m.File, m.Line)
+		fmt.Printf("      "+ui.Red+"- %s"+ui.Reset+"\n", m.Text)
+		fmt.Printf("      "+ui.Green+"+ %s"+ui.Reset+"\n", m.Rewritten)
+	}
+
+	if dryRun {
+		ui.Info(i18n.T("[DRY RUN] Would offer to rewrite %d file(s)", len(files)))
+		return nil, issues
+	}
+	if !ui.Confirm(i18n.T("Rewrite these for PicoClaw in all %d script(s)?", len(files))) {
+		ui.Info("Scripts left as they are")
+		return nil, issues
+	}
+	changed, err := fixer.RewriteScripts(workspace, files)
+	if err != nil {
+		ui.Error(i18n.T("Could not rewrite scripts: %v", err))
+	}
+	if len(changed) > 0 {
+		ui.Success(i18n.T("Updated %d script(s)", len(changed)))
+	}
+	return changed, issues
+}
+
+// refreshJournal re-records files the migration changed after copying
+// them, so verification doesn't report them as changed since
+func refreshJournal(j *journal.Journal, paths []string) {
+	changed := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		changed[p] = true
+	}
+	var entries []journal.FileEntry
+	for _, f := range j.Migrated() {
+		if !changed[f.Dest] {
+			continue
+		}
+		info, err := os.Stat(f.Dest)
+		if err != nil {
+			continue
+		}
+		sum, err := migrate.HashFile(f.Dest)
+		if err != nil {
+			continue
+		}
+		f.SHA256, f.Size, f.ModTime = sum, info.Size(), info.ModTime()
+		entries = append(entries, f)
+	}
+	j.Record(entries)
+}
+
+// offerStarterFiles offers PicoClaw starter versions of the standard
+// workspace files the migrated workspace still lacks, seeded with the
+// agent's name and model from the OpenClaw config
+func offerStarterFiles(oc detect.Installation, picoWorkspace string, dryRun bool) {
+	workspace := picoWorkspace
+	if dryRun {
+		workspace = oc.WorkspaceDir
+	}
+	missing := templates.Missing(workspace)
+	if len(missing) == 0 {
+		return
+	}
+	ui.Info(i18n.T("Not in the OpenClaw workspace: %s", strings.Join(missing, ", ")))
+	if dryRun {
+		ui.Info("[DRY RUN] Would offer PicoClaw starter versions of them")
+		return
+	}
+	if !ui.Confirm(i18n.T("Create starter versions of %d file(s)? (edit them afterwards)", len(missing))) {
+		return
+	}
+	seed := templates.Seed{Name: templates.AgentName(oc.Config), Model: oc.ConfigSummary.DefaultModel}
+	created := 0
+	for _, name := range missing {
+		if err := templates.Write(picoWorkspace, name, seed); err != nil {
+			ui.Warn(i18n.T("Could not create %s: %v", name, err))
+			continue
+		}
+		created++
+	}
+	if created > 0 {
+		ui.Success(i18n.T("Created %d starter file(s) in %s", created, picoWorkspace))
+	}
+}
+
+// migrateNativeHosts points OpenClaw's native messaging manifests at
+// PicoClaw, so the browser extension keeps working, when PicoClaw has a
+// native host of its own. Otherwise they are left for the uninstall to remove.
+func migrateNativeHosts(pc detect.Installation, picoHome string) {
+	hosts := nativehost.Find("openclaw")
+	if len(hosts) == 0 {
+		return
+	}
+	if pc.BinaryPath == "" || !pc.Capabilities.HasCommand("native-host") {
+		for _, h := range hosts {
+			ui.Warn(i18n.T("%s browser host %s runs OpenClaw and PicoClaw has no equivalent — it will stop working once OpenClaw is uninstalled", h.Browser, h.Name))
+		}
+		return
+	}
+	for _, h := range hosts {
+		if !h.Runs("openclaw") || !ui.Confirm(i18n.T("Point %s browser host %s at PicoClaw?", h.Browser, h.Name)) {
+			continue
+		}
+		// Named for PicoClaw, so the uninstall doesn't take it for OpenClaw's
+		wrapper := filepath.Join(picoHome, "native-host", strings.ReplaceAll(h.Name, "openclaw", "picoclaw"))
+		if err := nativehost.Wrapper(wrapper, pc.BinaryPath, "native-host"); err != nil {
+			ui.Error(i18n.T("Could not write %s: %v", wrapper, err))
+			continue
+		}
+		if err := nativehost.Repoint(h, wrapper); err != nil {
+			ui.Error(i18n.T("Could not update %s: %v", h.Manifest, err))
+			continue
+		}
+		ui.Success(i18n.T("%s browser host %s now runs %s", h.Browser, h.Name, wrapper))
+	}
+}
+
+// checkLocalModels asks each self-hosted endpoint in model_list — Ollama,
+// or any entry with its own api_base — whether it is reachable and which
+// models it serves, and offers to point entries at a model that exists
+func checkLocalModels(picoConfigPath string) {
+	cfg, err := config.ReadConfig(picoConfigPath)
+	if err != nil {
+		return
+	}
+	list, _ := cfg["model_list"].([]interface{})
+
+	changed := false
+	for _, item := range list {
+		entry, _ := item.(map[string]interface{})
+		model, _ := entry["model"].(string)
+		apiBase, _ := entry["api_base"].(string)
+		vendor, name, ok := strings.Cut(model, "/")
+		if !ok || (vendor != "ollama" && apiBase == "") {
+			continue
+		}
+
+		var server localmodels.Server
+		if vendor == "ollama" {
+			server = localmodels.Ollama(apiBase)
+		} else {
+			key, _ := entry["api_key"].(string)
+			server = localmodels.OpenAI(apiBase, key)
+		}
+		switch {
+		case !server.Reachable:
+			ui.Warn(i18n.T("%s: %s is not reachable from this machine (%v)", entry["model_name"], server.URL, server.Err))
+			continue
+		case server.Models == nil:
+			ui.Success(i18n.T("%s: endpoint reachable (could not list its models: %v)", entry["model_name"], server.Err))
+			continue
+		}
+
+		exact, suggestions := localmodels.Match(name, server.Models)
+		if exact != "" {
+			ui.Success(i18n.T("%s: %s is available", entry["model_name"], exact))
+			continue
+		}
+		ui.Warn(i18n.T("%s: %s is not served by %s", entry["model_name"], name, server.URL))
+		if len(server.Models) == 0 {
+			ui.Info("The server has no models yet — pull one, e.g.: ollama pull " + name)
+			continue
+		}
+		ui.Info(i18n.T("Served models: %s", previewList(server.Models, 8)))
+
+		// Unattended, only switch to the same model under another tag
+		if ui.AssumeYes() && len(suggestions) == 0 {
+			ui.Info(i18n.T("Keeping %s — pull it before starting PicoClaw", name))
+			continue
+		}
+		options := append(suggestions, without(server.Models, suggestions)...)
+		options = append(options[:min(len(options), 9)], i18n.T("Keep %s", name))
+		choice := ui.Choose(i18n.T("Which model should %s use?", entry["model_name"]), options, len(options)-1)
+		if choice == len(options)-1 {
+			ui.Info(i18n.T("Keeping %s — pull it before starting PicoClaw", name))
+			continue
+		}
+		next := vendor + "/" + options[choice]
+		for _, c := range models.ReplaceInConfig(cfg, model, next) {
+			fmt.Println("    " + ui.Dim + c.String() + ui.Reset)
+		}
+		changed = true
+	}
+
+	if changed {
+		if err := config.WriteConfig(cfg, picoConfigPath); err != nil {
+			ui.Error(i18n.T("Could not update the config: %v", err))
+		}
+	}
+}
+
+// without returns the names in all that aren't in skip
+func without(all, skip []string) []string {
+	var rest []string
+	for _, name := range all {
+		if !slices.Contains(skip, name) {
+			rest = append(rest, name)
+		}
+	}
+	return rest
+}
+
+// sandboxVerify converts the config and samples the workspace into a
+// temporary HOME and asks PicoClaw whether it accepts them, so conversion
+// problems show up before the real ~/.picoclaw is written. Reports false
+// only if PicoClaw rejected the result.
+func sandboxVerify(oc detect.Installation, pc detect.Installation, dryRun bool) bool {
+	ui.Info("Trying the converted config in a sandbox first (--sandbox)")
+	if dryRun {
+		ui.Info("[DRY RUN] Would run picoclaw status against the converted config under a temporary HOME")
+		return true
+	}
+	if pc.BinaryPath == "" {
+		ui.Warn("PicoClaw is not installed — skipping the sandbox check")
+		return true
+	}
+
+	home, sampled, err := sandbox.Prepare(oc)
+	if err != nil {
+		ui.Warn(i18n.T("Could not prepare the sandbox: %v", err))
+		return true
+	}
+	defer os.RemoveAll(home)
+	ui.Info(i18n.T("Sandbox HOME %s with the converted config and %d workspace file(s)", home, len(sampled)))
+
+	checks := sandbox.Run(pc.BinaryPath, home, pc.Capabilities)
+	for _, c := range checks {
+		if c.Err == nil {
+			ui.Success(i18n.T("%s accepts the converted config", c.Command))
+			continue
+		}
+		ui.Error(i18n.T("%s failed: %v", c.Command, c.Err))
+		lines := strings.Split(c.Output, "\n")
+		for _, line := range lines[max(0, len(lines)-8):] {
+			if line != "" {
+				fmt.Println("      " + ui.Dim + line + ui.Reset)
+			}
+		}
+	}
+	return sandbox.Passed(checks)
+}
+
+// webhooksLeft says the chat webhooks still deliver to the OpenClaw that
+// was kept, and how to move them once it goes
+func webhooksLeft() {
+	cfg, err := config.ReadConfig(filepath.Join(picoClawHome(), "config.json"))
+	if err != nil || len(webhooks.Configured(cfg)) == 0 {
+		return
+	}
+	ui.Info("Telegram and Discord webhooks were left delivering to OpenClaw while it runs — claw-migrate uninstall-openclaw offers to move them")
+}
+
+// moveWebhooks asks Telegram and Discord where they deliver for the
+// migrated bots, once OpenClaw is stopped. A webhook left by OpenClaw goes
+// dead with it — and keeps Telegram from answering PicoClaw's polling — so
+// it is removed, or pointed at base/<channel> when a base URL is given.
+// What it delivered to is kept in the journal for undo webhooks. The
+// Telegram command menu is updated from the config's custom commands.
+func moveWebhooks(picoConfigPath, base string) {
+	cfg, err := config.ReadConfig(picoConfigPath)
+	if err != nil {
+		return
+	}
+	var moved []journal.WebhookMove
+	defer func() { recordWebhookMoves(moved) }()
+	for _, hook := range webhooks.Find(cfg) {
+		name := strings.ToUpper(hook.Channel[:1]) + hook.Channel[1:]
+		target := ""
+		if base != "" {
+			target = strings.TrimRight(base, "/") + "/" + hook.Channel
+		}
+		switch {
+		case hook.Err != nil:
+			ui.Warn(i18n.T("Could not ask %s where it delivers: %v", name, hook.Err))
+			continue
+		case hook.URL == "":
+			ui.Success(i18n.T("%s: no webhook to move", name))
+		case hook.URL == target:
+			ui.Success(i18n.T("%s already delivers to %s", name, target))
+		default:
+			ui.Warn(i18n.T("%s still delivers to %s", name, hook.URL))
+			if hook.Pending > 0 {
+				ui.Info(i18n.T("%d update(s) are waiting to be delivered", hook.Pending))
+			}
+			question := i18n.T("Remove the %s webhook so PicoClaw can receive messages directly?", name)
+			if target != "" {
+				question = i18n.T("Point the %s webhook at %s?", name, target)
+			}
+			if !ui.ConfirmDangerous(question) {
+				ui.Info(i18n.T("Left as it is — PicoClaw won't receive %s messages until it's changed", name))
+				continue
+			}
+			if err := webhooks.Move(hook, target); err != nil {
+				ui.Error(i18n.T("Could not update the %s webhook: %v", name, err))
+				continue
+			}
+			moved = append(moved, journal.WebhookMove{Channel: hook.Channel, From: hook.URL, To: target, Changed: time.Now()})
+			if target != "" {
+				ui.Success(i18n.T("%s now delivers to %s", name, target))
+			} else {
+				ui.Success(i18n.T("%s webhook removed", name))
+			}
+		}
+
+		channels, _ := cfg["channels"].(map[string]interface{})
+		channel, _ := channels[hook.Channel].(map[string]interface{})
+		if cmds := webhooks.Commands(channel); hook.Channel == "telegram" && len(cmds) > 0 &&
+			ui.ConfirmDangerous(i18n.T("Set the Telegram command menu to the %d custom command(s) in the config?", len(cmds))) {
+			if err := webhooks.SetCommands(hook.Token, cmds); err != nil {
+				ui.Error(i18n.T("Could not update the command menu: %v", err))
+			} else {
+				ui.Success("Telegram command menu updated")
+			}
+		}
+	}
+}
+
+// recordWebhookMoves keeps where moved webhooks delivered before in the
+// journal, for undo webhooks
+func recordWebhookMoves(moved []journal.WebhookMove) {
+	if len(moved) == 0 {
+		return
+	}
+	j, err := journal.Load()
+	if os.IsNotExist(err) {
+		j, err = journal.New("", ""), nil
+	}
+	if err == nil {
+		j.Webhooks = append(j.Webhooks, moved...)
+		err = j.Save()
+	}
+	if err != nil {
+		ui.Warn(i18n.T("Could not record the old webhooks, so they can't be put back with undo webhooks: %v", err))
+		for _, m := range moved {
+			ui.Info(i18n.T("%s delivered to %s", m.Channel, m.From))
+		}
+		return
+	}
+	ui.Info("To point them back at what they delivered to before: claw-migrate undo webhooks")
+}
+
+// writeSlackManifest writes a Slack app manifest for PicoClaw next to the
+// config and prints the steps to apply it: Slack apps are configured on
+// Slack's side, so nothing else would tell the user the old app settings
+// point at OpenClaw
+func writeSlackManifest(picoConfigPath, base string) {
+	cfg, err := config.ReadConfig(picoConfigPath)
+	if err != nil {
+		return
+	}
+	channels, _ := cfg["channels"].(map[string]interface{})
+	slack, ok := channels["slack"].(map[string]interface{})
+	if !ok || slack["enabled"] == false {
+		return
+	}
+
+	requestURL := ""
+	if base != "" {
+		requestURL = strings.TrimRight(base, "/") + "/slack"
+	}
+	path := filepath.Join(filepath.Dir(picoConfigPath), webhooks.SlackManifestFile)
+	data, err := webhooks.SlackManifest(slack, requestURL)
+	if err == nil {
+		err = os.WriteFile(path, append(data, '\n'), 0600)
+	}
+	if err != nil {
+		ui.Error(i18n.T("Could not write the Slack app manifest: %v", err))
+		return
+	}
+
+	ui.Warn(i18n.T("Slack: the app still has OpenClaw's settings — manifest for PicoClaw written to %s", path))
+	steps := []string{i18n.T("Open %s, choose the app and go to App Manifest", webhooks.SlackAppURL(slack))}
+	if id, _ := slack["app_id"].(string); id != "" {
+		steps[0] = i18n.T("Open %s", webhooks.SlackAppURL(slack))
+	}
+	steps = append(steps,
+		i18n.T("Replace the manifest with the contents of %s and save", webhooks.SlackManifestFile),
+		i18n.T("Reinstall the app to your workspace (OAuth & Permissions) to grant any new scopes"),
+	)
+	if requestURL != "" {
+		steps = append(steps, i18n.T("Slack will verify %s — whatever serves it must be running", requestURL))
+	} else if app, _ := slack["app_token"].(string); app == "" {
+		steps = append(steps, i18n.T("Under Basic Information → App-Level Tokens, create a token with connections:write and set it as channels.slack.app_token"))
+	}
+	for i, step := range steps {
+		fmt.Printf("    %d. %s\n", i+1, step)
+	}
+}
+
+// assistUnmapped lists the OpenClaw config sections the conversion had no
+// place for. With --assist, a model from the migrated config proposes a
+// mapping, which is shown as a diff and only written once confirmed.
+func assistUnmapped(ocConfig map[string]interface{}, pc detect.Installation, picoConfigPath string, enabled, dryRun bool) {
+	sections := config.Unmapped(ocConfig)
+	if len(sections) == 0 {
+		return
+	}
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	switch {
+	case !enabled:
+		ui.Info(i18n.T("Not converted (unknown to the converter): %s — --assist can propose a mapping", strings.Join(names, ", ")))
+		return
+	case dryRun:
+		ui.Info(i18n.T("[DRY RUN] Would ask a configured model to map: %s", strings.Join(names, ", ")))
+		return
+	}
+
+	picoConfig, err := config.ReadConfig(picoConfigPath)
+	if err != nil {
+		ui.Warn(i18n.T("Could not read %s: %v", picoConfigPath, err))
+		return
+	}
+	providers := assist.Providers(picoConfig)
+	if len(providers) == 0 {
+		ui.Warn("--assist needs a provider with an API key (or Ollama) in config.json — skipping")
+		return
+	}
+	p := providers[0]
+	if len(providers) > 1 {
+		labels := make([]string, len(providers))
+		for i, pr := range providers {
+			labels[i] = pr.Name + "/" + pr.Model
+		}
+		choice := ui.Choose("Which model should propose a mapping?", labels, -1)
+		if choice < 0 {
 			return
 		}
+		p = providers[choice]
+	}
+	// Sending config off the machine needs a person's yes; --yes never gives it
+	if !p.Local() && !ui.ConfirmDangerous(i18n.T("Send %s to %s? (credentials are replaced by placeholders)", strings.Join(names, ", "), p.Name)) {
+		return
 	}
 
-	method := ui.Choose("How would you like to install PicoClaw?", []string{
-		fmt.Sprintf("Download pre-built binary (%s, recommended)", install.VersionTag()),
-		"Build from source (latest features, requires Go 1.21+)",
+	var proposal assist.Proposal
+	err = ui.SpinnerRun(i18n.T("Asking %s/%s...", p.Name, p.Model), func() error {
+		var err error
+		proposal, err = assist.Propose(p, sections, picoConfig, pc.Capabilities.Channels, pc.Capabilities.Tools)
+		return err
 	})
+	if err != nil {
+		ui.Warn(i18n.T("No proposal: %v", err))
+		return
+	}
+	if proposal.Notes != "" {
+		ui.Info(i18n.T("%s says: %s", p.Name, proposal.Notes))
+	}
 
-	if dryRun {
-		if method == 0 {
-			url, _, _ := install.GetDownloadURL()
-			ui.Info(fmt.Sprintf("[DRY RUN] Would download: %s", url))
-		} else {
-			ui.Info("[DRY RUN] Would clone and build from source")
+	// Diff the merged result rather than the proposal, since merging
+	// combines lists rather than taking either side's
+	merged := config.MergeConfig(picoConfig, proposal.Config)
+	before, after := config.Flatten(picoConfig), config.Flatten(merged)
+	var paths []string
+	for path := range before {
+		paths = append(paths, path)
+	}
+	for path := range after {
+		if _, ok := before[path]; !ok {
+			paths = append(paths, path)
 		}
-		ui.Info("[DRY RUN] Would run: picoclaw onboard")
+	}
+	sort.Strings(paths)
+	changes := 0
+	for _, path := range paths {
+		old, had := before[path]
+		now, has := after[path]
+		switch {
+		case had && has && reflect.DeepEqual(old, now):
+			continue
+		case !had:
+			fmt.Printf("    "+ui.Green+"+"+ui.Reset+" %s = %s\n", path, formatSetting(path, now))
+		case !has:
+			fmt.Printf("    "+ui.Red+"-"+ui.Reset+" %s = %s\n", path, formatSetting(path, old))
+		default:
+			fmt.Printf("    "+ui.Yellow+"~"+ui.Reset+" %s: %s → %s\n", path, formatSetting(path, old), formatSetting(path, now))
+		}
+		changes++
+	}
+	if changes == 0 {
+		ui.Info("The proposal doesn't change config.json")
 		return
 	}
 
-	if method == 0 {
-		installFromRelease(sys)
-	} else {
-		installFromSource()
+	// --yes answers prompts, but a model's guess is never applied unseen
+	if ui.AssumeYes() {
+		ui.Info("Not applied under --yes — rerun without it to review and apply the mapping")
+		return
 	}
-
-	// Initialize
-	ui.Step(3, "Initializing PicoClaw")
-	ui.Info("Running: picoclaw onboard")
-	if err := install.RunOnboard(); err != nil {
-		ui.Warn(fmt.Sprintf("Onboard had issues: %v", err))
-		ui.Info("You may need to run 'picoclaw onboard' manually after migration")
-	} else {
-		ui.Success("PicoClaw initialized")
+	if !ui.ConfirmDangerous(i18n.T("Apply these %d change(s) to config.json?", changes)) {
+		ui.Info("config.json left unchanged")
+		return
 	}
+	if err := config.WriteConfig(merged, picoConfigPath); err != nil {
+		ui.Error(i18n.T("Could not write config: %v", err))
+		return
+	}
+	ui.Success(i18n.T("Applied the proposed mapping for %s", strings.Join(names, ", ")))
 }
 
-func installFromRelease(sys detect.SystemInfo) {
-	ui.Step(1, "Downloading PicoClaw binary")
-
-	url, filename, err := install.GetDownloadURL()
-	if err != nil {
-		ui.Fatal(fmt.Sprintf("Unsupported platform: %v", err))
+// offerWorkspaceRepo carries the OpenClaw workspace's git history over if
+// it has one, or else offers to track the migrated workspace in git, so
+// edits to SOUL.md, memory and skills have a history from day one
+func offerWorkspaceRepo(oc detect.Installation, picoWorkspace string, dryRun bool) {
+	switch {
+	case migrate.IsRepo(picoWorkspace):
+		ui.Success("The workspace is already a git repository")
+		return
+	case !migrate.GitAvailable():
+		ui.Info("git is not installed — skipping")
+		return
+	case dryRun && migrate.IsRepo(oc.WorkspaceDir):
+		ui.Info(i18n.T("[DRY RUN] Would offer to carry the workspace's git history over to %s", picoWorkspace))
+		return
+	case dryRun:
+		ui.Info(i18n.T("[DRY RUN] Would offer to git init %s with a .gitignore for sessions, caches and secrets", picoWorkspace))
+		return
 	}
 
-	ui.Info(fmt.Sprintf("URL: %s", url))
-	tmpDir := os.TempDir()
-	archivePath := filepath.Join(tmpDir, filename)
-
-	dlErr := ui.SpinnerRun("Downloading...", func() error {
-		return install.Download(url, archivePath)
-	})
-	if dlErr != nil {
-		ui.Fatal(fmt.Sprintf("Download failed: %v", dlErr))
+	if migrate.IsRepo(oc.WorkspaceDir) {
+		if ui.Confirm("The OpenClaw workspace is a git repository — carry its history over?") {
+			rewritten, err := migrate.TransplantRepo(oc.WorkspaceDir, picoWorkspace)
+			if err != nil {
+				ui.Warn(i18n.T("Could not carry the history over: %v", err))
+				return
+			}
+			ui.Success("Git history preserved")
+			if len(rewritten) > 0 {
+				ui.Info(i18n.T("Updated paths to the new workspace in: %s", strings.Join(rewritten, ", ")))
+			}
+			if changes := migrate.RepoChanges(picoWorkspace); len(changes) > 0 {
+				ui.Info(i18n.T("%d path(s) differ from the last commit (e.g. %s) — items the migration doesn't copy show as deleted", len(changes), previewList(changes, 3)))
+			}
+			return
+		}
 	}
-	ui.Success("Download complete")
 
-	ui.Step(2, "Installing binary")
-	binaryPath, err := install.Extract(archivePath, tmpDir)
-	if err != nil {
-		ui.Fatal(fmt.Sprintf("Extraction failed: %v", err))
+	if !ui.Confirm("Track the workspace in git? (.gitignore keeps sessions, caches and secrets out)") {
+		return
 	}
-
-	ui.Info("Installing to /usr/local/bin/picoclaw (may require sudo)")
-	if err := install.InstallBinary(binaryPath); err != nil {
-		ui.Fatal(fmt.Sprintf("Install failed: %v", err))
+	files, err := migrate.InitWorkspaceRepo(picoWorkspace, filepath.Join(oc.WorkspaceDir, ".gitignore"))
+	if err != nil {
+		ui.Warn(i18n.T("Could not create the workspace repository: %v", err))
+		return
 	}
-	ui.Success("PicoClaw installed")
-
-	os.Remove(archivePath)
+	ui.Success(i18n.T("Initialized a git repository in %s (%d files committed)", picoWorkspace, files))
 }
 
-func installFromSource() {
-	ui.Step(1, "Building PicoClaw from source")
-	tmpDir := os.TempDir()
-
-	err := ui.SpinnerRun("Cloning and building (this may take a few minutes)...", func() error {
-		return install.BuildFromSource(tmpDir)
-	})
+// reviewConflicts shows what migrating would run into when PicoClaw
+// already has data — workspace files that differ, ones PicoClaw changed
+// more recently, and config values the two sides disagree on — and asks
+// how to settle them. Config keys are settled by --prefer, one by one
+// with ask. It returns the destination files to leave alone.
+func reviewConflicts(oc detect.Installation, picoWorkspace, picoConfigPath, prefer string, dryRun bool) map[string]bool {
+	var overlap migrate.Overlap
+	if entries, err := os.ReadDir(picoWorkspace); err == nil && len(entries) > 0 {
+		overlap = migrate.FindOverlap(oc.WorkspaceDir, picoWorkspace)
+	}
+	conflicts, err := migrate.ConfigConflicts(oc.ConfigPath, picoConfigPath)
 	if err != nil {
-		ui.Fatal(fmt.Sprintf("Build failed: %v", err))
+		ui.Warn(i18n.T("Could not compare configs: %v", err))
 	}
-	ui.Success("PicoClaw built and installed from source")
-}
-
-// ════════════════════════════════════════════════════════════
-// Phase 4: Migrate data
-// ════════════════════════════════════════════════════════════
-
-func phase4Migrate(oc, pc detect.Installation, dryRun bool) {
-	ui.Phase(4, "Migrate data")
-
-	home, _ := os.UserHomeDir()
-	picoHome := filepath.Join(home, ".picoclaw")
-	picoWorkspace := filepath.Join(picoHome, "workspace")
-
-	// Step 1: Check built-in migration tool
-	ui.Step(1, "Checking for PicoClaw's built-in migration tool")
-
-	builtInAvailable := pc.BinaryPath != ""
-	useBuiltIn := false
-	if builtInAvailable {
-		ui.Success("Built-in 'picoclaw migrate' command is available")
-		useBuiltIn = ui.Confirm("Use PicoClaw's built-in migration tool? (recommended)")
+	if len(overlap.Conflicts) == 0 && len(conflicts) == 0 {
+		return nil
 	}
+	keys, rest := config.ProviderKeys(conflicts)
 
-	if useBuiltIn && !dryRun {
-		ui.Info("Running: picoclaw migrate --force")
+	var newer []migrate.Conflict
+	for _, c := range overlap.Conflicts {
+		if c.DestNewer() {
+			newer = append(newer, c)
+		}
 	}
 
-	// Step 2: Migrate workspace — condensed output
-	ui.Step(2, "Migrating workspace (all files and directories)")
+	lines := []string{
+		i18n.T("Same on both sides:        %d file(s) — nothing to do", overlap.Identical),
+		i18n.T("Different on each side:    %d file(s)", len(overlap.Conflicts)),
+		i18n.T("  newer in PicoClaw:       %d file(s)", len(newer)),
+		i18n.T("Only in PicoClaw:          %d file(s) — left alone", overlap.DestOnly),
+		i18n.T("Config values that differ: %d", len(rest)+len(keys)),
+	}
+	if len(keys) > 0 {
+		lines = append(lines, i18n.T("  provider API keys:       %d", len(keys)))
+	}
+	ui.Box("PicoClaw already has data — review before migrating", lines)
+
+	if len(overlap.Conflicts) > 0 {
+		fmt.Println()
+		ui.Info("Files that differ (★ = changed more recently in PicoClaw):")
+		const shown = 15
+		for i, c := range overlap.Conflicts {
+			if i == shown {
+				ui.Info(i18n.T("…and %d more", len(overlap.Conflicts)-shown))
+				break
+			}
+			mark := " "
+			if c.DestNewer() {
+				mark = ui.Yellow + "★" + ui.Reset
+			}
+			fmt.Printf("    %s %s  %s%s, %s → %s, %s%s\n", mark, c.Rel, ui.Dim,
+				c.SrcTime.Format("2006-01-02 15:04"), detect.FormatSize(c.SrcSize),
+				c.DstTime.Format("2006-01-02 15:04"), detect.FormatSize(c.DstSize), ui.Reset)
+		}
+		ui.Info("Dates and sizes are OpenClaw's → PicoClaw's")
+	}
+	if len(rest) > 0 && prefer != config.PreferAsk {
+		fmt.Println()
+		ui.Info("Config values that differ (PicoClaw's → OpenClaw's):")
+		for _, c := range rest {
+			printConfigConflict(c)
+		}
+	}
+	if len(keys) > 0 && prefer != config.PreferAsk {
+		fmt.Println()
+		ui.Info("Providers with a different API key on each side (PicoClaw's → OpenClaw's):")
+		for _, k := range keys {
+			fmt.Printf("    "+ui.Yellow+"~"+ui.Reset+" %s: %s → %s\n", k.Provider, keyLabel(k.Existing), keyLabel(k.Incoming))
+		}
+	}
 
 	if dryRun {
-		fileCount := 0
-		dirCount := 0
-		entries, _ := os.ReadDir(oc.WorkspaceDir)
-		for _, entry := range entries {
-			if migrate.SkipEntries[entry.Name()] {
-				continue
+		ui.Info("[DRY RUN] Would ask which side to keep for these")
+		return nil
+	}
+
+	keep := map[string]bool{}
+	if len(overlap.Conflicts) > 0 {
+		options := []string{
+			i18n.T("Replace them all with OpenClaw's version"),
+			i18n.T("Keep PicoClaw's %d newer file(s), replace the rest", len(newer)),
+			i18n.T("Keep all of PicoClaw's files, only add what's missing"),
+		}
+		switch ui.Choose("Files that differ:", options, 2) {
+		case 1:
+			for _, c := range newer {
+				keep[c.Dst] = true
 			}
-			if entry.IsDir() {
-				dirCount++
-				dirPath := filepath.Join(oc.WorkspaceDir, entry.Name())
-				fileCount += detect.CountDirFiles(dirPath)
-			} else {
-				fileCount++
+		case 2:
+			for _, c := range overlap.Conflicts {
+				keep[c.Dst] = true
 			}
 		}
-		ui.Info(fmt.Sprintf("[DRY RUN] Would migrate %d files across %d directories", fileCount, dirCount))
-	} else {
-		var result migrate.Result
-		ui.SpinnerRun("Copying workspace files...", func() error {
-			result = migrate.MigrateWorkspace(oc.WorkspaceDir, picoWorkspace, true)
-			return nil
-		})
-
-		ui.Success(fmt.Sprintf("Migrated %d files (%d skipped, %d errors)",
-			result.Migrated, result.Skipped, result.Errors))
+		if len(keep) > 0 {
+			ui.Success(i18n.T("Keeping %d of PicoClaw's file(s)", len(keep)))
+		}
+	}
+	if len(conflicts) == 0 {
+		return keep
+	}
 
-		// Only show individual files if there were errors
-		if result.Errors > 0 {
-			for _, fr := range result.Files {
-				if fr.Error != nil {
-					ui.Error(fmt.Sprintf("  %s: %v", fr.Name, fr.Error))
+	switch prefer {
+	case config.PreferIncoming:
+		ui.Info("OpenClaw's config values win (--prefer incoming)")
+	case config.PreferExisting:
+		ui.Info("PicoClaw's config values stay (--prefer existing)")
+		migrate.KeepExisting = func(string) bool { return true }
+	case config.PreferAsk:
+		fmt.Println()
+		kept := map[string]bool{}
+		for i, c := range conflicts {
+			fmt.Printf("  %s[%d/%d]%s %s\n", ui.Dim, i+1, len(conflicts), ui.Reset, c.Path)
+			printConfigConflict(c)
+			if ui.Choose("Keep which value?", []string{i18n.T("OpenClaw's"), i18n.T("PicoClaw's")}, 1) == 1 {
+				kept[c.Path] = true
+			}
+		}
+		migrate.KeepExisting = func(path string) bool { return kept[path] }
+		ui.Success(i18n.T("Keeping PicoClaw's value for %d of %d key(s)", len(kept), len(conflicts)))
+	default:
+		// A key is never settled in bulk: keeping the wrong one breaks the agent
+		kept := map[string]bool{}
+		for _, k := range keys {
+			choice := ui.Choose(i18n.T("Keep which API key for %s?", k.Provider), []string{
+				i18n.T("OpenClaw's %s", keyLabel(k.Incoming)),
+				i18n.T("PicoClaw's %s", keyLabel(k.Existing)),
+			}, 1)
+			if choice == 1 {
+				for _, path := range k.Paths {
+					kept[path] = true
+				}
+			}
+		}
+		keepRest := len(rest) > 0 && !ui.Confirm(i18n.T("Let OpenClaw's config replace these %d value(s)?", len(rest)))
+		if keepRest {
+			ui.Success("Keeping PicoClaw's config values; OpenClaw's fill in the rest")
+		}
+		if len(kept) > 0 || keepRest {
+			isKey := map[string]bool{}
+			for _, k := range keys {
+				for _, path := range k.Paths {
+					isKey[path] = true
+				}
+			}
+			migrate.KeepExisting = func(path string) bool {
+				if isKey[path] {
+					return kept[path]
 				}
+				return keepRest
 			}
 		}
 	}
+	return keep
+}
 
-	// Step 3: Migrate config
-	ui.Step(3, "Converting configuration")
+// keyLabel shows an API key masked, with a fingerprint to tell apart keys
+// that end alike
+func keyLabel(key string) string {
+	return fmt.Sprintf("%s (%s)", secrets.Mask(key), secrets.Fingerprint(key))
+}
 
-	if dryRun {
-		ui.Info("[DRY RUN] Would convert: openclaw.json → config.json")
-	} else {
-		picoConfigPath := filepath.Join(picoHome, "config.json")
-		fr := migrate.MigrateConfig(oc.ConfigPath, picoConfigPath, true)
-		if fr.Error != nil {
-			ui.Error(fmt.Sprintf("Config migration failed: %v", fr.Error))
-		} else {
-			ui.Success("Configuration converted and written")
-			if fr.BackedUp {
-				ui.Info("Previous config backed up to config.json.bak")
+// printConfigConflict shows how the two sides of a config conflict differ.
+// Lists and objects are compared item by item, so secrets inside them
+// are masked like any other setting.
+func printConfigConflict(c config.Conflict) {
+	before := config.Flatten(map[string]interface{}{"": c.Existing})
+	after := config.Flatten(map[string]interface{}{"": c.Incoming})
+	var paths []string
+	for p := range before {
+		paths = append(paths, p)
+	}
+	for p := range after {
+		if _, ok := before[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		old, had := before[p]
+		now, has := after[p]
+		path := c.Path + p
+		switch {
+		case had && has && reflect.DeepEqual(old, now):
+			continue
+		case !had:
+			fmt.Printf("    "+ui.Green+"+"+ui.Reset+" %s = %s\n", path, formatSetting(path, now))
+		case !has:
+			fmt.Printf("    "+ui.Red+"-"+ui.Reset+" %s = %s\n", path, formatSetting(path, old))
+		default:
+			before, after := formatSetting(path, old), formatSetting(path, now)
+			oldKey, ok := old.(string)
+			newKey, ok2 := now.(string)
+			if ok && ok2 && secrets.IsSecretKey(path[strings.LastIndex(path, ".")+1:]) {
+				before, after = keyLabel(oldKey), keyLabel(newKey) // masks alone may look the same
 			}
+			fmt.Printf("    "+ui.Yellow+"~"+ui.Reset+" %s: %s → %s\n", path, before, after)
 		}
 	}
+}
 
-	// Step 4: Model version check
-	ui.Step(4, "Checking model version")
-	checkModelVersion(oc, picoHome, dryRun)
+// runBuiltInMigrate runs `picoclaw migrate --force` and reports whether it
+// wrote the PicoClaw config, so our own conversion can avoid redoing it.
+// The workspace copy still runs afterwards to fill gaps and journal hashes.
+func runBuiltInMigrate(picoConfigPath string) bool {
+	var before time.Time
+	if info, err := os.Stat(picoConfigPath); err == nil {
+		before = info.ModTime()
+	}
 
-	// Step 5: Manual items
-	ui.Step(5, "Items requiring manual attention")
+	ui.Info("Running: picoclaw migrate --force")
+	var result install.BuiltInMigrateResult
+	err := ui.SpinnerRun("Running PicoClaw's built-in migration...", func() error {
+		var runErr error
+		result, runErr = install.RunBuiltInMigrate()
+		return runErr
+	})
 
-	manualItems := []string{}
+	for _, line := range result.Problems() {
+		ui.Warn("picoclaw: " + line)
+	}
+	if err != nil {
+		ui.Warn(i18n.T("picoclaw migrate failed (exit code %d): %v", result.ExitCode, err))
+		ui.Info("Falling back to claw-migrate's own conversion")
+		return false
+	}
+	ui.Success("picoclaw migrate completed")
 
-	if oc.Config != nil {
-		mcpServers := detect.GetMCPServers(oc.Config)
-		if len(mcpServers) > 0 {
-			manualItems = append(manualItems, fmt.Sprintf("MCP Servers (%s) — verify format in config", strings.Join(mcpServers, ", ")))
-		}
+	info, statErr := os.Stat(picoConfigPath)
+	return statErr == nil && info.ModTime().After(before)
+}
+
+// mergeResult folds the files, counters and abort of extra into result,
+// keeping the reason of the first abort
+func mergeResult(result *migrate.Result, extra migrate.Result) {
+	result.Files = append(result.Files, extra.Files...)
+	result.TotalFiles += extra.TotalFiles
+	result.Migrated += extra.Migrated
+	result.Skipped += extra.Skipped
+	result.Errors += extra.Errors
+	result.Cloned += extra.Cloned
+	if extra.Aborted && !result.Aborted {
+		result.Aborted, result.AbortReason = true, extra.AbortReason
 	}
+}
 
-	if oc.HasCron {
-		manualItems = append(manualItems, "Cron jobs — recreate with: picoclaw cron add ...")
+// reportSecretCollisions warns about credential files that would overwrite
+// each other in the PicoClaw home, and says where the second one goes
+func reportSecretCollisions(oc detect.Installation) {
+	moved := migrate.SecretCollisions(homeItemNames(oc))
+	names := make([]string, 0, len(moved))
+	for name := range moved {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	for _, name := range names {
+		ui.Warn(i18n.T("%s would overwrite another credential file at %s — copying it to %s instead", name, migrate.RuleFor(name).Dest, moved[name]))
+		ui.Info("Merge the two by hand if PicoClaw needs what both hold")
+	}
+}
 
-	if oc.Config != nil {
-		channels := detect.GetConfiguredChannels(oc.Config)
-		unsupported := []string{}
-		supported := map[string]bool{
-			"telegram": true, "discord": true, "qq": true,
-			"dingtalk": true, "line": true, "slack": true,
-			"feishu": true, "onebot": true,
-		}
-		for _, ch := range channels {
-			if !supported[ch] {
-				unsupported = append(unsupported, ch)
-			}
-		}
-		if len(unsupported) > 0 {
-			manualItems = append(manualItems,
-				fmt.Sprintf("Unsupported channels: %s (not available in PicoClaw)",
-					strings.Join(unsupported, ", ")))
-		}
+// homeItemNames lists the non-workspace items found in ~/.openclaw
+func homeItemNames(oc detect.Installation) []string {
+	names := make([]string, 0, len(oc.HomeItems))
+	for _, item := range oc.HomeItems {
+		names = append(names, item.Name)
 	}
+	return names
+}
 
-	if len(manualItems) > 0 {
-		ui.Warn("The following items need manual attention:")
-		for _, item := range manualItems {
-			fmt.Printf("    "+ui.Yellow+"•"+ui.Reset+" %s\n", item)
+// journalEntries converts copy results into journal records
+func journalEntries(result migrate.Result) []journal.FileEntry {
+	entries := make([]journal.FileEntry, 0, len(result.Files))
+	for _, fr := range result.Files {
+		entries = append(entries, journalEntry(fr))
+	}
+	return entries
+}
+
+// journalEntry converts one file's copy result into its journal record
+func journalEntry(fr migrate.FileResult) journal.FileEntry {
+	e := journal.FileEntry{
+		Name:    fr.Name,
+		Source:  fr.Source,
+		Dest:    fr.Dest,
+		Moved:   fr.Moved,
+		SHA256:  fr.SHA256,
+		Size:    fr.Size,
+		ModTime: fr.ModTime,
+	}
+	switch {
+	case fr.Error != nil:
+		e.Status = journal.StatusFailed
+		e.Error = fr.Error.Error()
+	case fr.Skipped:
+		e.Status = journal.StatusSkipped
+	default:
+		e.Status = journal.StatusMigrated
+	}
+	return e
+}
+
+// appendMoved records a moved file in the journal before its source goes
+func appendMoved(fr migrate.FileResult) error {
+	return journal.Append(journalEntry(fr))
+}
+
+// migrationOutcome summarizes a copy result as a journal outcome
+func migrationOutcome(result migrate.Result) string {
+	switch {
+	case result.Aborted:
+		return journal.OutcomeAborted
+	case result.Errors > 0:
+		return journal.OutcomeErrors
+	default:
+		return journal.OutcomeSuccess
+	}
+}
+
+// showErrorSummary prints failed files grouped by cause, a few per group
+func showErrorSummary(result migrate.Result) {
+	const perGroup = 5
+
+	groups := result.ErrorsByCause()
+	causes := make([]string, 0, len(groups))
+	for cause := range groups {
+		causes = append(causes, cause)
+	}
+	sort.Strings(causes)
+
+	for _, cause := range causes {
+		files := groups[cause]
+		ui.Warn(i18n.T("%s: %d file(s)", cause, len(files)))
+		for i, fr := range files {
+			if i == perGroup {
+				ui.Info(i18n.T("    ... and %d more", len(files)-perGroup))
+				break
+			}
+			ui.Error(i18n.T("  %s: %v", fr.Name, fr.Error))
 		}
-	} else {
-		ui.Success("No manual items — everything migrated automatically!")
 	}
 }
 
-// checkModelVersion warns about outdated models and offers upgrade
-func checkModelVersion(oc detect.Installation, picoHome string, dryRun bool) {
+// checkModelVersion warns about outdated models and offers upgrade. An
+// accepted upgrade is recorded in j, the journal of this run.
+func checkModelVersion(oc detect.Installation, picoHome string, j *journal.Journal, dryRun bool) {
 	currentModel := extractModelString(oc.Config)
+	if converted := config.ConvertedModel(oc.Config); converted != "" {
+		currentModel = converted // as written to PicoClaw, provider prefix settled
+	}
 
 	if currentModel == "" {
 		ui.Info("No default model detected in config")
 		return
 	}
 
-	if upgrade, found := modelUpgrades[currentModel]; found {
-		ui.Warn(fmt.Sprintf("Current model: %s (outdated)", currentModel))
-		ui.Info(fmt.Sprintf("Recommended:   %s", upgrade))
+	upgrade, found := models.Upgrade(currentModel)
+	if found && settings.Load().Declined(currentModel, upgrade) {
+		ui.Info(i18n.T("Model: %s (upgrade to %s declined before — --reset-decisions asks again)", currentModel, upgrade))
+		return
+	}
+	if found {
+		ui.Warn(i18n.T("Current model: %s (outdated)", currentModel))
+		ui.Info(i18n.T("Recommended:   %s", upgrade))
+		compareModels(currentModel, upgrade)
 
 		if !dryRun {
-			if ui.Confirm(fmt.Sprintf("Update model to %s?", upgrade)) {
+			if ui.Confirm(i18n.T("Update model to %s?", upgrade)) {
 				picoConfigPath := filepath.Join(picoHome, "config.json")
-				if err := updateModelInConfig(picoConfigPath, upgrade); err != nil {
-					ui.Error(fmt.Sprintf("Could not update model: %v", err))
+				if changes, err := updateModelInConfig(picoConfigPath, currentModel, upgrade); err != nil {
+					ui.Error(i18n.T("Could not update model: %v", err))
+				} else if len(changes) == 0 {
+					ui.Info(i18n.T("The PicoClaw config no longer uses %s — nothing to update", currentModel))
 				} else {
-					ui.Success(fmt.Sprintf("Model updated to %s", upgrade))
+					ui.Success(i18n.T("Model updated to %s", upgrade))
+					for _, c := range changes {
+						fmt.Println("    " + ui.Dim + c.String() + ui.Reset)
+					}
+					recordModelUpgrade(j, picoConfigPath, currentModel, upgrade, changes)
 				}
 			} else {
-				ui.Info(fmt.Sprintf("Keeping %s — you can change later in ~/.picoclaw/config.json", currentModel))
+				ui.Info(i18n.T("Keeping %s — you can change later in ~/.picoclaw/config.json", currentModel))
+				rememberDeclined(currentModel, upgrade)
 			}
 		} else {
-			ui.Info(fmt.Sprintf("[DRY RUN] Would offer to upgrade to %s", upgrade))
+			ui.Info(i18n.T("[DRY RUN] Would offer to upgrade to %s", upgrade))
 		}
 	} else {
-		ui.Success(fmt.Sprintf("Model: %s (current)", currentModel))
+		ui.Success(i18n.T("Model: %s (current)", currentModel))
+	}
+}
+
+// recordModelUpgrade keeps the model an upgrade replaced in j, this run's
+// journal (a new one if the run has none), for undo model-upgrade
+func recordModelUpgrade(j *journal.Journal, configPath, from, to string, changes []models.Change) {
+	if j == nil {
+		j = journal.New("", "")
+	}
+	j.ModelUpgrade = &journal.ModelUpgrade{Config: configPath, From: from, To: to, Changed: time.Now(), Changes: changes}
+	if err := j.Save(); err != nil {
+		ui.Warn(i18n.T("Could not record the upgrade, so it can't be undone with undo model-upgrade: %v", err))
+		return
+	}
+	ui.Info(i18n.T("If %s isn't available on your plan: claw-migrate undo model-upgrade", to))
+}
+
+// modelUpgrade is models.Upgrade without the upgrades turned down in an
+// earlier run, so lint and detection don't bring them up again
+func modelUpgrade(model string) (string, bool) {
+	upgrade, found := models.Upgrade(model)
+	if !found || settings.Load().Declined(model, upgrade) {
+		return "", false
+	}
+	return upgrade, true
+}
+
+// rememberDeclined records a turned-down model upgrade in the settings
+func rememberDeclined(model, upgrade string) {
+	s := settings.Load()
+	s.Decline(model, upgrade)
+	if err := s.Save(); err != nil {
+		ui.Warn(i18n.T("Could not save settings: %v", err))
+		return
+	}
+	ui.Info(i18n.T("Won't suggest upgrading %s again (--reset-decisions to undo)", model))
+}
+
+// compareModels shows the limits and list price of an outdated model next
+// to its upgrade, from the catalog bundled with claw-migrate, so accepting
+// the upgrade isn't a blind choice. Nothing is shown for an unknown model.
+func compareModels(current, upgrade string) {
+	from, ok := models.Lookup(current)
+	if !ok {
+		return
+	}
+	to, ok := models.Lookup(upgrade)
+	if !ok {
+		return
+	}
+
+	tokens := func(n int) string {
+		if n >= 1000000 {
+			return fmt.Sprintf("%.1fM", float64(n)/1000000)
+		}
+		return fmt.Sprintf("%dK", n/1000)
+	}
+	// change is the difference in percent, green when it's for the better
+	change := func(a, b float64, moreIsBetter bool) string {
+		if a == 0 || a == b {
+			return ""
+		}
+		color := ui.Yellow
+		if b > a == moreIsBetter {
+			color = ui.Green
+		}
+		return fmt.Sprintf(color+" (%+.0f%%)"+ui.Reset, (b-a)/a*100)
+	}
+
+	fmt.Printf("    %-16s %-10s → %s\n", "", current, upgrade)
+	fmt.Printf("    %-16s %-10s → %s%s\n", i18n.T("Context window"), tokens(from.Context), tokens(to.Context),
+		change(float64(from.Context), float64(to.Context), true))
+	fmt.Printf("    %-16s %-10s → %s%s\n", i18n.T("Max output"), tokens(from.MaxOutput), tokens(to.MaxOutput),
+		change(float64(from.MaxOutput), float64(to.MaxOutput), true))
+	fmt.Printf("    %-16s %-10s → %s%s\n", i18n.T("Input $/MTok"), fmt.Sprintf("$%.2f", from.Input), fmt.Sprintf("$%.2f", to.Input),
+		change(from.Input, to.Input, false))
+	fmt.Printf("    %-16s %-10s → %s%s\n", i18n.T("Output $/MTok"), fmt.Sprintf("$%.2f", from.Output), fmt.Sprintf("$%.2f", to.Output),
+		change(from.Output, to.Output, false))
+	fmt.Println("    " + ui.Dim + i18n.T("List prices as of %s — check your provider for current pricing", models.CatalogDate) + ui.Reset)
+}
+
+// auditWorkspaceModels looks for outdated models written into workspace
+// files — skills, cron jobs, agent frontmatter — previews the rewrite and
+// applies it to every file at once if confirmed
+func auditWorkspaceModels(workspace string, dryRun bool) {
+	declined := settings.Load()
+	scanner := models.NewScanner()
+	scanner.Keep = declined.Declined
+	matches, err := scanner.Scan(workspace)
+	if err != nil {
+		ui.Warn(i18n.T("Could not search the workspace for models: %v", err))
+		return
+	}
+	if len(matches) == 0 {
+		ui.Success("No outdated models referenced in workspace files")
+		return
+	}
+
+	files := models.Files(matches)
+	ui.Warn(i18n.T("%d outdated model reference(s) in %d workspace file(s):", len(matches), len(files)))
+	const preview = 20
+	shown := 0
+	for i, m := range matches {
+		if i > 0 && matches[i-1].File == m.File && matches[i-1].Line == m.Line {
+			continue // the line was shown with all its replacements
+		}
+		if shown == preview {
+			ui.Info(i18n.T("...and more in %s", previewList(files, 5)))
+			break
+		}
+		shown++
+		fmt.Printf("    "+ui.Cyan+"%s:%d"+ui.Reset+"\n", m.File, m.Line)
+		fmt.Printf("      "+ui.Red+"- %s"+ui.Reset+"\n", m.Text)
+		fmt.Printf("      "+ui.Green+"+ %s"+ui.Reset+"\n", scanner.Replace(m.Text))
+	}
+
+	if dryRun {
+		ui.Info(i18n.T("[DRY RUN] Would offer to rewrite %d file(s)", len(files)))
+		return
+	}
+	if !ui.Confirm(i18n.T("Rewrite these to the recommended models in all %d file(s)?", len(files))) {
+		ui.Info("Workspace files left as they are")
+		return
+	}
+	changed, err := scanner.Rewrite(workspace, files)
+	if err != nil {
+		ui.Error(i18n.T("Could not rewrite models: %v", err))
+	}
+	if len(changed) > 0 {
+		ui.Success(i18n.T("Updated models in %d file(s)", len(changed)))
 	}
 }
 
@@ -909,43 +5915,36 @@ func extractModelString(config map[string]interface{}) string {
 	return ""
 }
 
-func updateModelInConfig(configPath, newModel string) error {
-	data, err := os.ReadFile(configPath)
+// updateModelInConfig replaces oldModel with newModel everywhere the
+// PicoClaw config refers to it, and returns what changed
+func updateModelInConfig(configPath, oldModel, newModel string) ([]models.Change, error) {
+	configMap, err := config.ReadConfig(configPath)
 	if err != nil {
-		return err
-	}
-
-	var configMap map[string]interface{}
-	if err := json.Unmarshal(data, &configMap); err != nil {
-		return err
+		return nil, err
 	}
-
-	if agents, ok := configMap["agents"].(map[string]interface{}); ok {
-		if defaults, ok := agents["defaults"].(map[string]interface{}); ok {
-			defaults["model"] = newModel
-		}
+	changes := models.ReplaceInConfig(configMap, oldModel, newModel)
+	if len(changes) == 0 {
+		return nil, nil
 	}
-
-	out, err := json.MarshalIndent(configMap, "", "  ")
-	if err != nil {
-		return err
+	if err := config.WriteConfig(configMap, configPath); err != nil {
+		return nil, err
 	}
-
-	return os.WriteFile(configPath, out, 0644)
+	return changes, nil
 }
 
 // ════════════════════════════════════════════════════════════
 // Phase 5: Verify
 // ════════════════════════════════════════════════════════════
 
-func phase5Verify() {
+func phase5Verify(dryRun bool) {
 	ui.Phase(5, "Verify migration")
 
 	home, _ := os.UserHomeDir()
-	picoWorkspace := filepath.Join(home, ".picoclaw", "workspace")
+	picoWorkspace := detect.PicoClawWorkspace(filepath.Join(home, ".picoclaw"))
 	picoConfig := filepath.Join(home, ".picoclaw", "config.json")
 
 	ui.Step(1, "Checking PicoClaw workspace")
+	ui.Found("Location", picoWorkspace)
 
 	// Check workspace exists
 	if _, err := os.Stat(picoWorkspace); os.IsNotExist(err) {
@@ -957,7 +5956,7 @@ func phase5Verify() {
 	// Count files
 	fileCount := detect.CountDirFiles(picoWorkspace)
 	size := detect.DirSize(picoWorkspace)
-	ui.Found("Workspace", fmt.Sprintf("%d files (%s)", fileCount, detect.FormatSize(size)))
+	ui.Found("Workspace", i18n.T("%d files (%s)", fileCount, detect.FormatSize(size)))
 
 	// Check config
 	if _, err := os.Stat(picoConfig); err == nil {
@@ -966,17 +5965,24 @@ func phase5Verify() {
 		ui.Warn("Configuration file missing")
 	}
 
+	// Check copied files against the journal
+	ui.Step(2, "Verifying copied files")
+	if j, err := journal.Load(); err == nil && j.DestDir == picoWorkspace {
+		verifyAgainstJournal(j)
+	} else {
+		ui.Info("No migration journal for this workspace — skipping checksum verification")
+	}
+
 	// Check key workspace files
-	ui.Step(2, "Checking key files")
+	ui.Step(3, "Checking key files")
 	keyFiles := []string{"SOUL.md", "IDENTITY.md", "AGENTS.md"}
 	allGood := true
 	for _, f := range keyFiles {
 		path := filepath.Join(picoWorkspace, f)
 		if _, err := os.Stat(path); err == nil {
-			lines := detect.CountFileLines(path)
-			ui.FileStatus(f, true, lines)
+			ui.FileStatus(f, true, fileDetail(path))
 		} else {
-			ui.FileStatus(f, false, 0)
+			ui.FileStatus(f, false, "")
 			allGood = false
 		}
 	}
@@ -985,47 +5991,684 @@ func phase5Verify() {
 		ui.Success("All key files present")
 	}
 
+	// Permissions
+	ui.Step(4, "Auditing permissions")
+	auditPermissions(filepath.Join(home, ".picoclaw"), !dryRun)
+
+	ui.Step(5, "Checking the gateway port")
+	checkGatewayPort(picoConfig, dryRun)
+
 	// Suggested test commands
-	ui.Step(3, "Test your PicoClaw installation")
+	ui.Step(6, "Test your PicoClaw installation")
 	ui.Info("Try these commands:")
 	fmt.Println()
-	fmt.Println("    " + ui.Cyan + "picoclaw status" + ui.Reset + "          # Check status")
-	fmt.Println("    " + ui.Cyan + "picoclaw agent" + ui.Reset + "           # Chat with your agent")
-	fmt.Println("    " + ui.Cyan + "picoclaw gateway" + ui.Reset + "         # Start the gateway")
+	fmt.Println("    " + ui.Cyan + "picoclaw status" + ui.Reset + "          # " + i18n.T("Check status"))
+	fmt.Println("    " + ui.Cyan + "picoclaw agent" + ui.Reset + "           # " + i18n.T("Chat with your agent"))
+	fmt.Println("    " + ui.Cyan + "picoclaw gateway" + ui.Reset + "         # " + i18n.T("Start the gateway"))
+	fmt.Println("    " + ui.Cyan + "claw-migrate watch" + ui.Reset + "       # " + i18n.T("Get alerted if it goes down in the next 24 hours"))
 	fmt.Println()
 }
 
+// checkGatewayPort checks the gateway can listen where the config says. A
+// port still held by OpenClaw can be freed by stopping it; one held by
+// anything else can be swapped for the next free port. A firewall that
+// would block the port is reported.
+func checkGatewayPort(picoConfig string, dryRun bool) {
+	cfg, err := config.ReadConfig(picoConfig)
+	if err != nil {
+		ui.Info("No PicoClaw config to check the gateway port in")
+		return
+	}
+	host, port := ports.Gateway(cfg)
+	ui.Found("Gateway", net.JoinHostPort(host, strconv.Itoa(port)))
+
+	if ports.Free(host, port) {
+		ui.Success(i18n.T("Port %d is free", port))
+	} else if port = freeGatewayPort(cfg, picoConfig, host, port, dryRun); port == 0 {
+		return
+	}
+
+	if blocked, note := ports.Firewall(host, port); blocked {
+		ui.Warn(i18n.T("Other machines may not reach the gateway: %s", note))
+	} else if note != "" {
+		ui.Success(i18n.T("Firewall: %s", note))
+	}
+}
+
+// freeGatewayPort deals with a gateway port already in use and returns the
+// port the gateway will use, or 0 if it's left for the user to sort out
+func freeGatewayPort(cfg map[string]interface{}, picoConfig, host string, port int, dryRun bool) int {
+	holder, known := ports.Listener(port)
+	command := strings.ToLower(holder.Command)
+	switch {
+	case known && strings.Contains(command, "picoclaw"):
+		ui.Success(i18n.T("Port %d is in use by PicoClaw (pid %d) — the gateway is already running", port, holder.PID))
+		return port
+	case known && strings.Contains(command, "openclaw"):
+		ui.Warn(i18n.T("Port %d is still held by OpenClaw (pid %d)", port, holder.PID))
+		if dryRun {
+			ui.Info("[DRY RUN] Would offer to stop it")
+		} else if ui.ConfirmDangerous(i18n.T("Stop OpenClaw (pid %d)?", holder.PID)) && stopProcess(holder.PID) && ports.Free(host, port) {
+			ui.Success(i18n.T("Port %d is free now", port))
+			return port
+		}
+	case known:
+		display := holder.Command
+		if len(display) > 60 {
+			display = display[:57] + "..."
+		}
+		ui.Warn(i18n.T("Port %d is in use by %s (pid %d)", port, display, holder.PID))
+	default:
+		ui.Warn(i18n.T("Port %d is in use by another process", port))
+	}
+
+	next := ports.Next(host, port)
+	switch {
+	case next == 0:
+		ui.Warn(i18n.T("No free port found after %d — set gateway.port in %s by hand", port, picoConfig))
+		return 0
+	case dryRun:
+		ui.Info(i18n.T("[DRY RUN] Would offer to move the gateway to port %d", next))
+		return 0
+	case !ui.Confirm(i18n.T("Move the gateway to port %d?", next)):
+		ui.Info(i18n.T("Keeping port %d — free it before running picoclaw gateway", port))
+		return 0
+	}
+
+	gateway, ok := cfg["gateway"].(map[string]interface{})
+	if !ok {
+		gateway = map[string]interface{}{"host": host}
+		cfg["gateway"] = gateway
+	}
+	gateway["port"] = next
+	if err := config.WriteConfig(cfg, picoConfig); err != nil {
+		ui.Error(i18n.T("Could not update the config: %v", err))
+		return 0
+	}
+	ui.Success(i18n.T("Gateway port set to %d", next))
+	return next
+}
+
+// stopProcess asks a process to exit and waits a few seconds for it
+func stopProcess(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err == nil {
+		err = p.Signal(syscall.SIGTERM)
+	}
+	if err != nil {
+		ui.Error(i18n.T("Could not stop pid %d: %v", pid, err))
+		return false
+	}
+	for i := 0; i < 30; i++ {
+		if p.Signal(syscall.Signal(0)) != nil {
+			ui.Success(i18n.T("Stopped pid %d", pid))
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	ui.Warn(i18n.T("pid %d is still running", pid))
+	return false
+}
+
+// verifyAgainstJournal checks migrated files against their recorded hashes.
+// Files whose size and mtime are unchanged since the copy are trusted; only
+// the rest are rehashed.
+func verifyAgainstJournal(j *journal.Journal) {
+	var missing, changed []string
+	verified, rehashed := 0, 0
+
+	for _, f := range j.Migrated() {
+		info, err := os.Stat(f.Dest)
+		if err != nil {
+			missing = append(missing, f.Name)
+			continue
+		}
+		if f.SHA256 == "" || (info.Size() == f.Size && info.ModTime().Equal(f.ModTime)) {
+			verified++
+			continue
+		}
+		rehashed++
+		if err := migrate.VerifyFile(f.Dest, f.SHA256); err != nil {
+			changed = append(changed, f.Name)
+			continue
+		}
+		verified++
+	}
+
+	if len(missing) == 0 && len(changed) == 0 {
+		ui.Success(i18n.T("%d files match the journal (%d rehashed)", verified, rehashed))
+		return
+	}
+	if len(missing) > 0 {
+		ui.Warn(i18n.T("%d migrated file(s) are missing: %s", len(missing), previewList(missing, 5)))
+	}
+	if len(changed) > 0 {
+		ui.Warn(i18n.T("%d file(s) changed since migration: %s", len(changed), previewList(changed, 5)))
+	}
+}
+
+// manualAttentionItems lists what the migration can't carry over automatically
+func manualAttentionItems(oc detect.Installation) []todo.Item {
+	manualItems := []todo.Item{}
+
+	if oc.Config != nil {
+		mcpServers := detect.GetMCPServers(oc.Config)
+		if len(mcpServers) > 0 {
+			manualItems = append(manualItems, todo.Item{ID: "mcp", Text: i18n.T("MCP Servers (%s) — verify format in config", strings.Join(mcpServers, ", "))})
+		}
+		for _, note := range config.ProviderNotes(oc.Config) {
+			manualItems = append(manualItems, todo.Item{ID: "provider:" + note.Provider, Text: note.Text})
+		}
+		for _, note := range config.ModelNotes(oc.Config) {
+			manualItems = append(manualItems, todo.Item{ID: "model:" + note.Path, Text: note.Text})
+		}
+		for _, r := range config.UnmappedRoutes(oc.Config) {
+			manualItems = append(manualItems, todo.Item{
+				ID:   "routing:" + r.Path,
+				Text: i18n.T("%s → %s — PicoClaw has no per-task model routing; this will use the default model", r.Path, r.Model),
+			})
+		}
+		for _, note := range config.ScheduleNotes(oc.Config, oc.HasCron) {
+			manualItems = append(manualItems, todo.Item{ID: note.ID, Text: note.Text})
+		}
+		for _, note := range config.AccessNotes(oc.Config) {
+			manualItems = append(manualItems, todo.Item{ID: note.ID, Text: note.Text})
+		}
+	}
+
+	for _, item := range oc.Extras {
+		if len(item.Secrets) > 0 {
+			manualItems = append(manualItems, todo.Item{
+				ID:   "secrets:" + item.Path,
+				Text: i18n.T("workspace/%s holds secrets (%s) — move them into config.json or a secret store", item.Path, strings.Join(item.Secrets, ", ")),
+			})
+		}
+	}
+
+	if oc.HasCron {
+		manualItems = append(manualItems, todo.Item{ID: "cron", Text: i18n.T("Cron jobs — recreate with: picoclaw cron add ...")})
+	}
+
+	for _, item := range oc.HomeItems {
+		if rule := migrate.RuleFor(item.Name); rule.Action == migrate.ActionManual {
+			manualItems = append(manualItems, todo.Item{
+				ID:   "home:" + item.Name,
+				Text: fmt.Sprintf("~/.openclaw/%s — %s", item.Name, rule.Description),
+			})
+		}
+	}
+
+	if oc.Config != nil {
+		channels := detect.GetConfiguredChannels(oc.Config)
+		unsupported := []string{}
+		for _, ch := range channels {
+			if !config.SupportsChannel(ch) {
+				unsupported = append(unsupported, ch)
+			}
+		}
+		if slices.Contains(channels, "slack") && config.SupportsChannel("slack") {
+			manualItems = append(manualItems, todo.Item{
+				ID:   "slack:manifest",
+				Text: i18n.T("Slack — apply ~/.picoclaw/%s to the Slack app and reinstall it", webhooks.SlackManifestFile),
+			})
+		}
+		if len(unsupported) > 0 {
+			manualItems = append(manualItems, todo.Item{
+				ID:   "channels",
+				Text: i18n.T("Unsupported channels: %s (not available in PicoClaw)", strings.Join(unsupported, ", ")),
+			})
+		}
+
+		if tools, ok := oc.Config["tools"].(map[string]interface{}); ok {
+			dropped := []string{}
+			for _, name := range []string{"web", "cron"} {
+				if _, ok := tools[name]; ok && !config.SupportsTool(name) {
+					dropped = append(dropped, name)
+				}
+			}
+			if len(dropped) > 0 {
+				manualItems = append(manualItems, todo.Item{
+					ID:   "tools",
+					Text: i18n.T("Tools not available in the installed PicoClaw: %s", strings.Join(dropped, ", ")),
+				})
+			}
+		}
+	}
+
+	return manualItems
+}
+
+// probePicoClaw learns what the installed PicoClaw supports and narrows
+// config conversion to it
+func probePicoClaw(pc detect.Installation) detect.Capabilities {
+	caps := detect.ProbePicoClaw(pc)
+	config.SetTarget(caps.Channels, caps.Tools)
+	switch caps.Source {
+	case detect.CapsBinary:
+		ui.Info(i18n.T("PicoClaw reports %d channels, %d tools and %d commands", len(caps.Channels), len(caps.Tools), len(caps.Commands)))
+	case detect.CapsHelp:
+		ui.Info("Read PicoClaw's commands from --help; assuming the default channels and tools")
+	case detect.CapsUnknown:
+		ui.Warn("Could not ask PicoClaw what it supports; assuming the default channels and tools")
+	}
+	return caps
+}
+
+// auditPermissions tightens modes under the PicoClaw home and summarizes what changed
+func auditPermissions(picoHome string, fix bool) {
+	changes := perms.Audit(picoHome, fix)
+	if len(changes) == 0 {
+		ui.Success("Permissions look good")
+		return
+	}
+
+	msg := "Fixed permissions on %d path(s):"
+	if !fix {
+		msg = "Would fix permissions on %d path(s):"
+	}
+	ui.Warn(i18n.T(msg, len(changes)))
+	for i, c := range changes {
+		if i == 10 {
+			ui.Info(i18n.T("    ... and %d more", len(changes)-10))
+			break
+		}
+		rel, _ := filepath.Rel(picoHome, c.Path)
+		ui.Info(i18n.T("  %-30s %04o → %04o (%s)", rel, c.Old, c.New, c.Reason))
+	}
+}
+
+// previewList joins up to max items, noting how many were left out
+func previewList(items []string, max int) string {
+	if len(items) <= max {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s, ... (+%d more)", strings.Join(items[:max], ", "), len(items)-max)
+}
+
 // ════════════════════════════════════════════════════════════
 // Phase 6: Uninstall OpenClaw
 // ════════════════════════════════════════════════════════════
 
-func phase6Uninstall(oc detect.Installation, dryRun bool) {
+// stopSupervised stops gateways that pm2 or forever would restart once
+// killed, and those left running in tmux panes or screen sessions. Each is
+// confirmed first, with the command it runs: a pm2 app is deleted from
+// pm2's saved list, and Ctrl-C reaches whatever a pane is running.
+func stopSupervised(name string) {
+	for _, s := range uninstall.FindSupervised(name) {
+		question := i18n.T("Send Ctrl-C to %s in %s %s?", s.Command, s.Manager, s.Name)
+		if s.Manager == uninstall.ManagerPM2 || s.Manager == uninstall.ManagerForever {
+			question = i18n.T("Stop and remove %s from %s? It runs: %s", s.Name, s.Manager, s.Command)
+		}
+		if !ui.Confirm(question) {
+			ui.Info(i18n.T("Left %s under %s running", s.Name, s.Manager))
+			continue
+		}
+		if err := uninstall.StopSupervised(s); err != nil {
+			ui.Warn(i18n.T("Could not stop %s under %s: %v", s.Name, s.Manager, err))
+			continue
+		}
+		ui.Success(i18n.T("Stopped %s under %s", s.Name, s.Manager))
+	}
+}
+
+// removeDockerArtifacts lists OpenClaw's Docker containers, images, compose
+// projects and volumes and removes them if confirmed. Volumes hold data
+// the backup doesn't cover, so they are asked about separately, unless
+// --keep-data or --purge says what to do. Like ~/.openclaw, they are only
+// deleted unattended with a verified backup, or --force.
+func removeDockerArtifacts(oc detect.Installation, backupResult backup.Result, opts options) {
+	data := opts.openclawData
+	a, err := uninstall.FindDocker()
+	if err != nil {
+		ui.Warn(i18n.T("Could not list Docker artifacts: %v", err))
+		return
+	}
+	if a.Empty() {
+		ui.Info("No OpenClaw Docker containers, images or volumes found")
+		return
+	}
+
+	names := func(items []uninstall.DockerItem) string {
+		var n []string
+		for _, it := range items {
+			n = append(n, it.Name)
+		}
+		return previewList(n, 5)
+	}
+	if len(a.Projects) > 0 {
+		ui.Found("Compose projects", previewList(a.Projects, 5))
+	}
+	if len(a.Containers) > 0 {
+		ui.Found("Containers", names(a.Containers))
+	}
+	if len(a.Images) > 0 {
+		ui.Found("Container images", names(a.Images))
+	}
+	if len(a.Volumes) > 0 {
+		ui.Found("Volumes", names(a.Volumes))
+	}
+
+	if preserve.Enabled() {
+		ui.Info("Docker containers, images and volumes can't be set aside — left in place (--no-delete)")
+		return
+	}
+	if len(a.Projects)+len(a.Containers)+len(a.Images) > 0 {
+		if data != "" && ui.AssumeYes() {
+			reportDockerErrors(uninstall.RemoveDockerContainers(a), "Docker containers and images removed")
+		} else if ui.ConfirmDangerous("Remove OpenClaw's Docker containers and images?") {
+			reportDockerErrors(uninstall.RemoveDockerContainers(a), "Docker containers and images removed")
+		} else {
+			ui.Info("Docker containers and images preserved")
+		}
+	}
+	if len(a.Volumes) == 0 {
+		return
+	}
+	backupPath := verifiedBackup(oc, backupResult)
+	switch {
+	case data == dataKeep:
+		ui.Info("Docker volumes preserved (--keep-data)")
+	case backupPath == "" && !opts.force && (data == dataPurge || ui.AssumeYes()):
+		ui.Error("No verified backup exists — refusing to delete OpenClaw's Docker volumes unattended")
+		ui.Info("Create one with: claw-migrate backup — or pass --force to delete them anyway")
+		ui.Info("Docker volumes preserved")
+	case data == dataPurge:
+		reportDockerErrors(uninstall.RemoveDockerVolumes(a), "Docker volumes removed")
+	default:
+		ui.Warn("Docker volumes are not part of the backup — their data is lost once they're deleted")
+		question := "Delete OpenClaw's Docker volumes?"
+		if backupPath == "" {
+			ui.Warn("No verified backup of OpenClaw's data exists either")
+			question = "Delete OpenClaw's Docker volumes without a backup?"
+		}
+		if ui.ConfirmDangerous(question) {
+			reportDockerErrors(uninstall.RemoveDockerVolumes(a), "Docker volumes removed")
+		} else {
+			ui.Info("Docker volumes preserved")
+		}
+	}
+}
+
+// cleanShellStartup offers to comment out the aliases, completions and
+// PATH entries for OpenClaw in shell startup files, then to add the same
+// aliases and completion for PicoClaw in their place
+func cleanShellStartup() {
+	home, _ := os.UserHomeDir()
+	lines := uninstall.FindShellLines(home)
+	if len(lines) == 0 {
+		ui.Info("No OpenClaw aliases, completions or PATH entries in shell startup files")
+		return
+	}
+
+	tilde := func(path string) string {
+		if rel, err := filepath.Rel(home, path); err == nil {
+			return filepath.Join("~", rel)
+		}
+		return path
+	}
+	ui.Warn(i18n.T("%d line(s) in shell startup files refer to OpenClaw:", len(lines)))
+	standalone := 0
+	for _, l := range lines {
+		if l.Standalone {
+			standalone++
+			fmt.Printf("    "+ui.Cyan+"%s:%d"+ui.Reset+"  %s\n", tilde(l.File), l.Line, strings.TrimSpace(l.Text))
+		}
+	}
+	// Commenting out a line inside an if, loop or function can break the
+	// whole file, so those are only pointed out
+	if standalone < len(lines) {
+		ui.Info("These are part of a larger statement — edit them by hand:")
+		for _, l := range lines {
+			if !l.Standalone {
+				fmt.Printf("    "+ui.Yellow+"%s:%d"+ui.Reset+"  %s\n", tilde(l.File), l.Line, strings.TrimSpace(l.Text))
+			}
+		}
+	}
+	switch {
+	case standalone == 0:
+	case ui.ConfirmDangerous(i18n.T("Comment out the %d standalone line(s)? (each file is copied to .claw-migrate.bak first)", standalone)):
+		if err := uninstall.CommentOut(lines); err != nil {
+			ui.Error(i18n.T("Could not update shell startup files: %v", err))
+			return
+		}
+		ui.Success("Commented out — search for \"disabled by claw-migrate\" to find them")
+	default:
+		ui.Info("Left as they are — they will fail once OpenClaw is gone")
+	}
+
+	var files []string
+	for _, l := range lines {
+		if !slices.Contains(files, l.File) {
+			files = append(files, l.File)
+		}
+	}
+	for _, file := range files {
+		add := uninstall.PicoClawLines(file, lines)
+		if len(add) == 0 {
+			continue
+		}
+		ui.Info(i18n.T("For PicoClaw in %s:", tilde(file)))
+		for _, line := range add {
+			fmt.Println("    " + ui.Green + "+ " + line + ui.Reset)
+		}
+		if !ui.Confirm(i18n.T("Add to %s?", tilde(file))) {
+			continue
+		}
+		if err := uninstall.AppendLines(file, add); err != nil {
+			ui.Error(i18n.T("Could not update %s: %v", tilde(file), err))
+		} else {
+			ui.Success(i18n.T("Added %d line(s) to %s — open a new shell to use them", len(add), tilde(file)))
+		}
+	}
+}
+
+// removeNativeHosts deletes the native messaging manifests that still
+// start OpenClaw, so the browser doesn't keep spawning a deleted binary
+func removeNativeHosts() {
+	for _, h := range nativehost.Find("openclaw") {
+		if !h.Runs("openclaw") {
+			continue
+		}
+		if err := nativehost.Remove(h); err != nil {
+			ui.Warn(i18n.T("Could not remove %v", err))
+			continue
+		}
+		ui.Success(i18n.T("Removed %s browser host %s", h.Browser, h.Name))
+	}
+}
+
+func reportDockerErrors(errs []error, success string) {
+	if len(errs) == 0 {
+		ui.Success(success)
+		return
+	}
+	for _, err := range errs {
+		ui.Warn(i18n.T("Could not remove %v", err))
+	}
+}
+
+// picoclawCommand matches picoclaw run by name in a shell command
+var picoclawCommand = regexp.MustCompile(`(^|[\s;&|(])picoclaw(\s|$)`)
+
+// cleanCrontab offers to point the user's crontab entries that run
+// OpenClaw at PicoClaw, commenting out those with no equivalent, or to
+// comment them all out. The crontab replaced is saved in ~/.claw-migrate.
+func cleanCrontab(oc detect.Installation) {
+	crontab, err := uninstall.ReadCrontab()
+	if err != nil {
+		ui.Warn(i18n.T("Could not read the crontab: %v", err))
+		return
+	}
+	pc := detect.DetectPicoClaw()
+	picoHome := picoClawHome()
+	fixer := migrate.ScriptFixer{
+		Rules: migrate.PathRules(oc.HomeDir, oc.WorkspaceDir, picoHome, detect.PicoClawWorkspace(picoHome)),
+		Has: func(command string) bool {
+			return pc.Capabilities.Source == "" || pc.Capabilities.HasCommand(command)
+		},
+	}
+	fix := fixer.FixLine
+	if pc.BinaryPath != "" {
+		// cron runs with a minimal PATH, so name the binary in full
+		binary := strings.ReplaceAll(pc.BinaryPath, "$", "$$")
+		fix = func(command string) (string, bool) {
+			fixed, ok := fixer.FixLine(command)
+			return picoclawCommand.ReplaceAllString(fixed, "${1}"+binary+"${2}"), ok
+		}
+	}
+	lines := uninstall.FindCronLines(crontab, fix)
+	if len(lines) == 0 {
+		ui.Info("No crontab entries run OpenClaw")
+		return
+	}
+
+	unmapped := 0
+	ui.Warn(i18n.T("%d crontab entr(ies) refer to OpenClaw:", len(lines)))
+	for _, l := range lines {
+		fmt.Printf("    "+ui.Cyan+"%d"+ui.Reset+"  %s\n", l.Line, l.Text)
+		if l.Rewritten != "" {
+			fmt.Printf("    "+ui.Green+"→"+ui.Reset+"  %s\n", l.Rewritten)
+		} else {
+			unmapped++
+			fmt.Printf("       %s\n", ui.Dim+i18n.T("(no PicoClaw equivalent)")+ui.Reset)
+		}
+	}
+
+	options := []string{"Point them at PicoClaw", "Comment them all out", "Leave them"}
+	if unmapped > 0 {
+		options[0] = i18n.T("Point them at PicoClaw (commenting out the %d with no equivalent)", unmapped)
+	}
+	choice := ui.Choose("What should happen to these entries?", options, 2)
+	if choice == 2 {
+		ui.Info("Left as they are — they will fail once OpenClaw is gone")
+		return
+	}
+	// One copy per run, so a later run doesn't replace the original
+	saved := filepath.Join(journal.Dir(), "crontab-"+time.Now().Format("20060102-150405")+".bak")
+	err = os.MkdirAll(journal.Dir(), 0700)
+	if err == nil {
+		err = uninstall.WriteCrontab(uninstall.FixCrontab(crontab, lines, choice == 0), crontab, saved)
+	}
+	if err != nil {
+		ui.Error(i18n.T("Could not update the crontab: %v", err))
+		return
+	}
+	ui.Success(i18n.T("Crontab updated — the previous one is saved in %s", saved))
+}
+
+// verifiedBackup returns a backup of OpenClaw's data that passed
+// verification and is still on disk: the one made in this run, else the
+// one the journal records for the last migration from the same place.
+// "" if there is none.
+func verifiedBackup(oc detect.Installation, fresh backup.Result) string {
+	if fresh.Verified {
+		if _, err := os.Stat(fresh.Path); err == nil {
+			return fresh.Path
+		}
+	}
+	j, err := journal.Load()
+	if err != nil || !j.BackupVerified || j.BackupPath == "" || j.SourceDir != oc.WorkspaceDir {
+		return ""
+	}
+	if _, err := os.Stat(j.BackupPath); err != nil {
+		return ""
+	}
+	return j.BackupPath
+}
+
+// schedulePurge leaves OpenClaw's data in place for days more and records
+// when it's due, offering a daily crontab entry that runs purge-due
+func schedulePurge(oc detect.Installation, backupPath string, days int) {
+	now := time.Now()
+	state, _ := uninstall.ReadDataState(oc.HomeDir)
+	p := uninstall.ScheduledPurge{Path: oc.HomeDir, Scheduled: now, Due: now.AddDate(0, 0, days), Backup: backupPath, State: state}
+	if err := uninstall.SchedulePurge(p); err != nil {
+		ui.Error(i18n.T("Could not schedule the purge: %v", err))
+		ui.Info("Data directory preserved.")
+		return
+	}
+	ui.Success(i18n.T("%s stays until %s, then: claw-migrate purge-due", oc.HomeDir, p.Due.Format("2006-01-02")))
+	ui.Info("Changed your mind? Keep it with: claw-migrate purge-due cancel")
+
+	if _, err := exec.LookPath("crontab"); err != nil {
+		return
+	}
+	if !ui.Confirm("Run purge-due daily from your crontab, so it happens on its own?") {
+		return
+	}
+	exe, err := os.Executable()
+	if err == nil {
+		err = uninstall.AddPurgeCron(exe)
+	}
+	if err != nil {
+		ui.Warn(i18n.T("Could not update the crontab: %v", err))
+		return
+	}
+	ui.Success("Added — the entry removes itself once the purge is done")
+}
+
+// What happens to OpenClaw's data on uninstall, for --keep-data and --purge;
+// "" asks
+const (
+	dataKeep  = "keep"
+	dataPurge = "purge"
+)
+
+// dataFlag names the flag that set data
+func dataFlag(data string) string {
+	if data == dataPurge {
+		return "--purge"
+	}
+	return "--keep-data"
+}
+
+func phase6Uninstall(oc detect.Installation, dryRun bool, backupResult backup.Result, opts options) {
+	data := opts.openclawData
 	ui.Phase(6, "Uninstall OpenClaw")
 
 	ui.Warn("This will remove OpenClaw completely:")
-	fmt.Printf("    "+ui.Yellow+"•"+ui.Reset+" Binary: %s\n", oc.BinaryPath)
-	fmt.Printf("    "+ui.Yellow+"•"+ui.Reset+" Data: %s\n", oc.HomeDir)
+	fmt.Println("    " + ui.Yellow + "•" + ui.Reset + " " + i18n.T("Binary: %s", oc.BinaryPath))
+	if data == dataKeep {
+		fmt.Println("    " + ui.Yellow + "•" + ui.Reset + " " + i18n.T("Data: %s (kept)", oc.HomeDir))
+	} else {
+		fmt.Println("    " + ui.Yellow + "•" + ui.Reset + " " + i18n.T("Data: %s", oc.HomeDir))
+	}
 
-	if !ui.ConfirmDangerous("Uninstall OpenClaw?") {
+	// --yes alone never uninstalls; --keep-data or --purge says to
+	if data != "" && ui.AssumeYes() {
+		ui.Info(i18n.T("Uninstalling OpenClaw (%s)", dataFlag(data)))
+	} else if !ui.ConfirmDangerous("Uninstall OpenClaw?") {
 		ui.Info("OpenClaw preserved. You can uninstall later with:")
 		ui.Info("  npm uninstall -g openclaw && rm -rf ~/.openclaw")
+		webhooksLeft()
 		return
 	}
 
 	if dryRun {
 		ui.Info("[DRY RUN] Would uninstall OpenClaw")
+		if cfg, err := config.ReadConfig(filepath.Join(picoClawHome(), "config.json")); err == nil && len(webhooks.Configured(cfg)) > 0 {
+			ui.Info("[DRY RUN] Would offer to move the Telegram and Discord webhooks OpenClaw left, once it is stopped")
+		}
 		return
 	}
+	run := startRun(runs.KindUninstallOpenClaw)
+	run.Backup = backupResult.Path
+	outcome, detail := runs.OutcomeSuccess, ""
+	defer func() { endRun(run, outcome, detail) }()
 
 	// Stop processes
 	ui.Step(1, "Stopping OpenClaw processes")
+	stopSupervised("openclaw")
 	uninstall.StopOpenClaw()
 	ui.Success("Processes stopped")
 
+	// Only now that OpenClaw can't answer them any more
+	moveWebhooks(filepath.Join(picoClawHome(), "config.json"), opts.webhookURL)
+
 	// Remove binary
 	ui.Step(2, "Removing binary")
 	if err := uninstall.RemoveBinary(); err != nil {
-		ui.Warn(fmt.Sprintf("Could not remove binary: %v", err))
+		ui.Warn(i18n.T("Could not remove binary: %v", err))
 	} else {
 		ui.Success("Binary removed")
 	}
@@ -1033,32 +6676,90 @@ func phase6Uninstall(oc detect.Installation, dryRun bool) {
 	// Remove launch agents (macOS)
 	ui.Step(3, "Removing launch agents")
 	if removed := uninstall.RemoveLaunchAgents(); len(removed) > 0 {
-		ui.Success(fmt.Sprintf("Removed %d launch agent(s)", len(removed)))
+		ui.Success(i18n.T("Removed %d launch agent(s)", len(removed)))
 	} else {
 		ui.Info("No launch agents found")
 	}
+	removeNativeHosts()
 
-	// Remove data
-	ui.Step(4, "Removing data directory")
-	ui.Warn(fmt.Sprintf("About to delete: %s", oc.HomeDir))
+	// Containerized installs
+	ui.Step(4, "Removing Docker containers and images")
+	removeDockerArtifacts(oc, backupResult, opts)
 
-	if !ui.ConfirmDangerous("Delete all OpenClaw data? (backup was created in Phase 2)") {
-		ui.Info("Data directory preserved.")
-		return
-	}
+	// Aliases and completions that would now fail
+	ui.Step(5, "Cleaning shell startup files and crontab")
+	cleanShellStartup()
+	cleanCrontab(oc)
 
-	if err := uninstall.RemoveData(oc.HomeDir); err != nil {
-		ui.Error(fmt.Sprintf("Could not remove data: %v", err))
+	// Remove data
+	ui.Step(6, "Removing data directory")
+	if data == dataKeep {
+		ui.Info(i18n.T("Keeping %s (--keep-data)", oc.HomeDir))
+		detail = "data kept"
 	} else {
-		ui.Success("OpenClaw data removed")
+		ui.Warn(i18n.T("About to delete: %s", oc.HomeDir))
+
+		// Only a backup that verified makes the data recoverable
+		question := "Delete all OpenClaw data?"
+		backupPath := verifiedBackup(oc, backupResult)
+		switch {
+		case backupPath != "":
+			ui.Info(i18n.T("Verified backup: %s", backupPath))
+		case preserve.Enabled():
+			ui.Info(i18n.T("No verified backup, but it will be set aside under %s (--no-delete)", preserve.Dir()))
+		case opts.force:
+			ui.Warn("No verified backup exists — deleting anyway (--force)")
+		case data == dataPurge || ui.AssumeYes():
+			ui.Error("No verified backup exists — refusing to delete OpenClaw's data unattended")
+			ui.Info("Create one with: claw-migrate backup — or pass --force to delete it anyway")
+			ui.Info("Data directory preserved.")
+			detail = "data kept: no verified backup"
+			return
+		default:
+			ui.Warn("No verified backup exists — once deleted, this data can't be restored")
+			question = "Delete all OpenClaw data without a backup?"
+		}
+		if opts.purgeAfter > 0 {
+			ui.Info(i18n.T("With --purge-after it stays for %d more day(s) first", opts.purgeAfter))
+		}
+		if data != dataPurge && !ui.ConfirmDangerous(question) {
+			ui.Info("Data directory preserved.")
+			detail = "data kept"
+			return
+		}
+		if opts.purgeAfter > 0 {
+			schedulePurge(oc, backupPath, opts.purgeAfter)
+			detail = fmt.Sprintf("data to be purged in %d day(s)", opts.purgeAfter)
+		} else if err := uninstall.RemoveData(oc.HomeDir); err != nil {
+			ui.Error(i18n.T("Could not remove data: %v", err))
+			outcome, detail = runs.OutcomeErrors, "could not remove data: "+err.Error()
+		} else {
+			ui.Success("OpenClaw data removed")
+		}
 	}
 
-	// Verify
-	ui.Step(5, "Verifying removal")
-	binaryGone, dataGone, _ := uninstall.VerifyRemoved()
-	if binaryGone && dataGone {
+	// Verify; data kept on purpose isn't a leftover
+	ui.Step(7, "Verifying removal")
+	left := uninstall.VerifyRemoved()
+	if data == dataKeep || opts.purgeAfter > 0 {
+		left = slices.DeleteFunc(left, func(l uninstall.Leftover) bool { return l.Kind == uninstall.LeftoverData })
+	}
+	reportLeftovers(left)
+	if len(left) > 0 && outcome == runs.OutcomeSuccess {
+		outcome, detail = runs.OutcomeErrors, strings.TrimPrefix(fmt.Sprintf("%s; %d trace(s) left", detail, len(left)), "; ")
+	}
+}
+
+// reportLeftovers lists what an uninstall left behind, each with the
+// command that removes it
+func reportLeftovers(left []uninstall.Leftover) {
+	if len(left) == 0 {
 		ui.Success("OpenClaw completely removed")
-	} else {
-		ui.Warn("Some traces of OpenClaw may remain")
+		return
+	}
+	ui.Warn(i18n.T("%d trace(s) of OpenClaw remain:", len(left)))
+	for _, l := range left {
+		fmt.Printf("    "+ui.Yellow+"•"+ui.Reset+" %-8s %s\n", l.Kind, l.What)
+		fmt.Printf("      "+ui.Dim+"→ %s"+ui.Reset+"\n", l.Fix)
 	}
 }
\ No newline at end of file